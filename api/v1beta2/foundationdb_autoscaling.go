@@ -0,0 +1,747 @@
+/*
+ * foundationdb_autoscaling.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"math"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// AutoscalingSpec defines the autoscaling policies for the cluster.
+type AutoscalingSpec struct {
+	// Storage defines the policy to scale the storage processes based on the disk usage.
+	Storage *StorageAutoscalingPolicy `json:"storage,omitempty"`
+
+	// Stateless defines the policy to scale the commit proxies, GRV proxies and resolvers based on the QoS
+	// information.
+	Stateless *StatelessAutoscalingPolicy `json:"stateless,omitempty"`
+
+	// Resources defines the policy to collect the CPU and memory usage of the processes and to recommend resource
+	// requests and limits per process class.
+	Resources *ResourceRecommendationPolicy `json:"resources,omitempty"`
+}
+
+// StorageAutoscalingPolicy defines how the operator scales the storage processes based on the disk usage reported in
+// the machine-readable status.
+type StorageAutoscalingPolicy struct {
+	// Enabled defines if the operator should scale the storage processes based on the disk usage.
+	// Defaults to false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// TargetDiskUsagePercentage defines the targeted percentage of used disk space of the storage processes. If the
+	// disk usage is above this value the operator will scale up, if the disk usage after removing StepSize process
+	// groups would still be below this value the operator will scale down.
+	// Defaults to 70.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	TargetDiskUsagePercentage *int `json:"targetDiskUsagePercentage,omitempty"`
+
+	// MinProcessGroups defines the minimum number of storage process groups.
+	// Defaults to the storage process count defined in the process counts.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MinProcessGroups *int `json:"minProcessGroups,omitempty"`
+
+	// MaxProcessGroups defines the maximum number of storage process groups.
+	// Defaults to MinProcessGroups.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxProcessGroups *int `json:"maxProcessGroups,omitempty"`
+
+	// StepSize defines how many storage process groups will be added or removed in a single scaling decision.
+	// Defaults to 1.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	StepSize *int `json:"stepSize,omitempty"`
+
+	// CooldownSeconds defines how long the operator waits after a scaling decision before making the next scaling
+	// decision.
+	// Defaults to 3600.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	CooldownSeconds *int `json:"cooldownSeconds,omitempty"`
+
+	// MaxVolumeSize defines up to which size the operator will grow the storage PVCs before adding new storage
+	// process groups. Growing the PVCs requires a storage class that allows volume expansion. If not set the operator
+	// will only change the number of storage process groups.
+	MaxVolumeSize *resource.Quantity `json:"maxVolumeSize,omitempty"`
+}
+
+// StatelessAutoscalingPolicy defines how the operator scales the commit proxies, GRV proxies and resolvers based on
+// the latency probe, the transaction rates and the QoS information reported in the machine-readable status.
+type StatelessAutoscalingPolicy struct {
+	// Enabled defines if the operator should scale the stateless roles based on the QoS information.
+	// Defaults to false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// CommitProxies defines the bounds for the number of commit proxies. Scaling the commit proxies requires
+	// separated proxies to be configured.
+	CommitProxies *RoleAutoscalingBounds `json:"commitProxies,omitempty"`
+
+	// GrvProxies defines the bounds for the number of GRV proxies. Scaling the GRV proxies requires separated
+	// proxies to be configured.
+	GrvProxies *RoleAutoscalingBounds `json:"grvProxies,omitempty"`
+
+	// Resolvers defines the bounds for the number of resolvers.
+	Resolvers *RoleAutoscalingBounds `json:"resolvers,omitempty"`
+
+	// TargetCommitLatencyMilliseconds defines the targeted commit latency reported by the latency probe. If the
+	// commit latency is above this value the operator will add commit proxies or resolvers.
+	// Defaults to 50.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TargetCommitLatencyMilliseconds *int `json:"targetCommitLatencyMilliseconds,omitempty"`
+
+	// TargetGRVLatencyMilliseconds defines the targeted transaction start latency reported by the latency probe. If
+	// the transaction start latency is above this value the operator will add GRV proxies.
+	// Defaults to 10.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TargetGRVLatencyMilliseconds *int `json:"targetGRVLatencyMilliseconds,omitempty"`
+
+	// TargetTransactionsPerSecondPerProxy defines the number of transactions per second a single commit proxy or GRV
+	// proxy should handle. The operator will only remove a proxy if the remaining proxies handle less than this
+	// value.
+	// Defaults to 10000.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TargetTransactionsPerSecondPerProxy *int `json:"targetTransactionsPerSecondPerProxy,omitempty"`
+
+	// ScaleDownLatencyPercentage defines the percentage of the targeted latency the measured latency must be below
+	// before the operator removes processes of a role. This prevents the operator from flapping between scaling up
+	// and scaling down.
+	// Defaults to 50.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	ScaleDownLatencyPercentage *int `json:"scaleDownLatencyPercentage,omitempty"`
+
+	// CooldownSeconds defines how long the operator waits after a scaling decision before making the next scaling
+	// decision. Every change of the role counts causes a recovery of the cluster.
+	// Defaults to 600.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	CooldownSeconds *int `json:"cooldownSeconds,omitempty"`
+}
+
+// ResourceRecommendationPolicy defines how the operator collects the CPU and memory usage reported in the
+// machine-readable status to recommend resource requests and limits per process class. The operator will only publish
+// the recommendations in the status and will not change the resources of the Pods.
+type ResourceRecommendationPolicy struct {
+	// Enabled defines if the operator should collect the resource usage and publish resource recommendations.
+	// Defaults to false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// SampleIntervalSeconds defines how often the operator collects a new sample of the resource usage.
+	// Defaults to 900.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	SampleIntervalSeconds *int `json:"sampleIntervalSeconds,omitempty"`
+
+	// MaxSamples defines how many samples will be kept per process class. If more samples are collected the oldest
+	// samples will be dropped. The samples are stored in the cluster status, so the number of samples is limited to 100
+	// per process class to keep the size of the cluster object bounded.
+	// Defaults to 96, which keeps the samples of one day with the default interval.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MaxSamples *int `json:"maxSamples,omitempty"`
+
+	// RequestPercentile defines the percentile of the collected samples that will be used for the recommended
+	// requests. The recommended limits are based on the maximum of the collected samples.
+	// Defaults to 90.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	RequestPercentile *int `json:"requestPercentile,omitempty"`
+
+	// HeadroomPercentage defines the percentage that will be added on top of the observed resource usage for the
+	// recommended requests and limits.
+	// Defaults to 20.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	HeadroomPercentage *int `json:"headroomPercentage,omitempty"`
+}
+
+// RoleAutoscalingBounds defines the bounds for the number of processes of an autoscaled role.
+type RoleAutoscalingBounds struct {
+	// Min defines the minimum number of processes for the role.
+	// Defaults to the role count defined in the database configuration.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Min *int `json:"min,omitempty"`
+
+	// Max defines the maximum number of processes for the role.
+	// Defaults to Min.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Max *int `json:"max,omitempty"`
+}
+
+// AutoscalingStatus contains information about the decisions of the autoscaling policies.
+type AutoscalingStatus struct {
+	// Storage contains information about the decisions of the storage autoscaling policy.
+	Storage *StorageAutoscalingStatus `json:"storage,omitempty"`
+
+	// Stateless contains information about the decisions of the stateless autoscaling policy.
+	Stateless *StatelessAutoscalingStatus `json:"stateless,omitempty"`
+
+	// Resources contains the collected resource usage and the resource recommendations per process class.
+	Resources *ResourceRecommendationStatus `json:"resources,omitempty"`
+}
+
+// StorageAutoscalingStatus contains information about the decisions of the storage autoscaling policy.
+type StorageAutoscalingStatus struct {
+	// DesiredProcessGroups defines the number of storage process groups chosen by the autoscaler.
+	DesiredProcessGroups int `json:"desiredProcessGroups,omitempty"`
+
+	// DesiredVolumeSize defines the size of the storage PVCs chosen by the autoscaler.
+	DesiredVolumeSize *resource.Quantity `json:"desiredVolumeSize,omitempty"`
+
+	// DiskUsagePercentage defines the last observed percentage of used disk space of the storage processes.
+	DiskUsagePercentage int `json:"diskUsagePercentage,omitempty"`
+
+	// LastScalingTimestamp defines when the autoscaler made the last scaling decision.
+	LastScalingTimestamp *metav1.Time `json:"lastScalingTimestamp,omitempty"`
+
+	// LastScalingDecision describes the last scaling decision of the autoscaler.
+	LastScalingDecision string `json:"lastScalingDecision,omitempty"`
+}
+
+// StatelessAutoscalingStatus contains information about the decisions of the stateless autoscaling policy.
+type StatelessAutoscalingStatus struct {
+	// CommitProxies defines the number of commit proxies chosen by the autoscaler.
+	CommitProxies int `json:"commitProxies,omitempty"`
+
+	// GrvProxies defines the number of GRV proxies chosen by the autoscaler.
+	GrvProxies int `json:"grvProxies,omitempty"`
+
+	// Resolvers defines the number of resolvers chosen by the autoscaler.
+	Resolvers int `json:"resolvers,omitempty"`
+
+	// CommitLatencyMilliseconds defines the last observed commit latency.
+	CommitLatencyMilliseconds int `json:"commitLatencyMilliseconds,omitempty"`
+
+	// GRVLatencyMilliseconds defines the last observed transaction start latency.
+	GRVLatencyMilliseconds int `json:"grvLatencyMilliseconds,omitempty"`
+
+	// TransactionsPerSecond defines the last observed rate of started transactions.
+	TransactionsPerSecond int `json:"transactionsPerSecond,omitempty"`
+
+	// LimitingReason defines the last observed reason the ratekeeper limits the transaction rate.
+	LimitingReason string `json:"limitingReason,omitempty"`
+
+	// LastScalingTimestamp defines when the autoscaler made the last scaling decision.
+	LastScalingTimestamp *metav1.Time `json:"lastScalingTimestamp,omitempty"`
+
+	// LastScalingDecision describes the last scaling decision of the autoscaler.
+	LastScalingDecision string `json:"lastScalingDecision,omitempty"`
+}
+
+// ResourceRecommendationStatus contains the collected resource usage and the resource recommendations per process
+// class.
+type ResourceRecommendationStatus struct {
+	// LastSampleTimestamp defines when the last sample of the resource usage was collected.
+	LastSampleTimestamp *metav1.Time `json:"lastSampleTimestamp,omitempty"`
+
+	// ProcessClasses contains the collected resource usage and the resource recommendations for each process class.
+	ProcessClasses []ProcessClassResourceRecommendation `json:"processClasses,omitempty"`
+}
+
+// ProcessClassResourceRecommendation contains the collected resource usage and the resource recommendation for a
+// single process class.
+type ProcessClassResourceRecommendation struct {
+	// ProcessClass defines the process class of the recommendation.
+	ProcessClass ProcessClass `json:"processClass"`
+
+	// Samples contains the collected resource usage samples, ordered from the oldest to the newest sample. The
+	// number of samples is limited by the MaxSamples setting of the policy and will never exceed 100.
+	Samples []ResourceUsageSample `json:"samples,omitempty"`
+
+	// Requests defines the recommended resource requests for the main container.
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+
+	// Limits defines the recommended resource limits for the main container.
+	Limits corev1.ResourceList `json:"limits,omitempty"`
+}
+
+// ResourceUsageSample contains the highest resource usage of a single Pod of a process class at a point in time. If
+// multiple processes are running in a single Pod, the resource usage of those processes is summed up.
+type ResourceUsageSample struct {
+	// CPUMilliCores defines the used CPU in milli cores.
+	CPUMilliCores int64 `json:"cpuMilliCores,omitempty"`
+
+	// MemoryBytes defines the used memory in bytes.
+	MemoryBytes int64 `json:"memoryBytes,omitempty"`
+}
+
+// UseStorageAutoscaling returns true if the storage processes should be scaled based on the disk usage.
+func (cluster *FoundationDBCluster) UseStorageAutoscaling() bool {
+	if cluster.Spec.Autoscaling.Storage == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.Autoscaling.Storage.Enabled, false)
+}
+
+// GetStorageAutoscalingTargetDiskUsagePercentage returns the targeted disk usage percentage for the storage
+// autoscaling or defaults to 70.
+func (cluster *FoundationDBCluster) GetStorageAutoscalingTargetDiskUsagePercentage() int {
+	if cluster.Spec.Autoscaling.Storage == nil {
+		return 70
+	}
+
+	return pointer.IntDeref(cluster.Spec.Autoscaling.Storage.TargetDiskUsagePercentage, 70)
+}
+
+// GetStorageAutoscalingBounds returns the minimum and the maximum number of storage process groups for the storage
+// autoscaling. The provided process count is used as default for the minimum.
+func (cluster *FoundationDBCluster) GetStorageAutoscalingBounds(processCount int) (int, int) {
+	if cluster.Spec.Autoscaling.Storage == nil {
+		return processCount, processCount
+	}
+
+	minimum := pointer.IntDeref(cluster.Spec.Autoscaling.Storage.MinProcessGroups, processCount)
+	maximum := max(
+		pointer.IntDeref(cluster.Spec.Autoscaling.Storage.MaxProcessGroups, minimum),
+		minimum,
+	)
+
+	return minimum, maximum
+}
+
+// GetStorageAutoscalingStepSize returns the number of process groups that will be added or removed in a single
+// scaling decision or defaults to 1.
+func (cluster *FoundationDBCluster) GetStorageAutoscalingStepSize() int {
+	if cluster.Spec.Autoscaling.Storage == nil {
+		return 1
+	}
+
+	return max(pointer.IntDeref(cluster.Spec.Autoscaling.Storage.StepSize, 1), 1)
+}
+
+// GetStorageAutoscalingCooldownSeconds returns the number of seconds to wait between two scaling decisions or
+// defaults to 3600.
+func (cluster *FoundationDBCluster) GetStorageAutoscalingCooldownSeconds() int {
+	if cluster.Spec.Autoscaling.Storage == nil {
+		return 3600
+	}
+
+	return max(pointer.IntDeref(cluster.Spec.Autoscaling.Storage.CooldownSeconds, 3600), 0)
+}
+
+// GetStorageAutoscalingStatus returns the status of the storage autoscaling or nil if no status is present.
+func (cluster *FoundationDBCluster) GetStorageAutoscalingStatus() *StorageAutoscalingStatus {
+	if cluster.Status.Autoscaling == nil {
+		return nil
+	}
+
+	return cluster.Status.Autoscaling.Storage
+}
+
+// getAutoscaledStorageProcessCount returns the number of storage process groups chosen by the storage autoscaling,
+// limited by the bounds of the storage autoscaling. If the storage autoscaling has not made a decision yet, the
+// provided process count limited by the bounds will be returned. If the storage autoscaling is disabled, the provided
+// process count will be returned.
+func (cluster *FoundationDBCluster) getAutoscaledStorageProcessCount(processCount int) int {
+	if !cluster.UseStorageAutoscaling() {
+		return processCount
+	}
+
+	minimum, maximum := cluster.GetStorageAutoscalingBounds(processCount)
+	desired := processCount
+	autoscalingStatus := cluster.GetStorageAutoscalingStatus()
+	if autoscalingStatus != nil && autoscalingStatus.DesiredProcessGroups > 0 {
+		desired = autoscalingStatus.DesiredProcessGroups
+	}
+
+	return min(max(desired, minimum), maximum)
+}
+
+// GetAutoscaledVolumeSize returns the size of the storage PVCs chosen by the storage autoscaling, limited by the
+// MaxVolumeSize. If the storage autoscaling is disabled, has not grown the volumes or the chosen size is smaller than
+// the provided size, nil will be returned.
+func (cluster *FoundationDBCluster) GetAutoscaledVolumeSize(
+	size resource.Quantity,
+) *resource.Quantity {
+	if !cluster.UseStorageAutoscaling() || cluster.Spec.Autoscaling.Storage.MaxVolumeSize == nil {
+		return nil
+	}
+
+	autoscalingStatus := cluster.GetStorageAutoscalingStatus()
+	if autoscalingStatus == nil || autoscalingStatus.DesiredVolumeSize == nil {
+		return nil
+	}
+
+	desired := autoscalingStatus.DesiredVolumeSize.DeepCopy()
+	if desired.Cmp(*cluster.Spec.Autoscaling.Storage.MaxVolumeSize) > 0 {
+		desired = cluster.Spec.Autoscaling.Storage.MaxVolumeSize.DeepCopy()
+	}
+
+	if desired.Cmp(size) <= 0 {
+		return nil
+	}
+
+	return &desired
+}
+
+// UseStatelessAutoscaling returns true if the stateless roles should be scaled based on the QoS information.
+func (cluster *FoundationDBCluster) UseStatelessAutoscaling() bool {
+	if cluster.Spec.Autoscaling.Stateless == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.Autoscaling.Stateless.Enabled, false)
+}
+
+// GetStatelessAutoscalingTargetCommitLatencyMilliseconds returns the targeted commit latency for the stateless
+// autoscaling or defaults to 50.
+func (cluster *FoundationDBCluster) GetStatelessAutoscalingTargetCommitLatencyMilliseconds() int {
+	if cluster.Spec.Autoscaling.Stateless == nil {
+		return 50
+	}
+
+	return pointer.IntDeref(cluster.Spec.Autoscaling.Stateless.TargetCommitLatencyMilliseconds, 50)
+}
+
+// GetStatelessAutoscalingTargetGRVLatencyMilliseconds returns the targeted transaction start latency for the
+// stateless autoscaling or defaults to 10.
+func (cluster *FoundationDBCluster) GetStatelessAutoscalingTargetGRVLatencyMilliseconds() int {
+	if cluster.Spec.Autoscaling.Stateless == nil {
+		return 10
+	}
+
+	return pointer.IntDeref(cluster.Spec.Autoscaling.Stateless.TargetGRVLatencyMilliseconds, 10)
+}
+
+// GetStatelessAutoscalingTargetTransactionsPerSecondPerProxy returns the number of transactions per second a single
+// proxy should handle or defaults to 10000.
+func (cluster *FoundationDBCluster) GetStatelessAutoscalingTargetTransactionsPerSecondPerProxy() int {
+	if cluster.Spec.Autoscaling.Stateless == nil {
+		return 10000
+	}
+
+	return max(
+		pointer.IntDeref(
+			cluster.Spec.Autoscaling.Stateless.TargetTransactionsPerSecondPerProxy,
+			10000,
+		),
+		1,
+	)
+}
+
+// GetStatelessAutoscalingScaleDownLatencyPercentage returns the percentage of the targeted latency the measured
+// latency must be below before processes will be removed or defaults to 50.
+func (cluster *FoundationDBCluster) GetStatelessAutoscalingScaleDownLatencyPercentage() int {
+	if cluster.Spec.Autoscaling.Stateless == nil {
+		return 50
+	}
+
+	return pointer.IntDeref(cluster.Spec.Autoscaling.Stateless.ScaleDownLatencyPercentage, 50)
+}
+
+// GetStatelessAutoscalingCooldownSeconds returns the number of seconds to wait between two scaling decisions or
+// defaults to 600.
+func (cluster *FoundationDBCluster) GetStatelessAutoscalingCooldownSeconds() int {
+	if cluster.Spec.Autoscaling.Stateless == nil {
+		return 600
+	}
+
+	return max(pointer.IntDeref(cluster.Spec.Autoscaling.Stateless.CooldownSeconds, 600), 0)
+}
+
+// GetStatelessAutoscalingStatus returns the status of the stateless autoscaling or nil if no status is present.
+func (cluster *FoundationDBCluster) GetStatelessAutoscalingStatus() *StatelessAutoscalingStatus {
+	if cluster.Status.Autoscaling == nil {
+		return nil
+	}
+
+	return cluster.Status.Autoscaling.Stateless
+}
+
+// getRoleAutoscalingBounds returns the minimum and the maximum for a role based on the provided bounds. The provided
+// count is used as default for the minimum.
+func getRoleAutoscalingBounds(bounds *RoleAutoscalingBounds, count int) (int, int) {
+	if bounds == nil {
+		return count, count
+	}
+
+	minimum := pointer.IntDeref(bounds.Min, count)
+	maximum := max(pointer.IntDeref(bounds.Max, minimum), minimum)
+
+	return minimum, maximum
+}
+
+// GetStatelessAutoscalingBounds returns the minimum and the maximum role counts for the stateless autoscaling. The
+// provided role counts are used as default for the minimum. Only the commit proxies, GRV proxies and resolvers will
+// be modified, the commit proxies and GRV proxies only if separated proxies are configured.
+func (cluster *FoundationDBCluster) GetStatelessAutoscalingBounds(
+	roleCounts RoleCounts,
+) (RoleCounts, RoleCounts) {
+	minimum := *roleCounts.DeepCopy()
+	maximum := *roleCounts.DeepCopy()
+	if cluster.Spec.Autoscaling.Stateless == nil {
+		return minimum, maximum
+	}
+
+	if roleCounts.Proxies == 0 {
+		minimum.CommitProxies, maximum.CommitProxies = getRoleAutoscalingBounds(
+			cluster.Spec.Autoscaling.Stateless.CommitProxies,
+			roleCounts.CommitProxies,
+		)
+		minimum.GrvProxies, maximum.GrvProxies = getRoleAutoscalingBounds(
+			cluster.Spec.Autoscaling.Stateless.GrvProxies,
+			roleCounts.GrvProxies,
+		)
+	}
+
+	minimum.Resolvers, maximum.Resolvers = getRoleAutoscalingBounds(
+		cluster.Spec.Autoscaling.Stateless.Resolvers,
+		roleCounts.Resolvers,
+	)
+
+	return minimum, maximum
+}
+
+// getAutoscaledRoleCount returns the count chosen by the autoscaler limited by the provided bounds. If the autoscaler
+// has not chosen a count, the provided count limited by the bounds will be returned.
+func getAutoscaledRoleCount(count int, autoscaledCount int, minimum int, maximum int) int {
+	desired := count
+	if autoscaledCount > 0 {
+		desired = autoscaledCount
+	}
+
+	return min(max(desired, minimum), maximum)
+}
+
+// getAutoscaledRoleCounts returns the role counts chosen by the stateless autoscaling, limited by the bounds of the
+// stateless autoscaling. If the stateless autoscaling is disabled, the provided role counts will be returned.
+func (cluster *FoundationDBCluster) getAutoscaledRoleCounts(roleCounts RoleCounts) RoleCounts {
+	if !cluster.UseStatelessAutoscaling() {
+		return roleCounts
+	}
+
+	minimum, maximum := cluster.GetStatelessAutoscalingBounds(roleCounts)
+	autoscalingStatus := cluster.GetStatelessAutoscalingStatus()
+	if autoscalingStatus == nil {
+		autoscalingStatus = &StatelessAutoscalingStatus{}
+	}
+
+	if roleCounts.Proxies == 0 {
+		roleCounts.CommitProxies = getAutoscaledRoleCount(
+			roleCounts.CommitProxies,
+			autoscalingStatus.CommitProxies,
+			minimum.CommitProxies,
+			maximum.CommitProxies,
+		)
+		roleCounts.GrvProxies = getAutoscaledRoleCount(
+			roleCounts.GrvProxies,
+			autoscalingStatus.GrvProxies,
+			minimum.GrvProxies,
+			maximum.GrvProxies,
+		)
+	}
+
+	roleCounts.Resolvers = getAutoscaledRoleCount(
+		roleCounts.Resolvers,
+		autoscalingStatus.Resolvers,
+		minimum.Resolvers,
+		maximum.Resolvers,
+	)
+
+	return roleCounts
+}
+
+// getAutoscaledStatelessProcessCountDelta returns the difference between the number of stateless processes required
+// by the autoscaled role counts and the number of stateless processes required by the provided role counts. Roles
+// that run on a dedicated process class are ignored.
+func (cluster *FoundationDBCluster) getAutoscaledStatelessProcessCountDelta(
+	roleCounts RoleCounts,
+	autoscaledRoleCounts RoleCounts,
+) int {
+	processCounts := cluster.Spec.ProcessCounts
+	var delta int
+	if processCounts.CommitProxy <= 0 {
+		delta += autoscaledRoleCounts.CommitProxies - roleCounts.CommitProxies
+	}
+
+	if processCounts.GrvProxy <= 0 {
+		delta += autoscaledRoleCounts.GrvProxies - roleCounts.GrvProxies
+	}
+
+	if processCounts.Resolution <= 0 {
+		delta += autoscaledRoleCounts.Resolvers - roleCounts.Resolvers
+	}
+
+	return delta
+}
+
+// UseResourceRecommendations returns true if the operator should collect the resource usage and publish resource
+// recommendations.
+func (cluster *FoundationDBCluster) UseResourceRecommendations() bool {
+	if cluster.Spec.Autoscaling.Resources == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.Autoscaling.Resources.Enabled, false)
+}
+
+// GetResourceRecommendationSampleIntervalSeconds returns the interval between two resource usage samples or defaults
+// to 900.
+func (cluster *FoundationDBCluster) GetResourceRecommendationSampleIntervalSeconds() int {
+	if cluster.Spec.Autoscaling.Resources == nil {
+		return 900
+	}
+
+	return max(pointer.IntDeref(cluster.Spec.Autoscaling.Resources.SampleIntervalSeconds, 900), 1)
+}
+
+// GetResourceRecommendationMaxSamples returns the number of samples that will be kept per process class or defaults
+// to 96. The returned value is limited to 100, to bound the size of the samples in the cluster status.
+func (cluster *FoundationDBCluster) GetResourceRecommendationMaxSamples() int {
+	if cluster.Spec.Autoscaling.Resources == nil {
+		return 96
+	}
+
+	return min(max(pointer.IntDeref(cluster.Spec.Autoscaling.Resources.MaxSamples, 96), 1), 100)
+}
+
+// GetResourceRecommendationRequestPercentile returns the percentile of the samples used for the recommended requests
+// or defaults to 90.
+func (cluster *FoundationDBCluster) GetResourceRecommendationRequestPercentile() int {
+	if cluster.Spec.Autoscaling.Resources == nil {
+		return 90
+	}
+
+	return min(
+		max(pointer.IntDeref(cluster.Spec.Autoscaling.Resources.RequestPercentile, 90), 1),
+		100,
+	)
+}
+
+// GetResourceRecommendationHeadroomPercentage returns the percentage added on top of the observed resource usage or
+// defaults to 20.
+func (cluster *FoundationDBCluster) GetResourceRecommendationHeadroomPercentage() int {
+	if cluster.Spec.Autoscaling.Resources == nil {
+		return 20
+	}
+
+	return max(pointer.IntDeref(cluster.Spec.Autoscaling.Resources.HeadroomPercentage, 20), 0)
+}
+
+// GetResourceRecommendationStatus returns the status of the resource recommendations or nil if no status is present.
+func (cluster *FoundationDBCluster) GetResourceRecommendationStatus() *ResourceRecommendationStatus {
+	if cluster.Status.Autoscaling == nil {
+		return nil
+	}
+
+	return cluster.Status.Autoscaling.Resources
+}
+
+// GetResourceRecommendation returns the resource recommendation for the provided process class or nil if no
+// recommendation is present.
+func (cluster *FoundationDBCluster) GetResourceRecommendation(
+	processClass ProcessClass,
+) *ProcessClassResourceRecommendation {
+	recommendationStatus := cluster.GetResourceRecommendationStatus()
+	if recommendationStatus == nil {
+		return nil
+	}
+
+	for idx, recommendation := range recommendationStatus.ProcessClasses {
+		if recommendation.ProcessClass == processClass {
+			return &recommendationStatus.ProcessClasses[idx]
+		}
+	}
+
+	return nil
+}
+
+// AddSample adds the sample to the samples of the recommendation. If more than maxSamples samples are present, the
+// oldest samples will be dropped.
+func (recommendation *ProcessClassResourceRecommendation) AddSample(
+	sample ResourceUsageSample,
+	maxSamples int,
+) {
+	recommendation.Samples = append(recommendation.Samples, sample)
+	if len(recommendation.Samples) > maxSamples {
+		recommendation.Samples = slices.Clone(
+			recommendation.Samples[len(recommendation.Samples)-maxSamples:],
+		)
+	}
+}
+
+// UpdateRecommendation calculates the recommended requests and limits based on the collected samples. The requests
+// are based on the provided percentile of the samples and the limits are based on the maximum of the samples, both
+// with the provided headroom added. If no samples are present, the recommendation will be removed.
+func (recommendation *ProcessClassResourceRecommendation) UpdateRecommendation(
+	percentile int,
+	headroomPercentage int,
+) {
+	if len(recommendation.Samples) == 0 {
+		recommendation.Requests = nil
+		recommendation.Limits = nil
+		return
+	}
+
+	cpu := make([]int64, 0, len(recommendation.Samples))
+	memory := make([]int64, 0, len(recommendation.Samples))
+	for _, sample := range recommendation.Samples {
+		cpu = append(cpu, sample.CPUMilliCores)
+		memory = append(memory, sample.MemoryBytes)
+	}
+
+	slices.Sort(cpu)
+	slices.Sort(memory)
+
+	// Use the nearest-rank method to calculate the percentile.
+	rank := max(int(math.Ceil(float64(percentile)*float64(len(cpu))/100.0))-1, 0)
+	recommendation.Requests = getRecommendedResources(cpu[rank], memory[rank], headroomPercentage)
+	recommendation.Limits = getRecommendedResources(
+		cpu[len(cpu)-1],
+		memory[len(memory)-1],
+		headroomPercentage,
+	)
+}
+
+// getRecommendedResources returns the resource list for the provided usage with the headroom added. The CPU is
+// rounded up to the next milli core and the memory is rounded up to the next MiB.
+func getRecommendedResources(
+	cpuMilliCores int64,
+	memoryBytes int64,
+	headroomPercentage int,
+) corev1.ResourceList {
+	cpu := max((cpuMilliCores*int64(100+headroomPercentage)+99)/100, 1)
+	memory := (memoryBytes*int64(100+headroomPercentage) + 99) / 100
+	mebibyte := int64(1024 * 1024)
+	memory = max((memory+mebibyte-1)/mebibyte, 1) * mebibyte
+
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    *resource.NewMilliQuantity(cpu, resource.DecimalSI),
+		corev1.ResourceMemory: *resource.NewQuantity(memory, resource.BinarySI),
+	}
+}
@@ -0,0 +1,391 @@
+/*
+ * foundationdb_autoscaling_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("[api] FoundationDB autoscaling", func() {
+	var cluster *FoundationDBCluster
+
+	BeforeEach(func() {
+		cluster = &FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "default",
+			},
+			Spec: FoundationDBClusterSpec{
+				Version: Versions.Default.String(),
+				DatabaseConfiguration: DatabaseConfiguration{
+					RedundancyMode: RedundancyModeDouble,
+					RoleCounts: RoleCounts{
+						Storage: 5,
+					},
+				},
+			},
+		}
+	})
+
+	When("getting the storage process count", func() {
+		DescribeTable(
+			"should return the expected storage process count",
+			func(policy *StorageAutoscalingPolicy, autoscalingStatus *StorageAutoscalingStatus, expected int) {
+				cluster.Spec.Autoscaling.Storage = policy
+				if autoscalingStatus != nil {
+					cluster.Status.Autoscaling = &AutoscalingStatus{
+						Storage: autoscalingStatus,
+					}
+				}
+
+				counts, err := cluster.GetProcessCountsWithDefaults()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(counts.Storage).To(Equal(expected))
+			},
+			Entry("autoscaling is not configured", nil, nil, 5),
+			Entry("autoscaling is disabled",
+				&StorageAutoscalingPolicy{
+					Enabled:          pointer.Bool(false),
+					MinProcessGroups: pointer.Int(8),
+				},
+				&StorageAutoscalingStatus{
+					DesiredProcessGroups: 10,
+				},
+				5,
+			),
+			Entry("autoscaling is enabled without a decision",
+				&StorageAutoscalingPolicy{
+					Enabled:          pointer.Bool(true),
+					MinProcessGroups: pointer.Int(3),
+					MaxProcessGroups: pointer.Int(10),
+				},
+				nil,
+				5,
+			),
+			Entry(
+				"autoscaling is enabled without a decision and the minimum is above the process count",
+				&StorageAutoscalingPolicy{
+					Enabled:          pointer.Bool(true),
+					MinProcessGroups: pointer.Int(8),
+					MaxProcessGroups: pointer.Int(10),
+				},
+				nil,
+				8,
+			),
+			Entry("autoscaling is enabled with a decision",
+				&StorageAutoscalingPolicy{
+					Enabled:          pointer.Bool(true),
+					MinProcessGroups: pointer.Int(3),
+					MaxProcessGroups: pointer.Int(10),
+				},
+				&StorageAutoscalingStatus{
+					DesiredProcessGroups: 7,
+				},
+				7,
+			),
+			Entry("autoscaling is enabled with a decision above the maximum",
+				&StorageAutoscalingPolicy{
+					Enabled:          pointer.Bool(true),
+					MinProcessGroups: pointer.Int(3),
+					MaxProcessGroups: pointer.Int(10),
+				},
+				&StorageAutoscalingStatus{
+					DesiredProcessGroups: 12,
+				},
+				10,
+			),
+		)
+	})
+
+	When("getting the autoscaled volume size", func() {
+		var maxVolumeSize resource.Quantity
+
+		BeforeEach(func() {
+			maxVolumeSize = resource.MustParse("256G")
+			cluster.Spec.Autoscaling.Storage = &StorageAutoscalingPolicy{
+				Enabled:       pointer.Bool(true),
+				MaxVolumeSize: &maxVolumeSize,
+			}
+		})
+
+		DescribeTable("should return the expected volume size",
+			func(desiredVolumeSize *resource.Quantity, expected *resource.Quantity) {
+				if desiredVolumeSize != nil {
+					cluster.Status.Autoscaling = &AutoscalingStatus{
+						Storage: &StorageAutoscalingStatus{
+							DesiredVolumeSize: desiredVolumeSize,
+						},
+					}
+				}
+
+				size := cluster.GetAutoscaledVolumeSize(resource.MustParse("128G"))
+				if expected == nil {
+					Expect(size).To(BeNil())
+					return
+				}
+
+				Expect(size).NotTo(BeNil())
+				Expect(size.Equal(*expected)).To(BeTrue())
+			},
+			Entry("no decision was made", nil, nil),
+			Entry("the desired size is smaller than the current size",
+				resource.NewScaledQuantity(64, resource.Giga),
+				nil,
+			),
+			Entry("the desired size is bigger than the current size",
+				resource.NewScaledQuantity(200, resource.Giga),
+				resource.NewScaledQuantity(200, resource.Giga),
+			),
+			Entry("the desired size is bigger than the maximum volume size",
+				resource.NewScaledQuantity(512, resource.Giga),
+				resource.NewScaledQuantity(256, resource.Giga),
+			),
+		)
+	})
+
+	When("getting the stateless role counts", func() {
+		BeforeEach(func() {
+			cluster.Spec.DatabaseConfiguration.CommitProxies = 2
+			cluster.Spec.DatabaseConfiguration.GrvProxies = 1
+		})
+
+		DescribeTable(
+			"should return the expected role counts",
+			func(policy *StatelessAutoscalingPolicy, autoscalingStatus *StatelessAutoscalingStatus, expected RoleCounts) {
+				cluster.Spec.Autoscaling.Stateless = policy
+				if autoscalingStatus != nil {
+					cluster.Status.Autoscaling = &AutoscalingStatus{
+						Stateless: autoscalingStatus,
+					}
+				}
+
+				counts := cluster.GetRoleCountsWithDefaults()
+				Expect(counts.CommitProxies).To(Equal(expected.CommitProxies))
+				Expect(counts.GrvProxies).To(Equal(expected.GrvProxies))
+				Expect(counts.Resolvers).To(Equal(expected.Resolvers))
+			},
+			Entry("autoscaling is not configured", nil, nil, RoleCounts{
+				CommitProxies: 2,
+				GrvProxies:    1,
+				Resolvers:     1,
+			}),
+			Entry("autoscaling is disabled",
+				&StatelessAutoscalingPolicy{
+					Enabled: pointer.Bool(false),
+				},
+				&StatelessAutoscalingStatus{
+					CommitProxies: 4,
+				},
+				RoleCounts{
+					CommitProxies: 2,
+					GrvProxies:    1,
+					Resolvers:     1,
+				},
+			),
+			Entry("autoscaling is enabled with a decision",
+				&StatelessAutoscalingPolicy{
+					Enabled: pointer.Bool(true),
+					CommitProxies: &RoleAutoscalingBounds{
+						Max: pointer.Int(4),
+					},
+					Resolvers: &RoleAutoscalingBounds{
+						Max: pointer.Int(2),
+					},
+				},
+				&StatelessAutoscalingStatus{
+					CommitProxies: 3,
+					GrvProxies:    2,
+					Resolvers:     2,
+				},
+				RoleCounts{
+					CommitProxies: 3,
+					GrvProxies:    1,
+					Resolvers:     2,
+				},
+			),
+			Entry("autoscaling is enabled with a decision above the maximum",
+				&StatelessAutoscalingPolicy{
+					Enabled: pointer.Bool(true),
+					CommitProxies: &RoleAutoscalingBounds{
+						Max: pointer.Int(4),
+					},
+				},
+				&StatelessAutoscalingStatus{
+					CommitProxies: 6,
+				},
+				RoleCounts{
+					CommitProxies: 4,
+					GrvProxies:    1,
+					Resolvers:     1,
+				},
+			),
+		)
+
+		When("the stateless process count is defined in the spec", func() {
+			BeforeEach(func() {
+				cluster.Spec.ProcessCounts.Stateless = 10
+				cluster.Spec.Autoscaling.Stateless = &StatelessAutoscalingPolicy{
+					Enabled: pointer.Bool(true),
+					CommitProxies: &RoleAutoscalingBounds{
+						Min: pointer.Int(1),
+						Max: pointer.Int(4),
+					},
+				}
+			})
+
+			It("should add the processes for the additional roles", func() {
+				cluster.Status.Autoscaling = &AutoscalingStatus{
+					Stateless: &StatelessAutoscalingStatus{
+						CommitProxies: 4,
+					},
+				}
+
+				counts, err := cluster.GetProcessCountsWithDefaults()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(counts.Stateless).To(Equal(12))
+			})
+
+			It("should remove the processes for the removed roles", func() {
+				cluster.Status.Autoscaling = &AutoscalingStatus{
+					Stateless: &StatelessAutoscalingStatus{
+						CommitProxies: 1,
+					},
+				}
+
+				counts, err := cluster.GetProcessCountsWithDefaults()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(counts.Stateless).To(Equal(9))
+			})
+		})
+
+		It(
+			"should add the processes for the additional roles to the default stateless process count",
+			func() {
+				counts, err := cluster.GetProcessCountsWithDefaults()
+				Expect(err).NotTo(HaveOccurred())
+				defaultStateless := counts.Stateless
+
+				cluster.Spec.Autoscaling.Stateless = &StatelessAutoscalingPolicy{
+					Enabled: pointer.Bool(true),
+					CommitProxies: &RoleAutoscalingBounds{
+						Max: pointer.Int(4),
+					},
+				}
+				cluster.Status.Autoscaling = &AutoscalingStatus{
+					Stateless: &StatelessAutoscalingStatus{
+						CommitProxies: 4,
+					},
+				}
+
+				counts, err = cluster.GetProcessCountsWithDefaults()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(counts.Stateless).To(Equal(defaultStateless + 2))
+			},
+		)
+	})
+
+	When("collecting resource recommendations", func() {
+		var recommendation *ProcessClassResourceRecommendation
+
+		BeforeEach(func() {
+			recommendation = &ProcessClassResourceRecommendation{
+				ProcessClass: ProcessClassStorage,
+			}
+		})
+
+		It("should only keep the newest samples", func() {
+			for i := 1; i <= 5; i++ {
+				recommendation.AddSample(ResourceUsageSample{CPUMilliCores: int64(i)}, 3)
+			}
+
+			Expect(recommendation.Samples).To(ConsistOf(
+				ResourceUsageSample{CPUMilliCores: 3},
+				ResourceUsageSample{CPUMilliCores: 4},
+				ResourceUsageSample{CPUMilliCores: 5},
+			))
+			Expect(recommendation.Samples[0].CPUMilliCores).To(BeNumerically("==", 3))
+		})
+
+		It("should remove the recommendation if no samples are present", func() {
+			recommendation.Requests = corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			}
+			recommendation.UpdateRecommendation(90, 20)
+			Expect(recommendation.Requests).To(BeNil())
+			Expect(recommendation.Limits).To(BeNil())
+		})
+
+		It(
+			"should recommend the requests based on the percentile and the limits based on the maximum",
+			func() {
+				for i := 1; i <= 10; i++ {
+					recommendation.AddSample(ResourceUsageSample{
+						CPUMilliCores: int64(i * 100),
+						MemoryBytes:   int64(i) * 1024 * 1024 * 1024,
+					}, 10)
+				}
+
+				recommendation.UpdateRecommendation(90, 20)
+				Expect(recommendation.Requests.Cpu().MilliValue()).To(BeNumerically("==", 1080))
+				Expect(recommendation.Requests.Memory().Value()).To(
+					BeNumerically("==", int64(11060)*1024*1024),
+				)
+				Expect(recommendation.Limits.Cpu().MilliValue()).To(BeNumerically("==", 1200))
+				Expect(recommendation.Limits.Memory().Value()).To(
+					BeNumerically("==", int64(12288)*1024*1024),
+				)
+			},
+		)
+	})
+
+	When("getting the maximum number of resource usage samples", func() {
+		DescribeTable("should return the expected number of samples",
+			func(resources *ResourceRecommendationPolicy, expected int) {
+				cluster := &FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Autoscaling: AutoscalingSpec{
+							Resources: resources,
+						},
+					},
+				}
+
+				Expect(cluster.GetResourceRecommendationMaxSamples()).To(Equal(expected))
+			},
+			Entry("no policy is defined", nil, 96),
+			Entry("max samples is not defined", &ResourceRecommendationPolicy{}, 96),
+			Entry(
+				"max samples is defined",
+				&ResourceRecommendationPolicy{MaxSamples: pointer.Int(10)},
+				10,
+			),
+			Entry(
+				"max samples is above the limit",
+				&ResourceRecommendationPolicy{MaxSamples: pointer.Int(1000)},
+				100,
+			),
+		)
+	})
+})
@@ -30,6 +30,9 @@ const (
 	// InitContainerName represents the container name of the init container.
 	InitContainerName = "foundationdb-kubernetes-init"
 
+	// TraceLogForwarderContainerName represents the container name of the trace log forwarder container.
+	TraceLogForwarderContainerName = "trace-log-forwarder"
+
 	// NoneFaultDomainKey represents the none fault domain, where every Pod is a fault domain.
 	NoneFaultDomainKey = "foundationdb.org/none"
 
@@ -29,6 +29,10 @@ const (
 	// config map.
 	LastConfigMapKey = "foundationdb.org/last-applied-config-map"
 
+	// InPlaceResizeSpecKey provides the annotation name we use to store the hash
+	// of the pod spec that is applied by an in-place resize of the pod.
+	InPlaceResizeSpecKey = "foundationdb.org/in-place-resize-spec"
+
 	// BackupDeploymentLabel provides the label we use to connect backup
 	// deployments to a cluster.
 	BackupDeploymentLabel = "foundationdb.org/backup-for"
@@ -59,6 +63,10 @@ const (
 	// ImageTypeAnnotation is an annotation key that specifies the image type of the Pod.
 	ImageTypeAnnotation = "foundationdb.org/image-type"
 
+	// StorageClassMigrationPausedAnnotation is an annotation key on the cluster that pauses an ongoing storage class
+	// migration if set to "true".
+	StorageClassMigrationPausedAnnotation = "foundationdb.org/storage-class-migration-paused"
+
 	// FDBProcessGroupIDLabel represents the label that is used to represent a instance ID
 	FDBProcessGroupIDLabel = "foundationdb.org/fdb-process-group-id"
 
@@ -0,0 +1,119 @@
+/*
+ * foundationdb_recovery_budget.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// RecoveryBudgetOptions defines how many actions that can cause a recovery the operator is allowed to perform in a
+// time window.
+type RecoveryBudgetOptions struct {
+	// MaxRecoveries defines how many actions that can cause a recovery the operator is allowed to perform in the
+	// time window. Those actions are exclusions, inclusions, coordinator changes, database configuration changes,
+	// process bounces and Pod recreations for spec updates. If unset, the recovery budget is disabled.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxRecoveries *int `json:"maxRecoveries,omitempty"`
+
+	// WindowSeconds defines the duration of the sliding time window for the recovery budget.
+	// Defaults to 3600.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	WindowSeconds *int `json:"windowSeconds,omitempty"`
+}
+
+// RecoveryAction represents an action of the operator that can cause a recovery.
+// +kubebuilder:validation:MaxLength=64
+type RecoveryAction string
+
+const (
+	// RecoveryActionExclude represents the exclusion of processes.
+	RecoveryActionExclude RecoveryAction = "Exclude"
+	// RecoveryActionInclude represents the inclusion of processes.
+	RecoveryActionInclude RecoveryAction = "Include"
+	// RecoveryActionChangeCoordinators represents a change of the coordinators.
+	RecoveryActionChangeCoordinators RecoveryAction = "ChangeCoordinators"
+	// RecoveryActionConfigureDatabase represents a change of the database configuration.
+	RecoveryActionConfigureDatabase RecoveryAction = "ConfigureDatabase"
+	// RecoveryActionBounce represents the restart of processes.
+	RecoveryActionBounce RecoveryAction = "Bounce"
+	// RecoveryActionUpdatePods represents the recreation of Pods to apply spec updates.
+	RecoveryActionUpdatePods RecoveryAction = "UpdatePods"
+)
+
+// RecoveryBudgetEntry represents an action of the operator that can cause a recovery.
+type RecoveryBudgetEntry struct {
+	// Action defines the action that was performed by the operator.
+	Action RecoveryAction `json:"action,omitempty"`
+
+	// Timestamp defines when the action was performed.
+	Timestamp metav1.Time `json:"timestamp,omitempty"`
+}
+
+// RecoveryBudgetStatus contains information about the recovery budget of the cluster.
+type RecoveryBudgetStatus struct {
+	// Recoveries contains the actions that can cause a recovery and were performed by the operator in the current
+	// time window.
+	Recoveries []RecoveryBudgetEntry `json:"recoveries,omitempty"`
+
+	// RemainingRecoveries defines how many actions that can cause a recovery the operator is still allowed to
+	// perform in the current time window.
+	RemainingRecoveries int `json:"remainingRecoveries"`
+
+	// NextRecoveryTimestamp defines when the operator is allowed to perform the next action that can cause a
+	// recovery, if the recovery budget is exhausted.
+	NextRecoveryTimestamp *metav1.Time `json:"nextRecoveryTimestamp,omitempty"`
+
+	// LastRecoveryTimestamp defines when the cluster recovered the last time, based on the recovery state in the
+	// machine-readable status.
+	LastRecoveryTimestamp *metav1.Time `json:"lastRecoveryTimestamp,omitempty"`
+}
+
+// UseRecoveryBudget returns true if the recovery budget is enabled for the cluster.
+func (cluster *FoundationDBCluster) UseRecoveryBudget() bool {
+	return cluster.Spec.AutomationOptions.RecoveryBudget != nil &&
+		cluster.Spec.AutomationOptions.RecoveryBudget.MaxRecoveries != nil
+}
+
+// GetMaxRecoveries returns how many actions that can cause a recovery the operator is allowed to perform in the time
+// window of the recovery budget. If the recovery budget is disabled, 0 will be returned.
+func (cluster *FoundationDBCluster) GetMaxRecoveries() int {
+	if !cluster.UseRecoveryBudget() {
+		return 0
+	}
+
+	return *cluster.Spec.AutomationOptions.RecoveryBudget.MaxRecoveries
+}
+
+// GetRecoveryBudgetWindow returns the duration of the time window for the recovery budget or defaults to 1 hour.
+func (cluster *FoundationDBCluster) GetRecoveryBudgetWindow() time.Duration {
+	if cluster.Spec.AutomationOptions.RecoveryBudget == nil {
+		return time.Hour
+	}
+
+	return time.Duration(
+		pointer.IntDeref(cluster.Spec.AutomationOptions.RecoveryBudget.WindowSeconds, 3600),
+	) * time.Second
+}
@@ -107,6 +107,10 @@ type FoundationDBStatusClusterInfo struct {
 	// MaintenanceZone contains current zone under maintenance, if any.
 	MaintenanceZone FaultDomain `json:"maintenance_zone,omitempty"`
 
+	// MaintenanceSecondsRemaining contains the remaining seconds until the
+	// maintenance zone expires, if any.
+	MaintenanceSecondsRemaining float64 `json:"maintenance_seconds_remaining,omitempty"`
+
 	// Clients provides information about clients that are connected to the
 	// database.
 	Clients FoundationDBStatusClusterClientInfo `json:"clients,omitempty"`
@@ -121,6 +125,12 @@ type FoundationDBStatusClusterInfo struct {
 	// Qos provides information about various qos metrics of the cluster.
 	Qos FoundationDBStatusQosInfo `json:"qos,omitempty"`
 
+	// LatencyProbe provides information about the latencies measured by the cluster controller.
+	LatencyProbe FoundationDBStatusLatencyProbe `json:"latency_probe,omitempty"`
+
+	// Workload provides information about the workload of the cluster.
+	Workload FoundationDBStatusWorkload `json:"workload,omitempty"`
+
 	// FaultTolerance provides information about the fault tolerance status
 	// of the cluster.
 	FaultTolerance FaultTolerance `json:"fault_tolerance,omitempty"`
@@ -203,6 +213,66 @@ type FoundationDBStatusProcessInfo struct {
 
 	// Messages contains error messages from that fdbserver process instance
 	Messages []FoundationDBStatusProcessMessage `json:"messages,omitempty"`
+
+	// CPU contains the CPU statistics of the process.
+	CPU FoundationDBStatusProcessCPU `json:"cpu,omitempty"`
+
+	// Memory contains the memory statistics of the process.
+	Memory FoundationDBStatusProcessMemory `json:"memory,omitempty"`
+
+	// Disk contains the statistics of the disk used by the process.
+	Disk FoundationDBStatusProcessDisk `json:"disk,omitempty"`
+
+	// Network contains the network statistics of the process.
+	Network FoundationDBStatusProcessNetwork `json:"network,omitempty"`
+}
+
+// FoundationDBStatusProcessCPU contains the CPU statistics of a process.
+type FoundationDBStatusProcessCPU struct {
+	// UsageCores defines the number of CPU cores used by the process.
+	UsageCores float64 `json:"usage_cores,omitempty"`
+}
+
+// FoundationDBStatusProcessMemory contains the memory statistics of a process.
+type FoundationDBStatusProcessMemory struct {
+	// AvailableBytes defines the memory in bytes that is available for the process.
+	AvailableBytes int64 `json:"available_bytes,omitempty"`
+
+	// LimitBytes defines the memory limit in bytes of the process.
+	LimitBytes int64 `json:"limit_bytes,omitempty"`
+
+	// UsedBytes defines the memory in bytes that is used by the process.
+	UsedBytes int64 `json:"used_bytes,omitempty"`
+}
+
+// FoundationDBStatusProcessDisk contains the statistics of the disk used by a process.
+type FoundationDBStatusProcessDisk struct {
+	// Busy defines the fraction of time the disk was busy.
+	Busy float64 `json:"busy,omitempty"`
+
+	// FreeBytes defines the free space of the disk in bytes.
+	FreeBytes int64 `json:"free_bytes,omitempty"`
+
+	// TotalBytes defines the total space of the disk in bytes.
+	TotalBytes int64 `json:"total_bytes,omitempty"`
+}
+
+// FoundationDBStatusProcessNetwork contains the network statistics of a process.
+type FoundationDBStatusProcessNetwork struct {
+	// CurrentConnections defines the number of currently open connections.
+	CurrentConnections int `json:"current_connections,omitempty"`
+
+	// MegabitsReceived defines the rate of received megabits per second.
+	MegabitsReceived FoundationDBStatusRate `json:"megabits_received,omitempty"`
+
+	// MegabitsSent defines the rate of sent megabits per second.
+	MegabitsSent FoundationDBStatusRate `json:"megabits_sent,omitempty"`
+}
+
+// FoundationDBStatusRate represents a rate in the machine-readable status.
+type FoundationDBStatusRate struct {
+	// Hz defines the rate per second.
+	Hz float64 `json:"hz,omitempty"`
 }
 
 // FoundationDBStatusProcessMessage represents an error message in the status json
@@ -228,6 +298,12 @@ type FoundationDBStatusProcessRoleInfo struct {
 	// DataLag indicates whether this process is lagging in its writes.
 	DataLag FoundationDBStatusLagInfo `json:"data_lag,omitempty"`
 
+	// DurabilityLag indicates whether this process is lagging in making its writes durable.
+	DurabilityLag FoundationDBStatusLagInfo `json:"durability_lag,omitempty"`
+
+	// StorageMetadata contains the metadata of a storage server, e.g. the storage engine it uses.
+	StorageMetadata *FoundationDBStatusStorageMetadata `json:"storage_metadata,omitempty"`
+
 	// KVStoreUsedBytes indicates how much space this process is using on its disk.
 	KVStoreUsedBytes *int64 `json:"kvstore_used_bytes"`
 
@@ -250,6 +326,15 @@ type FoundationDBStatusProcessRoleInfo struct {
 	GRVLatencyStatistics FoundationDBStatusGRVStatistics `json:"grv_latency_statistics"`
 }
 
+// FoundationDBStatusStorageMetadata provides the metadata of a storage server.
+type FoundationDBStatusStorageMetadata struct {
+	// CreatedTimeTimestamp provides the timestamp when the storage server was created.
+	CreatedTimeTimestamp float64 `json:"created_time_timestamp,omitempty"`
+
+	// StorageEngine provides the storage engine the storage server uses.
+	StorageEngine StorageEngine `json:"storage_engine,omitempty"`
+}
+
 // FoundationDBStatusPerfStatistics models information about one dimension of a process's performance.
 type FoundationDBStatusPerfStatistics struct {
 	// Count provides the number of observations for this stat.
@@ -467,6 +552,49 @@ type FoundationDBStatusQosInfo struct {
 	WorstQueueBytesLogServer int64 `json:"worst_queue_bytes_log_server,omitempty"`
 	// WorstQueueBytesStorageServer refers to the worst_queue_bytes_storage_server field in the machine readable status
 	WorstQueueBytesStorageServer int64 `json:"worst_queue_bytes_storage_server,omitempty"`
+	// PerformanceLimitedBy refers to the performance_limited_by field in the machine readable status
+	PerformanceLimitedBy FoundationDBStatusPerformanceLimitedBy `json:"performance_limited_by,omitempty"`
+	// ReleasedTransactionsPerSecond refers to the released_transactions_per_second field in the machine readable status
+	ReleasedTransactionsPerSecond float64 `json:"released_transactions_per_second,omitempty"`
+	// TransactionsPerSecondLimit refers to the transactions_per_second_limit field in the machine readable status
+	TransactionsPerSecondLimit float64 `json:"transactions_per_second_limit,omitempty"`
+}
+
+// FoundationDBStatusPerformanceLimitedBy provides information about the reason the ratekeeper limits the
+// transaction rate.
+type FoundationDBStatusPerformanceLimitedBy struct {
+	// Name of the limiting reason, "workload" if the cluster is not saturated.
+	Name string `json:"name,omitempty"`
+	// Description of the limiting reason.
+	Description string `json:"description,omitempty"`
+	// ReasonID is the ID of the limiting reason.
+	ReasonID int `json:"reason_id,omitempty"`
+}
+
+// FoundationDBStatusLatencyProbe provides information about the latencies measured by the cluster controller.
+type FoundationDBStatusLatencyProbe struct {
+	// CommitSeconds defines the time in seconds to commit a transaction.
+	CommitSeconds float64 `json:"commit_seconds,omitempty"`
+	// ReadSeconds defines the time in seconds to perform a single read.
+	ReadSeconds float64 `json:"read_seconds,omitempty"`
+	// TransactionStartSeconds defines the time in seconds to get a read version for a default priority transaction.
+	TransactionStartSeconds float64 `json:"transaction_start_seconds,omitempty"`
+}
+
+// FoundationDBStatusWorkload provides information about the workload of the cluster.
+type FoundationDBStatusWorkload struct {
+	// Transactions provides information about the transaction rates.
+	Transactions FoundationDBStatusWorkloadTransactions `json:"transactions,omitempty"`
+}
+
+// FoundationDBStatusWorkloadTransactions provides information about the transaction rates.
+type FoundationDBStatusWorkloadTransactions struct {
+	// Started defines the rate of started transactions.
+	Started FoundationDBStatusRate `json:"started,omitempty"`
+	// Committed defines the rate of committed transactions.
+	Committed FoundationDBStatusRate `json:"committed,omitempty"`
+	// Conflicted defines the rate of conflicted transactions.
+	Conflicted FoundationDBStatusRate `json:"conflicted,omitempty"`
 }
 
 // ProcessRole models the role of a pod.
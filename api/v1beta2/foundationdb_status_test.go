@@ -83,6 +83,22 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0026,
+					CPU:           FoundationDBStatusProcessCPU{UsageCores: 0.036252700000000006},
+					Memory: FoundationDBStatusProcessMemory{
+						AvailableBytes: 8589934592,
+						LimitBytes:     8589934592,
+						UsedBytes:      189898752,
+					},
+					Disk: FoundationDBStatusProcessDisk{
+						Busy:       0.00979976,
+						FreeBytes:  84178145280,
+						TotalBytes: 135012552704,
+					},
+					Network: FoundationDBStatusProcessNetwork{
+						CurrentConnections: 9,
+						MegabitsReceived:   FoundationDBStatusRate{Hz: 0.17158},
+						MegabitsSent:       FoundationDBStatusRate{Hz: 0.224244},
+					},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{Role: string(ProcessRoleCoordinator)},
 						{
@@ -108,6 +124,13 @@ var _ = Describe("FoundationDBStatus", func() {
 								Seconds:  0.19625800000000002,
 								Versions: 196258,
 							},
+							DurabilityLag: FoundationDBStatusLagInfo{
+								Seconds:  5.19626,
+								Versions: 5196258,
+							},
+							StorageMetadata: &FoundationDBStatusStorageMetadata{
+								CreatedTimeTimestamp: 1646933167898430464,
+							},
 							KVStoreUsedBytes:      pointer.Int64(104878232),
 							KVStoreTotalBytes:     pointer.Int64(135012552704),
 							KVStoreFreeBytes:      pointer.Int64(84178223104),
@@ -137,6 +160,22 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0031,
+					CPU:           FoundationDBStatusProcessCPU{UsageCores: 0.0126458},
+					Memory: FoundationDBStatusProcessMemory{
+						AvailableBytes: 8589934592,
+						LimitBytes:     8589934592,
+						UsedBytes:      196194304,
+					},
+					Disk: FoundationDBStatusProcessDisk{
+						Busy:       0.00979973,
+						FreeBytes:  84178145280,
+						TotalBytes: 135012552704,
+					},
+					Network: FoundationDBStatusProcessNetwork{
+						CurrentConnections: 9,
+						MegabitsReceived:   FoundationDBStatusRate{Hz: 0.07050680000000001},
+						MegabitsSent:       FoundationDBStatusRate{Hz: 0.09460210000000001},
+					},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{Role: string(ProcessRoleCoordinator)},
 						{
@@ -146,6 +185,13 @@ var _ = Describe("FoundationDBStatus", func() {
 								Seconds:  2.1227,
 								Versions: 2122697,
 							},
+							DurabilityLag: FoundationDBStatusLagInfo{
+								Seconds:  5.0,
+								Versions: 5000000,
+							},
+							StorageMetadata: &FoundationDBStatusStorageMetadata{
+								CreatedTimeTimestamp: 1646933167898430464,
+							},
 							KVStoreUsedBytes:      pointer.Int64(104878232),
 							KVStoreTotalBytes:     pointer.Int64(135012552704),
 							KVStoreFreeBytes:      pointer.Int64(84178239488),
@@ -179,6 +225,22 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0029,
+					CPU:           FoundationDBStatusProcessCPU{UsageCores: 0.016351300000000003},
+					Memory: FoundationDBStatusProcessMemory{
+						AvailableBytes: 8589934592,
+						LimitBytes:     8589934592,
+						UsedBytes:      196325376,
+					},
+					Disk: FoundationDBStatusProcessDisk{
+						Busy:       0.0097998,
+						FreeBytes:  84178145280,
+						TotalBytes: 135012552704,
+					},
+					Network: FoundationDBStatusProcessNetwork{
+						CurrentConnections: 9,
+						MegabitsReceived:   FoundationDBStatusRate{Hz: 0.07294579999999999},
+						MegabitsSent:       FoundationDBStatusRate{Hz: 0.0944302},
+					},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{Role: string(ProcessRoleCoordinator)},
 						{
@@ -197,6 +259,13 @@ var _ = Describe("FoundationDBStatus", func() {
 								Seconds:  0.19625800000000002,
 								Versions: 196258,
 							},
+							DurabilityLag: FoundationDBStatusLagInfo{
+								Seconds:  5.0,
+								Versions: 5000000,
+							},
+							StorageMetadata: &FoundationDBStatusStorageMetadata{
+								CreatedTimeTimestamp: 1646933168295447808,
+							},
 							KVStoreUsedBytes:      pointer.Int64(104861752),
 							KVStoreTotalBytes:     pointer.Int64(135012552704),
 							KVStoreFreeBytes:      pointer.Int64(84178112512),
@@ -226,6 +295,22 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0027,
+					CPU:           FoundationDBStatusProcessCPU{UsageCores: 0.0418108},
+					Memory: FoundationDBStatusProcessMemory{
+						AvailableBytes: 8589934592,
+						LimitBytes:     8589934592,
+						UsedBytes:      141787136,
+					},
+					Disk: FoundationDBStatusProcessDisk{
+						Busy:       0.0101997,
+						FreeBytes:  84178165760,
+						TotalBytes: 135012552704,
+					},
+					Network: FoundationDBStatusProcessNetwork{
+						CurrentConnections: 7,
+						MegabitsReceived:   FoundationDBStatusRate{Hz: 0.24052400000000002},
+						MegabitsSent:       FoundationDBStatusRate{Hz: 0.156584},
+					},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{
 							Role: string(ProcessRoleMaster),
@@ -258,6 +343,22 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0029,
+					CPU:           FoundationDBStatusProcessCPU{UsageCores: 0.011798900000000001},
+					Memory: FoundationDBStatusProcessMemory{
+						AvailableBytes: 8589934592,
+						LimitBytes:     8589934592,
+						UsedBytes:      142704640,
+					},
+					Disk: FoundationDBStatusProcessDisk{
+						Busy:       0.0101994,
+						FreeBytes:  84178165760,
+						TotalBytes: 135012552704,
+					},
+					Network: FoundationDBStatusProcessNetwork{
+						CurrentConnections: 9,
+						MegabitsReceived:   FoundationDBStatusRate{Hz: 0.186401},
+						MegabitsSent:       FoundationDBStatusRate{Hz: 0.188231},
+					},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{
 							Role: string(ProcessClassClusterController),
@@ -282,6 +383,22 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0029,
+					CPU:           FoundationDBStatusProcessCPU{UsageCores: 0.012726600000000001},
+					Memory: FoundationDBStatusProcessMemory{
+						AvailableBytes: 8589934592,
+						LimitBytes:     8589934592,
+						UsedBytes:      216772608,
+					},
+					Disk: FoundationDBStatusProcessDisk{
+						Busy:       0.0101993,
+						FreeBytes:  84178165760,
+						TotalBytes: 135012552704,
+					},
+					Network: FoundationDBStatusProcessNetwork{
+						CurrentConnections: 5,
+						MegabitsReceived:   FoundationDBStatusRate{Hz: 0.0389479},
+						MegabitsSent:       FoundationDBStatusRate{Hz: 0.054306900000000005},
+					},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{
 							Role:                  string(ProcessRoleLog),
@@ -310,6 +427,22 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.003,
+					CPU:           FoundationDBStatusProcessCPU{UsageCores: 0.0137228},
+					Memory: FoundationDBStatusProcessMemory{
+						AvailableBytes: 8589934592,
+						LimitBytes:     8589934592,
+						UsedBytes:      197763072,
+					},
+					Disk: FoundationDBStatusProcessDisk{
+						Busy:       0.0101996,
+						FreeBytes:  84178165760,
+						TotalBytes: 135012552704,
+					},
+					Network: FoundationDBStatusProcessNetwork{
+						CurrentConnections: 6,
+						MegabitsReceived:   FoundationDBStatusRate{Hz: 0.041451},
+						MegabitsSent:       FoundationDBStatusRate{Hz: 0.058755800000000004},
+					},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{
 							Role:                  string(ProcessRoleLog),
@@ -338,6 +471,22 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0027,
+					CPU:           FoundationDBStatusProcessCPU{UsageCores: 0.0140474},
+					Memory: FoundationDBStatusProcessMemory{
+						AvailableBytes: 8589934592,
+						LimitBytes:     8589934592,
+						UsedBytes:      210481152,
+					},
+					Disk: FoundationDBStatusProcessDisk{
+						Busy:       0.0101996,
+						FreeBytes:  84178165760,
+						TotalBytes: 135012552704,
+					},
+					Network: FoundationDBStatusProcessNetwork{
+						CurrentConnections: 6,
+						MegabitsReceived:   FoundationDBStatusRate{Hz: 0.0412078},
+						MegabitsSent:       FoundationDBStatusRate{Hz: 0.058487000000000004},
+					},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{
 							Role:                  string(ProcessRoleLog),
@@ -528,6 +677,31 @@ var _ = Describe("FoundationDBStatus", func() {
 				},
 				WorstQueueBytesStorageServer: 1996,
 				WorstQueueBytesLogServer:     12144,
+				PerformanceLimitedBy: FoundationDBStatusPerformanceLimitedBy{
+					Name:        "workload",
+					Description: "The database is not being saturated by the workload.",
+					ReasonID:    2,
+				},
+				ReleasedTransactionsPerSecond: 6.0522499999999999,
+				TransactionsPerSecondLimit:    159461000,
+			},
+			LatencyProbe: FoundationDBStatusLatencyProbe{
+				CommitSeconds:           0.0045864599999999997,
+				ReadSeconds:             0.00039434399999999998,
+				TransactionStartSeconds: 0.00389361,
+			},
+			Workload: FoundationDBStatusWorkload{
+				Transactions: FoundationDBStatusWorkloadTransactions{
+					Started: FoundationDBStatusRate{
+						Hz: 5.99993,
+					},
+					Committed: FoundationDBStatusRate{
+						Hz: 0.39998900000000004,
+					},
+					Conflicted: FoundationDBStatusRate{
+						Hz: 0,
+					},
+				},
 			},
 			FaultTolerance: FaultTolerance{
 				MaxZoneFailuresWithoutLosingData:         1,
@@ -582,6 +756,8 @@ var _ = Describe("FoundationDBStatus", func() {
 			Expect(clusterInfoParsed.Layers).To(Equal(clusterInfo.Layers))
 			Expect(clusterInfoParsed.Logs).To(HaveExactElements(clusterInfo.Logs))
 			Expect(clusterInfoParsed.Qos).To(Equal(clusterInfo.Qos))
+			Expect(clusterInfoParsed.LatencyProbe).To(Equal(clusterInfo.LatencyProbe))
+			Expect(clusterInfoParsed.Workload).To(Equal(clusterInfo.Workload))
 			Expect(clusterInfoParsed.FaultTolerance).To(Equal(clusterInfo.FaultTolerance))
 			Expect(clusterInfoParsed.MaintenanceZone).To(Equal(clusterInfo.MaintenanceZone))
 			Expect(
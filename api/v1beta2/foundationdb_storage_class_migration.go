@@ -0,0 +1,121 @@
+/*
+ * foundationdb_storage_class_migration.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// StorageClassMigrationOptions defines how the operator migrates the process groups to a new storage class.
+type StorageClassMigrationOptions struct {
+	// Enabled defines if the operator should migrate the process groups in waves, one fault domain at a time, when
+	// the storage class in the VolumeClaimTemplate is changed. If disabled, the process groups will be replaced like
+	// any other misconfigured process group.
+	// Defaults to false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MaxInFlightBytes defines the maximum amount of data that can be moved by the cluster before the operator
+	// replaces the process groups of the next fault domain. The data movement is taken from the in-flight bytes
+	// reported in the machine-readable status. If unset, the data movement will not be checked.
+	// +kubebuilder:validation:Optional
+	MaxInFlightBytes *resource.Quantity `json:"maxInFlightBytes,omitempty"`
+}
+
+// StorageClassMigrationPhase represents the phase of a storage class migration.
+// +kubebuilder:validation:MaxLength=32
+type StorageClassMigrationPhase string
+
+const (
+	// StorageClassMigrationPhaseMigrating represents a migration where the process groups are replaced to use the new
+	// storage class.
+	StorageClassMigrationPhaseMigrating StorageClassMigrationPhase = "Migrating"
+	// StorageClassMigrationPhasePaused represents a migration that is paused by the
+	// StorageClassMigrationPausedAnnotation.
+	StorageClassMigrationPhasePaused StorageClassMigrationPhase = "Paused"
+	// StorageClassMigrationPhaseCompleted represents a completed migration.
+	StorageClassMigrationPhaseCompleted StorageClassMigrationPhase = "Completed"
+)
+
+// StorageClassMigrationStatus contains information about the progress of a storage class migration.
+type StorageClassMigrationStatus struct {
+	// SourceStorageClass defines the storage class the process groups are migrated from.
+	SourceStorageClass string `json:"sourceStorageClass,omitempty"`
+
+	// TargetStorageClass defines the storage class the process groups are migrated to.
+	TargetStorageClass string `json:"targetStorageClass,omitempty"`
+
+	// Phase defines the current phase of the migration.
+	Phase StorageClassMigrationPhase `json:"phase,omitempty"`
+
+	// CurrentFaultDomain defines the fault domain in which the process groups are currently replaced.
+	CurrentFaultDomain FaultDomain `json:"currentFaultDomain,omitempty"`
+
+	// ProcessGroups defines the number of process groups that must use the target storage class.
+	ProcessGroups int `json:"processGroups,omitempty"`
+
+	// MigratedProcessGroups defines the number of process groups that use the target storage class.
+	MigratedProcessGroups int `json:"migratedProcessGroups,omitempty"`
+
+	// MigratedPercentage defines the percentage of process groups that use the target storage class.
+	MigratedPercentage int `json:"migratedPercentage,omitempty"`
+
+	// EstimatedRemainingSeconds defines the estimated time until all process groups use the target storage class,
+	// based on the progress since the start of the migration.
+	EstimatedRemainingSeconds *int64 `json:"estimatedRemainingSeconds,omitempty"`
+
+	// StartTimestamp defines when the migration was started.
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// CompletionTimestamp defines when the migration was completed.
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Message provides additional information about the current phase of the migration.
+	Message string `json:"message,omitempty"`
+}
+
+// UseStorageClassMigration returns true if the operator should migrate the process groups to a new storage class in
+// waves. Defaults to false.
+func (cluster *FoundationDBCluster) UseStorageClassMigration() bool {
+	if cluster.Spec.AutomationOptions.StorageClassMigration == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.StorageClassMigration.Enabled, false)
+}
+
+// GetStorageClassMigrationMaxInFlightBytes returns the maximum amount of data that can be in flight before the next
+// fault domain is migrated. If no limit is defined, 0 will be returned.
+func (cluster *FoundationDBCluster) GetStorageClassMigrationMaxInFlightBytes() int64 {
+	if cluster.Spec.AutomationOptions.StorageClassMigration == nil ||
+		cluster.Spec.AutomationOptions.StorageClassMigration.MaxInFlightBytes == nil {
+		return 0
+	}
+
+	return cluster.Spec.AutomationOptions.StorageClassMigration.MaxInFlightBytes.Value()
+}
+
+// IsStorageClassMigrationPaused returns true if the StorageClassMigrationPausedAnnotation is set to "true" on the
+// cluster.
+func (cluster *FoundationDBCluster) IsStorageClassMigrationPaused() bool {
+	return cluster.GetAnnotations()[StorageClassMigrationPausedAnnotation] == "true"
+}
@@ -0,0 +1,167 @@
+/*
+ * foundationdb_storage_engine_migration.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// StorageEngineMigrationOptions defines how the operator migrates the storage servers to a new storage engine.
+type StorageEngineMigrationOptions struct {
+	// Enabled defines if the operator should orchestrate the migration of the storage servers when the storage
+	// engine in the database configuration is changed. If enabled the operator will set the storage migration type
+	// and the perpetual storage wiggle until all storage servers use the new storage engine.
+	// Defaults to false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MigrationType defines the storage migration type that will be used during the migration.
+	// Defaults to gradual.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=gradual;aggressive
+	MigrationType *StorageMigrationType `json:"migrationType,omitempty"`
+}
+
+// StorageEngineMigrationPhase represents the phase of a storage engine migration.
+// +kubebuilder:validation:MaxLength=32
+type StorageEngineMigrationPhase string
+
+const (
+	// StorageEngineMigrationPhaseMigrating represents a migration where the storage servers are migrated to the new
+	// storage engine.
+	StorageEngineMigrationPhaseMigrating StorageEngineMigrationPhase = "Migrating"
+	// StorageEngineMigrationPhasePaused represents a migration that is paused because the cluster doesn't have the
+	// desired fault tolerance.
+	StorageEngineMigrationPhasePaused StorageEngineMigrationPhase = "Paused"
+	// StorageEngineMigrationPhaseFinalizing represents a migration where all storage servers use the new storage
+	// engine and the migration settings are removed from the database configuration.
+	StorageEngineMigrationPhaseFinalizing StorageEngineMigrationPhase = "Finalizing"
+	// StorageEngineMigrationPhaseCompleted represents a completed migration.
+	StorageEngineMigrationPhaseCompleted StorageEngineMigrationPhase = "Completed"
+)
+
+// StorageEngineMigrationStatus contains information about the progress of a storage engine migration.
+type StorageEngineMigrationStatus struct {
+	// SourceEngine defines the storage engine the storage servers are migrated from.
+	SourceEngine StorageEngine `json:"sourceEngine,omitempty"`
+
+	// TargetEngine defines the storage engine the storage servers are migrated to.
+	TargetEngine StorageEngine `json:"targetEngine,omitempty"`
+
+	// Phase defines the current phase of the migration.
+	Phase StorageEngineMigrationPhase `json:"phase,omitempty"`
+
+	// StorageServers defines the number of storage servers that report their storage engine.
+	StorageServers int `json:"storageServers,omitempty"`
+
+	// MigratedStorageServers defines the number of storage servers that use the target storage engine.
+	MigratedStorageServers int `json:"migratedStorageServers,omitempty"`
+
+	// MigratedPercentage defines the percentage of storage servers that use the target storage engine.
+	MigratedPercentage int `json:"migratedPercentage,omitempty"`
+
+	// EstimatedRemainingSeconds defines the estimated time until all storage servers use the target storage engine,
+	// based on the progress since the start of the migration.
+	EstimatedRemainingSeconds *int64 `json:"estimatedRemainingSeconds,omitempty"`
+
+	// StartTimestamp defines when the migration was started.
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// CompletionTimestamp defines when the migration was completed.
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Message provides additional information about the current phase of the migration.
+	Message string `json:"message,omitempty"`
+}
+
+// UseStorageEngineMigration returns true if the operator should orchestrate the migration of the storage servers to a
+// new storage engine. Defaults to false.
+func (cluster *FoundationDBCluster) UseStorageEngineMigration() bool {
+	if cluster.Spec.AutomationOptions.StorageEngineMigration == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.StorageEngineMigration.Enabled, false)
+}
+
+// GetStorageEngineMigrationType returns the storage migration type used during the storage engine migration or
+// defaults to gradual.
+func (cluster *FoundationDBCluster) GetStorageEngineMigrationType() StorageMigrationType {
+	if cluster.Spec.AutomationOptions.StorageEngineMigration == nil ||
+		cluster.Spec.AutomationOptions.StorageEngineMigration.MigrationType == nil {
+		return StorageMigrationTypeGradual
+	}
+
+	return *cluster.Spec.AutomationOptions.StorageEngineMigration.MigrationType
+}
+
+// getActiveStorageEngineMigration returns the status of the storage engine migration if the migration is enabled, not
+// completed and targets the provided storage engine, otherwise nil will be returned.
+func (cluster *FoundationDBCluster) getActiveStorageEngineMigration(
+	storageEngine StorageEngine,
+) *StorageEngineMigrationStatus {
+	if !cluster.UseStorageEngineMigration() {
+		return nil
+	}
+
+	migration := cluster.Status.StorageEngineMigration
+	if migration == nil || migration.Phase == "" ||
+		migration.Phase == StorageEngineMigrationPhaseCompleted ||
+		migration.TargetEngine != storageEngine {
+		return nil
+	}
+
+	return migration
+}
+
+// applyStorageEngineMigration sets the storage migration type and the perpetual storage wiggle in the provided
+// configuration based on the phase of the active storage engine migration. Settings that are defined in the cluster
+// spec will not be modified. The migration settings are kept while the migration is paused, as every change of the
+// database configuration causes a recovery, and are only cleared once all storage servers are migrated.
+//
+// The result depends on the storage engine migration in the cluster status. If the status contains no active
+// migration, e.g. because the status was lost, the migration settings will not be set and the current migration
+// settings of the database will be ignored by ClearUnsetDatabaseConfigurationKnobs, so no reconfiguration is triggered.
+func (cluster *FoundationDBCluster) applyStorageEngineMigration(
+	configuration *DatabaseConfiguration,
+) {
+	migration := cluster.getActiveStorageEngineMigration(configuration.StorageEngine)
+	if migration == nil {
+		return
+	}
+
+	migrationType := StorageMigrationTypeDisabled
+	wiggle := 0
+	if migration.Phase != StorageEngineMigrationPhaseFinalizing {
+		migrationType = cluster.GetStorageEngineMigrationType()
+		if migrationType == StorageMigrationTypeGradual {
+			wiggle = 1
+		}
+	}
+
+	if cluster.Spec.DatabaseConfiguration.StorageMigrationType == nil {
+		configuration.StorageMigrationType = &migrationType
+	}
+
+	if cluster.Spec.DatabaseConfiguration.PerpetualStorageWiggle == nil {
+		configuration.PerpetualStorageWiggle = pointer.Int(wiggle)
+	}
+}
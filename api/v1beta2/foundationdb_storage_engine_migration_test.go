@@ -0,0 +1,222 @@
+/*
+ * foundationdb_storage_engine_migration_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("[api] FoundationDB storage engine migration", func() {
+	var cluster *FoundationDBCluster
+
+	BeforeEach(func() {
+		cluster = &FoundationDBCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "default",
+			},
+			Spec: FoundationDBClusterSpec{
+				Version: Versions.Default.String(),
+				DatabaseConfiguration: DatabaseConfiguration{
+					RedundancyMode: RedundancyModeDouble,
+					StorageEngine:  StorageEngineRedwood1,
+				},
+				AutomationOptions: FoundationDBClusterAutomationOptions{
+					StorageEngineMigration: &StorageEngineMigrationOptions{
+						Enabled: pointer.Bool(true),
+					},
+				},
+			},
+		}
+	})
+
+	When("getting the desired database configuration", func() {
+		DescribeTable(
+			"should return the expected migration settings",
+			func(migration *StorageEngineMigrationStatus, migrationType *StorageMigrationType, expectedMigrationType *StorageMigrationType, expectedWiggle *int) {
+				cluster.Status.StorageEngineMigration = migration
+				cluster.Spec.AutomationOptions.StorageEngineMigration.MigrationType = migrationType
+
+				configuration := cluster.DesiredDatabaseConfiguration()
+				Expect(configuration.StorageEngine).To(Equal(StorageEngineRedwood1))
+				Expect(configuration.StorageMigrationType).To(Equal(expectedMigrationType))
+				Expect(configuration.PerpetualStorageWiggle).To(Equal(expectedWiggle))
+			},
+			Entry("no migration", nil, nil, nil, nil),
+			Entry(
+				"empty migration status",
+				&StorageEngineMigrationStatus{
+					TargetEngine: StorageEngineRedwood1,
+				},
+				nil,
+				nil,
+				nil,
+			),
+			Entry(
+				"migrating",
+				&StorageEngineMigrationStatus{
+					TargetEngine: StorageEngineRedwood1,
+					Phase:        StorageEngineMigrationPhaseMigrating,
+				},
+				nil,
+				ptr.To(StorageMigrationTypeGradual),
+				pointer.Int(1),
+			),
+			Entry(
+				"migrating with the aggressive migration type",
+				&StorageEngineMigrationStatus{
+					TargetEngine: StorageEngineRedwood1,
+					Phase:        StorageEngineMigrationPhaseMigrating,
+				},
+				ptr.To(StorageMigrationTypeAggressive),
+				ptr.To(StorageMigrationTypeAggressive),
+				pointer.Int(0),
+			),
+			Entry(
+				"paused",
+				&StorageEngineMigrationStatus{
+					TargetEngine: StorageEngineRedwood1,
+					Phase:        StorageEngineMigrationPhasePaused,
+				},
+				nil,
+				ptr.To(StorageMigrationTypeGradual),
+				pointer.Int(1),
+			),
+			Entry(
+				"finalizing",
+				&StorageEngineMigrationStatus{
+					TargetEngine: StorageEngineRedwood1,
+					Phase:        StorageEngineMigrationPhaseFinalizing,
+				},
+				nil,
+				ptr.To(StorageMigrationTypeDisabled),
+				pointer.Int(0),
+			),
+			Entry(
+				"completed",
+				&StorageEngineMigrationStatus{
+					TargetEngine: StorageEngineRedwood1,
+					Phase:        StorageEngineMigrationPhaseCompleted,
+				},
+				nil,
+				nil,
+				nil,
+			),
+			Entry(
+				"migrating to another storage engine",
+				&StorageEngineMigrationStatus{
+					TargetEngine: StorageEngineSSD2,
+					Phase:        StorageEngineMigrationPhaseMigrating,
+				},
+				nil,
+				nil,
+				nil,
+			),
+		)
+
+		It("should not modify the migration settings defined in the spec", func() {
+			cluster.Spec.DatabaseConfiguration.PerpetualStorageWiggle = pointer.Int(1)
+			cluster.Status.StorageEngineMigration = &StorageEngineMigrationStatus{
+				TargetEngine: StorageEngineRedwood1,
+				Phase:        StorageEngineMigrationPhaseFinalizing,
+			}
+
+			configuration := cluster.DesiredDatabaseConfiguration()
+			Expect(configuration.PerpetualStorageWiggle).To(Equal(pointer.Int(1)))
+			Expect(
+				configuration.StorageMigrationType,
+			).To(Equal(ptr.To(StorageMigrationTypeDisabled)))
+		})
+
+		It("should not modify the configuration if the migration is disabled", func() {
+			cluster.Spec.AutomationOptions.StorageEngineMigration.Enabled = pointer.Bool(false)
+			cluster.Status.StorageEngineMigration = &StorageEngineMigrationStatus{
+				TargetEngine: StorageEngineRedwood1,
+				Phase:        StorageEngineMigrationPhaseMigrating,
+			}
+
+			configuration := cluster.DesiredDatabaseConfiguration()
+			Expect(configuration.PerpetualStorageWiggle).To(BeNil())
+			Expect(configuration.StorageMigrationType).To(BeNil())
+		})
+	})
+
+	When("normalizing the current database configuration", func() {
+		var configuration DatabaseConfiguration
+
+		BeforeEach(func() {
+			configuration = DatabaseConfiguration{
+				RedundancyMode:               RedundancyModeDouble,
+				StorageEngine:                StorageEngineRedwood1,
+				StorageMigrationType:         ptr.To(StorageMigrationTypeGradual),
+				PerpetualStorageWiggle:       pointer.Int(1),
+				PerpetualStorageWiggleEngine: ptr.To(StorageEngineNone),
+			}
+		})
+
+		It("should clear the migration settings if no migration is active", func() {
+			normalized := configuration.NormalizeConfiguration(cluster)
+			Expect(normalized.StorageMigrationType).To(BeNil())
+			Expect(normalized.PerpetualStorageWiggle).To(BeNil())
+			Expect(normalized.PerpetualStorageWiggleEngine).To(BeNil())
+		})
+
+		It("should not trigger a reconfiguration if the migration status is lost", func() {
+			cluster.Status.StorageEngineMigration = nil
+
+			normalized := configuration.NormalizeConfiguration(cluster)
+			desired := cluster.DesiredDatabaseConfiguration()
+			Expect(normalized.StorageMigrationType).To(Equal(desired.StorageMigrationType))
+			Expect(normalized.PerpetualStorageWiggle).To(Equal(desired.PerpetualStorageWiggle))
+		})
+
+		It("should not trigger a reconfiguration if the migration is paused", func() {
+			cluster.Status.StorageEngineMigration = &StorageEngineMigrationStatus{
+				TargetEngine: StorageEngineRedwood1,
+				Phase:        StorageEngineMigrationPhasePaused,
+			}
+
+			normalized := configuration.NormalizeConfiguration(cluster)
+			desired := cluster.DesiredDatabaseConfiguration()
+			Expect(normalized.StorageMigrationType).To(Equal(desired.StorageMigrationType))
+			Expect(normalized.PerpetualStorageWiggle).To(Equal(desired.PerpetualStorageWiggle))
+		})
+
+		It("should keep the migration settings if a migration is active", func() {
+			cluster.Status.StorageEngineMigration = &StorageEngineMigrationStatus{
+				TargetEngine: StorageEngineRedwood1,
+				Phase:        StorageEngineMigrationPhaseMigrating,
+			}
+
+			normalized := configuration.NormalizeConfiguration(cluster)
+			Expect(normalized.StorageMigrationType).To(Equal(ptr.To(StorageMigrationTypeGradual)))
+			Expect(normalized.PerpetualStorageWiggle).To(Equal(pointer.Int(1)))
+			Expect(normalized.PerpetualStorageWiggleEngine).To(BeNil())
+			Expect(normalized.StorageMigrationType).To(Equal(
+				cluster.DesiredDatabaseConfiguration().StorageMigrationType,
+			))
+		})
+	})
+})
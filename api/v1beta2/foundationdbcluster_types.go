@@ -31,6 +31,7 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
@@ -115,6 +116,15 @@ type FoundationDBClusterSpec struct {
 	// can be used to create a multi-region cluster or to recover a cluster if it is out of sync.
 	SeedConnectionString string `json:"seedConnectionString,omitempty"`
 
+	// Adoption defines the settings to adopt an existing FoundationDB cluster that is not managed by the operator.
+	// The operator will join the existing cluster with the SeedConnectionString, migrate the coordinators to
+	// processes managed by the operator and exclude all processes that are not managed by the operator.
+	Adoption *AdoptionConfig `json:"adoption,omitempty"`
+
+	// Autoscaling defines the autoscaling policies for the cluster. If an autoscaling policy is enabled, the operator
+	// will overwrite the according process counts with the values chosen by the autoscaler.
+	Autoscaling AutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// PartialConnectionString provides a way to specify part of the
 	// connection string (e.g. the database name and coordinator generation)
 	// without specifying the entire string. This does not allow for setting
@@ -143,6 +153,17 @@ type FoundationDBClusterSpec struct {
 	// +kubebuilder:validation:MaxItems=500
 	ProcessGroupsToRemoveWithoutExclusion []ProcessGroupID `json:"processGroupsToRemoveWithoutExclusion,omitempty"`
 
+	// ManuallyExcludedProcessGroups defines the process groups that were excluded
+	// manually, e.g. with the kubectl-fdb plugin, without removing them. This list
+	// contains the process group IDs.
+	//
+	// The operator will not replace those process groups because of the exclusion.
+	// The process groups must be included again and removed from this list to be
+	// used by the cluster again.
+	// +kubebuilder:validation:MinItems=0
+	// +kubebuilder:validation:MaxItems=500
+	ManuallyExcludedProcessGroups []ProcessGroupID `json:"manuallyExcludedProcessGroups,omitempty"`
+
 	// ConfigMap allows customizing the config map the operator creates.
 	ConfigMap *corev1.ConfigMap `json:"configMap,omitempty"`
 
@@ -257,6 +278,15 @@ type FoundationDBClusterSpec struct {
 	// MaxZonesWithUnavailablePods defines the maximum number of zones that can have unavailable pods during the update process.
 	// When unset, there is no limit to the  number of zones with unavailable pods.
 	MaxZonesWithUnavailablePods *int `json:"maxZonesWithUnavailablePods,omitempty"`
+
+	// PrometheusRuleOptions defines the alerting rules the operator should manage for this cluster.
+	// +kubebuilder:validation:Optional
+	PrometheusRuleOptions PrometheusRuleOptions `json:"prometheusRuleOptions,omitempty"`
+
+	// TraceLogOptions defines the retention of the fdbserver trace logs and if the trace logs should be forwarded
+	// to stdout.
+	// +kubebuilder:validation:Optional
+	TraceLogOptions TraceLogOptions `json:"traceLogOptions,omitempty"`
 }
 
 // ImageType defines a single kind of images used in the cluster.
@@ -345,6 +375,52 @@ type FoundationDBClusterStatus struct {
 
 	// ReconciledProcessGroups reflects the number of process groups that have no condition and are not marked for removal.
 	ReconciledProcessGroups int `json:"reconciledProcessGroups,omitempty"`
+
+	// Adoption contains information about the progress of adopting an existing cluster. This will only be set if
+	// the adoption settings are defined in the cluster spec.
+	Adoption *AdoptionStatus `json:"adoption,omitempty"`
+
+	// Autoscaling contains information about the decisions of the autoscaling policies. This will only be set if
+	// an autoscaling policy is enabled.
+	Autoscaling *AutoscalingStatus `json:"autoscaling,omitempty"`
+
+	// StorageEngineMigration contains information about the progress of the storage engine migration. This will only
+	// be set if the storage engine migration is enabled.
+	StorageEngineMigration *StorageEngineMigrationStatus `json:"storageEngineMigration,omitempty"`
+
+	// StorageClassMigration contains information about the progress of the storage class migration. This will only
+	// be set if the storage class migration is enabled.
+	StorageClassMigration *StorageClassMigrationStatus `json:"storageClassMigration,omitempty"`
+
+	// RecoveryBudget contains information about the recovery budget of the cluster. This will only be set if the
+	// recovery budget is enabled.
+	RecoveryBudget *RecoveryBudgetStatus `json:"recoveryBudget,omitempty"`
+}
+
+// AdoptionConfig defines the settings to adopt an existing FoundationDB cluster that is not managed by the operator.
+type AdoptionConfig struct {
+	// ExcludeForeignProcesses defines if the operator should exclude all processes that are not managed by the
+	// operator. The operator will only exclude those processes once all coordinators are running on processes
+	// managed by the operator and all process groups have their processes running.
+	// +kubebuilder:default:=false
+	ExcludeForeignProcesses *bool `json:"excludeForeignProcesses,omitempty"`
+}
+
+// AdoptionStatus contains information about the progress of adopting an existing cluster.
+type AdoptionStatus struct {
+	// ForeignProcesses is the number of processes that are not managed by the operator.
+	ForeignProcesses int `json:"foreignProcesses,omitempty"`
+
+	// ForeignCoordinators is the number of coordinators that are running on processes that are not managed by the
+	// operator.
+	ForeignCoordinators int `json:"foreignCoordinators,omitempty"`
+
+	// ExcludedForeignProcesses is the number of processes that are not managed by the operator and are excluded.
+	ExcludedForeignProcesses int `json:"excludedForeignProcesses,omitempty"`
+
+	// FullyExcludedForeignProcesses is the number of processes that are not managed by the operator, are excluded
+	// and have no roles assigned anymore. Those processes can be shut down safely.
+	FullyExcludedForeignProcesses int `json:"fullyExcludedForeignProcesses,omitempty"`
 }
 
 // MaintenanceModeInfo contains information regarding the zone and process groups that are put
@@ -384,6 +460,9 @@ type ProcessGroupStatus struct {
 	ExclusionTimestamp *metav1.Time `json:"exclusionTimestamp,omitempty"`
 	// ExclusionSkipped determines if exclusion has been skipped for a process, which will allow the process group to be removed without exclusion.
 	ExclusionSkipped bool `json:"exclusionSkipped,omitempty"`
+	// SuspensionTimestamp defines when the Pod of the process group was deleted while the other resources were kept.
+	// The remaining resources will be deleted once the minimum suspension duration has passed.
+	SuspensionTimestamp *metav1.Time `json:"suspensionTimestamp,omitempty"`
 	// ProcessGroupConditions represents a list of degraded conditions that the process group is in.
 	ProcessGroupConditions []*ProcessGroupCondition `json:"processGroupConditions,omitempty"`
 	// FaultDomain represents the last seen fault domain from the cluster status. This can be used if a Pod or process
@@ -419,6 +498,13 @@ func (processGroupStatus *ProcessGroupStatus) String() string {
 	sb.WriteString(", ExclusionSkipped: ")
 	sb.WriteString(strconv.FormatBool(processGroupStatus.ExclusionSkipped))
 
+	sb.WriteString(", SuspensionTimestamp: ")
+	if processGroupStatus.SuspensionTimestamp.IsZero() {
+		sb.WriteString("-")
+	} else {
+		sb.WriteString(processGroupStatus.SuspensionTimestamp.String())
+	}
+
 	sb.WriteString(", ProcessGroupConditions: ")
 	for _, condition := range processGroupStatus.ProcessGroupConditions {
 		sb.WriteString(condition.String())
@@ -517,6 +603,20 @@ func (processGroupStatus *ProcessGroupStatus) MarkForRemoval() {
 	processGroupStatus.RemovalTimestamp = &metav1.Time{Time: time.Now()}
 }
 
+// IsSuspended returns if the Pod of the process group was deleted while the other resources were kept.
+func (processGroupStatus *ProcessGroupStatus) IsSuspended() bool {
+	return !processGroupStatus.SuspensionTimestamp.IsZero()
+}
+
+// Suspend marks a process group as suspended. If the SuspensionTimestamp is already set it won't be changed.
+func (processGroupStatus *ProcessGroupStatus) Suspend() {
+	if !processGroupStatus.SuspensionTimestamp.IsZero() {
+		return
+	}
+
+	processGroupStatus.SuspensionTimestamp = &metav1.Time{Time: time.Now()}
+}
+
 // GetPodName returns the Pod name for the associated Process Group.
 func (processGroupStatus *ProcessGroupStatus) GetPodName(cluster *FoundationDBCluster) string {
 	var sb strings.Builder
@@ -1070,6 +1170,13 @@ const (
 	// This condition can occur during the migration of the image type, the change of the image configuration
 	// for the sidecar or during version incompatible upgrades until the sidecar is updated to the new desired version.
 	IncorrectSidecarImage ProcessGroupConditionType = "IncorrectSidecarImage"
+	// FileSystemResizePending represents a process group where the PVC was expanded but the file system resize
+	// is still pending. If the condition is present for too long, the operator will recreate the Pod to trigger
+	// the file system resize.
+	FileSystemResizePending ProcessGroupConditionType = "FileSystemResizePending"
+	// PodResizeInProgress represents a process group where the resources of the Pod are resized in place and the
+	// resize is not yet completed by the kubelet.
+	PodResizeInProgress ProcessGroupConditionType = "PodResizeInProgress"
 )
 
 // AllProcessGroupConditionTypes returns all ProcessGroupConditionType
@@ -1092,6 +1199,8 @@ func AllProcessGroupConditionTypes() []ProcessGroupConditionType {
 		ProcessIsMarkedAsExcluded,
 		ProcessHasIOError,
 		IncorrectSidecarImage,
+		FileSystemResizePending,
+		PodResizeInProgress,
 	}
 }
 
@@ -1136,6 +1245,10 @@ func GetProcessGroupConditionType(
 		return ProcessHasIOError, nil
 	case "IncorrectSidecarImage":
 		return IncorrectSidecarImage, nil
+	case "FileSystemResizePending":
+		return FileSystemResizePending, nil
+	case "PodResizeInProgress":
+		return PodResizeInProgress, nil
 	}
 
 	return "", fmt.Errorf("unknown process group condition type: %s", processGroupConditionType)
@@ -1307,6 +1420,50 @@ type FoundationDBClusterAutomationOptions struct {
 	// Defaults to 60.
 	WaitBetweenRemovalsSeconds *int `json:"waitBetweenRemovalsSeconds,omitempty"`
 
+	// MinimumSuspensionDurationSeconds defines how long the operator keeps the PVC and the Service of a removed process
+	// group after the Pod was deleted. During this time the process group can be recovered by recreating the Pod on the
+	// retained PVC. If set to 0 the operator removes all resources of a process group at the same time.
+	// Defaults to 0.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinimumSuspensionDurationSeconds *int `json:"minimumSuspensionDurationSeconds,omitempty"`
+
+	// UseVolumeExpansion defines if the operator should expand the existing PVCs in place when only the storage
+	// request of the VolumeClaimTemplate was increased and the storage class allows volume expansion. If disabled
+	// or if the storage class doesn't allow volume expansion, the operator will replace the affected process groups.
+	// This requires the operator to be allowed to read StorageClass resources.
+	// Defaults to false.
+	// +kubebuilder:validation:Optional
+	UseVolumeExpansion *bool `json:"useVolumeExpansion,omitempty"`
+
+	// UseInPlacePodResize defines if the operator should resize the resources of the main container and the sidecar
+	// container in place by using the resize subresource of the Pod, when only the resources of those containers
+	// were changed. If the node cannot fit the new resources, the operator will update the Pod with the configured
+	// PodUpdateStrategy. This requires a Kubernetes version that supports in-place Pod resizing.
+	// Defaults to false.
+	// +kubebuilder:validation:Optional
+	UseInPlacePodResize *bool `json:"useInPlacePodResize,omitempty"`
+
+	// StorageEngineMigration defines if and how the operator migrates the storage servers to a new storage engine
+	// when the storage engine in the database configuration is changed.
+	// +kubebuilder:validation:Optional
+	StorageEngineMigration *StorageEngineMigrationOptions `json:"storageEngineMigration,omitempty"`
+
+	// StorageClassMigration defines if and how the operator migrates the process groups to a new storage class
+	// when the storage class in the VolumeClaimTemplate is changed.
+	// +kubebuilder:validation:Optional
+	StorageClassMigration *StorageClassMigrationOptions `json:"storageClassMigration,omitempty"`
+
+	// ExclusionThrottling defines how the operator throttles the exclusion of process groups based on the data that
+	// must be moved by the exclusions.
+	// +kubebuilder:validation:Optional
+	ExclusionThrottling *ExclusionThrottlingOptions `json:"exclusionThrottling,omitempty"`
+
+	// RecoveryBudget defines how many actions that can cause a recovery the operator is allowed to perform in a
+	// time window across all reconciliation steps.
+	// +kubebuilder:validation:Optional
+	RecoveryBudget *RecoveryBudgetOptions `json:"recoveryBudget,omitempty"`
+
 	// PodUpdateStrategy defines how Pod spec changes are rolled out either by replacing Pods or by deleting Pods.
 	// The default for this is ReplaceTransactionSystem.
 	// +kubebuilder:validation:Optional
@@ -1382,6 +1539,24 @@ type MaintenanceModeOptions struct {
 	MaintenanceModeTimeSeconds *int `json:"maintenanceModeTimeSeconds,omitempty"`
 }
 
+// ExclusionThrottlingOptions defines how the operator throttles the exclusion of process groups based on the data
+// that must be moved. The data that must be moved for a process group is estimated based on the stored bytes of its
+// storage roles. Process groups that don't store any data, e.g. stateless process groups, will not be throttled.
+type ExclusionThrottlingOptions struct {
+	// MaxInFlightBytes defines the maximum amount of data that can be stored by the processes that are currently
+	// excluded. The operator will not exclude further process groups if the exclusion would exceed this limit. If no
+	// data is stored by the excluded processes, the operator will always exclude at least one process group.
+	// If unset, the stored bytes will not be checked.
+	// +kubebuilder:validation:Optional
+	MaxInFlightBytes *resource.Quantity `json:"maxInFlightBytes,omitempty"`
+
+	// MaxMovingDataBytes defines the maximum amount of data movement that is allowed before the operator excludes
+	// further process groups. The data movement is the sum of the in-flight and the in-queue bytes of the moving data
+	// reported in the machine-readable status. If unset, the data movement will not be checked.
+	// +kubebuilder:validation:Optional
+	MaxMovingDataBytes *resource.Quantity `json:"maxMovingDataBytes,omitempty"`
+}
+
 // TaintReplacementOption defines the taint key and taint duration the operator will react to a tainted node
 // Example of TaintReplacementOption
 //   - key: "example.org/maintenance"
@@ -1518,9 +1693,14 @@ func (cluster *FoundationDBCluster) GetProcessSettings(processClass ProcessClass
 // The default LogRouters value will be equal to 3 times the Logs value when
 // the UsableRegions is greater than 1. It will be equal to -1 when the
 // UsableRegions is less than or equal to 1.
+//
+// If the stateless autoscaling is enabled, the CommitProxies, GrvProxies and
+// Resolvers values will be the values chosen by the autoscaler.
 func (cluster *FoundationDBCluster) GetRoleCountsWithDefaults() RoleCounts {
-	return cluster.Spec.DatabaseConfiguration.GetRoleCountsWithDefaults(
-		cluster.DesiredFaultTolerance(),
+	return cluster.getAutoscaledRoleCounts(
+		cluster.Spec.DatabaseConfiguration.GetRoleCountsWithDefaults(
+			cluster.DesiredFaultTolerance(),
+		),
 	)
 }
 
@@ -1626,6 +1806,7 @@ func (cluster *FoundationDBCluster) GetProcessCountsWithDefaults() (ProcessCount
 		processCounts.Storage = cluster.calculateProcessCount(false,
 			roleCounts.Storage)
 	}
+	processCounts.Storage = cluster.getAutoscaledStorageProcessCount(processCounts.Storage)
 
 	if processCounts.Log == 0 {
 		processCounts.Log = cluster.calculateProcessCount(true,
@@ -1634,6 +1815,20 @@ func (cluster *FoundationDBCluster) GetProcessCountsWithDefaults() (ProcessCount
 		)
 	}
 
+	if processCounts.Stateless > 0 && cluster.UseStatelessAutoscaling() {
+		// The stateless process count is defined in the spec, so we have to add the processes for the autoscaled
+		// roles.
+		processCounts.Stateless = max(
+			processCounts.Stateless+cluster.getAutoscaledStatelessProcessCountDelta(
+				cluster.Spec.DatabaseConfiguration.GetRoleCountsWithDefaults(
+					cluster.DesiredFaultTolerance(),
+				),
+				roleCounts,
+			),
+			1,
+		)
+	}
+
 	if processCounts.Stateless == 0 {
 		primaryStatelessCount := cluster.calculateProcessCountFromRole(1, processCounts.Master) +
 			cluster.calculateProcessCountFromRole(1, processCounts.ClusterController) +
@@ -2186,7 +2381,9 @@ type ContainerOverrides struct {
 }
 
 // DesiredDatabaseConfiguration builds the database configuration for the
-// cluster based on its spec.
+// cluster based on its spec. If a storage engine migration is enabled, the
+// storage migration type and the perpetual storage wiggle are based on the
+// storage engine migration in the cluster status.
 func (cluster *FoundationDBCluster) DesiredDatabaseConfiguration() DatabaseConfiguration {
 	configuration := cluster.Spec.DatabaseConfiguration.NormalizeConfiguration(cluster)
 	configuration.RoleCounts = cluster.GetRoleCountsWithDefaults()
@@ -2199,6 +2396,8 @@ func (cluster *FoundationDBCluster) DesiredDatabaseConfiguration() DatabaseConfi
 		configuration.StorageEngine = StorageEngineMemory2
 	}
 
+	cluster.applyStorageEngineMigration(&configuration)
+
 	return configuration
 }
 
@@ -2213,7 +2412,10 @@ func (cluster *FoundationDBCluster) ClearUnsetDatabaseConfigurationKnobs(
 	// Remove any specific version flags
 	cluster.ClearMissingVersionFlags(configuration)
 
-	if cluster.Spec.DatabaseConfiguration.StorageMigrationType == nil {
+	// The storage migration type and the perpetual storage wiggle are managed by the operator during a storage
+	// engine migration.
+	migrationIsActive := cluster.getActiveStorageEngineMigration(configuration.StorageEngine) != nil
+	if cluster.Spec.DatabaseConfiguration.StorageMigrationType == nil && !migrationIsActive {
 		configuration.StorageMigrationType = nil
 	}
 
@@ -2225,7 +2427,7 @@ func (cluster *FoundationDBCluster) ClearUnsetDatabaseConfigurationKnobs(
 		configuration.PerpetualStorageWiggleEngine = nil
 	}
 
-	if cluster.Spec.DatabaseConfiguration.PerpetualStorageWiggle == nil {
+	if cluster.Spec.DatabaseConfiguration.PerpetualStorageWiggle == nil && !migrationIsActive {
 		configuration.PerpetualStorageWiggle = nil
 	}
 
@@ -2283,6 +2485,19 @@ func (cluster *FoundationDBCluster) VersionCompatibleUpgradeInProgress() bool {
 	return runningVersion.IsProtocolCompatible(desiredVersion)
 }
 
+// ProcessGroupIsManuallyExcluded determines if a process group was excluded manually without being removed.
+func (cluster *FoundationDBCluster) ProcessGroupIsManuallyExcluded(
+	processGroupID ProcessGroupID,
+) bool {
+	for _, id := range cluster.Spec.ManuallyExcludedProcessGroups {
+		if id == processGroupID {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ProcessGroupIsBeingRemoved determines if an instance is pending removal.
 func (cluster *FoundationDBCluster) ProcessGroupIsBeingRemoved(processGroupID ProcessGroupID) bool {
 	if processGroupID == "" {
@@ -2348,6 +2563,53 @@ func (cluster *FoundationDBCluster) GetLockDuration() time.Duration {
 	return time.Duration(minutes) * time.Minute
 }
 
+// ShouldCreatePrometheusRule returns true if the operator should manage a PrometheusRule for this cluster.
+func (cluster *FoundationDBCluster) ShouldCreatePrometheusRule() bool {
+	return pointer.BoolDeref(cluster.Spec.PrometheusRuleOptions.Enabled, false)
+}
+
+// GetPrometheusRuleUnavailableDuration returns the duration the database must be unavailable before the alert fires.
+func (cluster *FoundationDBCluster) GetPrometheusRuleUnavailableDuration() time.Duration {
+	return time.Duration(
+		pointer.IntDeref(cluster.Spec.PrometheusRuleOptions.UnavailableSeconds, 60),
+	) * time.Second
+}
+
+// GetPrometheusRuleFaultToleranceReducedDuration returns the duration the database must not be fully replicated before
+// the alert fires.
+func (cluster *FoundationDBCluster) GetPrometheusRuleFaultToleranceReducedDuration() time.Duration {
+	return time.Duration(
+		pointer.IntDeref(cluster.Spec.PrometheusRuleOptions.FaultToleranceReducedSeconds, 300),
+	) * time.Second
+}
+
+// GetPrometheusRuleProcessGroupConditionDuration returns the duration process groups must have a condition before the
+// alert fires.
+func (cluster *FoundationDBCluster) GetPrometheusRuleProcessGroupConditionDuration() time.Duration {
+	return time.Duration(
+		pointer.IntDeref(cluster.Spec.PrometheusRuleOptions.ProcessGroupConditionSeconds, 1800),
+	) * time.Second
+}
+
+// GetPrometheusRuleReconciliationStuckDuration returns the duration the cluster must not be reconciled before the
+// alert fires.
+func (cluster *FoundationDBCluster) GetPrometheusRuleReconciliationStuckDuration() time.Duration {
+	return time.Duration(
+		pointer.IntDeref(cluster.Spec.PrometheusRuleOptions.ReconciliationStuckSeconds, 3600),
+	) * time.Second
+}
+
+// ShouldForwardTraceLogs returns true if the trace logs of the fdbserver processes should be forwarded to stdout.
+func (cluster *FoundationDBCluster) ShouldForwardTraceLogs() bool {
+	return pointer.BoolDeref(cluster.Spec.TraceLogOptions.ForwardToStdout, false)
+}
+
+// NeedsTraceLogForwarder returns true if the trace log forwarder container should be added to the fdbserver pods. The
+// container is required to forward the trace logs to stdout and to delete trace logs older than the max age.
+func (cluster *FoundationDBCluster) NeedsTraceLogForwarder() bool {
+	return cluster.ShouldForwardTraceLogs() || cluster.Spec.TraceLogOptions.MaxAgeSeconds != nil
+}
+
 // GetLockID gets the identifier for this instance of the operator when taking
 // locks. This is the `ProcessGroupIDPrefix` defined for this cluster.
 func (cluster *FoundationDBCluster) GetLockID() string {
@@ -2389,6 +2651,75 @@ type LockOptions struct {
 	DenyList []LockDenyListEntry `json:"denyList,omitempty"`
 }
 
+// PrometheusRuleOptions provides customization for the PrometheusRule that the operator creates for the cluster.
+// The alerting rules are based on the metrics exposed by the operator.
+type PrometheusRuleOptions struct {
+	// Enabled defines if the operator should create and manage a PrometheusRule for this cluster. The
+	// PrometheusRule custom resource definition from the Prometheus operator must be installed, otherwise
+	// the operator will skip the creation. If set to false, the operator will delete the PrometheusRule it
+	// created before. If unset, the operator will not check for a PrometheusRule.
+	// Default: false
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Labels will be added to the PrometheusRule metadata, this can be used to match the rule selector of
+	// the Prometheus instance.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// AlertLabels will be added to every alert, e.g. to define the severity or the team that should be notified.
+	AlertLabels map[string]string `json:"alertLabels,omitempty"`
+
+	// UnavailableSeconds defines how long the database must be unavailable before the alert fires.
+	// Default: 60
+	// +kubebuilder:validation:Minimum=0
+	UnavailableSeconds *int `json:"unavailableSeconds,omitempty"`
+
+	// FaultToleranceReducedSeconds defines how long the database must not be fully replicated before the
+	// alert fires.
+	// Default: 300
+	// +kubebuilder:validation:Minimum=0
+	FaultToleranceReducedSeconds *int `json:"faultToleranceReducedSeconds,omitempty"`
+
+	// ProcessGroupConditionSeconds defines how long process groups must have a condition before the alert fires.
+	// Default: 1800
+	// +kubebuilder:validation:Minimum=0
+	ProcessGroupConditionSeconds *int `json:"processGroupConditionSeconds,omitempty"`
+
+	// ReconciliationStuckSeconds defines how long the cluster must not be reconciled before the alert fires.
+	// Default: 3600
+	// +kubebuilder:validation:Minimum=0
+	ReconciliationStuckSeconds *int `json:"reconciliationStuckSeconds,omitempty"`
+}
+
+// TraceLogOptions provides customization for the retention and the forwarding of the fdbserver trace logs.
+type TraceLogOptions struct {
+	// RollSizeBytes defines the size of a trace log file in bytes after which fdbserver will roll over to a new trace
+	// log file. The value is passed to fdbserver as --logsize. If unset, the fdbserver default will be used.
+	// Default: 10485760
+	// +kubebuilder:validation:Minimum=1
+	RollSizeBytes *int `json:"rollSizeBytes,omitempty"`
+
+	// MaxSizeBytes defines the combined size of all trace log files in bytes after which fdbserver will delete the
+	// oldest trace log file. The value is passed to fdbserver as --maxlogs. A value of 0 disables the deletion of
+	// trace log files. If unset, the fdbserver default will be used.
+	// Default: 104857600
+	// +kubebuilder:validation:Minimum=0
+	MaxSizeBytes *int `json:"maxSizeBytes,omitempty"`
+
+	// MaxAgeSeconds defines the maximum age of trace log files in seconds. Older trace log files will be deleted by
+	// the trace log forwarder container, so setting this value will add the trace log forwarder container to the
+	// fdbserver pods. If unset, trace log files will only be deleted based on their size.
+	// +kubebuilder:validation:Minimum=60
+	MaxAgeSeconds *int `json:"maxAgeSeconds,omitempty"`
+
+	// ForwardToStdout defines if the trace logs should be forwarded to stdout by the trace log forwarder container.
+	// If enabled, fdbserver will write the trace logs in the JSON format and the trace log forwarder container
+	// will write every trace event as a single JSON line to stdout, so that the trace events can be collected by
+	// the log pipeline of the Kubernetes cluster. The trace log forwarder container can be customized by adding a
+	// container with the name "trace-log-forwarder" to the pod template.
+	// Default: false
+	ForwardToStdout *bool `json:"forwardToStdout,omitempty"`
+}
+
 // LockDenyListEntry models an entry in the deny list for the locking system.
 type LockDenyListEntry struct {
 	// The ID of the operator instance this entry is targeting.
@@ -2643,6 +2974,21 @@ func (cluster *FoundationDBCluster) ShouldFilterOnOwnerReferences() bool {
 	return pointer.BoolDeref(cluster.Spec.LabelConfig.FilterOnOwnerReferences, false)
 }
 
+// IsAdopting returns true if the cluster is adopting an existing cluster that is not managed by the operator.
+func (cluster *FoundationDBCluster) IsAdopting() bool {
+	return cluster.Spec.Adoption != nil
+}
+
+// ExcludeForeignProcesses returns true if the operator should exclude all processes that are not managed by the
+// operator.
+func (cluster *FoundationDBCluster) ExcludeForeignProcesses() bool {
+	if !cluster.IsAdopting() {
+		return false
+	}
+
+	return pointer.BoolDeref(cluster.Spec.Adoption.ExcludeForeignProcesses, false)
+}
+
 // SkipProcessGroup checks if a ProcessGroupStatus should be skipped during reconciliation.
 func (cluster *FoundationDBCluster) SkipProcessGroup(processGroup *ProcessGroupStatus) bool {
 	if processGroup == nil {
@@ -2723,6 +3069,28 @@ func (cluster *FoundationDBCluster) GetMaxConcurrentReplacements() int {
 	return pointer.IntDeref(cluster.Spec.AutomationOptions.MaxConcurrentReplacements, math.MaxInt64)
 }
 
+// GetMaxExclusionInFlightBytes returns the maximum amount of data that can be stored by the excluded processes before
+// the operator excludes further process groups. If no limit is defined, 0 will be returned.
+func (cluster *FoundationDBCluster) GetMaxExclusionInFlightBytes() int64 {
+	if cluster.Spec.AutomationOptions.ExclusionThrottling == nil ||
+		cluster.Spec.AutomationOptions.ExclusionThrottling.MaxInFlightBytes == nil {
+		return 0
+	}
+
+	return cluster.Spec.AutomationOptions.ExclusionThrottling.MaxInFlightBytes.Value()
+}
+
+// GetMaxMovingDataBytesForExclusion returns the maximum amount of data movement that is allowed before the operator
+// excludes further process groups. If no limit is defined, 0 will be returned.
+func (cluster *FoundationDBCluster) GetMaxMovingDataBytesForExclusion() int64 {
+	if cluster.Spec.AutomationOptions.ExclusionThrottling == nil ||
+		cluster.Spec.AutomationOptions.ExclusionThrottling.MaxMovingDataBytes == nil {
+		return 0
+	}
+
+	return cluster.Spec.AutomationOptions.ExclusionThrottling.MaxMovingDataBytes.Value()
+}
+
 // UseManagementAPI returns the value of UseManagementAPI or false if unset.
 func (cluster *FoundationDBCluster) UseManagementAPI() bool {
 	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseManagementAPI, false)
@@ -2786,6 +3154,27 @@ func (cluster *FoundationDBCluster) GetWaitBetweenRemovalsSeconds() int {
 	return duration
 }
 
+// GetMinimumSuspensionDurationSeconds returns the MinimumSuspensionDurationSeconds if set or defaults to 0, which
+// disables the suspension of process groups.
+func (cluster *FoundationDBCluster) GetMinimumSuspensionDurationSeconds() int {
+	return max(
+		pointer.IntDeref(cluster.Spec.AutomationOptions.MinimumSuspensionDurationSeconds, 0),
+		0,
+	)
+}
+
+// UseVolumeExpansion returns true if the operator should expand PVCs in place instead of replacing the process
+// groups. Defaults to false.
+func (cluster *FoundationDBCluster) UseVolumeExpansion() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseVolumeExpansion, false)
+}
+
+// UseInPlacePodResize returns true if the operator should resize the resources of the Pods in place instead of
+// recreating the Pods. Defaults to false.
+func (cluster *FoundationDBCluster) UseInPlacePodResize() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseInPlacePodResize, false)
+}
+
 // UseMaintenaceMode returns true if UseMaintenanceModeChecker is set.
 func (cluster *FoundationDBCluster) UseMaintenaceMode() bool {
 	return pointer.BoolDeref(
@@ -3321,6 +3710,28 @@ func (cluster *FoundationDBCluster) Validate() error {
 		}
 	}
 
+	// Check that the trace log options are not overwritten by custom parameters.
+	managedTraceLogParameters := map[string]bool{
+		"logsize":      cluster.Spec.TraceLogOptions.RollSizeBytes != nil,
+		"maxlogs":      cluster.Spec.TraceLogOptions.MaxSizeBytes != nil,
+		"trace_format": cluster.ShouldForwardTraceLogs(),
+	}
+	for processClass, settings := range cluster.Spec.Processes {
+		for _, parameter := range settings.CustomParameters {
+			parameterName := strings.TrimSpace(strings.Split(string(parameter), "=")[0])
+			if managedTraceLogParameters[parameterName] {
+				validations = append(
+					validations,
+					fmt.Sprintf(
+						"customParameter %s for process class %s conflicts with the traceLogOptions",
+						parameterName,
+						processClass,
+					),
+				)
+			}
+		}
+	}
+
 	currentMode := cluster.GetDatabaseInteractionMode()
 	if currentMode != DatabaseInteractionModeMgmtAPI &&
 		currentMode != DatabaseInteractionModeFdbcli {
@@ -3178,6 +3178,36 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 		})
 	})
 
+	When("a process group is manually excluded", func() {
+		It("should report the process group as manually excluded", func() {
+			cluster := &FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sample-cluster",
+				},
+			}
+			Expect(cluster.ProcessGroupIsManuallyExcluded("storage-1")).To(BeFalse())
+
+			cluster.Spec.ManuallyExcludedProcessGroups = []ProcessGroupID{"log-1"}
+			Expect(cluster.ProcessGroupIsManuallyExcluded("storage-1")).To(BeFalse())
+			Expect(cluster.ProcessGroupIsManuallyExcluded("log-1")).To(BeTrue())
+		})
+	})
+
+	When("the cluster adopts an existing cluster", func() {
+		It("should only exclude the foreign processes if enabled", func() {
+			cluster := &FoundationDBCluster{}
+			Expect(cluster.IsAdopting()).To(BeFalse())
+			Expect(cluster.ExcludeForeignProcesses()).To(BeFalse())
+
+			cluster.Spec.Adoption = &AdoptionConfig{}
+			Expect(cluster.IsAdopting()).To(BeTrue())
+			Expect(cluster.ExcludeForeignProcesses()).To(BeFalse())
+
+			cluster.Spec.Adoption.ExcludeForeignProcesses = pointer.Bool(true)
+			Expect(cluster.ExcludeForeignProcesses()).To(BeTrue())
+		})
+	})
+
 	When("checking the reconciliation for a cluster", func() {
 		var createCluster func() *FoundationDBCluster
 
@@ -5186,6 +5216,30 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 					"storage engine ssd-sharded-rocksdb is not supported on version 7.1.57, stateless is not a valid process class for coordinators",
 				),
 			),
+			Entry("using custom parameters that conflict with the trace log options",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: Versions.Default.String(),
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						TraceLogOptions: TraceLogOptions{
+							ForwardToStdout: pointer.Bool(true),
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassGeneral: {
+								CustomParameters: FoundationDBCustomParameters{
+									"trace_format=xml",
+									"logsize=1000",
+								},
+							},
+						},
+					},
+				},
+				fmt.Errorf(
+					"customParameter trace_format for process class general conflicts with the traceLogOptions",
+				),
+			),
 			Entry("using invalid version for sharded rocksdb",
 				&FoundationDBCluster{
 					Spec: FoundationDBClusterSpec{
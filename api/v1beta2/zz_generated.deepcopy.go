@@ -21,13 +21,48 @@ limitations under the License.
 package v1beta2
 
 import (
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	netx "net"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptionConfig) DeepCopyInto(out *AdoptionConfig) {
+	*out = *in
+	if in.ExcludeForeignProcesses != nil {
+		in, out := &in.ExcludeForeignProcesses, &out.ExcludeForeignProcesses
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionConfig.
+func (in *AdoptionConfig) DeepCopy() *AdoptionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptionStatus) DeepCopyInto(out *AdoptionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionStatus.
+func (in *AdoptionStatus) DeepCopy() *AdoptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutomaticReplacementOptions) DeepCopyInto(out *AutomaticReplacementOptions) {
 	*out = *in
@@ -80,6 +115,66 @@ func (in *AutomaticReplacementOptions) DeepCopy() *AutomaticReplacementOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSpec) DeepCopyInto(out *AutoscalingSpec) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageAutoscalingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Stateless != nil {
+		in, out := &in.Stateless, &out.Stateless
+		*out = new(StatelessAutoscalingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRecommendationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSpec.
+func (in *AutoscalingSpec) DeepCopy() *AutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingStatus) DeepCopyInto(out *AutoscalingStatus) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Stateless != nil {
+		in, out := &in.Stateless, &out.Stateless
+		*out = new(StatelessAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceRecommendationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingStatus.
+func (in *AutoscalingStatus) DeepCopy() *AutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupGenerationStatus) DeepCopyInto(out *BackupGenerationStatus) {
 	*out = *in
@@ -351,6 +446,31 @@ func (in *ExcludedServers) DeepCopy() *ExcludedServers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExclusionThrottlingOptions) DeepCopyInto(out *ExclusionThrottlingOptions) {
+	*out = *in
+	if in.MaxInFlightBytes != nil {
+		in, out := &in.MaxInFlightBytes, &out.MaxInFlightBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxMovingDataBytes != nil {
+		in, out := &in.MaxMovingDataBytes, &out.MaxMovingDataBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExclusionThrottlingOptions.
+func (in *ExclusionThrottlingOptions) DeepCopy() *ExclusionThrottlingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExclusionThrottlingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FaultTolerance) DeepCopyInto(out *FaultTolerance) {
 	*out = *in
@@ -440,12 +560,12 @@ func (in *FoundationDBBackupSpec) DeepCopyInto(out *FoundationDBBackupSpec) {
 	}
 	if in.BackupDeploymentMetadata != nil {
 		in, out := &in.BackupDeploymentMetadata, &out.BackupDeploymentMetadata
-		*out = new(v1.ObjectMeta)
+		*out = new(metav1.ObjectMeta)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.PodTemplateSpec != nil {
 		in, out := &in.PodTemplateSpec, &out.PodTemplateSpec
-		*out = new(corev1.PodTemplateSpec)
+		*out = new(v1.PodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CustomParameters != nil {
@@ -619,6 +739,41 @@ func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBCl
 		*out = new(int)
 		**out = **in
 	}
+	if in.MinimumSuspensionDurationSeconds != nil {
+		in, out := &in.MinimumSuspensionDurationSeconds, &out.MinimumSuspensionDurationSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.UseVolumeExpansion != nil {
+		in, out := &in.UseVolumeExpansion, &out.UseVolumeExpansion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseInPlacePodResize != nil {
+		in, out := &in.UseInPlacePodResize, &out.UseInPlacePodResize
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StorageEngineMigration != nil {
+		in, out := &in.StorageEngineMigration, &out.StorageEngineMigration
+		*out = new(StorageEngineMigrationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageClassMigration != nil {
+		in, out := &in.StorageClassMigration, &out.StorageClassMigration
+		*out = new(StorageClassMigrationOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExclusionThrottling != nil {
+		in, out := &in.ExclusionThrottling, &out.ExclusionThrottling
+		*out = new(ExclusionThrottlingOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecoveryBudget != nil {
+		in, out := &in.RecoveryBudget, &out.RecoveryBudget
+		*out = new(RecoveryBudgetOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UseManagementAPI != nil {
 		in, out := &in.UseManagementAPI, &out.UseManagementAPI
 		*out = new(bool)
@@ -711,6 +866,12 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 		}
 	}
 	out.ProcessCounts = in.ProcessCounts
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(AdoptionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Autoscaling.DeepCopyInto(&out.Autoscaling)
 	in.PartialConnectionString.DeepCopyInto(&out.PartialConnectionString)
 	out.FaultDomain = in.FaultDomain
 	if in.ProcessGroupsToRemove != nil {
@@ -723,9 +884,14 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 		*out = make([]ProcessGroupID, len(*in))
 		copy(*out, *in)
 	}
+	if in.ManuallyExcludedProcessGroups != nil {
+		in, out := &in.ManuallyExcludedProcessGroups, &out.ManuallyExcludedProcessGroups
+		*out = make([]ProcessGroupID, len(*in))
+		copy(*out, *in)
+	}
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(corev1.ConfigMap)
+		*out = new(v1.ConfigMap)
 		(*in).DeepCopyInto(*out)
 	}
 	in.MainContainer.DeepCopyInto(&out.MainContainer)
@@ -770,6 +936,8 @@ func (in *FoundationDBClusterSpec) DeepCopyInto(out *FoundationDBClusterSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	in.PrometheusRuleOptions.DeepCopyInto(&out.PrometheusRuleOptions)
+	in.TraceLogOptions.DeepCopyInto(&out.TraceLogOptions)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterSpec.
@@ -817,6 +985,31 @@ func (in *FoundationDBClusterStatus) DeepCopyInto(out *FoundationDBClusterStatus
 	}
 	in.Locks.DeepCopyInto(&out.Locks)
 	in.MaintenanceModeInfo.DeepCopyInto(&out.MaintenanceModeInfo)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(AdoptionStatus)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(AutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageEngineMigration != nil {
+		in, out := &in.StorageEngineMigration, &out.StorageEngineMigration
+		*out = new(StorageEngineMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageClassMigration != nil {
+		in, out := &in.StorageClassMigration, &out.StorageClassMigration
+		*out = new(StorageClassMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecoveryBudget != nil {
+		in, out := &in.RecoveryBudget, &out.RecoveryBudget
+		*out = new(RecoveryBudgetStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterStatus.
@@ -1129,6 +1322,8 @@ func (in *FoundationDBStatusClusterInfo) DeepCopyInto(out *FoundationDBStatusClu
 		copy(*out, *in)
 	}
 	out.Qos = in.Qos
+	out.LatencyProbe = in.LatencyProbe
+	out.Workload = in.Workload
 	out.FaultTolerance = in.FaultTolerance
 	if in.IncompatibleConnections != nil {
 		in, out := &in.IncompatibleConnections, &out.IncompatibleConnections
@@ -1296,6 +1491,21 @@ func (in *FoundationDBStatusLagInfo) DeepCopy() *FoundationDBStatusLagInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusLatencyProbe) DeepCopyInto(out *FoundationDBStatusLatencyProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusLatencyProbe.
+func (in *FoundationDBStatusLatencyProbe) DeepCopy() *FoundationDBStatusLatencyProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusLatencyProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusLayerInfo) DeepCopyInto(out *FoundationDBStatusLayerInfo) {
 	*out = *in
@@ -1441,6 +1651,51 @@ func (in *FoundationDBStatusPerfStatistics) DeepCopy() *FoundationDBStatusPerfSt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusPerformanceLimitedBy) DeepCopyInto(out *FoundationDBStatusPerformanceLimitedBy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusPerformanceLimitedBy.
+func (in *FoundationDBStatusPerformanceLimitedBy) DeepCopy() *FoundationDBStatusPerformanceLimitedBy {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusPerformanceLimitedBy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusProcessCPU) DeepCopyInto(out *FoundationDBStatusProcessCPU) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessCPU.
+func (in *FoundationDBStatusProcessCPU) DeepCopy() *FoundationDBStatusProcessCPU {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusProcessCPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusProcessDisk) DeepCopyInto(out *FoundationDBStatusProcessDisk) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessDisk.
+func (in *FoundationDBStatusProcessDisk) DeepCopy() *FoundationDBStatusProcessDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusProcessDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusProcessInfo) DeepCopyInto(out *FoundationDBStatusProcessInfo) {
 	*out = *in
@@ -1464,6 +1719,10 @@ func (in *FoundationDBStatusProcessInfo) DeepCopyInto(out *FoundationDBStatusPro
 		*out = make([]FoundationDBStatusProcessMessage, len(*in))
 		copy(*out, *in)
 	}
+	out.CPU = in.CPU
+	out.Memory = in.Memory
+	out.Disk = in.Disk
+	out.Network = in.Network
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessInfo.
@@ -1476,6 +1735,21 @@ func (in *FoundationDBStatusProcessInfo) DeepCopy() *FoundationDBStatusProcessIn
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusProcessMemory) DeepCopyInto(out *FoundationDBStatusProcessMemory) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessMemory.
+func (in *FoundationDBStatusProcessMemory) DeepCopy() *FoundationDBStatusProcessMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusProcessMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusProcessMessage) DeepCopyInto(out *FoundationDBStatusProcessMessage) {
 	*out = *in
@@ -1491,10 +1765,33 @@ func (in *FoundationDBStatusProcessMessage) DeepCopy() *FoundationDBStatusProces
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusProcessNetwork) DeepCopyInto(out *FoundationDBStatusProcessNetwork) {
+	*out = *in
+	out.MegabitsReceived = in.MegabitsReceived
+	out.MegabitsSent = in.MegabitsSent
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessNetwork.
+func (in *FoundationDBStatusProcessNetwork) DeepCopy() *FoundationDBStatusProcessNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusProcessNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusProcessRoleInfo) DeepCopyInto(out *FoundationDBStatusProcessRoleInfo) {
 	*out = *in
 	out.DataLag = in.DataLag
+	out.DurabilityLag = in.DurabilityLag
+	if in.StorageMetadata != nil {
+		in, out := &in.StorageMetadata, &out.StorageMetadata
+		*out = new(FoundationDBStatusStorageMetadata)
+		**out = **in
+	}
 	if in.KVStoreUsedBytes != nil {
 		in, out := &in.KVStoreUsedBytes, &out.KVStoreUsedBytes
 		*out = new(int64)
@@ -1536,6 +1833,7 @@ func (in *FoundationDBStatusQosInfo) DeepCopyInto(out *FoundationDBStatusQosInfo
 	out.LimitingDurabilityLagStorageServer = in.LimitingDurabilityLagStorageServer
 	out.WorstDataLagStorageServer = in.WorstDataLagStorageServer
 	out.WorstDurabilityLagStorageServer = in.WorstDurabilityLagStorageServer
+	out.PerformanceLimitedBy = in.PerformanceLimitedBy
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusQosInfo.
@@ -1548,6 +1846,36 @@ func (in *FoundationDBStatusQosInfo) DeepCopy() *FoundationDBStatusQosInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusRate) DeepCopyInto(out *FoundationDBStatusRate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusRate.
+func (in *FoundationDBStatusRate) DeepCopy() *FoundationDBStatusRate {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusRate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusStorageMetadata) DeepCopyInto(out *FoundationDBStatusStorageMetadata) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusStorageMetadata.
+func (in *FoundationDBStatusStorageMetadata) DeepCopy() *FoundationDBStatusStorageMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusStorageMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusSupportedVersion) DeepCopyInto(out *FoundationDBStatusSupportedVersion) {
 	*out = *in
@@ -1594,6 +1922,40 @@ func (in *FoundationDBStatusTeamTracker) DeepCopy() *FoundationDBStatusTeamTrack
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusWorkload) DeepCopyInto(out *FoundationDBStatusWorkload) {
+	*out = *in
+	out.Transactions = in.Transactions
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusWorkload.
+func (in *FoundationDBStatusWorkload) DeepCopy() *FoundationDBStatusWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusWorkloadTransactions) DeepCopyInto(out *FoundationDBStatusWorkloadTransactions) {
+	*out = *in
+	out.Started = in.Started
+	out.Committed = in.Committed
+	out.Conflicted = in.Conflicted
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusWorkloadTransactions.
+func (in *FoundationDBStatusWorkloadTransactions) DeepCopy() *FoundationDBStatusWorkloadTransactions {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusWorkloadTransactions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBUnreachableProcess) DeepCopyInto(out *FoundationDBUnreachableProcess) {
 	*out = *in
@@ -1829,6 +2191,40 @@ func (in *ProcessAddress) DeepCopy() *ProcessAddress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessClassResourceRecommendation) DeepCopyInto(out *ProcessClassResourceRecommendation) {
+	*out = *in
+	if in.Samples != nil {
+		in, out := &in.Samples, &out.Samples
+		*out = make([]ResourceUsageSample, len(*in))
+		copy(*out, *in)
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessClassResourceRecommendation.
+func (in *ProcessClassResourceRecommendation) DeepCopy() *ProcessClassResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessClassResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessCounts) DeepCopyInto(out *ProcessCounts) {
 	*out = *in
@@ -1875,6 +2271,10 @@ func (in *ProcessGroupStatus) DeepCopyInto(out *ProcessGroupStatus) {
 		in, out := &in.ExclusionTimestamp, &out.ExclusionTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.SuspensionTimestamp != nil {
+		in, out := &in.SuspensionTimestamp, &out.SuspensionTimestamp
+		*out = (*in).DeepCopy()
+	}
 	if in.ProcessGroupConditions != nil {
 		in, out := &in.ProcessGroupConditions, &out.ProcessGroupConditions
 		*out = make([]*ProcessGroupCondition, len(*in))
@@ -1903,12 +2303,12 @@ func (in *ProcessSettings) DeepCopyInto(out *ProcessSettings) {
 	*out = *in
 	if in.PodTemplate != nil {
 		in, out := &in.PodTemplate, &out.PodTemplate
-		*out = new(corev1.PodTemplateSpec)
+		*out = new(v1.PodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.VolumeClaimTemplate != nil {
 		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
-		*out = new(corev1.PersistentVolumeClaim)
+		*out = new(v1.PersistentVolumeClaim)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CustomParameters != nil {
@@ -1929,23 +2329,148 @@ func (in *ProcessSettings) DeepCopy() *ProcessSettings {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RecoveryState) DeepCopyInto(out *RecoveryState) {
+func (in *PrometheusRuleOptions) DeepCopyInto(out *PrometheusRuleOptions) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryState.
-func (in *RecoveryState) DeepCopy() *RecoveryState {
-	if in == nil {
-		return nil
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
 	}
-	out := new(RecoveryState)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Region) DeepCopyInto(out *Region) {
-	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AlertLabels != nil {
+		in, out := &in.AlertLabels, &out.AlertLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UnavailableSeconds != nil {
+		in, out := &in.UnavailableSeconds, &out.UnavailableSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.FaultToleranceReducedSeconds != nil {
+		in, out := &in.FaultToleranceReducedSeconds, &out.FaultToleranceReducedSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.ProcessGroupConditionSeconds != nil {
+		in, out := &in.ProcessGroupConditionSeconds, &out.ProcessGroupConditionSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.ReconciliationStuckSeconds != nil {
+		in, out := &in.ReconciliationStuckSeconds, &out.ReconciliationStuckSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRuleOptions.
+func (in *PrometheusRuleOptions) DeepCopy() *PrometheusRuleOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRuleOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryBudgetEntry) DeepCopyInto(out *RecoveryBudgetEntry) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryBudgetEntry.
+func (in *RecoveryBudgetEntry) DeepCopy() *RecoveryBudgetEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryBudgetEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryBudgetOptions) DeepCopyInto(out *RecoveryBudgetOptions) {
+	*out = *in
+	if in.MaxRecoveries != nil {
+		in, out := &in.MaxRecoveries, &out.MaxRecoveries
+		*out = new(int)
+		**out = **in
+	}
+	if in.WindowSeconds != nil {
+		in, out := &in.WindowSeconds, &out.WindowSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryBudgetOptions.
+func (in *RecoveryBudgetOptions) DeepCopy() *RecoveryBudgetOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryBudgetOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryBudgetStatus) DeepCopyInto(out *RecoveryBudgetStatus) {
+	*out = *in
+	if in.Recoveries != nil {
+		in, out := &in.Recoveries, &out.Recoveries
+		*out = make([]RecoveryBudgetEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextRecoveryTimestamp != nil {
+		in, out := &in.NextRecoveryTimestamp, &out.NextRecoveryTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRecoveryTimestamp != nil {
+		in, out := &in.LastRecoveryTimestamp, &out.LastRecoveryTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryBudgetStatus.
+func (in *RecoveryBudgetStatus) DeepCopy() *RecoveryBudgetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryBudgetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecoveryState) DeepCopyInto(out *RecoveryState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecoveryState.
+func (in *RecoveryState) DeepCopy() *RecoveryState {
+	if in == nil {
+		return nil
+	}
+	out := new(RecoveryState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Region) DeepCopyInto(out *Region) {
+	*out = *in
 	if in.DataCenters != nil {
 		in, out := &in.DataCenters, &out.DataCenters
 		*out = make([]DataCenter, len(*in))
@@ -1978,6 +2503,112 @@ func (in *RequiredAddressSet) DeepCopy() *RequiredAddressSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationPolicy) DeepCopyInto(out *ResourceRecommendationPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SampleIntervalSeconds != nil {
+		in, out := &in.SampleIntervalSeconds, &out.SampleIntervalSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxSamples != nil {
+		in, out := &in.MaxSamples, &out.MaxSamples
+		*out = new(int)
+		**out = **in
+	}
+	if in.RequestPercentile != nil {
+		in, out := &in.RequestPercentile, &out.RequestPercentile
+		*out = new(int)
+		**out = **in
+	}
+	if in.HeadroomPercentage != nil {
+		in, out := &in.HeadroomPercentage, &out.HeadroomPercentage
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationPolicy.
+func (in *ResourceRecommendationPolicy) DeepCopy() *ResourceRecommendationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationStatus) DeepCopyInto(out *ResourceRecommendationStatus) {
+	*out = *in
+	if in.LastSampleTimestamp != nil {
+		in, out := &in.LastSampleTimestamp, &out.LastSampleTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ProcessClasses != nil {
+		in, out := &in.ProcessClasses, &out.ProcessClasses
+		*out = make([]ProcessClassResourceRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationStatus.
+func (in *ResourceRecommendationStatus) DeepCopy() *ResourceRecommendationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageSample) DeepCopyInto(out *ResourceUsageSample) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageSample.
+func (in *ResourceUsageSample) DeepCopy() *ResourceUsageSample {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageSample)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAutoscalingBounds) DeepCopyInto(out *RoleAutoscalingBounds) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAutoscalingBounds.
+func (in *RoleAutoscalingBounds) DeepCopy() *RoleAutoscalingBounds {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAutoscalingBounds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleCounts) DeepCopyInto(out *RoleCounts) {
 	*out = *in
@@ -2038,6 +2669,265 @@ func (in *RoutingConfig) DeepCopy() *RoutingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatelessAutoscalingPolicy) DeepCopyInto(out *StatelessAutoscalingPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CommitProxies != nil {
+		in, out := &in.CommitProxies, &out.CommitProxies
+		*out = new(RoleAutoscalingBounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GrvProxies != nil {
+		in, out := &in.GrvProxies, &out.GrvProxies
+		*out = new(RoleAutoscalingBounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resolvers != nil {
+		in, out := &in.Resolvers, &out.Resolvers
+		*out = new(RoleAutoscalingBounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetCommitLatencyMilliseconds != nil {
+		in, out := &in.TargetCommitLatencyMilliseconds, &out.TargetCommitLatencyMilliseconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.TargetGRVLatencyMilliseconds != nil {
+		in, out := &in.TargetGRVLatencyMilliseconds, &out.TargetGRVLatencyMilliseconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.TargetTransactionsPerSecondPerProxy != nil {
+		in, out := &in.TargetTransactionsPerSecondPerProxy, &out.TargetTransactionsPerSecondPerProxy
+		*out = new(int)
+		**out = **in
+	}
+	if in.ScaleDownLatencyPercentage != nil {
+		in, out := &in.ScaleDownLatencyPercentage, &out.ScaleDownLatencyPercentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.CooldownSeconds != nil {
+		in, out := &in.CooldownSeconds, &out.CooldownSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatelessAutoscalingPolicy.
+func (in *StatelessAutoscalingPolicy) DeepCopy() *StatelessAutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StatelessAutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatelessAutoscalingStatus) DeepCopyInto(out *StatelessAutoscalingStatus) {
+	*out = *in
+	if in.LastScalingTimestamp != nil {
+		in, out := &in.LastScalingTimestamp, &out.LastScalingTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatelessAutoscalingStatus.
+func (in *StatelessAutoscalingStatus) DeepCopy() *StatelessAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StatelessAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscalingPolicy) DeepCopyInto(out *StorageAutoscalingPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TargetDiskUsagePercentage != nil {
+		in, out := &in.TargetDiskUsagePercentage, &out.TargetDiskUsagePercentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinProcessGroups != nil {
+		in, out := &in.MinProcessGroups, &out.MinProcessGroups
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxProcessGroups != nil {
+		in, out := &in.MaxProcessGroups, &out.MaxProcessGroups
+		*out = new(int)
+		**out = **in
+	}
+	if in.StepSize != nil {
+		in, out := &in.StepSize, &out.StepSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.CooldownSeconds != nil {
+		in, out := &in.CooldownSeconds, &out.CooldownSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxVolumeSize != nil {
+		in, out := &in.MaxVolumeSize, &out.MaxVolumeSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscalingPolicy.
+func (in *StorageAutoscalingPolicy) DeepCopy() *StorageAutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscalingStatus) DeepCopyInto(out *StorageAutoscalingStatus) {
+	*out = *in
+	if in.DesiredVolumeSize != nil {
+		in, out := &in.DesiredVolumeSize, &out.DesiredVolumeSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LastScalingTimestamp != nil {
+		in, out := &in.LastScalingTimestamp, &out.LastScalingTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscalingStatus.
+func (in *StorageAutoscalingStatus) DeepCopy() *StorageAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClassMigrationOptions) DeepCopyInto(out *StorageClassMigrationOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxInFlightBytes != nil {
+		in, out := &in.MaxInFlightBytes, &out.MaxInFlightBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClassMigrationOptions.
+func (in *StorageClassMigrationOptions) DeepCopy() *StorageClassMigrationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClassMigrationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClassMigrationStatus) DeepCopyInto(out *StorageClassMigrationStatus) {
+	*out = *in
+	if in.EstimatedRemainingSeconds != nil {
+		in, out := &in.EstimatedRemainingSeconds, &out.EstimatedRemainingSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClassMigrationStatus.
+func (in *StorageClassMigrationStatus) DeepCopy() *StorageClassMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClassMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageEngineMigrationOptions) DeepCopyInto(out *StorageEngineMigrationOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MigrationType != nil {
+		in, out := &in.MigrationType, &out.MigrationType
+		*out = new(StorageMigrationType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageEngineMigrationOptions.
+func (in *StorageEngineMigrationOptions) DeepCopy() *StorageEngineMigrationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageEngineMigrationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageEngineMigrationStatus) DeepCopyInto(out *StorageEngineMigrationStatus) {
+	*out = *in
+	if in.EstimatedRemainingSeconds != nil {
+		in, out := &in.EstimatedRemainingSeconds, &out.EstimatedRemainingSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageEngineMigrationStatus.
+func (in *StorageEngineMigrationStatus) DeepCopy() *StorageEngineMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageEngineMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaintReplacementOption) DeepCopyInto(out *TaintReplacementOption) {
 	*out = *in
@@ -2063,6 +2953,41 @@ func (in *TaintReplacementOption) DeepCopy() *TaintReplacementOption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TraceLogOptions) DeepCopyInto(out *TraceLogOptions) {
+	*out = *in
+	if in.RollSizeBytes != nil {
+		in, out := &in.RollSizeBytes, &out.RollSizeBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxSizeBytes != nil {
+		in, out := &in.MaxSizeBytes, &out.MaxSizeBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxAgeSeconds != nil {
+		in, out := &in.MaxAgeSeconds, &out.MaxAgeSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.ForwardToStdout != nil {
+		in, out := &in.ForwardToStdout, &out.ForwardToStdout
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TraceLogOptions.
+func (in *TraceLogOptions) DeepCopy() *TraceLogOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TraceLogOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Version) DeepCopyInto(out *Version) {
 	*out = *in
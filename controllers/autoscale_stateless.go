@@ -0,0 +1,165 @@
+/*
+ * autoscale_stateless.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/autoscaling"
+)
+
+// autoscaleStateless provides a reconciliation step for scaling the commit proxies, GRV proxies and resolvers based on
+// the QoS information.
+type autoscaleStateless struct{}
+
+// reconcile runs the reconciler's work.
+func (a autoscaleStateless) reconcile(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	logger logr.Logger,
+) *requeue {
+	if !cluster.UseStatelessAutoscaling() {
+		return nil
+	}
+
+	processCounts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	// Wait until the last scaling decision is reconciled before making a new scaling decision.
+	var statelessProcessGroups int
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStateless ||
+			processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		statelessProcessGroups++
+	}
+
+	if processCounts.Stateless > 0 && statelessProcessGroups != processCounts.Stateless {
+		logger.V(1).Info(
+			"Skipping stateless autoscaling until the stateless process groups are reconciled",
+			"current",
+			statelessProcessGroups,
+			"desired",
+			processCounts.Stateless,
+		)
+		return nil
+	}
+
+	if status == nil {
+		adminClient, err := r.getAdminClient(logger, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+		defer func() {
+			_ = adminClient.Close()
+		}()
+
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	roleCounts := cluster.GetRoleCountsWithDefaults()
+	currentConfiguration := status.Cluster.DatabaseConfiguration
+	if currentConfiguration.Resolvers != roleCounts.Resolvers ||
+		(roleCounts.Proxies == 0 && (currentConfiguration.CommitProxies != roleCounts.CommitProxies ||
+			currentConfiguration.GrvProxies != roleCounts.GrvProxies)) {
+		logger.V(1).Info(
+			"Skipping stateless autoscaling until the database configuration is reconciled",
+			"current",
+			currentConfiguration.RoleCounts,
+			"desired",
+			roleCounts,
+		)
+		return nil
+	}
+
+	decision := autoscaling.GetStatelessScalingDecision(
+		logger,
+		cluster,
+		status,
+		roleCounts,
+		time.Now(),
+	)
+
+	autoscalingStatus := &fdbv1beta2.StatelessAutoscalingStatus{
+		CommitProxies: roleCounts.CommitProxies,
+		GrvProxies:    roleCounts.GrvProxies,
+		Resolvers:     roleCounts.Resolvers,
+	}
+	currentStatus := cluster.GetStatelessAutoscalingStatus()
+	if currentStatus != nil {
+		autoscalingStatus.CommitLatencyMilliseconds = currentStatus.CommitLatencyMilliseconds
+		autoscalingStatus.GRVLatencyMilliseconds = currentStatus.GRVLatencyMilliseconds
+		autoscalingStatus.TransactionsPerSecond = currentStatus.TransactionsPerSecond
+		autoscalingStatus.LimitingReason = currentStatus.LimitingReason
+		autoscalingStatus.LastScalingTimestamp = currentStatus.LastScalingTimestamp
+		autoscalingStatus.LastScalingDecision = currentStatus.LastScalingDecision
+	}
+
+	signals, ok := autoscaling.GetStatelessSignals(status)
+	if ok {
+		autoscalingStatus.CommitLatencyMilliseconds = signals.CommitLatencyMilliseconds
+		autoscalingStatus.GRVLatencyMilliseconds = signals.GRVLatencyMilliseconds
+		autoscalingStatus.TransactionsPerSecond = signals.StartedTransactionsPerSecond
+		autoscalingStatus.LimitingReason = signals.LimitingReason
+	}
+
+	if decision != nil {
+		logger.Info("Stateless autoscaling decision", "message", decision.Message)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "StatelessAutoscaling", decision.Message)
+		autoscalingStatus.CommitProxies = decision.CommitProxies
+		autoscalingStatus.GrvProxies = decision.GrvProxies
+		autoscalingStatus.Resolvers = decision.Resolvers
+		autoscalingStatus.LastScalingTimestamp = &metav1.Time{Time: time.Now()}
+		autoscalingStatus.LastScalingDecision = decision.Message
+	}
+
+	if equality.Semantic.DeepEqual(currentStatus, autoscalingStatus) {
+		return nil
+	}
+
+	if cluster.Status.Autoscaling == nil {
+		cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{}
+	}
+	cluster.Status.Autoscaling.Stateless = autoscalingStatus
+
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+ * autoscale_stateless_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient/mock"
+	"k8s.io/utils/pointer"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("autoscale_stateless", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+	var req *requeue
+	var commitLatencySeconds float64
+	var limitingReason string
+
+	BeforeEach(func() {
+		commitLatencySeconds = 0.1
+		limitingReason = "workload"
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.DatabaseConfiguration.CommitProxies = 2
+		cluster.Spec.DatabaseConfiguration.GrvProxies = 1
+		cluster.Spec.Autoscaling.Stateless = &fdbv1beta2.StatelessAutoscalingPolicy{
+			Enabled: pointer.Bool(true),
+			CommitProxies: &fdbv1beta2.RoleAutoscalingBounds{
+				Max: pointer.Int(4),
+			},
+		}
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		status, err = adminClient.GetStatus()
+		Expect(err).NotTo(HaveOccurred())
+		status.Cluster.LatencyProbe.CommitSeconds = commitLatencySeconds
+		status.Cluster.LatencyProbe.TransactionStartSeconds = 0.008
+		status.Cluster.Qos.PerformanceLimitedBy.Name = limitingReason
+
+		req = autoscaleStateless{}.reconcile(
+			context.TODO(),
+			clusterReconciler,
+			cluster,
+			status,
+			globalControllerLogger,
+		)
+	})
+
+	When("the commit latency is above the target", func() {
+		It("should scale up the commit proxies", func() {
+			Expect(req).To(BeNil())
+			autoscalingStatus := cluster.GetStatelessAutoscalingStatus()
+			Expect(autoscalingStatus).NotTo(BeNil())
+			Expect(autoscalingStatus.CommitProxies).To(Equal(3))
+			Expect(autoscalingStatus.GrvProxies).To(Equal(1))
+			Expect(autoscalingStatus.Resolvers).To(Equal(1))
+			Expect(autoscalingStatus.CommitLatencyMilliseconds).To(Equal(100))
+			Expect(autoscalingStatus.LastScalingTimestamp).NotTo(BeNil())
+			Expect(autoscalingStatus.LastScalingDecision).NotTo(BeEmpty())
+
+			Expect(cluster.GetRoleCountsWithDefaults().CommitProxies).To(Equal(3))
+			Expect(cluster.DesiredDatabaseConfiguration().CommitProxies).To(Equal(3))
+		})
+
+		When("the ratekeeper limits the transaction rate because of the log servers", func() {
+			BeforeEach(func() {
+				limitingReason = "log_server_write_queue"
+			})
+
+			It("should only update the observed values", func() {
+				Expect(req).To(BeNil())
+				autoscalingStatus := cluster.GetStatelessAutoscalingStatus()
+				Expect(autoscalingStatus).NotTo(BeNil())
+				Expect(autoscalingStatus.CommitProxies).To(Equal(2))
+				Expect(autoscalingStatus.LimitingReason).To(Equal("log_server_write_queue"))
+				Expect(autoscalingStatus.LastScalingTimestamp).To(BeNil())
+			})
+		})
+
+		When("the last scaling decision is not reconciled", func() {
+			BeforeEach(func() {
+				cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{
+					Stateless: &fdbv1beta2.StatelessAutoscalingStatus{
+						CommitProxies: 3,
+					},
+				}
+			})
+
+			It("should not make a scaling decision", func() {
+				Expect(req).To(BeNil())
+				autoscalingStatus := cluster.GetStatelessAutoscalingStatus()
+				Expect(autoscalingStatus).NotTo(BeNil())
+				Expect(autoscalingStatus.CommitProxies).To(Equal(3))
+				Expect(autoscalingStatus.LastScalingTimestamp).To(BeNil())
+			})
+		})
+	})
+
+	When("the commit latency is below the target", func() {
+		BeforeEach(func() {
+			commitLatencySeconds = 0.04
+		})
+
+		It("should not scale below the minimum", func() {
+			Expect(req).To(BeNil())
+			autoscalingStatus := cluster.GetStatelessAutoscalingStatus()
+			Expect(autoscalingStatus).NotTo(BeNil())
+			Expect(autoscalingStatus.CommitProxies).To(Equal(2))
+			Expect(autoscalingStatus.LastScalingTimestamp).To(BeNil())
+		})
+	})
+})
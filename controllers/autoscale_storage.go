@@ -0,0 +1,191 @@
+/*
+ * autoscale_storage.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/autoscaling"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/volumes"
+)
+
+// autoscaleStorage provides a reconciliation step for scaling the storage processes based on the disk usage.
+type autoscaleStorage struct{}
+
+// reconcile runs the reconciler's work.
+func (a autoscaleStorage) reconcile(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	logger logr.Logger,
+) *requeue {
+	if !cluster.UseStorageAutoscaling() {
+		return nil
+	}
+
+	processCounts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	// Wait until the last scaling decision is reconciled before making a new scaling decision.
+	var storageProcessGroups int
+	var storageProcessGroup *fdbv1beta2.ProcessGroupStatus
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage ||
+			processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		storageProcessGroups++
+		storageProcessGroup = processGroup
+	}
+
+	if storageProcessGroups != processCounts.Storage {
+		logger.V(1).Info(
+			"Skipping storage autoscaling until the storage process groups are reconciled",
+			"current",
+			storageProcessGroups,
+			"desired",
+			processCounts.Storage,
+		)
+		return nil
+	}
+
+	if status == nil {
+		adminClient, err := r.getAdminClient(logger, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+		defer func() {
+			_ = adminClient.Close()
+		}()
+
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	if storageProcessGroup == nil {
+		storageProcessGroup = &fdbv1beta2.ProcessGroupStatus{
+			ProcessClass: fdbv1beta2.ProcessClassStorage,
+		}
+	}
+
+	desiredPVC, err := internal.GetPvc(cluster, storageProcessGroup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	canExpandVolumes, err := storageVolumesCanBeExpanded(ctx, r, cluster, desiredPVC)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	decision, err := autoscaling.GetStorageScalingDecision(
+		logger,
+		cluster,
+		status,
+		processCounts.Storage,
+		desiredPVC.Spec.Resources.Requests[corev1.ResourceStorage],
+		canExpandVolumes,
+		time.Now(),
+	)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	autoscalingStatus := &fdbv1beta2.StorageAutoscalingStatus{
+		DesiredProcessGroups: processCounts.Storage,
+	}
+	currentStatus := cluster.GetStorageAutoscalingStatus()
+	if currentStatus != nil {
+		autoscalingStatus.DesiredVolumeSize = currentStatus.DesiredVolumeSize
+		autoscalingStatus.LastScalingTimestamp = currentStatus.LastScalingTimestamp
+		autoscalingStatus.LastScalingDecision = currentStatus.LastScalingDecision
+	}
+
+	usage, ok := autoscaling.GetStorageDiskUsagePercentage(status)
+	if ok {
+		autoscalingStatus.DiskUsagePercentage = usage
+	} else if currentStatus != nil {
+		autoscalingStatus.DiskUsagePercentage = currentStatus.DiskUsagePercentage
+	}
+
+	if decision != nil {
+		logger.Info("Storage autoscaling decision", "message", decision.Message)
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "StorageAutoscaling", decision.Message)
+		autoscalingStatus.DesiredProcessGroups = decision.ProcessGroups
+		if decision.VolumeSize != nil {
+			autoscalingStatus.DesiredVolumeSize = decision.VolumeSize
+		}
+		autoscalingStatus.LastScalingTimestamp = &metav1.Time{Time: time.Now()}
+		autoscalingStatus.LastScalingDecision = decision.Message
+	}
+
+	if equality.Semantic.DeepEqual(currentStatus, autoscalingStatus) {
+		return nil
+	}
+
+	if cluster.Status.Autoscaling == nil {
+		cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{}
+	}
+	cluster.Status.Autoscaling.Storage = autoscalingStatus
+
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// storageVolumesCanBeExpanded returns true if the storage volumes of the cluster can be expanded in place. The
+// storage class of an existing PVC will be used if present.
+func storageVolumesCanBeExpanded(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	desiredPVC *corev1.PersistentVolumeClaim,
+) (bool, error) {
+	if !cluster.UseVolumeExpansion() {
+		return false, nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(desiredPVC), pvc)
+	if err != nil {
+		pvc = desiredPVC
+	}
+
+	return volumes.StorageClassAllowsExpansion(ctx, r.getAPIReader(), pvc)
+}
@@ -0,0 +1,162 @@
+/*
+ * autoscale_storage_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("autoscale_storage", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+	var req *requeue
+	var usage int64
+
+	BeforeEach(func() {
+		usage = 85
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.Autoscaling.Storage = &fdbv1beta2.StorageAutoscalingPolicy{
+			Enabled:          pointer.Bool(true),
+			MaxProcessGroups: pointer.Int(6),
+		}
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		status = &fdbv1beta2.FoundationDBStatus{
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+					"storage-1": {
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{
+								Role:                  string(fdbv1beta2.ProcessRoleStorage),
+								KVStoreUsedBytes:      pointer.Int64(usage),
+								KVStoreAvailableBytes: pointer.Int64(100 - usage),
+							},
+						},
+					},
+				},
+			},
+		}
+
+		req = autoscaleStorage{}.reconcile(
+			context.TODO(),
+			clusterReconciler,
+			cluster,
+			status,
+			globalControllerLogger,
+		)
+	})
+
+	When("the disk usage is above the target", func() {
+		It("should scale up the storage process groups", func() {
+			Expect(req).To(BeNil())
+			autoscalingStatus := cluster.GetStorageAutoscalingStatus()
+			Expect(autoscalingStatus).NotTo(BeNil())
+			Expect(autoscalingStatus.DesiredProcessGroups).To(Equal(5))
+			Expect(autoscalingStatus.DiskUsagePercentage).To(Equal(85))
+			Expect(autoscalingStatus.LastScalingTimestamp).NotTo(BeNil())
+			Expect(autoscalingStatus.LastScalingDecision).NotTo(BeEmpty())
+
+			processCounts, err := cluster.GetProcessCountsWithDefaults()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processCounts.Storage).To(Equal(5))
+		})
+
+		When("the storage autoscaling is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.Autoscaling.Storage.Enabled = pointer.Bool(false)
+			})
+
+			It("should not make a scaling decision", func() {
+				Expect(req).To(BeNil())
+				autoscalingStatus := cluster.GetStorageAutoscalingStatus()
+				if autoscalingStatus != nil {
+					Expect(autoscalingStatus.LastScalingTimestamp).To(BeNil())
+				}
+
+				processCounts, err := cluster.GetProcessCountsWithDefaults()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(processCounts.Storage).To(Equal(4))
+			})
+		})
+
+		When("the last scaling decision is not reconciled", func() {
+			BeforeEach(func() {
+				cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{
+					Storage: &fdbv1beta2.StorageAutoscalingStatus{
+						DesiredProcessGroups: 5,
+					},
+				}
+			})
+
+			It("should not make a scaling decision", func() {
+				Expect(req).To(BeNil())
+				autoscalingStatus := cluster.GetStorageAutoscalingStatus()
+				Expect(autoscalingStatus).NotTo(BeNil())
+				Expect(autoscalingStatus.DesiredProcessGroups).To(Equal(5))
+				Expect(autoscalingStatus.LastScalingTimestamp).To(BeNil())
+			})
+		})
+
+		When("the last scaling decision is in the cooldown period", func() {
+			BeforeEach(func() {
+				cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{
+					Storage: &fdbv1beta2.StorageAutoscalingStatus{
+						DesiredProcessGroups: 4,
+						LastScalingTimestamp: &metav1.Time{Time: time.Now().Add(-1 * time.Minute)},
+					},
+				}
+			})
+
+			It("should only update the disk usage", func() {
+				Expect(req).To(BeNil())
+				autoscalingStatus := cluster.GetStorageAutoscalingStatus()
+				Expect(autoscalingStatus).NotTo(BeNil())
+				Expect(autoscalingStatus.DesiredProcessGroups).To(Equal(4))
+				Expect(autoscalingStatus.DiskUsagePercentage).To(Equal(85))
+			})
+		})
+	})
+
+	When("the disk usage is below the target", func() {
+		BeforeEach(func() {
+			usage = 30
+		})
+
+		It("should not scale below the minimum", func() {
+			Expect(req).To(BeNil())
+			autoscalingStatus := cluster.GetStorageAutoscalingStatus()
+			Expect(autoscalingStatus).NotTo(BeNil())
+			Expect(autoscalingStatus.DesiredProcessGroups).To(Equal(4))
+			Expect(autoscalingStatus.LastScalingTimestamp).To(BeNil())
+		})
+	})
+})
@@ -44,7 +44,7 @@ type bounceProcesses struct{}
 
 // reconcile runs the reconciler's work.
 func (c bounceProcesses) reconcile(
-	_ context.Context,
+	ctx context.Context,
 	r *FoundationDBClusterReconciler,
 	cluster *fdbv1beta2.FoundationDBCluster,
 	status *fdbv1beta2.FoundationDBStatus,
@@ -267,6 +267,18 @@ func (c bounceProcesses) reconcile(
 		return nil
 	}
 
+	err = r.checkRecoveryBudget(logger, cluster, fdbv1beta2.RecoveryActionBounce)
+	if err != nil {
+		return getRecoveryBudgetRequeue(err)
+	}
+
+	// The kill command is not reliable and will not return an error if some kill requests were not delivered, so the
+	// bounce is recorded before the processes are restarted.
+	err = r.recordRecovery(ctx, cluster, fdbv1beta2.RecoveryActionBounce)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
 	logger.Info("Bouncing processes", "addresses", addresses, "upgrading", upgrading)
 	r.Recorder.Event(
 		cluster,
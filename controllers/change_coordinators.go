@@ -94,6 +94,11 @@ func (c changeCoordinators) reconcile(
 		return nil
 	}
 
+	err = r.checkRecoveryBudget(logger, cluster, fdbv1beta2.RecoveryActionChangeCoordinators)
+	if err != nil {
+		return getRecoveryBudgetRequeue(err)
+	}
+
 	err = r.takeLock(logger, cluster, "changing coordinators")
 	if err != nil {
 		return &requeue{curError: err, delayedRequeue: true}
@@ -115,7 +120,7 @@ func (c changeCoordinators) reconcile(
 	// Reset the SecondsSinceLastRecovered sine the operator just changed the coordinators, which will cause a recovery.
 	status.Cluster.RecoveryState.SecondsSinceLastRecovered = 0.0
 
-	err = r.updateOrApply(ctx, cluster)
+	err = r.recordRecoveryAndUpdate(ctx, cluster, fdbv1beta2.RecoveryActionChangeCoordinators)
 	if err != nil {
 		return &requeue{curError: err, delayedRequeue: true}
 	}
@@ -23,6 +23,7 @@ package controllers
 import (
 	"context"
 	"math"
+	"time"
 
 	"k8s.io/utils/pointer"
 
@@ -32,6 +33,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("Change coordinators", func() {
@@ -124,6 +126,47 @@ var _ = Describe("Change coordinators", func() {
 					cluster.Status.ConnectionString,
 				).NotTo(ContainSubstring("my-ns.svc.cluster.local"))
 			})
+
+			When("the recovery budget is enabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.RecoveryBudget = &fdbv1beta2.RecoveryBudgetOptions{
+						MaxRecoveries: pointer.Int(1),
+					}
+				})
+
+				It("should record the coordinator change", func() {
+					Expect(requeue).To(BeNil())
+					Expect(cluster.Status.ConnectionString).NotTo(Equal(originalConnectionString))
+					Expect(cluster.Status.RecoveryBudget).NotTo(BeNil())
+					Expect(cluster.Status.RecoveryBudget.Recoveries).To(HaveLen(1))
+					Expect(
+						cluster.Status.RecoveryBudget.Recoveries[0].Action,
+					).To(Equal(fdbv1beta2.RecoveryActionChangeCoordinators))
+					Expect(cluster.Status.RecoveryBudget.RemainingRecoveries).To(BeZero())
+				})
+
+				When("the recovery budget is exhausted", func() {
+					BeforeEach(func() {
+						cluster.Status.RecoveryBudget = &fdbv1beta2.RecoveryBudgetStatus{
+							Recoveries: []fdbv1beta2.RecoveryBudgetEntry{
+								{
+									Action: fdbv1beta2.RecoveryActionBounce,
+									Timestamp: metav1.Time{
+										Time: time.Now().Add(-10 * time.Minute),
+									},
+								},
+							},
+						}
+					})
+
+					It("should delay the coordinator change", func() {
+						Expect(requeue).NotTo(BeNil())
+						Expect(requeue.delayedRequeue).To(BeTrue())
+						Expect(requeue.delay).To(BeNumerically("~", 50*time.Minute, time.Minute))
+						Expect(cluster.Status.ConnectionString).To(Equal(originalConnectionString))
+					})
+				})
+			})
 		})
 
 		When("one coordinator is missing localities", func() {
@@ -23,6 +23,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -46,6 +47,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/recoverybudget"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	sigyaml "sigs.k8s.io/yaml"
 
@@ -71,22 +73,32 @@ var subReconcilers = []clusterSubReconciler{
 	deletePodsForBuggification{},
 	replaceMisconfiguredProcessGroups{},
 	replaceFailedProcessGroups{},
+	migrateStorageClass{},
+	autoscaleStorage{},
+	autoscaleStateless{},
+	recommendResources{},
 	addProcessGroups{},
 	addServices{},
+	updatePrometheusRule{},
 	addPVCs{},
+	expandPVCs{},
 	addPodsReconciler,
 	generateInitialClusterFile{},
 	removeIncompatibleProcesses{},
 	updateSidecarVersions{},
 	updatePodConfig{},
 	updateMetadata{},
+	trackStorageEngineMigration{},
 	updateDatabaseConfiguration{},
 	chooseRemovals{},
 	excludeProcesses{},
 	changeCoordinators{},
+	excludeForeignProcesses{},
 	bounceProcesses{},
 	maintenanceModeChecker{},
+	resizePods{},
 	updatePods{},
+	suspendProcessGroups{},
 	removeProcessGroups{},
 	removeServices{},
 	updateStatus{},
@@ -103,6 +115,9 @@ type SimulationOptions struct {
 // FoundationDBClusterReconciler reconciles a FoundationDBCluster object
 type FoundationDBClusterReconciler struct {
 	client.Client
+	// APIReader is used to read resources that are not cached by the controller manager, e.g. the storage classes.
+	// If not set, the Client will be used.
+	APIReader                                   client.Reader
 	Recorder                                    record.EventRecorder
 	Log                                         logr.Logger
 	EnableRestartIncompatibleProcesses          bool
@@ -141,6 +156,8 @@ type FoundationDBClusterReconciler struct {
 	ClusterLabelKeyForNodeTrigger string
 	decodingSerializer            runtime.Serializer
 	SimulationOptions             SimulationOptions
+	// clusterInsights stores the information gathered during the reconciliation that is served by the InsightServer.
+	clusterInsights clusterInsightCache
 }
 
 // NewFoundationDBClusterReconciler creates a new FoundationDBClusterReconciler with defaults.
@@ -159,7 +176,11 @@ func NewFoundationDBClusterReconciler(
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=pods;configmaps;persistentvolumeclaims;events;secrets;services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods/resize,verbs=patch;update
 // +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="monitoring.coreos.com",resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="authentication.k8s.io",resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups="authorization.k8s.io",resources=subjectaccessreviews,verbs=create
 
 // Reconcile runs the reconciliation logic.
 func (r *FoundationDBClusterReconciler) Reconcile(
@@ -171,6 +192,8 @@ func (r *FoundationDBClusterReconciler) Reconcile(
 	err := r.Get(ctx, request.NamespacedName, cluster)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
+			// The cluster was deleted, so the gathered information is not needed anymore.
+			r.clusterInsights.delete(request.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -325,6 +348,7 @@ func (r *FoundationDBClusterReconciler) Reconcile(
 	normalizedSpec := cluster.Spec.DeepCopy()
 	var delayedRequeueDuration time.Duration
 	var delayedRequeue bool
+	var blockers []ReconciliationBlocker
 
 	for _, subReconciler := range subReconcilers {
 		// We have to set the normalized spec here again otherwise any call to Update() for the status of the cluster
@@ -347,12 +371,19 @@ func (r *FoundationDBClusterReconciler) Reconcile(
 			}
 
 			delayedRequeue = true
+			blockers = append(blockers, newReconciliationBlocker(subReconciler, req))
 			continue
 		}
 
+		r.clusterInsights.setReconciliation(
+			cluster,
+			append(blockers, newReconciliationBlocker(subReconciler, req)),
+		)
 		return processRequeue(req, subReconciler, cluster, r.Recorder, clusterLog)
 	}
 
+	r.clusterInsights.setReconciliation(cluster, blockers)
+
 	if cluster.Status.Generations.Reconciled < originalGeneration || delayedRequeue {
 		clusterLog.Info(
 			"Cluster was not fully reconciled by reconciliation process",
@@ -646,6 +677,17 @@ func (r *FoundationDBClusterReconciler) getLockClient(
 	return r.getDatabaseClientProvider().GetLockClientWithLogger(cluster, logger)
 }
 
+// getAPIReader returns the reader for resources that are not cached by the controller manager. Reading those resources
+// through the cached client would start an informer, which never syncs if the operator is not allowed to list and
+// watch the resources.
+func (r *FoundationDBClusterReconciler) getAPIReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+
+	return r.APIReader
+}
+
 // takeLock attempts to acquire a lock.
 func (r *FoundationDBClusterReconciler) takeLock(
 	logger logr.Logger,
@@ -678,6 +720,72 @@ func (r *FoundationDBClusterReconciler) releaseLock(
 	return lockClient.ReleaseLock()
 }
 
+// checkRecoveryBudget returns an error if the recovery budget of the cluster doesn't allow the operator to perform
+// the provided action.
+func (r *FoundationDBClusterReconciler) checkRecoveryBudget(
+	logger logr.Logger,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	action fdbv1beta2.RecoveryAction,
+) error {
+	err := recoverybudget.CheckBudget(cluster, time.Now())
+	if err != nil {
+		logger.Info(
+			"Recovery budget is exhausted, delaying action",
+			"action",
+			action,
+			"error",
+			err.Error(),
+		)
+		r.Recorder.Event(
+			cluster,
+			corev1.EventTypeNormal,
+			"RecoveryBudgetExhausted",
+			fmt.Sprintf("Delaying %s: %s", action, err.Error()),
+		)
+	}
+
+	return err
+}
+
+// recordRecovery adds the provided action to the recovery budget of the cluster and persists the cluster status. If the
+// recovery budget is disabled, this method is a no-op. Sub-reconcilers must use this method after performing an action
+// that can cause a recovery.
+func (r *FoundationDBClusterReconciler) recordRecovery(
+	ctx context.Context,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	action fdbv1beta2.RecoveryAction,
+) error {
+	if !cluster.UseRecoveryBudget() {
+		return nil
+	}
+
+	return r.recordRecoveryAndUpdate(ctx, cluster, action)
+}
+
+// recordRecoveryAndUpdate adds the provided action to the recovery budget of the cluster, if the recovery budget is
+// enabled, and persists the cluster status. Sub-reconcilers that must persist the cluster status after performing an
+// action that can cause a recovery must use this method instead of recordRecovery.
+func (r *FoundationDBClusterReconciler) recordRecoveryAndUpdate(
+	ctx context.Context,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	action fdbv1beta2.RecoveryAction,
+) error {
+	recoverybudget.RecordRecovery(cluster, action, time.Now())
+
+	return r.updateOrApply(ctx, cluster)
+}
+
+// getRecoveryBudgetRequeue returns the requeue for an error returned by checkRecoveryBudget. The requeue will be
+// delayed until the recovery budget allows the next action.
+func getRecoveryBudgetRequeue(err error) *requeue {
+	budgetErr := &recoverybudget.BudgetExhaustedError{}
+	if errors.As(err, budgetErr) {
+		return &requeue{curError: err, delayedRequeue: true, delay: budgetErr.GetWaitTime()}
+	}
+
+	return &requeue{curError: err, delayedRequeue: true}
+}
+
 // clusterSubReconciler describes a class that does part of the work of
 // reconciliation for a cluster.
 type clusterSubReconciler interface {
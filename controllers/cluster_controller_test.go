@@ -4101,6 +4101,58 @@ var _ = Describe("cluster_controller", func() {
 			})
 		})
 	})
+
+	Describe("recordRecovery", func() {
+		var err error
+
+		BeforeEach(func() {
+			Expect(setupClusterForTest(cluster)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			err = clusterReconciler.recordRecovery(
+				context.TODO(),
+				cluster,
+				fdbv1beta2.RecoveryActionBounce,
+			)
+		})
+
+		When("the recovery budget is disabled", func() {
+			It("should not update the cluster status", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Status.RecoveryBudget).To(BeNil())
+
+				storedCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(
+					k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), storedCluster),
+				).To(Succeed())
+				Expect(storedCluster.Status.RecoveryBudget).To(BeNil())
+			})
+		})
+
+		When("the recovery budget is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.RecoveryBudget = &fdbv1beta2.RecoveryBudgetOptions{
+					MaxRecoveries: pointer.Int(2),
+				}
+			})
+
+			It("should persist the recovery in the cluster status", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				storedCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(
+					k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), storedCluster),
+				).To(Succeed())
+				Expect(storedCluster.Status.RecoveryBudget).NotTo(BeNil())
+				Expect(storedCluster.Status.RecoveryBudget.Recoveries).To(HaveLen(1))
+				Expect(
+					storedCluster.Status.RecoveryBudget.Recoveries[0].Action,
+				).To(Equal(fdbv1beta2.RecoveryActionBounce))
+				Expect(storedCluster.Status.RecoveryBudget.RemainingRecoveries).To(Equal(1))
+			})
+		})
+	})
 })
 
 func getProcessClassMap(
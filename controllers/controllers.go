@@ -40,6 +40,10 @@ const (
 	// podSchedulingDelayDuration determines how long we should delay a requeue
 	// of reconciliation when a pod is not ready.
 	podSchedulingDelayDuration = 15 * time.Second
+
+	// fileSystemResizeGracePeriod determines how long the operator waits for an online file system resize before the
+	// Pod will be recreated to trigger the file system resize.
+	fileSystemResizeGracePeriod = 5 * time.Minute
 )
 
 // requeue provides a wrapper around different results from a subreconciler.
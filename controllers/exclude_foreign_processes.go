@@ -0,0 +1,149 @@
+/*
+ * exclude_foreign_processes.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/adoption"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// excludeForeignProcesses provides a reconciliation step for excluding the processes that are not managed by the
+// operator when an existing cluster is adopted.
+type excludeForeignProcesses struct{}
+
+// reconcile runs the reconciler's work.
+func (e excludeForeignProcesses) reconcile(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	logger logr.Logger,
+) *requeue {
+	if !cluster.ExcludeForeignProcesses() {
+		return nil
+	}
+
+	adminClient, err := r.getAdminClient(logger, cluster)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+	defer func() {
+		_ = adminClient.Close()
+	}()
+
+	// If the status is not cached, we have to fetch it.
+	if status == nil {
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	foreignProcesses := adoption.GetForeignProcesses(cluster, status)
+	addresses := make([]fdbv1beta2.ProcessAddress, 0, len(foreignProcesses))
+	for _, process := range foreignProcesses {
+		// The coordinators must be migrated before the foreign processes can be excluded.
+		if adoption.IsCoordinator(process) {
+			return &requeue{
+				message:        "waiting for the coordinators to be migrated to processes managed by the operator",
+				delayedRequeue: true,
+			}
+		}
+
+		if process.Excluded {
+			continue
+		}
+
+		addresses = append(addresses, adoption.GetExclusionAddress(process))
+	}
+
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	// Make sure that all process groups managed by the operator have their processes running, otherwise the
+	// exclusion could reduce the fault tolerance of the cluster.
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.MissingProcesses) != nil {
+			return &requeue{
+				message: fmt.Sprintf(
+					"waiting for process group %s to have its processes running before excluding foreign processes",
+					processGroup.ProcessGroupID,
+				),
+				delayedRequeue: true,
+			}
+		}
+	}
+
+	err = fdbstatus.CanSafelyExcludeProcessesWithRecoveryState(
+		cluster,
+		status,
+		r.MinimumRecoveryTimeForExclusion,
+	)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true, delay: 10 * time.Second}
+	}
+
+	err = r.takeLock(logger, cluster, "exclude foreign processes")
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	err = r.checkRecoveryBudget(logger, cluster, fdbv1beta2.RecoveryActionExclude)
+	if err != nil {
+		return getRecoveryBudgetRequeue(err)
+	}
+
+	logger.Info("Excluding foreign processes", "addresses", addresses)
+	r.Recorder.Event(
+		cluster,
+		corev1.EventTypeNormal,
+		"ExcludingForeignProcesses",
+		fmt.Sprintf("Excluding foreign processes %v", addresses),
+	)
+
+	// We use the no_wait exclusion here to trigger the exclusion without waiting for the data movement to complete.
+	// The progress of the exclusion will be reported in the adoption status.
+	err = adminClient.ExcludeProcessesWithNoWait(addresses, true)
+	// Reset the SecondsSinceLastRecovered since the operator just excluded some processes, which will cause a recovery.
+	status.Cluster.RecoveryState.SecondsSinceLastRecovered = 0.0
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	err = r.recordRecovery(ctx, cluster, fdbv1beta2.RecoveryActionExclude)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * exclude_foreign_processes_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("exclude_foreign_processes", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+	var req *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).To(Succeed())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		adminClient.MockAdditionalProcesses([]fdbv1beta2.ProcessGroupStatus{
+			{
+				ProcessGroupID: "vm-storage-1",
+				ProcessClass:   fdbv1beta2.ProcessClassStorage,
+				Addresses:      []string{"10.3.10.3"},
+			},
+		})
+	})
+
+	JustBeforeEach(func() {
+		req = excludeForeignProcesses{}.reconcile(
+			context.TODO(),
+			clusterReconciler,
+			cluster,
+			nil,
+			globalControllerLogger,
+		)
+	})
+
+	When("the cluster is not adopting an existing cluster", func() {
+		It("should not exclude the foreign processes", func() {
+			Expect(req).To(BeNil())
+			Expect(adminClient.ExcludedAddresses).To(BeEmpty())
+		})
+	})
+
+	When("the cluster is adopting an existing cluster", func() {
+		BeforeEach(func() {
+			cluster.Spec.Adoption = &fdbv1beta2.AdoptionConfig{}
+		})
+
+		When("the foreign processes should not be excluded", func() {
+			It("should not exclude the foreign processes", func() {
+				Expect(req).To(BeNil())
+				Expect(adminClient.ExcludedAddresses).To(BeEmpty())
+			})
+		})
+
+		When("the foreign processes should be excluded", func() {
+			BeforeEach(func() {
+				cluster.Spec.Adoption.ExcludeForeignProcesses = pointer.Bool(true)
+			})
+
+			It("should exclude the foreign processes", func() {
+				Expect(req).To(BeNil())
+				Expect(adminClient.ExcludedAddresses).To(HaveLen(1))
+				Expect(adminClient.ExcludedAddresses).To(HaveKey(
+					cluster.GetFullAddress("10.3.10.3", 1).StringWithoutFlags(),
+				))
+			})
+
+			When("a process group is missing its processes", func() {
+				BeforeEach(func() {
+					cluster.Status.ProcessGroups[0].UpdateCondition(
+						fdbv1beta2.MissingProcesses,
+						true,
+					)
+				})
+
+				It("should not exclude the foreign processes", func() {
+					Expect(req).NotTo(BeNil())
+					Expect(req.message).To(ContainSubstring("waiting for process group"))
+					Expect(adminClient.ExcludedAddresses).To(BeEmpty())
+				})
+			})
+		})
+	})
+})
@@ -155,6 +155,19 @@ func (e excludeProcesses) reconcile(
 	transactionSystemExclusionAllowed := true
 	allProcessesExcluded := true
 	desiredProcessesMap := desiredProcesses.Map()
+
+	// If the exclusions should be throttled based on the data movement, we estimate the data that must be moved for
+	// every process group and the data that is still stored on the currently excluded processes.
+	throttleDataMovement := cluster.GetMaxExclusionInFlightBytes() > 0 ||
+		cluster.GetMaxMovingDataBytesForExclusion() > 0
+	var storedBytes map[fdbv1beta2.ProcessGroupID]int64
+	var inFlightBytes int64
+	var throttledByDataMovement bool
+	if throttleDataMovement {
+		storedBytes = fdbstatus.GetStoredBytesPerProcessGroup(status)
+		inFlightBytes = fdbstatus.GetStoredBytesOfExcludedProcesses(status)
+	}
+
 	for processClass := range fdbProcessesToExcludeByClass {
 		contextLogger := logger.WithValues("processClass", processClass)
 		ongoingExclusions := ongoingExclusionsByClass[processClass]
@@ -207,6 +220,24 @@ func (e excludeProcesses) reconcile(
 			allowedExclusions = len(processesToExclude)
 		}
 
+		if throttleDataMovement {
+			var dataMovementExclusions int
+			dataMovementExclusions, inFlightBytes = getAllowedExclusionsForDataMovement(
+				contextLogger,
+				cluster,
+				status,
+				processesToExclude[:allowedExclusions],
+				storedBytes,
+				inFlightBytes,
+			)
+
+			if dataMovementExclusions < allowedExclusions {
+				allProcessesExcluded = false
+				throttledByDataMovement = true
+				allowedExclusions = dataMovementExclusions
+			}
+		}
+
 		// Add as many processes as allowed to the exclusion list. The allowedExclusions reflects the count of processes
 		// that can be excluded, that could also be multiple addresses.
 		var exclusionIdx int
@@ -220,6 +251,14 @@ func (e excludeProcesses) reconcile(
 		}
 	}
 
+	if len(fdbProcessesToExclude) == 0 && throttledByDataMovement {
+		return &requeue{
+			message:        "more exclusions needed but not allowed, have to wait for the data movement to finish",
+			delay:          1 * time.Minute,
+			delayedRequeue: true,
+		}
+	}
+
 	if len(fdbProcessesToExclude) == 0 {
 		return &requeue{
 			message:        "more exclusions needed but not allowed, have to wait for new processes to come up",
@@ -294,6 +333,11 @@ func (e excludeProcesses) reconcile(
 		}
 	}
 
+	err = r.checkRecoveryBudget(logger, cluster, fdbv1beta2.RecoveryActionExclude)
+	if err != nil {
+		return getRecoveryBudgetRequeue(err)
+	}
+
 	r.Recorder.Event(
 		cluster,
 		corev1.EventTypeNormal,
@@ -312,6 +356,11 @@ func (e excludeProcesses) reconcile(
 		return &requeue{curError: err, delayedRequeue: true}
 	}
 
+	err = r.recordRecovery(ctx, cluster, fdbv1beta2.RecoveryActionExclude)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
 	var coordinatorExcluded bool
 	for _, excludeProcess := range fdbProcessesToExclude {
 		excludeString := excludeProcess.String()
@@ -332,12 +381,23 @@ func (e excludeProcesses) reconcile(
 	if coordinatorExcluded {
 		// If a coordinator should be excluded, we will change the coordinators directly after the exclusion.
 		// This should reduce the observed recoveries, see: https://github.com/FoundationDB/fdb-kubernetes-operator/v2/issues/2018.
+		// The coordinator change causes an additional recovery, if the recovery budget doesn't allow it, the
+		// changeCoordinators sub-reconciler will change the coordinators once the recovery budget allows it.
+		err = r.checkRecoveryBudget(logger, cluster, fdbv1beta2.RecoveryActionChangeCoordinators)
+		if err != nil {
+			return getRecoveryBudgetRequeue(err)
+		}
+
 		coordinatorErr := coordinator.ChangeCoordinators(logger, adminClient, cluster, status)
 		if coordinatorErr != nil {
 			return &requeue{curError: coordinatorErr, delayedRequeue: true}
 		}
 
-		err = r.updateOrApply(ctx, cluster)
+		err = r.recordRecoveryAndUpdate(
+			ctx,
+			cluster,
+			fdbv1beta2.RecoveryActionChangeCoordinators,
+		)
 		if err != nil {
 			return &requeue{curError: err, delayedRequeue: true}
 		}
@@ -466,6 +526,65 @@ func getProcessesToExclude(
 	return fdbProcessesToExcludeByClass, ongoingExclusionsByClass
 }
 
+// getAllowedExclusionsForDataMovement returns how many of the provided entries can be excluded without exceeding the
+// data movement limits of the cluster and the updated estimation of the bytes that must be moved by the exclusions.
+// The data that must be moved for a process group is estimated based on the stored bytes of its storage roles,
+// entries without stored bytes are not throttled. If no data must be moved by the current exclusions, at least one
+// process group can be excluded, otherwise a process group that stores more data than the limit would never be
+// excluded.
+func getAllowedExclusionsForDataMovement(
+	logger logr.Logger,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	entries []excludeEntry,
+	storedBytes map[fdbv1beta2.ProcessGroupID]int64,
+	inFlightBytes int64,
+) (int, int64) {
+	maxInFlightBytes := cluster.GetMaxExclusionInFlightBytes()
+	maxMovingDataBytes := cluster.GetMaxMovingDataBytesForExclusion()
+	movingDataBytes := int64(
+		status.Cluster.Data.MovingData.InFlightBytes + status.Cluster.Data.MovingData.InQueueBytes,
+	)
+
+	for idx, entry := range entries {
+		entryBytes := storedBytes[entry.processGroupID]
+		if entryBytes <= 0 {
+			continue
+		}
+
+		if maxMovingDataBytes > 0 && movingDataBytes > maxMovingDataBytes {
+			logger.Info(
+				"Deferring exclusions because of the ongoing data movement",
+				"movingData",
+				fdbstatus.PrettyPrintBytes(movingDataBytes),
+				"maxMovingData",
+				fdbstatus.PrettyPrintBytes(maxMovingDataBytes),
+			)
+			return idx, inFlightBytes
+		}
+
+		if maxInFlightBytes > 0 && inFlightBytes > 0 &&
+			inFlightBytes+entryBytes > maxInFlightBytes {
+			logger.Info(
+				"Deferring exclusions because the data that must be moved exceeds the limit",
+				"processGroupID",
+				entry.processGroupID,
+				"storedBytes",
+				fdbstatus.PrettyPrintBytes(entryBytes),
+				"inFlightBytes",
+				fdbstatus.PrettyPrintBytes(inFlightBytes),
+				"maxInFlightBytes",
+				fdbstatus.PrettyPrintBytes(maxInFlightBytes),
+			)
+			return idx, inFlightBytes
+		}
+
+		inFlightBytes += entryBytes
+	}
+
+	return len(entries), inFlightBytes
+}
+
 // getAllowedExclusionsAndMissingProcesses will check if new processes for the specified process class can be excluded. The calculation takes
 // the current ongoing exclusions into account and the desired process count. If there are process groups that have
 // the MissingProcesses condition this method will forbid exclusions until all process groups with this condition have
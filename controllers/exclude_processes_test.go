@@ -32,6 +32,7 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient/mock"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/pointer"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
@@ -490,6 +491,121 @@ var _ = Describe("exclude_processes", func() {
 		})
 	})
 
+	When("validating the data movement throttling of exclusions", func() {
+		var status *fdbv1beta2.FoundationDBStatus
+		var entries []excludeEntry
+		var storedBytes map[fdbv1beta2.ProcessGroupID]int64
+		var currentInFlightBytes, newInFlightBytes int64
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			status = &fdbv1beta2.FoundationDBStatus{}
+			entries = []excludeEntry{
+				{processGroupID: "storage-1"},
+				{processGroupID: "stateless-1"},
+				{processGroupID: "storage-2"},
+			}
+			storedBytes = map[fdbv1beta2.ProcessGroupID]int64{
+				"storage-1": 100,
+				"storage-2": 100,
+			}
+			currentInFlightBytes = 0
+		})
+
+		JustBeforeEach(func() {
+			allowedExclusions, newInFlightBytes = getAllowedExclusionsForDataMovement(
+				globalControllerLogger,
+				cluster,
+				status,
+				entries,
+				storedBytes,
+				currentInFlightBytes,
+			)
+		})
+
+		When("no limits are defined", func() {
+			It("should allow all exclusions", func() {
+				Expect(allowedExclusions).To(Equal(3))
+				Expect(newInFlightBytes).To(BeNumerically("==", 200))
+			})
+		})
+
+		When("the in-flight bytes are limited", func() {
+			BeforeEach(func() {
+				quantity := resource.MustParse("150")
+				cluster.Spec.AutomationOptions.ExclusionThrottling = &fdbv1beta2.ExclusionThrottlingOptions{
+					MaxInFlightBytes: &quantity,
+				}
+			})
+
+			It("should only allow the exclusions below the limit", func() {
+				Expect(allowedExclusions).To(Equal(2))
+				Expect(newInFlightBytes).To(BeNumerically("==", 100))
+			})
+
+			When("the excluded processes still store data", func() {
+				BeforeEach(func() {
+					currentInFlightBytes = 100
+				})
+
+				It("should not allow exclusions of process groups with data", func() {
+					Expect(allowedExclusions).To(BeZero())
+					Expect(newInFlightBytes).To(BeNumerically("==", 100))
+				})
+			})
+
+			When("a single process group stores more data than the limit", func() {
+				BeforeEach(func() {
+					storedBytes["storage-1"] = 1000
+				})
+
+				It("should allow the exclusion of the first process group", func() {
+					Expect(allowedExclusions).To(Equal(2))
+					Expect(newInFlightBytes).To(BeNumerically("==", 1000))
+				})
+			})
+		})
+
+		When("the moving data is limited", func() {
+			BeforeEach(func() {
+				quantity := resource.MustParse("1Gi")
+				cluster.Spec.AutomationOptions.ExclusionThrottling = &fdbv1beta2.ExclusionThrottlingOptions{
+					MaxMovingDataBytes: &quantity,
+				}
+			})
+
+			When("the moving data is below the limit", func() {
+				It("should allow all exclusions", func() {
+					Expect(allowedExclusions).To(Equal(3))
+				})
+			})
+
+			When("the moving data is above the limit", func() {
+				BeforeEach(func() {
+					status.Cluster.Data.MovingData.InFlightBytes = 512 * 1024 * 1024
+					status.Cluster.Data.MovingData.InQueueBytes = 1024 * 1024 * 1024
+				})
+
+				It("should not allow exclusions of process groups with data", func() {
+					Expect(allowedExclusions).To(BeZero())
+				})
+
+				When("the first process group doesn't store data", func() {
+					BeforeEach(func() {
+						entries = []excludeEntry{
+							{processGroupID: "stateless-1"},
+							{processGroupID: "storage-1"},
+						}
+					})
+
+					It("should allow the exclusion of the process group without data", func() {
+						Expect(allowedExclusions).To(Equal(1))
+					})
+				})
+			})
+		})
+	})
+
 	// TODO (johscheuer) add test cases for global synchronization mode --> map[fdbv1beta2.ProcessGroupID]time.Time{}, make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.UpdateAction
 	When("validating getProcessesToExclude", func() {
 		var exclusions []fdbv1beta2.ProcessAddress
@@ -1735,6 +1851,68 @@ var _ = Describe("exclude_processes", func() {
 					Expect(initialConnectionString).NotTo(Equal(cluster.Status.ConnectionString))
 				})
 
+				When("the recovery budget allows the exclusion and the coordinator change", func() {
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.RecoveryBudget = &fdbv1beta2.RecoveryBudgetOptions{
+							MaxRecoveries: pointer.Int(2),
+						}
+						// Persist the spec, otherwise the spec will be reset when the status is updated. The status
+						// contains the process group that is marked for removal and must be kept.
+						status := cluster.Status.DeepCopy()
+						Expect(k8sClient.Update(context.TODO(), cluster)).To(Succeed())
+						cluster.Status = *status
+					})
+
+					It("should record the exclusion and the coordinator change", func() {
+						Expect(req).To(BeNil())
+
+						_, err := reloadCluster(cluster)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(
+							initialConnectionString,
+						).NotTo(Equal(cluster.Status.ConnectionString))
+						Expect(cluster.Status.RecoveryBudget).NotTo(BeNil())
+						Expect(cluster.Status.RecoveryBudget.Recoveries).To(HaveLen(2))
+						Expect(
+							cluster.Status.RecoveryBudget.Recoveries[0].Action,
+						).To(Equal(fdbv1beta2.RecoveryActionExclude))
+						Expect(
+							cluster.Status.RecoveryBudget.Recoveries[1].Action,
+						).To(Equal(fdbv1beta2.RecoveryActionChangeCoordinators))
+					})
+				})
+
+				When("the recovery budget only allows the exclusion", func() {
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.RecoveryBudget = &fdbv1beta2.RecoveryBudgetOptions{
+							MaxRecoveries: pointer.Int(1),
+						}
+						// Persist the spec, otherwise the spec will be reset when the status is updated. The status
+						// contains the process group that is marked for removal and must be kept.
+						status := cluster.Status.DeepCopy()
+						Expect(k8sClient.Update(context.TODO(), cluster)).To(Succeed())
+						cluster.Status = *status
+					})
+
+					It("should exclude the process and delay the coordinator change", func() {
+						adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(req).NotTo(BeNil())
+						Expect(req.delayedRequeue).To(BeTrue())
+						Expect(adminClient.ExcludedAddresses).To(HaveLen(1))
+
+						_, err = reloadCluster(cluster)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(initialConnectionString).To(Equal(cluster.Status.ConnectionString))
+						Expect(cluster.Status.RecoveryBudget).NotTo(BeNil())
+						Expect(cluster.Status.RecoveryBudget.Recoveries).To(HaveLen(1))
+						Expect(
+							cluster.Status.RecoveryBudget.Recoveries[0].Action,
+						).To(Equal(fdbv1beta2.RecoveryActionExclude))
+					})
+				})
+
 				When("using localities", func() {
 					BeforeEach(func() {
 						cluster.Spec.AutomationOptions.UseLocalitiesForExclusion = pointer.Bool(
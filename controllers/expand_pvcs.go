@@ -0,0 +1,154 @@
+/*
+ * expand_pvcs.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/volumes"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// expandPVCs provides a reconciliation step for expanding existing PVCs in place if only the storage request was
+// increased. The PVCs are expanded one fault domain at a time.
+type expandPVCs struct{}
+
+// reconcile runs the reconciler's work.
+func (e expandPVCs) reconcile(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	_ *fdbv1beta2.FoundationDBStatus,
+	logger logr.Logger,
+) *requeue {
+	if !cluster.UseVolumeExpansion() {
+		return nil
+	}
+
+	expansions := map[fdbv1beta2.FaultDomain][]*corev1.PersistentVolumeClaim{}
+	var resizing int
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !processGroup.ProcessClass.IsStateful() || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		desiredPVC, err := internal.GetPvc(cluster, processGroup)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		currentPVC := &corev1.PersistentVolumeClaim{}
+		err = r.Get(ctx, client.ObjectKeyFromObject(desiredPVC), currentPVC)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		if volumes.IsResizing(currentPVC) {
+			resizing++
+			continue
+		}
+
+		canBeExpanded, err := volumes.CanBeExpanded(
+			ctx,
+			r.getAPIReader(),
+			cluster,
+			currentPVC,
+			desiredPVC,
+		)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		if !canBeExpanded {
+			continue
+		}
+
+		volumes.Expand(currentPVC, desiredPVC)
+		expansions[processGroup.FaultDomain] = append(
+			expansions[processGroup.FaultDomain],
+			currentPVC,
+		)
+	}
+
+	if len(expansions) == 0 {
+		return nil
+	}
+
+	// Only expand the PVCs of the next fault domain once all previous resize operations are done.
+	if resizing > 0 {
+		logger.Info("Waiting for PVCs to be resized", "resizing", resizing)
+		return &requeue{
+			message:        fmt.Sprintf("waiting for %d PVCs to be resized", resizing),
+			delay:          podSchedulingDelayDuration,
+			delayedRequeue: true,
+		}
+	}
+
+	faultDomains := make([]fdbv1beta2.FaultDomain, 0, len(expansions))
+	for faultDomain := range expansions {
+		faultDomains = append(faultDomains, faultDomain)
+	}
+	slices.Sort(faultDomains)
+
+	faultDomain := faultDomains[0]
+	pvcs := expansions[faultDomain]
+	logger.Info("Expanding PVCs", "faultDomain", faultDomain, "count", len(pvcs))
+	r.Recorder.Event(
+		cluster,
+		corev1.EventTypeNormal,
+		"ExpandingPVCs",
+		fmt.Sprintf("Expanding %d PVCs in fault domain %s", len(pvcs), faultDomain),
+	)
+
+	for _, pvc := range pvcs {
+		logger.V(1).Info("Expanding PVC", "name", pvc.Name)
+		err := r.Update(ctx, pvc)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	if len(faultDomains) > 1 {
+		return &requeue{
+			message: fmt.Sprintf(
+				"waiting for PVCs to be resized before expanding the PVCs in %d other fault domains",
+				len(faultDomains)-1,
+			),
+			delay:          podSchedulingDelayDuration,
+			delayedRequeue: true,
+		}
+	}
+
+	return nil
+}
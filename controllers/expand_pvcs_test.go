@@ -0,0 +1,219 @@
+/*
+ * expand_pvcs_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// forbiddenReader is a client.Reader that is not allowed to read any resources.
+type forbiddenReader struct {
+	client.Reader
+}
+
+// Get returns a forbidden error for all resources.
+func (forbiddenReader) Get(
+	_ context.Context,
+	key client.ObjectKey,
+	_ client.Object,
+	_ ...client.GetOption,
+) error {
+	return k8serrors.NewForbidden(
+		storagev1.Resource("storageclasses"),
+		key.Name,
+		fmt.Errorf("not allowed"),
+	)
+}
+
+var _ = Describe("expand_pvcs", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var req *requeue
+	var allowVolumeExpansion *bool
+	var reconciler *FoundationDBClusterReconciler
+
+	// getExpandedPVCs returns the number of PVCs with the increased storage request.
+	getExpandedPVCs := func() int {
+		pvcs := &corev1.PersistentVolumeClaimList{}
+		Expect(k8sClient.List(context.TODO(), pvcs)).To(Succeed())
+
+		var expanded int
+		for _, pvc := range pvcs.Items {
+			if pvc.Spec.Resources.Requests.Storage().Equal(resource.MustParse("256G")) {
+				expanded++
+			}
+		}
+
+		return expanded
+	}
+
+	BeforeEach(func() {
+		reconciler = clusterReconciler
+		allowVolumeExpansion = pointer.Bool(true)
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.AutomationOptions.UseVolumeExpansion = pointer.Bool(true)
+		processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+		processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.String("expandable"),
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("128G"),
+					},
+				},
+			},
+		}
+		cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(context.TODO(), &storagev1.StorageClass{
+			ObjectMeta:           metav1.ObjectMeta{Name: "expandable"},
+			AllowVolumeExpansion: allowVolumeExpansion,
+		})).To(Succeed())
+
+		cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].VolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse(
+			"256G",
+		)
+
+		req = expandPVCs{}.reconcile(
+			context.TODO(),
+			reconciler,
+			cluster,
+			nil,
+			globalControllerLogger,
+		)
+	})
+
+	When("the storage class allows volume expansion", func() {
+		It("should expand the PVCs of a single fault domain", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.delayedRequeue).To(BeTrue())
+			Expect(getExpandedPVCs()).To(Equal(1))
+		})
+
+		It("should not mark the process groups for removal", func() {
+			Expect(replaceMisconfiguredProcessGroups{}.reconcile(
+				context.TODO(),
+				clusterReconciler,
+				cluster,
+				nil,
+				globalControllerLogger,
+			)).To(BeNil())
+
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+			}
+		})
+
+		When("the expansion is repeated", func() {
+			It("should expand all PVCs", func() {
+				statefulProcessGroups := 0
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					if processGroup.ProcessClass.IsStateful() {
+						statefulProcessGroups++
+					}
+				}
+
+				Eventually(func() int {
+					expandPVCs{}.reconcile(
+						context.TODO(),
+						clusterReconciler,
+						cluster,
+						nil,
+						globalControllerLogger,
+					)
+
+					return getExpandedPVCs()
+				}).Should(Equal(statefulProcessGroups))
+			})
+		})
+
+		When("a PVC is currently resizing", func() {
+			BeforeEach(func() {
+				pvcs := &corev1.PersistentVolumeClaimList{}
+				Expect(k8sClient.List(context.TODO(), pvcs)).To(Succeed())
+				pvc := pvcs.Items[0]
+				pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+					{
+						Type:   corev1.PersistentVolumeClaimFileSystemResizePending,
+						Status: corev1.ConditionTrue,
+					},
+				}
+				Expect(k8sClient.Status().Update(context.TODO(), &pvc)).To(Succeed())
+			})
+
+			It("should not expand any PVCs", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(getExpandedPVCs()).To(BeZero())
+			})
+		})
+
+		When("volume expansion is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.UseVolumeExpansion = pointer.Bool(false)
+			})
+
+			It("should not expand any PVCs", func() {
+				Expect(req).To(BeNil())
+				Expect(getExpandedPVCs()).To(BeZero())
+			})
+		})
+	})
+
+	When("the operator is not allowed to read the storage class", func() {
+		BeforeEach(func() {
+			reconciler = createTestClusterReconciler()
+			reconciler.APIReader = forbiddenReader{}
+		})
+
+		It("should not expand any PVCs", func() {
+			Expect(req).To(BeNil())
+			Expect(getExpandedPVCs()).To(BeZero())
+		})
+	})
+
+	When("the storage class doesn't allow volume expansion", func() {
+		BeforeEach(func() {
+			allowVolumeExpansion = pointer.Bool(false)
+		})
+
+		It("should not expand any PVCs", func() {
+			Expect(req).To(BeNil())
+			Expect(getExpandedPVCs()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,116 @@
+/*
+ * insight_auth.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// NewInsightAuthenticationFilter returns a filter that authenticates the bearer token of every request with a
+// TokenReview and authorizes the requested path with a SubjectAccessReview. This follows the authentication and
+// authorization of the controller-runtime metrics server, but uses the provided client instead of the delegating
+// authenticator and authorizer from k8s.io/apiserver. The reviews are not cached, as the insight API is meant for
+// debugging and is not expected to receive many requests.
+func NewInsightAuthenticationFilter(kubeClient client.Client) metricsserver.Filter {
+	return func(log logr.Logger, handler http.Handler) (http.Handler, error) {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			token, ok := getBearerToken(req)
+			if !ok {
+				log.V(1).Info("Request without bearer token")
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			tokenReview := &authenticationv1.TokenReview{
+				Spec: authenticationv1.TokenReviewSpec{
+					Token: token,
+				},
+			}
+			err := kubeClient.Create(req.Context(), tokenReview)
+			if err != nil {
+				log.Error(err, "Authentication failed")
+				http.Error(w, "Authentication failed", http.StatusInternalServerError)
+				return
+			}
+
+			if !tokenReview.Status.Authenticated {
+				log.V(1).Info("Authentication failed", "error", tokenReview.Status.Error)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			user := tokenReview.Status.User
+			extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+			for key, value := range user.Extra {
+				extra[key] = authorizationv1.ExtraValue(value)
+			}
+
+			accessReview := &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+						Path: req.URL.Path,
+						Verb: strings.ToLower(req.Method),
+					},
+					User:   user.Username,
+					Groups: user.Groups,
+					UID:    user.UID,
+					Extra:  extra,
+				},
+			}
+			err = kubeClient.Create(req.Context(), accessReview)
+			if err != nil {
+				msg := fmt.Sprintf("Authorization for user %s failed", user.Username)
+				log.Error(err, msg)
+				http.Error(w, msg, http.StatusInternalServerError)
+				return
+			}
+
+			if !accessReview.Status.Allowed || accessReview.Status.Denied {
+				msg := fmt.Sprintf("Authorization denied for user %s", user.Username)
+				log.V(1).Info(msg, "reason", accessReview.Status.Reason)
+				http.Error(w, msg, http.StatusForbidden)
+				return
+			}
+
+			handler.ServeHTTP(w, req)
+		}), nil
+	}
+}
+
+// getBearerToken returns the bearer token from the Authorization header of the request.
+func getBearerToken(req *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
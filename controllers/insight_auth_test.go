@@ -0,0 +1,171 @@
+/*
+ * insight_auth_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("insight_auth", func() {
+	// This test requires a real API server to verify that the shipped ClusterRole contains all the permissions that
+	// are required to authenticate and authorize the requests to the insight API.
+	When("the operator only has the permissions of the shipped ClusterRole", func() {
+		var testEnv *envtest.Environment
+		var clientset *kubernetes.Clientset
+		var handler http.Handler
+
+		createServiceAccount := func(adminClient client.Client, name string) {
+			Expect(adminClient.Create(context.Background(), &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+					Name:      name,
+				},
+			})).To(Succeed())
+		}
+
+		bindClusterRole := func(adminClient client.Client, clusterRole string, serviceAccount string) {
+			Expect(adminClient.Create(context.Background(), &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: serviceAccount,
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "ClusterRole",
+					Name:     clusterRole,
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						Kind:      rbacv1.ServiceAccountKind,
+						Namespace: metav1.NamespaceDefault,
+						Name:      serviceAccount,
+					},
+				},
+			})).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+				Skip("the envtest binaries are not available, set KUBEBUILDER_ASSETS to run this test")
+			}
+
+			testEnv = &envtest.Environment{}
+			config, err := testEnv.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			adminClient, err := client.New(config, client.Options{Scheme: scheme.Scheme})
+			Expect(err).NotTo(HaveOccurred())
+			clientset, err = kubernetes.NewForConfig(config)
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := os.ReadFile(filepath.Join("..", "config", "rbac", "cluster_role.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+			operatorRole := &rbacv1.ClusterRole{}
+			Expect(yaml.Unmarshal(content, operatorRole)).To(Succeed())
+			Expect(adminClient.Create(context.Background(), operatorRole)).To(Succeed())
+
+			createServiceAccount(adminClient, "operator")
+			bindClusterRole(adminClient, operatorRole.Name, "operator")
+
+			readerRole := &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "insight-reader",
+				},
+				Rules: []rbacv1.PolicyRule{
+					{
+						NonResourceURLs: []string{insightAPIPrefix + "/*"},
+						Verbs:           []string{"get"},
+					},
+				},
+			}
+			Expect(adminClient.Create(context.Background(), readerRole)).To(Succeed())
+			createServiceAccount(adminClient, "reader")
+			bindClusterRole(adminClient, readerRole.Name, "reader")
+
+			operatorConfig := rest.CopyConfig(config)
+			operatorConfig.Impersonate = rest.ImpersonationConfig{
+				UserName: "system:serviceaccount:default:operator",
+			}
+			operatorClient, err := client.New(
+				operatorConfig,
+				client.Options{Scheme: scheme.Scheme},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler, err = NewInsightAuthenticationFilter(operatorClient)(
+				globalControllerLogger,
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			if testEnv != nil {
+				Expect(testEnv.Stop()).To(Succeed())
+			}
+		})
+
+		getWithServiceAccountToken := func(serviceAccount string) int {
+			tokenRequest, err := clientset.CoreV1().
+				ServiceAccounts(metav1.NamespaceDefault).
+				CreateToken(
+					context.Background(),
+					serviceAccount,
+					&authenticationv1.TokenRequest{},
+					metav1.CreateOptions{},
+				)
+			Expect(err).NotTo(HaveOccurred())
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, insightAPIPrefix+"/clusters", nil)
+			request.Header.Set("Authorization", "Bearer "+tokenRequest.Status.Token)
+			handler.ServeHTTP(recorder, request)
+
+			return recorder.Code
+		}
+
+		It("allows the requests of authorized clients", func() {
+			Expect(getWithServiceAccountToken("reader")).To(Equal(http.StatusOK))
+		})
+
+		It("rejects the requests of clients without the permissions", func() {
+			Expect(getWithServiceAccountToken("operator")).To(Equal(http.StatusForbidden))
+		})
+	})
+})
@@ -0,0 +1,720 @@
+/*
+ * insight_server.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+const (
+	// insightAPIPrefix is the path prefix for all endpoints served by the InsightServer.
+	insightAPIPrefix = "/api/v1"
+	// insightAPICertName is the name of the certificate file in the certificate directory of the InsightServer.
+	insightAPICertName = "tls.crt"
+	// insightAPIKeyName is the name of the key file in the certificate directory of the InsightServer.
+	insightAPIKeyName = "tls.key"
+)
+
+// ClusterInsightSummary provides a short overview of a FoundationDBCluster.
+type ClusterInsightSummary struct {
+	// Namespace of the FoundationDBCluster.
+	Namespace string `json:"namespace"`
+
+	// Name of the FoundationDBCluster.
+	Name string `json:"name"`
+
+	// Generation is the current generation of the FoundationDBCluster.
+	Generation int64 `json:"generation"`
+
+	// Reconciled defines if the current generation is reconciled.
+	Reconciled bool `json:"reconciled"`
+
+	// Health is the health information reported in the FoundationDBCluster status.
+	Health fdbv1beta2.ClusterHealth `json:"health"`
+}
+
+// ClusterInsight provides the operator's view of a FoundationDBCluster.
+type ClusterInsight struct {
+	ClusterInsightSummary `json:",inline"`
+
+	// Generations provides the generation status of the FoundationDBCluster.
+	Generations fdbv1beta2.ClusterGenerationStatus `json:"generations"`
+
+	// ProcessCounts provides the computed process counts of the FoundationDBCluster.
+	ProcessCounts ProcessCountsInsight `json:"processCounts"`
+
+	// LastReconciliation provides information about the last reconciliation that was performed by this operator
+	// instance.
+	LastReconciliation *ReconciliationInsight `json:"lastReconciliation,omitempty"`
+
+	// StatusTimestamp is the time when the cached machine-readable status was fetched. If no status is cached
+	// this field will be empty.
+	StatusTimestamp *time.Time `json:"statusTimestamp,omitempty"`
+}
+
+// ProcessCountsInsight provides the process counts per process class.
+type ProcessCountsInsight struct {
+	// Desired is the number of process groups the operator wants to run, including the defaults.
+	Desired map[fdbv1beta2.ProcessClass]int `json:"desired"`
+
+	// Current is the number of process groups that are not marked for removal.
+	Current map[fdbv1beta2.ProcessClass]int `json:"current"`
+
+	// MarkedForRemoval is the number of process groups that are marked for removal.
+	MarkedForRemoval map[fdbv1beta2.ProcessClass]int `json:"markedForRemoval"`
+
+	// Excluded is the number of process groups that are marked as excluded.
+	Excluded map[fdbv1beta2.ProcessClass]int `json:"excluded"`
+}
+
+// ReconciliationInsight provides information about a reconciliation run.
+type ReconciliationInsight struct {
+	// Timestamp is the time when the reconciliation run finished.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Generation is the generation of the FoundationDBCluster that was reconciled.
+	Generation int64 `json:"generation"`
+
+	// Blockers contains all the sub-reconcilers that requeued the reconciliation.
+	Blockers []ReconciliationBlocker `json:"blockers,omitempty"`
+}
+
+// ReconciliationBlocker provides information about a sub-reconciler that requeued the reconciliation.
+type ReconciliationBlocker struct {
+	// Reconciler is the name of the sub-reconciler.
+	Reconciler string `json:"reconciler"`
+
+	// Message is the message of the requeue.
+	Message string `json:"message,omitempty"`
+
+	// Error is the error of the requeue.
+	Error string `json:"error,omitempty"`
+
+	// Delayed defines if the requeue was delayed and the other sub-reconcilers were still running.
+	Delayed bool `json:"delayed"`
+}
+
+// GlobalCoordinationInsight provides the pending and ready lists that are used for the global coordination.
+type GlobalCoordinationInsight struct {
+	// SynchronizationMode is the synchronization mode of the FoundationDBCluster.
+	SynchronizationMode fdbv1beta2.SynchronizationMode `json:"synchronizationMode"`
+
+	// PendingForRemoval contains the process groups that are marked for removal.
+	PendingForRemoval map[fdbv1beta2.ProcessGroupID]time.Time `json:"pendingForRemoval"`
+
+	// PendingForExclusion contains the process groups that should be excluded.
+	PendingForExclusion map[fdbv1beta2.ProcessGroupID]time.Time `json:"pendingForExclusion"`
+
+	// PendingForInclusion contains the process groups that should be included.
+	PendingForInclusion map[fdbv1beta2.ProcessGroupID]time.Time `json:"pendingForInclusion"`
+
+	// PendingForRestart contains the process groups that should be restarted.
+	PendingForRestart map[fdbv1beta2.ProcessGroupID]time.Time `json:"pendingForRestart"`
+
+	// ReadyForExclusion contains the process groups that are ready to be excluded.
+	ReadyForExclusion map[fdbv1beta2.ProcessGroupID]time.Time `json:"readyForExclusion"`
+
+	// ReadyForInclusion contains the process groups that are ready to be included.
+	ReadyForInclusion map[fdbv1beta2.ProcessGroupID]time.Time `json:"readyForInclusion"`
+
+	// ReadyForRestart contains the process groups that are ready to be restarted.
+	ReadyForRestart map[fdbv1beta2.ProcessGroupID]time.Time `json:"readyForRestart"`
+}
+
+// LockInsight provides information about the locking system of a FoundationDBCluster.
+type LockInsight struct {
+	// Disabled defines if locks are disabled for this FoundationDBCluster.
+	Disabled bool `json:"disabled"`
+
+	// Holder is the current lock holder, if no lock is held or the lock client cannot provide the lock holder this
+	// field will be empty.
+	Holder *fdbadminclient.LockHolder `json:"holder,omitempty"`
+
+	// DenyList contains the lock IDs that are not allowed to take a lock.
+	DenyList []string `json:"denyList,omitempty"`
+}
+
+// clusterInsightEntry contains the in-memory information for a single FoundationDBCluster.
+type clusterInsightEntry struct {
+	status             *fdbv1beta2.FoundationDBStatus
+	statusTimestamp    time.Time
+	lastReconciliation *ReconciliationInsight
+}
+
+// clusterInsightCache stores the information the reconciler gathered during the reconciliation of the
+// FoundationDBClusters. The zero value is ready to use.
+type clusterInsightCache struct {
+	lock    sync.RWMutex
+	entries map[types.NamespacedName]*clusterInsightEntry
+}
+
+// getEntry returns the entry for the provided cluster, a new entry will be created if no entry exists. The caller must
+// hold the write lock.
+func (cache *clusterInsightCache) getEntry(
+	cluster *fdbv1beta2.FoundationDBCluster,
+) *clusterInsightEntry {
+	if cache.entries == nil {
+		cache.entries = map[types.NamespacedName]*clusterInsightEntry{}
+	}
+
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	entry, ok := cache.entries[key]
+	if !ok {
+		entry = &clusterInsightEntry{}
+		cache.entries[key] = entry
+	}
+
+	return entry
+}
+
+// setStatus stores the machine-readable status for the provided cluster.
+func (cache *clusterInsightCache) setStatus(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+) {
+	if status == nil {
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	entry := cache.getEntry(cluster)
+	entry.status = status
+	entry.statusTimestamp = time.Now()
+}
+
+// setReconciliation stores the result of the last reconciliation run for the provided cluster.
+func (cache *clusterInsightCache) setReconciliation(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	blockers []ReconciliationBlocker,
+) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.getEntry(cluster).lastReconciliation = &ReconciliationInsight{
+		Timestamp:  time.Now(),
+		Generation: cluster.ObjectMeta.Generation,
+		Blockers:   blockers,
+	}
+}
+
+// get returns a copy of the entry for the provided cluster.
+func (cache *clusterInsightCache) get(key types.NamespacedName) (clusterInsightEntry, bool) {
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+
+	entry, ok := cache.entries[key]
+	if !ok {
+		return clusterInsightEntry{}, false
+	}
+
+	return *entry, true
+}
+
+// delete removes the entry for the provided cluster, e.g. once the cluster was deleted.
+func (cache *clusterInsightCache) delete(key types.NamespacedName) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	delete(cache.entries, key)
+}
+
+// newReconciliationBlocker creates a ReconciliationBlocker for the requeue of the provided sub-reconciler.
+func newReconciliationBlocker(
+	subReconciler clusterSubReconciler,
+	req *requeue,
+) ReconciliationBlocker {
+	blocker := ReconciliationBlocker{
+		Reconciler: fmt.Sprintf("%T", subReconciler),
+		Message:    req.message,
+		Delayed:    req.delayedRequeue,
+	}
+
+	if req.curError != nil {
+		blocker.Error = req.curError.Error()
+	}
+
+	return blocker
+}
+
+// InsightServer provides a read-only HTTP API that exposes the operator's view of the FoundationDBClusters it manages.
+type InsightServer struct {
+	// reconciler is the FoundationDBClusterReconciler that provides the information.
+	reconciler *FoundationDBClusterReconciler
+	// bindAddress is the address the server binds to.
+	bindAddress string
+	// certDir is the directory that contains the tls.crt and tls.key files used to serve the API over TLS. If empty,
+	// the API will be served without TLS.
+	certDir string
+	// filter is an optional filter that will be applied to all requests, e.g. for authentication and authorization.
+	filter metricsserver.Filter
+	// log is the logger for the server.
+	log logr.Logger
+}
+
+// NewInsightServer creates a new InsightServer. If a filter is provided, the filter will be wrapped around all
+// handlers. If a certDir is provided, the API will be served over TLS with the tls.crt and tls.key files from this
+// directory, changes to those files will be picked up without a restart.
+func NewInsightServer(
+	reconciler *FoundationDBClusterReconciler,
+	bindAddress string,
+	certDir string,
+	filter metricsserver.Filter,
+	log logr.Logger,
+) *InsightServer {
+	return &InsightServer{
+		reconciler:  reconciler,
+		bindAddress: bindAddress,
+		certDir:     certDir,
+		filter:      filter,
+		log:         log,
+	}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface. The in-memory information is only gathered
+// by the leader, so the server only runs on the leader.
+func (server *InsightServer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements the Runnable interface and serves the API until the context is cancelled.
+func (server *InsightServer) Start(ctx context.Context) error {
+	handler, err := server.Handler()
+	if err != nil {
+		return err
+	}
+
+	listener, err := server.listen(ctx)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			server.log.Error(err, "error shutting down the insight server")
+		}
+	}()
+
+	server.log.Info(
+		"Starting insight server",
+		"bindAddress",
+		listener.Addr().String(),
+		"tls",
+		server.certDir != "",
+	)
+	err = httpServer.Serve(listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// listen creates the listener for the configured bind address. If a certificate directory is configured, the listener
+// will only accept TLS connections.
+func (server *InsightServer) listen(ctx context.Context) (net.Listener, error) {
+	if server.certDir == "" {
+		return net.Listen("tcp", server.bindAddress)
+	}
+
+	certWatcher, err := certwatcher.New(
+		filepath.Join(server.certDir, insightAPICertName),
+		filepath.Join(server.certDir, insightAPIKeyName),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		if err := certWatcher.Start(ctx); err != nil {
+			server.log.Error(err, "error watching the insight server certificate")
+		}
+	}()
+
+	listener, err := net.Listen("tcp", server.bindAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return tls.NewListener(listener, &tls.Config{
+		GetCertificate: certWatcher.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}), nil
+}
+
+// Handler returns the http.Handler for the API, including the configured filter.
+func (server *InsightServer) Handler() (http.Handler, error) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+insightAPIPrefix+"/clusters", server.listClusters)
+	mux.HandleFunc(
+		"GET "+insightAPIPrefix+"/namespaces/{namespace}/clusters/{name}",
+		server.getClusterInsight,
+	)
+	mux.HandleFunc(
+		"GET "+insightAPIPrefix+"/namespaces/{namespace}/clusters/{name}/status",
+		server.getCachedStatus,
+	)
+	mux.HandleFunc(
+		"GET "+insightAPIPrefix+"/namespaces/{namespace}/clusters/{name}/coordination",
+		server.getGlobalCoordination,
+	)
+	mux.HandleFunc(
+		"GET "+insightAPIPrefix+"/namespaces/{namespace}/clusters/{name}/lock",
+		server.getLock,
+	)
+
+	if server.filter == nil {
+		return mux, nil
+	}
+
+	return server.filter(server.log, mux)
+}
+
+// listClusters returns a summary for all FoundationDBClusters that are managed by the operator.
+func (server *InsightServer) listClusters(w http.ResponseWriter, r *http.Request) {
+	clusters := &fdbv1beta2.FoundationDBClusterList{}
+	var options []client.ListOption
+	if server.reconciler.Namespace != "" {
+		options = append(options, client.InNamespace(server.reconciler.Namespace))
+	}
+
+	err := server.reconciler.List(r.Context(), clusters, options...)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	summaries := make([]ClusterInsightSummary, 0, len(clusters.Items))
+	for idx := range clusters.Items {
+		summaries = append(summaries, newClusterInsightSummary(&clusters.Items[idx]))
+	}
+
+	server.writeJSON(w, summaries)
+}
+
+// getClusterInsight returns the ClusterInsight for the requested cluster.
+func (server *InsightServer) getClusterInsight(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := server.getCluster(w, r)
+	if !ok {
+		return
+	}
+
+	insight, err := server.reconciler.getClusterInsight(cluster)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.writeJSON(w, insight)
+}
+
+// getCachedStatus returns the last machine-readable status that was fetched during the reconciliation.
+func (server *InsightServer) getCachedStatus(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := server.getCluster(w, r)
+	if !ok {
+		return
+	}
+
+	entry, ok := server.reconciler.clusterInsights.get(client.ObjectKeyFromObject(cluster))
+	if !ok || entry.status == nil {
+		server.writeError(
+			w,
+			http.StatusNotFound,
+			fmt.Errorf("no cached status for cluster %s/%s", cluster.Namespace, cluster.Name),
+		)
+		return
+	}
+
+	server.writeJSON(w, entry.status)
+}
+
+// getGlobalCoordination returns the current state of the global coordination lists.
+func (server *InsightServer) getGlobalCoordination(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := server.getCluster(w, r)
+	if !ok {
+		return
+	}
+
+	logger := server.log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name)
+	adminClient, err := server.reconciler.getAdminClient(logger, cluster)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer func() {
+		_ = adminClient.Close()
+	}()
+
+	insight, err := getGlobalCoordinationInsight(cluster, adminClient)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.writeJSON(w, insight)
+}
+
+// getLock returns the information about the current lock holder.
+func (server *InsightServer) getLock(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := server.getCluster(w, r)
+	if !ok {
+		return
+	}
+
+	insight := LockInsight{
+		Disabled: !cluster.ShouldUseLocks(),
+	}
+
+	if insight.Disabled {
+		server.writeJSON(w, insight)
+		return
+	}
+
+	logger := server.log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name)
+	lockClient, err := server.reconciler.getLockClient(logger, cluster)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	insight.Holder, err = getLockHolder(lockClient)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	insight.DenyList, err = lockClient.GetDenyList()
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	server.writeJSON(w, insight)
+}
+
+// getLockHolder returns the current lock holder if the lock client implements the LockHolderReader interface,
+// otherwise nil will be returned.
+func getLockHolder(lockClient fdbadminclient.LockClient) (*fdbadminclient.LockHolder, error) {
+	lockHolderReader, ok := lockClient.(fdbadminclient.LockHolderReader)
+	if !ok {
+		return nil, nil
+	}
+
+	return lockHolderReader.GetLockHolder()
+}
+
+// getCluster fetches the requested cluster and normalizes the spec. If the cluster cannot be fetched, an error will be
+// written to the response and false will be returned.
+func (server *InsightServer) getCluster(
+	w http.ResponseWriter,
+	r *http.Request,
+) (*fdbv1beta2.FoundationDBCluster, bool) {
+	key := types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if server.reconciler.Namespace != "" && server.reconciler.Namespace != key.Namespace {
+		server.writeError(
+			w,
+			http.StatusNotFound,
+			fmt.Errorf("namespace %s is not watched by the operator", key.Namespace),
+		)
+		return nil, false
+	}
+
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err := server.reconciler.Get(r.Context(), key, cluster)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			server.writeError(w, http.StatusNotFound, err)
+			return nil, false
+		}
+
+		server.writeError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	err = internal.NormalizeClusterSpec(cluster, server.reconciler.DeprecationOptions)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	return cluster, true
+}
+
+// writeJSON writes the provided value as JSON to the response.
+func (server *InsightServer) writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		server.log.Error(err, "could not write response")
+	}
+}
+
+// writeError writes the provided error as JSON to the response.
+func (server *InsightServer) writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	encodeErr := json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+	if encodeErr != nil {
+		server.log.Error(encodeErr, "could not write error response")
+	}
+}
+
+// newClusterInsightSummary creates the ClusterInsightSummary for the provided cluster.
+func newClusterInsightSummary(cluster *fdbv1beta2.FoundationDBCluster) ClusterInsightSummary {
+	return ClusterInsightSummary{
+		Namespace:  cluster.Namespace,
+		Name:       cluster.Name,
+		Generation: cluster.ObjectMeta.Generation,
+		Reconciled: cluster.ObjectMeta.Generation == cluster.Status.Generations.Reconciled,
+		Health:     cluster.Status.Health,
+	}
+}
+
+// getClusterInsight creates the ClusterInsight for the provided cluster based on the cluster resource and the
+// information that was gathered during the reconciliation.
+func (r *FoundationDBClusterReconciler) getClusterInsight(
+	cluster *fdbv1beta2.FoundationDBCluster,
+) (*ClusterInsight, error) {
+	desiredCounts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	_, removals, exclusions := getProcessGroupMetrics(cluster)
+	current := map[fdbv1beta2.ProcessClass]int{}
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		current[processGroup.ProcessClass]++
+	}
+
+	desired := map[fdbv1beta2.ProcessClass]int{}
+	for processClass, count := range desiredCounts.Map() {
+		if count == 0 {
+			continue
+		}
+
+		desired[processClass] = count
+	}
+
+	insight := &ClusterInsight{
+		ClusterInsightSummary: newClusterInsightSummary(cluster),
+		Generations:           cluster.Status.Generations,
+		ProcessCounts: ProcessCountsInsight{
+			Desired:          desired,
+			Current:          current,
+			MarkedForRemoval: removals,
+			Excluded:         exclusions,
+		},
+	}
+
+	entry, ok := r.clusterInsights.get(client.ObjectKeyFromObject(cluster))
+	if !ok {
+		return insight, nil
+	}
+
+	insight.LastReconciliation = entry.lastReconciliation
+	if entry.status != nil {
+		statusTimestamp := entry.statusTimestamp
+		insight.StatusTimestamp = &statusTimestamp
+	}
+
+	return insight, nil
+}
+
+// getGlobalCoordinationInsight reads the global coordination lists for the provided cluster.
+func getGlobalCoordinationInsight(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	adminClient fdbadminclient.AdminClient,
+) (*GlobalCoordinationInsight, error) {
+	var err error
+	prefix := cluster.Spec.ProcessGroupIDPrefix
+	insight := &GlobalCoordinationInsight{
+		SynchronizationMode: cluster.GetSynchronizationMode(),
+	}
+
+	insight.PendingForRemoval, err = adminClient.GetPendingForRemoval(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	insight.PendingForExclusion, err = adminClient.GetPendingForExclusion(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	insight.PendingForInclusion, err = adminClient.GetPendingForInclusion(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	insight.PendingForRestart, err = adminClient.GetPendingForRestart(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	insight.ReadyForExclusion, err = adminClient.GetReadyForExclusion(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	insight.ReadyForInclusion, err = adminClient.GetReadyForInclusion(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	insight.ReadyForRestart, err = adminClient.GetReadyForRestart(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return insight, nil
+}
@@ -0,0 +1,465 @@
+/*
+ * insight_server_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient/mock"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("insight_server", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var handler http.Handler
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.LockOptions.DisableLocks = pointer.Bool(false)
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+
+		var err error
+		handler, err = NewInsightServer(clusterReconciler, "", "", nil, globalControllerLogger).
+			Handler()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	clusterPath := func() string {
+		return insightAPIPrefix + "/namespaces/" + cluster.Namespace + "/clusters/" + cluster.Name
+	}
+
+	When("listing the clusters", func() {
+		It("returns the summary of the cluster", func() {
+			response := get(insightAPIPrefix + "/clusters")
+			Expect(response.Code).To(Equal(http.StatusOK))
+
+			var summaries []ClusterInsightSummary
+			Expect(json.Unmarshal(response.Body.Bytes(), &summaries)).To(Succeed())
+			Expect(summaries).To(ConsistOf(ClusterInsightSummary{
+				Namespace:  cluster.Namespace,
+				Name:       cluster.Name,
+				Generation: cluster.Generation,
+				Reconciled: true,
+				Health:     cluster.Status.Health,
+			}))
+		})
+	})
+
+	When("getting the insight of a cluster", func() {
+		It("returns the process counts and the last reconciliation", func() {
+			response := get(clusterPath())
+			Expect(response.Code).To(Equal(http.StatusOK))
+
+			insight := &ClusterInsight{}
+			Expect(json.Unmarshal(response.Body.Bytes(), insight)).To(Succeed())
+			Expect(insight.Reconciled).To(BeTrue())
+			Expect(insight.ProcessCounts.Desired).To(Equal(map[fdbv1beta2.ProcessClass]int{
+				fdbv1beta2.ProcessClassStorage:           4,
+				fdbv1beta2.ProcessClassLog:               4,
+				fdbv1beta2.ProcessClassStateless:         8,
+				fdbv1beta2.ProcessClassClusterController: 1,
+			}))
+			Expect(insight.ProcessCounts.Current).To(Equal(insight.ProcessCounts.Desired))
+			Expect(insight.LastReconciliation).NotTo(BeNil())
+			Expect(insight.LastReconciliation.Blockers).To(BeEmpty())
+			Expect(insight.StatusTimestamp).NotTo(BeNil())
+		})
+
+		When("the cluster doesn't exist", func() {
+			It("returns not found", func() {
+				response := get(
+					insightAPIPrefix + "/namespaces/" + cluster.Namespace + "/clusters/missing",
+				)
+				Expect(response.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	When("getting the cached status of a cluster", func() {
+		It("returns the machine-readable status", func() {
+			response := get(clusterPath() + "/status")
+			Expect(response.Code).To(Equal(http.StatusOK))
+
+			status := &fdbv1beta2.FoundationDBStatus{}
+			Expect(json.Unmarshal(response.Body.Bytes(), status)).To(Succeed())
+			Expect(status.Cluster.Processes).NotTo(BeEmpty())
+		})
+	})
+
+	When("getting the global coordination state of a cluster", func() {
+		BeforeEach(func() {
+			adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(
+				adminClient.UpdatePendingForRemoval(
+					map[fdbv1beta2.ProcessGroupID]fdbv1beta2.UpdateAction{
+						"storage-1": fdbv1beta2.UpdateActionAdd,
+					},
+				),
+			).To(Succeed())
+		})
+
+		It("returns the pending process groups", func() {
+			response := get(clusterPath() + "/coordination")
+			Expect(response.Code).To(Equal(http.StatusOK))
+
+			insight := &GlobalCoordinationInsight{}
+			Expect(json.Unmarshal(response.Body.Bytes(), insight)).To(Succeed())
+			Expect(insight.PendingForRemoval).To(HaveKey(fdbv1beta2.ProcessGroupID("storage-1")))
+			Expect(insight.ReadyForRestart).To(BeEmpty())
+		})
+	})
+
+	When("getting the lock information of a cluster", func() {
+		BeforeEach(func() {
+			lockClient := mock.NewMockLockClientUncast(cluster)
+			Expect(lockClient.TakeLock()).To(Succeed())
+		})
+
+		It("returns the current lock holder", func() {
+			response := get(clusterPath() + "/lock")
+			Expect(response.Code).To(Equal(http.StatusOK))
+
+			insight := &LockInsight{}
+			Expect(json.Unmarshal(response.Body.Bytes(), insight)).To(Succeed())
+			Expect(insight.Disabled).To(BeFalse())
+			Expect(insight.Holder).NotTo(BeNil())
+			Expect(insight.Holder.OwnerID).To(Equal(cluster.GetLockID()))
+		})
+	})
+
+	When("the lock client cannot provide the lock holder", func() {
+		It("returns no lock holder", func() {
+			lockClient := mock.NewMockLockClientUncast(cluster)
+			Expect(lockClient.TakeLock()).To(Succeed())
+
+			holder, err := getLockHolder(lockClientWithoutHolder{LockClient: lockClient})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(holder).To(BeNil())
+		})
+	})
+
+	When("the cluster was deleted", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Delete(context.TODO(), cluster)).To(Succeed())
+			_, err := reconcileObject(clusterReconciler, cluster.ObjectMeta, 1)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes the cached information", func() {
+			_, ok := clusterReconciler.clusterInsights.get(client.ObjectKeyFromObject(cluster))
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("the authentication filter is configured", func() {
+		var authenticated, allowed bool
+		var accessReview *authorizationv1.SubjectAccessReview
+
+		BeforeEach(func() {
+			authenticated = true
+			allowed = true
+			accessReview = nil
+		})
+
+		JustBeforeEach(func() {
+			reviewClient := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(
+						_ context.Context,
+						_ client.WithWatch,
+						obj client.Object,
+						_ ...client.CreateOption,
+					) error {
+						switch review := obj.(type) {
+						case *authenticationv1.TokenReview:
+							review.Status.Authenticated = authenticated && review.Spec.Token == "token"
+							review.Status.User = authenticationv1.UserInfo{
+								Username: "insight-reader",
+								Groups:   []string{"readers"},
+							}
+						case *authorizationv1.SubjectAccessReview:
+							review.Status.Allowed = allowed
+							accessReview = review
+						}
+
+						return nil
+					},
+				}).
+				Build()
+
+			var err error
+			handler, err = NewInsightServer(
+				clusterReconciler,
+				"",
+				"",
+				NewInsightAuthenticationFilter(reviewClient),
+				globalControllerLogger,
+			).Handler()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		getWithToken := func(path string, token string) *httptest.ResponseRecorder {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, path, nil)
+			if token != "" {
+				request.Header.Set("Authorization", "Bearer "+token)
+			}
+			handler.ServeHTTP(recorder, request)
+			return recorder
+		}
+
+		It("authorizes the requested path for the authenticated user", func() {
+			Expect(
+				getWithToken(insightAPIPrefix+"/clusters", "token").Code,
+			).To(Equal(http.StatusOK))
+			Expect(accessReview).NotTo(BeNil())
+			Expect(accessReview.Spec.User).To(Equal("insight-reader"))
+			Expect(accessReview.Spec.Groups).To(ConsistOf("readers"))
+			Expect(
+				accessReview.Spec.NonResourceAttributes,
+			).To(Equal(&authorizationv1.NonResourceAttributes{
+				Path: insightAPIPrefix + "/clusters",
+				Verb: "get",
+			}))
+		})
+
+		When("no token is provided", func() {
+			It("rejects the request", func() {
+				Expect(
+					getWithToken(insightAPIPrefix+"/clusters", "").Code,
+				).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		When("the token is not authenticated", func() {
+			BeforeEach(func() {
+				authenticated = false
+			})
+
+			It("rejects the request", func() {
+				Expect(
+					getWithToken(insightAPIPrefix+"/clusters", "token").Code,
+				).To(Equal(http.StatusUnauthorized))
+				Expect(accessReview).To(BeNil())
+			})
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				allowed = false
+			})
+
+			It("rejects the request", func() {
+				Expect(
+					getWithToken(insightAPIPrefix+"/clusters", "token").Code,
+				).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	When("a certificate directory is configured", func() {
+		var certDir string
+		var listenErr error
+		var httpClient *http.Client
+		var address string
+
+		BeforeEach(func() {
+			certDir = GinkgoT().TempDir()
+			certPEM, keyPEM := generateSelfSignedCertificate()
+			Expect(
+				os.WriteFile(filepath.Join(certDir, insightAPICertName), certPEM, 0600),
+			).To(Succeed())
+			Expect(
+				os.WriteFile(filepath.Join(certDir, insightAPIKeyName), keyPEM, 0600),
+			).To(Succeed())
+
+			certPool := x509.NewCertPool()
+			Expect(certPool.AppendCertsFromPEM(certPEM)).To(BeTrue())
+			httpClient = &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						RootCAs:    certPool,
+						MinVersion: tls.VersionTLS12,
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+
+			var listener net.Listener
+			listener, listenErr = NewInsightServer(
+				clusterReconciler,
+				"127.0.0.1:0",
+				certDir,
+				nil,
+				globalControllerLogger,
+			).listen(ctx)
+			if listenErr != nil {
+				return
+			}
+
+			DeferCleanup(listener.Close)
+			address = listener.Addr().String()
+			go func() {
+				defer GinkgoRecover()
+				_ = http.Serve(listener, handler)
+			}()
+		})
+
+		It("serves the API over TLS", func() {
+			Expect(listenErr).NotTo(HaveOccurred())
+			response, err := httpClient.Get("https://" + address + insightAPIPrefix + "/clusters")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				_ = response.Body.Close()
+			}()
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.TLS).NotTo(BeNil())
+		})
+
+		It("doesn't serve the API without TLS", func() {
+			Expect(listenErr).NotTo(HaveOccurred())
+			response, err := http.Get("http://" + address + insightAPIPrefix + "/clusters")
+			if err == nil {
+				_ = response.Body.Close()
+				Expect(response.StatusCode).NotTo(Equal(http.StatusOK))
+			}
+		})
+
+		When("the certificate is missing", func() {
+			BeforeEach(func() {
+				Expect(os.Remove(filepath.Join(certDir, insightAPICertName))).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				Expect(listenErr).To(HaveOccurred())
+			})
+		})
+	})
+
+	When("a filter is configured", func() {
+		BeforeEach(func() {
+			var err error
+			handler, err = NewInsightServer(
+				clusterReconciler,
+				"",
+				"",
+				func(_ logr.Logger, _ http.Handler) (http.Handler, error) {
+					return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					}), nil
+				},
+				globalControllerLogger,
+			).Handler()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("applies the filter", func() {
+			Expect(get(insightAPIPrefix + "/clusters").Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	When("the filter cannot be created", func() {
+		It("returns an error", func() {
+			_, err := NewInsightServer(
+				clusterReconciler,
+				"",
+				"",
+				func(_ logr.Logger, _ http.Handler) (http.Handler, error) {
+					return nil, errors.New("filter error")
+				},
+				globalControllerLogger,
+			).Handler()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+// lockClientWithoutHolder is a LockClient that doesn't implement the LockHolderReader interface.
+type lockClientWithoutHolder struct {
+	fdbadminclient.LockClient
+}
+
+// generateSelfSignedCertificate generates a self-signed certificate for 127.0.0.1 and returns the PEM encoded
+// certificate and key.
+func generateSelfSignedCertificate() ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			CommonName: "insight-api",
+		},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:   time.Now().Add(-time.Minute),
+		NotAfter:    time.Now().Add(time.Hour),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).NotTo(HaveOccurred())
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+}
@@ -0,0 +1,126 @@
+/*
+ * migrate_storage_class.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/replacements"
+)
+
+// migrateStorageClass provides a reconciliation step for migrating the process groups to a new storage class. The
+// process groups are replaced one fault domain at a time.
+type migrateStorageClass struct{}
+
+// reconcile runs the reconciler's work.
+func (migrateStorageClass) reconcile(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	logger logr.Logger,
+) *requeue {
+	if !cluster.UseStorageClassMigration() {
+		return nil
+	}
+
+	// The machine-readable status is only required to check the data movement.
+	if status == nil && cluster.GetStorageClassMigrationMaxInFlightBytes() > 0 {
+		adminClient, err := r.getAdminClient(logger, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+		defer func() {
+			_ = adminClient.Close()
+		}()
+
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	currentStatus := cluster.Status.StorageClassMigration
+	hasReplacements, migrationStatus, err := replacements.MigrateStorageClass(
+		ctx,
+		r,
+		logger,
+		cluster,
+		status,
+		time.Now(),
+	)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
+	}
+
+	if !hasReplacements && equality.Semantic.DeepEqual(currentStatus, migrationStatus) {
+		return nil
+	}
+
+	if migrationStatus != nil &&
+		(currentStatus == nil || currentStatus.Phase != migrationStatus.Phase ||
+			currentStatus.CurrentFaultDomain != migrationStatus.CurrentFaultDomain) {
+		logger.Info(
+			"Storage class migration changed phase",
+			"sourceStorageClass",
+			migrationStatus.SourceStorageClass,
+			"targetStorageClass",
+			migrationStatus.TargetStorageClass,
+			"phase",
+			migrationStatus.Phase,
+			"faultDomain",
+			migrationStatus.CurrentFaultDomain,
+			"message",
+			migrationStatus.Message,
+		)
+		r.Recorder.Event(
+			cluster,
+			corev1.EventTypeNormal,
+			"StorageClassMigration",
+			fmt.Sprintf(
+				"Storage class migration from %s to %s is %s: %s",
+				migrationStatus.SourceStorageClass,
+				migrationStatus.TargetStorageClass,
+				migrationStatus.Phase,
+				migrationStatus.Message,
+			),
+		)
+	}
+
+	cluster.Status.StorageClassMigration = migrationStatus
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if hasReplacements {
+		logger.Info("Replacements have been updated in the cluster status")
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+ * migrate_storage_class_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"k8s.io/utils/pointer"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("migrate_storage_class", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var req *requeue
+
+	// getMarkedForRemoval returns the number of process groups that are marked for removal.
+	getMarkedForRemoval := func() int {
+		var marked int
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if processGroup.IsMarkedForRemoval() {
+				marked++
+			}
+		}
+
+		return marked
+	}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.AutomationOptions.StorageClassMigration = &fdbv1beta2.StorageClassMigrationOptions{
+			Enabled: pointer.Bool(true),
+		}
+		processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+		processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.String("old"),
+			},
+		}
+		cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+
+		cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].VolumeClaimTemplate.Spec.StorageClassName = pointer.String(
+			"new",
+		)
+		Expect(k8sClient.Update(context.TODO(), cluster)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		req = migrateStorageClass{}.reconcile(
+			context.TODO(),
+			clusterReconciler,
+			cluster,
+			nil,
+			globalControllerLogger,
+		)
+	})
+
+	It("should replace the process groups of a single fault domain", func() {
+		Expect(req).To(BeNil())
+		Expect(getMarkedForRemoval()).To(Equal(1))
+
+		migration := cluster.Status.StorageClassMigration
+		Expect(migration).NotTo(BeNil())
+		Expect(migration.SourceStorageClass).To(Equal("old"))
+		Expect(migration.TargetStorageClass).To(Equal("new"))
+		Expect(migration.Phase).To(Equal(fdbv1beta2.StorageClassMigrationPhaseMigrating))
+		Expect(migration.CurrentFaultDomain).NotTo(BeEmpty())
+		Expect(migration.MigratedProcessGroups).To(BeZero())
+	})
+
+	It("should not mark the process groups for removal because of the misconfiguration", func() {
+		Expect(replaceMisconfiguredProcessGroups{}.reconcile(
+			context.TODO(),
+			clusterReconciler,
+			cluster,
+			nil,
+			globalControllerLogger,
+		)).To(BeNil())
+		Expect(getMarkedForRemoval()).To(Equal(1))
+	})
+
+	When("the migration is repeated", func() {
+		JustBeforeEach(func() {
+			req = migrateStorageClass{}.reconcile(
+				context.TODO(),
+				clusterReconciler,
+				cluster,
+				nil,
+				globalControllerLogger,
+			)
+		})
+
+		It("should wait until the process groups of the current fault domain are removed", func() {
+			Expect(req).To(BeNil())
+			Expect(getMarkedForRemoval()).To(Equal(1))
+			Expect(
+				cluster.Status.StorageClassMigration.Message,
+			).To(HavePrefix("Waiting for 1 process groups"))
+		})
+	})
+
+	When("the migration is paused", func() {
+		BeforeEach(func() {
+			cluster.Annotations = map[string]string{
+				fdbv1beta2.StorageClassMigrationPausedAnnotation: "true",
+			}
+			Expect(k8sClient.Update(context.TODO(), cluster)).To(Succeed())
+		})
+
+		It("should not replace any process groups", func() {
+			Expect(req).To(BeNil())
+			Expect(getMarkedForRemoval()).To(BeZero())
+			Expect(
+				cluster.Status.StorageClassMigration.Phase,
+			).To(Equal(fdbv1beta2.StorageClassMigrationPhasePaused))
+		})
+	})
+
+	When("the migration is disabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.StorageClassMigration = nil
+			Expect(k8sClient.Update(context.TODO(), cluster)).To(Succeed())
+		})
+
+		It("should not replace any process groups", func() {
+			Expect(req).To(BeNil())
+			Expect(getMarkedForRemoval()).To(BeZero())
+			Expect(cluster.Status.StorageClassMigration).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,86 @@
+/*
+ * recommend_resources.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/autoscaling"
+)
+
+// recommendResources provides a reconciliation step for collecting the resource usage of the processes and
+// publishing resource recommendations per process class.
+type recommendResources struct{}
+
+// reconcile runs the reconciler's work.
+func (recommendResources) reconcile(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	logger logr.Logger,
+) *requeue {
+	if !cluster.UseResourceRecommendations() {
+		return nil
+	}
+
+	if status == nil {
+		adminClient, err := r.getAdminClient(logger, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+		defer func() {
+			_ = adminClient.Close()
+		}()
+
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	recommendationStatus := autoscaling.GetResourceRecommendationStatus(cluster, status, time.Now())
+	if recommendationStatus == nil {
+		return nil
+	}
+
+	logger.V(1).Info(
+		"Collected resource usage sample",
+		"processClasses",
+		len(recommendationStatus.ProcessClasses),
+	)
+
+	if cluster.Status.Autoscaling == nil {
+		cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{}
+	}
+	cluster.Status.Autoscaling.Resources = recommendationStatus
+
+	err := r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+ * recommend_resources_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("recommend_resources", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+	var req *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.Autoscaling.Resources = &fdbv1beta2.ResourceRecommendationPolicy{
+			Enabled: pointer.Bool(true),
+		}
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+		// Remove the samples that were collected during the setup of the cluster.
+		cluster.Status.Autoscaling = nil
+	})
+
+	JustBeforeEach(func() {
+		adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		status, err = adminClient.GetStatus()
+		Expect(err).NotTo(HaveOccurred())
+
+		for processGroupID, process := range status.Cluster.Processes {
+			process.CPU.UsageCores = 0.5
+			process.Memory.UsedBytes = 2 * 1024 * 1024 * 1024
+			status.Cluster.Processes[processGroupID] = process
+		}
+
+		req = recommendResources{}.reconcile(
+			context.TODO(),
+			clusterReconciler,
+			cluster,
+			status,
+			globalControllerLogger,
+		)
+	})
+
+	It("should publish the recommendations per process class", func() {
+		Expect(req).To(BeNil())
+		recommendationStatus := cluster.GetResourceRecommendationStatus()
+		Expect(recommendationStatus).NotTo(BeNil())
+		Expect(recommendationStatus.LastSampleTimestamp).NotTo(BeNil())
+
+		recommendation := cluster.GetResourceRecommendation(fdbv1beta2.ProcessClassStorage)
+		Expect(recommendation).NotTo(BeNil())
+		Expect(recommendation.Samples).To(HaveLen(1))
+		Expect(recommendation.Requests.Cpu().MilliValue()).To(BeNumerically("==", 600))
+		Expect(recommendation.Limits.Memory().Value()).To(
+			BeNumerically("==", int64(2458)*1024*1024),
+		)
+		Expect(cluster.GetResourceRecommendation(fdbv1beta2.ProcessClassLog)).NotTo(BeNil())
+
+		storedCluster := &fdbv1beta2.FoundationDBCluster{}
+		Expect(
+			k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), storedCluster),
+		).To(Succeed())
+		Expect(
+			storedCluster.GetResourceRecommendation(fdbv1beta2.ProcessClassStorage),
+		).NotTo(BeNil())
+	})
+
+	When("the last sample was collected recently", func() {
+		BeforeEach(func() {
+			cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{
+				Resources: &fdbv1beta2.ResourceRecommendationStatus{
+					LastSampleTimestamp: &metav1.Time{Time: time.Now()},
+				},
+			}
+		})
+
+		It("should not collect a new sample", func() {
+			Expect(req).To(BeNil())
+			Expect(cluster.GetResourceRecommendation(fdbv1beta2.ProcessClassStorage)).To(BeNil())
+		})
+	})
+
+	When("the resource recommendations are disabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.Autoscaling.Resources.Enabled = pointer.Bool(false)
+		})
+
+		It("should not publish any recommendations", func() {
+			Expect(req).To(BeNil())
+			Expect(cluster.GetResourceRecommendationStatus()).To(BeNil())
+		})
+	})
+})
@@ -114,6 +114,25 @@ func (u removeProcessGroups) reconcile(
 		return nil
 	}
 
+	// Ensure we only remove process groups that were suspended for at least the minimum suspension duration.
+	processGroupsToRemove, remainingTime := removals.FilterSuspendedProcessGroups(
+		cluster,
+		processGroupsToRemove,
+		time.Now(),
+	)
+	// If all of the process groups are filtered out we have to wait until the suspension duration has passed.
+	if len(processGroupsToRemove) == 0 {
+		if remainingTime > 0 {
+			return &requeue{
+				message:        "waiting for the suspension of process groups to pass",
+				delay:          remainingTime,
+				delayedRequeue: true,
+			}
+		}
+
+		return nil
+	}
+
 	// We don't use the "cached" of the cluster status from the CRD to minimize the window between data loss (e.g. a node
 	// or a set of Pods is not reachable anymore). We still end up with the risk to actually query the FDB cluster and after that
 	// query the cluster gets into a degraded state.
@@ -410,6 +429,11 @@ func includeProcessGroup(
 		return err
 	}
 
+	err = r.checkRecoveryBudget(logger, cluster, fdbv1beta2.RecoveryActionInclude)
+	if err != nil {
+		return err
+	}
+
 	// Make sure the inclusion are coordinated across multiple operator instances.
 	err = r.takeLock(logger, cluster, "include removed process groups")
 	if err != nil {
@@ -467,7 +491,7 @@ func includeProcessGroup(
 	// Update the process group list and remove all removed and included process groups.
 	cluster.Status.ProcessGroups = newProcessGroups
 
-	return r.updateOrApply(ctx, cluster)
+	return r.recordRecoveryAndUpdate(ctx, cluster, fdbv1beta2.RecoveryActionInclude)
 }
 
 // filterAddressesToInclude will remove all addresses that are part of the fdbProcessesToInclude slice but are not excluded in FDB itself.
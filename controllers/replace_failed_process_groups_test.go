@@ -764,6 +764,44 @@ var _ = Describe("replace_failed_process_groups", func() {
 						removedIDs,
 					).To(ConsistOf([]fdbv1beta2.ProcessGroupID{processGroup.ProcessGroupID}))
 				})
+
+				When("the process group is manually excluded", func() {
+					BeforeEach(func() {
+						cluster.Spec.ManuallyExcludedProcessGroups = []fdbv1beta2.ProcessGroupID{
+							processGroup.ProcessGroupID,
+						}
+					})
+
+					It("should return nil", func() {
+						Expect(result).To(BeNil())
+					})
+
+					It("should not mark the process group to be removed", func() {
+						Expect(
+							getRemovedProcessGroupIDs(cluster),
+						).To(ConsistOf([]fdbv1beta2.ProcessGroupID{}))
+					})
+
+					When("the process group has been missing for a long time", func() {
+						BeforeEach(func() {
+							processGroup.ProcessGroupConditions = append(
+								processGroup.ProcessGroupConditions,
+								&fdbv1beta2.ProcessGroupCondition{
+									ProcessGroupConditionType: fdbv1beta2.MissingProcesses,
+									Timestamp: time.Now().
+										Add(-1 * time.Hour).
+										Unix(),
+								},
+							)
+						})
+
+						It("should mark the process group to be removed", func() {
+							Expect(
+								getRemovedProcessGroupIDs(cluster),
+							).To(ConsistOf([]fdbv1beta2.ProcessGroupID{processGroup.ProcessGroupID}))
+						})
+					})
+				})
 			})
 
 			When(
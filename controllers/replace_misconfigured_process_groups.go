@@ -61,6 +61,7 @@ func (c replaceMisconfiguredProcessGroups) reconcile(
 		ctx,
 		r.PodLifecycleManager,
 		r,
+		r.getAPIReader(),
 		logger,
 		cluster,
 		r.ReplaceOnSecurityContextChange,
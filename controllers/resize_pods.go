@@ -0,0 +1,167 @@
+/*
+ * resize_pods.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/podresize"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// resizePods provides a reconciliation step for resizing the resources of Pods in place if only the resources of the
+// main container or the sidecar container were changed. The Pods are resized one fault domain at a time.
+type resizePods struct{}
+
+// podResize contains the information to resize a single Pod.
+type podResize struct {
+	pod      *corev1.Pod
+	spec     *corev1.PodSpec
+	specHash string
+}
+
+// reconcile runs the reconciler's work.
+func (resizePods) reconcile(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	_ *fdbv1beta2.FoundationDBStatus,
+	logger logr.Logger,
+) *requeue {
+	if !cluster.UseInPlacePodResize() {
+		return nil
+	}
+
+	resizes := map[fdbv1beta2.FaultDomain][]podResize{}
+	var resizing int
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() || cluster.SkipProcessGroup(processGroup) {
+			continue
+		}
+
+		pod, err := r.PodLifecycleManager.GetPod(
+			ctx,
+			r,
+			cluster,
+			processGroup.GetPodName(cluster),
+		)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		spec, err := internal.GetPodSpec(cluster, processGroup)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		canBeResized, err := podresize.CanBeResizedInPlace(cluster, processGroup, pod, spec)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if !canBeResized {
+			continue
+		}
+
+		specHash, err := internal.GetPodSpecHash(cluster, processGroup, spec)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		// The resize was already requested, check if the kubelet has completed the resize.
+		if pod.Annotations[fdbv1beta2.InPlaceResizeSpecKey] == specHash &&
+			podresize.ResourcesMatch(pod, spec) {
+			if podresize.IsResizing(pod) {
+				resizing++
+				continue
+			}
+
+			logger.Info("Pod resize completed", "processGroupID", processGroup.ProcessGroupID)
+			err = podresize.CompleteResize(ctx, r, pod, specHash)
+			if err != nil {
+				return &requeue{curError: err, delayedRequeue: true}
+			}
+
+			continue
+		}
+
+		resizes[processGroup.FaultDomain] = append(
+			resizes[processGroup.FaultDomain],
+			podResize{pod: pod, spec: spec, specHash: specHash},
+		)
+	}
+
+	// Only resize the Pods of the next fault domain once all previous resize operations are done.
+	if resizing > 0 {
+		logger.Info("Waiting for Pods to be resized", "resizing", resizing)
+		return &requeue{
+			message:        fmt.Sprintf("waiting for %d Pods to be resized", resizing),
+			delay:          podSchedulingDelayDuration,
+			delayedRequeue: true,
+		}
+	}
+
+	if len(resizes) == 0 {
+		return nil
+	}
+
+	faultDomains := make([]fdbv1beta2.FaultDomain, 0, len(resizes))
+	for faultDomain := range resizes {
+		faultDomains = append(faultDomains, faultDomain)
+	}
+	slices.Sort(faultDomains)
+
+	faultDomain := faultDomains[0]
+	pods := resizes[faultDomain]
+	logger.Info("Resizing Pods in place", "faultDomain", faultDomain, "count", len(pods))
+	r.Recorder.Event(
+		cluster,
+		corev1.EventTypeNormal,
+		"ResizingPods",
+		fmt.Sprintf("Resizing %d Pods in fault domain %s", len(pods), faultDomain),
+	)
+
+	for _, resize := range pods {
+		logger.V(1).Info("Resizing Pod", "name", resize.pod.Name)
+		err := podresize.Resize(ctx, r, resize.pod, resize.spec, resize.specHash)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	return &requeue{
+		message:        fmt.Sprintf("waiting for %d Pods to be resized", len(pods)),
+		delay:          podSchedulingDelayDuration,
+		delayedRequeue: true,
+	}
+}
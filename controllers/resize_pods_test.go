@@ -0,0 +1,220 @@
+/*
+ * resize_pods_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/podresize"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("resize_pods", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var req *requeue
+	var originalPods *corev1.PodList
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.AutomationOptions.UseInPlacePodResize = pointer.Bool(true)
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+
+		originalPods = &corev1.PodList{}
+		Expect(k8sClient.List(context.TODO(), originalPods)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		req = resizePods{}.reconcile(
+			context.TODO(),
+			clusterReconciler,
+			cluster,
+			nil,
+			globalControllerLogger,
+		)
+	})
+
+	When("the resources were not changed", func() {
+		It("should not requeue", func() {
+			Expect(req).To(BeNil())
+		})
+	})
+
+	When("the resources of the main container were changed", func() {
+		BeforeEach(func() {
+			processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+			for idx, container := range processSettings.PodTemplate.Spec.Containers {
+				if container.Name != fdbv1beta2.MainContainerName {
+					continue
+				}
+
+				resources := corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				}
+				processSettings.PodTemplate.Spec.Containers[idx].Resources = corev1.ResourceRequirements{
+					Requests: resources,
+					Limits:   resources.DeepCopy(),
+				}
+			}
+		})
+
+		It("should resize the Pods of a single fault domain", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.delayedRequeue).To(BeTrue())
+
+			pods := &corev1.PodList{}
+			Expect(k8sClient.List(context.TODO(), pods)).To(Succeed())
+			Expect(pods.Items).To(HaveLen(len(originalPods.Items)))
+
+			faultDomains := map[fdbv1beta2.FaultDomain]fdbv1beta2.None{}
+			var resized int
+			for _, pod := range pods.Items {
+				if _, ok := pod.Annotations[fdbv1beta2.InPlaceResizeSpecKey]; !ok {
+					continue
+				}
+
+				resized++
+				processGroup := fdbv1beta2.FindProcessGroupByID(
+					cluster.Status.ProcessGroups,
+					internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta),
+				)
+				Expect(processGroup).NotTo(BeNil())
+				faultDomains[processGroup.FaultDomain] = fdbv1beta2.None{}
+				spec, err := internal.GetPodSpec(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podresize.ResourcesMatch(&pod, spec)).To(BeTrue())
+			}
+
+			Expect(resized).To(BeNumerically(">", 0))
+			Expect(resized).To(BeNumerically("<", len(pods.Items)))
+			Expect(faultDomains).To(HaveLen(1))
+		})
+
+		When("the resize was completed by the kubelet", func() {
+			JustBeforeEach(func() {
+				req = resizePods{}.reconcile(
+					context.TODO(),
+					clusterReconciler,
+					cluster,
+					nil,
+					globalControllerLogger,
+				)
+			})
+
+			It("should complete the resize and start with the next fault domain", func() {
+				Expect(req).NotTo(BeNil())
+
+				pods := &corev1.PodList{}
+				Expect(k8sClient.List(context.TODO(), pods)).To(Succeed())
+
+				var completed, resizing int
+				for _, pod := range pods.Items {
+					processGroup := fdbv1beta2.FindProcessGroupByID(
+						cluster.Status.ProcessGroups,
+						internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta),
+					)
+					Expect(processGroup).NotTo(BeNil())
+					spec, err := internal.GetPodSpec(cluster, processGroup)
+					Expect(err).NotTo(HaveOccurred())
+					specHash, err := internal.GetPodSpecHash(cluster, processGroup, spec)
+					Expect(err).NotTo(HaveOccurred())
+
+					if pod.Annotations[fdbv1beta2.LastSpecKey] == specHash {
+						completed++
+						continue
+					}
+
+					if pod.Annotations[fdbv1beta2.InPlaceResizeSpecKey] == specHash {
+						resizing++
+					}
+				}
+
+				Expect(completed).To(BeNumerically(">", 0))
+				Expect(resizing).To(BeNumerically(">", 0))
+			})
+		})
+
+		When("the Pods are currently resized", func() {
+			JustBeforeEach(func() {
+				pods := &corev1.PodList{}
+				Expect(k8sClient.List(context.TODO(), pods)).To(Succeed())
+				for _, pod := range pods.Items {
+					if _, ok := pod.Annotations[fdbv1beta2.InPlaceResizeSpecKey]; !ok {
+						continue
+					}
+
+					pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+						Type:   corev1.PodResizeInProgress,
+						Status: corev1.ConditionTrue,
+					})
+					Expect(k8sClient.Status().Update(context.TODO(), &pod)).To(Succeed())
+				}
+
+				req = resizePods{}.reconcile(
+					context.TODO(),
+					clusterReconciler,
+					cluster,
+					nil,
+					globalControllerLogger,
+				)
+			})
+
+			It("should wait for the resize to complete", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.message).To(HavePrefix("waiting for"))
+
+				pods := &corev1.PodList{}
+				Expect(k8sClient.List(context.TODO(), pods)).To(Succeed())
+				var resized int
+				for _, pod := range pods.Items {
+					if _, ok := pod.Annotations[fdbv1beta2.InPlaceResizeSpecKey]; ok {
+						resized++
+					}
+				}
+
+				Expect(resized).To(BeNumerically("<", len(pods.Items)))
+			})
+		})
+
+		When("in-place resizing is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.UseInPlacePodResize = pointer.Bool(false)
+			})
+
+			It("should not resize any Pods", func() {
+				Expect(req).To(BeNil())
+
+				pods := &corev1.PodList{}
+				Expect(k8sClient.List(context.TODO(), pods)).To(Succeed())
+				for _, pod := range pods.Items {
+					Expect(pod.Annotations).NotTo(HaveKey(fdbv1beta2.InPlaceResizeSpecKey))
+				}
+			})
+		})
+	})
+})
@@ -0,0 +1,274 @@
+/*
+ * suspend_process_groups.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/buggify"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/removals"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// suspendProcessGroups provides a reconciliation step for deleting the Pods of fully excluded process groups, while
+// keeping the PVC and the Service until the minimum suspension duration has passed.
+type suspendProcessGroups struct{}
+
+// reconcile runs the reconciler's work.
+func (u suspendProcessGroups) reconcile(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	logger logr.Logger,
+) *requeue {
+	// If the suspension is disabled the removeProcessGroups reconciler will remove all resources at once.
+	if cluster.GetMinimumSuspensionDurationSeconds() == 0 {
+		return nil
+	}
+
+	adminClient, err := r.getAdminClient(logger, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer func() {
+		_ = adminClient.Close()
+	}()
+
+	// If the status is not cached, we have to fetch it.
+	if status == nil {
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	remainingMap, err := removals.GetRemainingMap(
+		logger,
+		adminClient,
+		cluster,
+		status,
+		r.MinimumRecoveryTimeForExclusion,
+	)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	coordinators := fdbstatus.GetCoordinatorsFromStatus(status)
+	_, newExclusions, processGroupsToRemove := r.getProcessGroupsToRemove(
+		logger,
+		cluster,
+		remainingMap,
+		coordinators,
+	)
+
+	// Update the cluster to reflect the new exclusions in our status
+	if newExclusions {
+		err = r.updateOrApply(ctx, cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	processGroupsToSuspend := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupsToRemove))
+	for _, processGroup := range processGroupsToRemove {
+		if processGroup.IsSuspended() {
+			continue
+		}
+
+		processGroupsToSuspend = append(processGroupsToSuspend, processGroup)
+	}
+
+	// Ensure we only suspend process groups that are not blocked to be removed by the buggify config.
+	processGroupsToSuspend = buggify.FilterBlockedRemovals(cluster, processGroupsToSuspend)
+	// If all of the process groups are filtered out we can stop doing the next steps.
+	if len(processGroupsToSuspend) == 0 {
+		return nil
+	}
+
+	hasDesiredFaultTolerance := fdbstatus.HasDesiredFaultToleranceFromStatus(
+		logger,
+		status,
+		cluster,
+	)
+	if !hasDesiredFaultTolerance {
+		return &requeue{
+			message: "Suspensions cannot proceed because cluster has degraded fault tolerance",
+			delay:   30 * time.Second,
+		}
+	}
+
+	zonedRemovals, lastDeletion, err := removals.GetZonedRemovals(processGroupsToSuspend)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	// If the operator is allowed to suspend all process groups at the same time we don't enforce any safety checks.
+	if cluster.GetRemovalMode() != fdbv1beta2.PodUpdateModeAll {
+		// The Pods of suspended process groups are deleted, so the last suspension must be considered the same way as
+		// the last deletion to not suspend zones faster than Kubernetes actually removes Pods.
+		lastDeletion = max(lastDeletion, removals.GetLastSuspension(cluster.Status.ProcessGroups))
+		waitTime, allowed := removals.RemovalAllowed(
+			lastDeletion,
+			time.Now().Unix(),
+			cluster.GetWaitBetweenRemovalsSeconds(),
+		)
+		if !allowed {
+			return &requeue{
+				message: fmt.Sprintf(
+					"not allowed to suspend process groups, waiting: %vs",
+					waitTime,
+				),
+				delay: time.Duration(waitTime) * time.Second,
+			}
+		}
+	}
+
+	zone, zoneSuspensions, err := removals.GetProcessGroupsToRemove(
+		cluster.GetRemovalMode(),
+		zonedRemovals,
+	)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	logger.Info(
+		"Suspending process groups",
+		"zone",
+		zone,
+		"count",
+		len(zoneSuspensions),
+		"deletionMode",
+		cluster.GetRemovalMode(),
+	)
+	// Process groups with the ResourcesTerminating condition have no running Pod, so they can be suspended directly.
+	suspendedProcessGroups := r.suspendProcessGroups(
+		ctx,
+		logger,
+		cluster,
+		zoneSuspensions,
+		zonedRemovals[removals.TerminatingZone],
+	)
+
+	// The process group status might have been updated by the exclusion update, so the current status must be used
+	// to set the suspension timestamp.
+	for _, processGroupID := range suspendedProcessGroups {
+		processGroup := fdbv1beta2.FindProcessGroupByID(
+			cluster.Status.ProcessGroups,
+			processGroupID,
+		)
+		if processGroup == nil {
+			continue
+		}
+
+		processGroup.Suspend()
+	}
+
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// suspendProcessGroup deletes the Pod of the process group and keeps all other resources.
+func suspendProcessGroup(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroup *fdbv1beta2.ProcessGroupStatus,
+) error {
+	pod, err := r.PodLifecycleManager.GetPod(ctx, r, cluster, processGroup.GetPodName(cluster))
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	value, ok := pod.Annotations[fdbv1beta2.IsolateProcessGroupAnnotation]
+	if ok {
+		// Ignore the parsing error here and assume the pod was not isolated.
+		isolated, _ := strconv.ParseBool(value)
+		if isolated {
+			return fmt.Errorf("not allowed to delete Pod as the Pod is isolated")
+		}
+	}
+
+	if !pod.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	err = r.PodLifecycleManager.DeletePod(ctx, r, pod)
+	if err != nil {
+		return fmt.Errorf("could not delete Pod: %w", err)
+	}
+
+	return nil
+}
+
+func (r *FoundationDBClusterReconciler) suspendProcessGroups(
+	ctx context.Context,
+	logger logr.Logger,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroupsToSuspend []*fdbv1beta2.ProcessGroupStatus,
+	terminatingProcessGroups []*fdbv1beta2.ProcessGroupStatus,
+) []fdbv1beta2.ProcessGroupID {
+	processGroupNames := make([]fdbv1beta2.ProcessGroupID, len(processGroupsToSuspend))
+	for i, processGroup := range processGroupsToSuspend {
+		processGroupNames[i] = processGroup.ProcessGroupID
+	}
+
+	r.Recorder.Event(
+		cluster,
+		corev1.EventTypeNormal,
+		"SuspendingProcesses",
+		fmt.Sprintf("Suspending process groups: %v", processGroupNames),
+	)
+
+	processGroups := append(processGroupsToSuspend, terminatingProcessGroups...)
+	suspendedProcessGroups := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroups))
+	for _, processGroup := range processGroups {
+		err := suspendProcessGroup(logr.NewContext(ctx, logger), r, cluster, processGroup)
+		if err != nil {
+			logger.Error(
+				err,
+				"Error during suspend process group",
+				"processGroupID",
+				processGroup.ProcessGroupID,
+			)
+			continue
+		}
+
+		suspendedProcessGroups = append(suspendedProcessGroups, processGroup.ProcessGroupID)
+	}
+
+	return suspendedProcessGroups
+}
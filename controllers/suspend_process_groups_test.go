@@ -0,0 +1,200 @@
+/*
+ * suspend_process_groups_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("suspend_process_groups", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var suspendedProcessGroup *fdbv1beta2.ProcessGroupStatus
+	var minimumSuspensionDuration *int
+	var result *requeue
+
+	getPod := func() error {
+		return k8sClient.Get(context.Background(), ctrlClient.ObjectKey{
+			Name:      suspendedProcessGroup.GetPodName(cluster),
+			Namespace: cluster.Namespace,
+		}, &corev1.Pod{})
+	}
+
+	getProcessGroup := func() *fdbv1beta2.ProcessGroupStatus {
+		return fdbv1beta2.FindProcessGroupByID(
+			cluster.Status.ProcessGroups,
+			suspendedProcessGroup.ProcessGroupID,
+		)
+	}
+
+	getPVC := func() error {
+		return k8sClient.Get(context.Background(), ctrlClient.ObjectKey{
+			Name:      suspendedProcessGroup.GetPvcName(cluster),
+			Namespace: cluster.Namespace,
+		}, &corev1.PersistentVolumeClaim{})
+	}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+		minimumSuspensionDuration = nil
+
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage {
+				continue
+			}
+
+			suspendedProcessGroup = processGroup
+			break
+		}
+		Expect(suspendedProcessGroup).NotTo(BeNil())
+	})
+
+	JustBeforeEach(func() {
+		cluster.Spec.AutomationOptions.MinimumSuspensionDurationSeconds = minimumSuspensionDuration
+		Expect(k8sClient.Update(context.TODO(), cluster)).To(Succeed())
+		suspendedProcessGroup = fdbv1beta2.FindProcessGroupByID(
+			cluster.Status.ProcessGroups,
+			suspendedProcessGroup.ProcessGroupID,
+		)
+
+		marked, processGroup := fdbv1beta2.MarkProcessGroupForRemoval(
+			cluster.Status.ProcessGroups,
+			suspendedProcessGroup.ProcessGroupID,
+			suspendedProcessGroup.ProcessClass,
+			suspendedProcessGroup.Addresses[0],
+		)
+		Expect(marked).To(BeTrue())
+		Expect(processGroup).To(BeNil())
+
+		adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		for _, address := range suspendedProcessGroup.Addresses {
+			adminClient.ExcludedAddresses[address] = fdbv1beta2.None{}
+		}
+
+		result = suspendProcessGroups{}.reconcile(
+			context.TODO(),
+			clusterReconciler,
+			cluster,
+			nil,
+			globalControllerLogger,
+		)
+	})
+
+	When("the suspension is disabled", func() {
+		It("should not suspend the process group", func() {
+			Expect(result).To(BeNil())
+			Expect(getProcessGroup().IsSuspended()).To(BeFalse())
+			Expect(getPod()).To(Succeed())
+		})
+	})
+
+	When("the suspension is enabled", func() {
+		BeforeEach(func() {
+			minimumSuspensionDuration = pointer.Int(3600)
+		})
+
+		It("should delete the Pod and keep the PVC", func() {
+			Expect(result).To(BeNil())
+			Expect(getProcessGroup().IsSuspended()).To(BeTrue())
+			Expect(getProcessGroup().IsExcluded()).To(BeTrue())
+			Expect(k8serrors.IsNotFound(getPod())).To(BeTrue())
+			Expect(getPVC()).To(Succeed())
+		})
+
+		When("the process group is removed before the suspension duration has passed", func() {
+			var removalResult *requeue
+
+			JustBeforeEach(func() {
+				removalResult = removeProcessGroups{}.reconcile(
+					context.TODO(),
+					clusterReconciler,
+					cluster,
+					nil,
+					globalControllerLogger,
+				)
+			})
+
+			It("should keep the PVC and requeue", func() {
+				Expect(removalResult).NotTo(BeNil())
+				Expect(removalResult.delayedRequeue).To(BeTrue())
+				Expect(
+					removalResult.message,
+				).To(Equal("waiting for the suspension of process groups to pass"))
+				Expect(removalResult.delay).To(BeNumerically(">", 59*time.Minute))
+				Expect(getPVC()).To(Succeed())
+			})
+		})
+
+		When("the process group is removed after the suspension duration has passed", func() {
+			var removalResult *requeue
+
+			JustBeforeEach(func() {
+				getProcessGroup().SuspensionTimestamp = &metav1.Time{
+					Time: time.Now().Add(-2 * time.Hour),
+				}
+
+				removalResult = removeProcessGroups{}.reconcile(
+					context.TODO(),
+					clusterReconciler,
+					cluster,
+					nil,
+					globalControllerLogger,
+				)
+			})
+
+			It("should remove the PVC", func() {
+				Expect(removalResult).To(BeNil())
+				Expect(k8serrors.IsNotFound(getPVC())).To(BeTrue())
+			})
+		})
+
+		When("the Pod is marked as isolated", func() {
+			BeforeEach(func() {
+				pod := &corev1.Pod{}
+				Expect(k8sClient.Get(context.Background(), ctrlClient.ObjectKey{
+					Name:      suspendedProcessGroup.GetPodName(cluster),
+					Namespace: cluster.Namespace,
+				}, pod)).To(Succeed())
+				pod.Annotations[fdbv1beta2.IsolateProcessGroupAnnotation] = "true"
+				Expect(k8sClient.Update(context.Background(), pod)).To(Succeed())
+			})
+
+			It("should not suspend the process group", func() {
+				Expect(result).To(BeNil())
+				Expect(getProcessGroup().IsSuspended()).To(BeFalse())
+				Expect(getPod()).To(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,115 @@
+/*
+ * track_storage_engine_migration.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/storagemigration"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
+)
+
+// trackStorageEngineMigration provides a reconciliation step for tracking the progress of a storage engine migration.
+// The phase of the migration defines the storage migration settings in the desired database configuration.
+type trackStorageEngineMigration struct{}
+
+// reconcile runs the reconciler's work.
+func (trackStorageEngineMigration) reconcile(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	logger logr.Logger,
+) *requeue {
+	if !cluster.UseStorageEngineMigration() {
+		return nil
+	}
+
+	if status == nil {
+		adminClient, err := r.getAdminClient(logger, cluster)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+		defer func() {
+			_ = adminClient.Close()
+		}()
+
+		status, err = adminClient.GetStatus()
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	currentStatus := cluster.Status.StorageEngineMigration
+	migrationStatus := storagemigration.GetMigrationStatus(
+		cluster,
+		status,
+		fdbstatus.HasDesiredFaultToleranceFromStatus(logger, status, cluster),
+		time.Now(),
+	)
+
+	if equality.Semantic.DeepEqual(currentStatus, migrationStatus) {
+		return nil
+	}
+
+	if migrationStatus != nil &&
+		(currentStatus == nil || currentStatus.Phase != migrationStatus.Phase ||
+			currentStatus.TargetEngine != migrationStatus.TargetEngine) {
+		logger.Info(
+			"Storage engine migration changed phase",
+			"sourceEngine",
+			migrationStatus.SourceEngine,
+			"targetEngine",
+			migrationStatus.TargetEngine,
+			"phase",
+			migrationStatus.Phase,
+			"message",
+			migrationStatus.Message,
+		)
+		r.Recorder.Event(
+			cluster,
+			corev1.EventTypeNormal,
+			"StorageEngineMigration",
+			fmt.Sprintf(
+				"Storage engine migration from %s to %s is %s: %s",
+				migrationStatus.SourceEngine,
+				migrationStatus.TargetEngine,
+				migrationStatus.Phase,
+				migrationStatus.Message,
+			),
+		)
+	}
+
+	cluster.Status.StorageEngineMigration = migrationStatus
+	err := r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+ * track_storage_engine_migration_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient/mock"
+	"k8s.io/utils/pointer"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("track_storage_engine_migration", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+	var status *fdbv1beta2.FoundationDBStatus
+	var req *requeue
+	var migratedStorageServers int
+
+	BeforeEach(func() {
+		migratedStorageServers = 0
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.AutomationOptions.StorageEngineMigration = &fdbv1beta2.StorageEngineMigrationOptions{
+			Enabled: pointer.Bool(true),
+		}
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+
+		var err error
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		cluster.Spec.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineRedwood1
+		Expect(k8sClient.Update(context.TODO(), cluster)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		status, err = adminClient.GetStatus()
+		Expect(err).NotTo(HaveOccurred())
+
+		// The mock admin client doesn't report the storage role, so we add it for all storage processes.
+		var migrated int
+		for processGroupID, process := range status.Cluster.Processes {
+			if process.ProcessClass != fdbv1beta2.ProcessClassStorage {
+				continue
+			}
+
+			engine := fdbv1beta2.StorageEngineSSD2
+			if migrated < migratedStorageServers {
+				engine = fdbv1beta2.StorageEngineRedwood1
+				migrated++
+			}
+
+			process.Roles = append(process.Roles, fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+				Role: string(fdbv1beta2.ProcessRoleStorage),
+				StorageMetadata: &fdbv1beta2.FoundationDBStatusStorageMetadata{
+					StorageEngine: engine,
+				},
+			})
+			status.Cluster.Processes[processGroupID] = process
+		}
+
+		req = trackStorageEngineMigration{}.reconcile(
+			context.TODO(),
+			clusterReconciler,
+			cluster,
+			status,
+			globalControllerLogger,
+		)
+	})
+
+	It("should start the migration", func() {
+		Expect(req).To(BeNil())
+		migration := cluster.Status.StorageEngineMigration
+		Expect(migration).NotTo(BeNil())
+		Expect(migration.SourceEngine).To(Equal(fdbv1beta2.StorageEngineSSD2))
+		Expect(migration.TargetEngine).To(Equal(fdbv1beta2.StorageEngineRedwood1))
+		Expect(migration.Phase).To(Equal(fdbv1beta2.StorageEngineMigrationPhaseMigrating))
+		Expect(migration.StorageServers).To(Equal(4))
+		Expect(migration.MigratedStorageServers).To(BeZero())
+	})
+
+	When("the database configuration is updated", func() {
+		JustBeforeEach(func() {
+			Expect(updateDatabaseConfiguration{}.reconcile(
+				context.TODO(),
+				clusterReconciler,
+				cluster,
+				nil,
+				globalControllerLogger,
+			)).To(BeNil())
+		})
+
+		It("should configure the migration settings", func() {
+			Expect(
+				adminClient.DatabaseConfiguration.StorageEngine,
+			).To(Equal(fdbv1beta2.StorageEngineRedwood1))
+			Expect(adminClient.DatabaseConfiguration.StorageMigrationType).NotTo(BeNil())
+			Expect(
+				*adminClient.DatabaseConfiguration.StorageMigrationType,
+			).To(Equal(fdbv1beta2.StorageMigrationTypeGradual))
+			Expect(adminClient.DatabaseConfiguration.PerpetualStorageWiggle).To(HaveValue(Equal(1)))
+
+			currentStatus, err := adminClient.GetStatus()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(currentStatus.Cluster.DatabaseConfiguration.NormalizeConfiguration(cluster)).To(
+				Equal(cluster.DesiredDatabaseConfiguration()),
+			)
+		})
+	})
+
+	When("the storage servers are partially migrated", func() {
+		BeforeEach(func() {
+			migratedStorageServers = 1
+		})
+
+		It("should update the progress", func() {
+			Expect(req).To(BeNil())
+			migration := cluster.Status.StorageEngineMigration
+			Expect(migration).NotTo(BeNil())
+			Expect(migration.MigratedStorageServers).To(Equal(1))
+			Expect(migration.MigratedPercentage).To(Equal(25))
+			Expect(migration.EstimatedRemainingSeconds).NotTo(BeNil())
+		})
+	})
+
+	When("the storage engine migration is disabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.StorageEngineMigration.Enabled = pointer.Bool(false)
+		})
+
+		It("should not track the migration", func() {
+			Expect(req).To(BeNil())
+			Expect(cluster.Status.StorageEngineMigration).To(BeNil())
+		})
+	})
+})
@@ -39,7 +39,7 @@ type updateDatabaseConfiguration struct{}
 
 // reconcile runs the reconciler's work.
 func (u updateDatabaseConfiguration) reconcile(
-	_ context.Context,
+	ctx context.Context,
 	r *FoundationDBClusterReconciler,
 	cluster *fdbv1beta2.FoundationDBCluster,
 	status *fdbv1beta2.FoundationDBStatus,
@@ -125,6 +125,11 @@ func (u updateDatabaseConfiguration) reconcile(
 				}
 			}
 
+			err = r.checkRecoveryBudget(logger, cluster, fdbv1beta2.RecoveryActionConfigureDatabase)
+			if err != nil {
+				return getRecoveryBudgetRequeue(err)
+			}
+
 			err = r.takeLock(logger, cluster,
 				fmt.Sprintf("reconfiguring the database to `%s`", configurationString))
 			if err != nil {
@@ -148,6 +153,14 @@ func (u updateDatabaseConfiguration) reconcile(
 		}
 
 		logger.Info("Configured database", "clusterIsConfigured", clusterIsConfigured)
+		// The initial configuration of the database is not counted against the recovery budget.
+		if clusterIsConfigured {
+			err = r.recordRecovery(ctx, cluster, fdbv1beta2.RecoveryActionConfigureDatabase)
+			if err != nil {
+				return &requeue{curError: err, delayedRequeue: true}
+			}
+		}
+
 		if !equality.Semantic.DeepEqual(nextConfiguration, desiredConfiguration) {
 			return &requeue{
 				message:        "Requeuing for next stage of database configuration change",
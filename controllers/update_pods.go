@@ -27,6 +27,7 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/podresize"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/replacements"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
@@ -89,8 +90,13 @@ func (u updatePods) reconcile(
 
 	if len(updates) > 0 {
 		if cluster.Spec.AutomationOptions.PodUpdateStrategy == fdbv1beta2.PodUpdateStrategyReplacement {
-			logger.Info("Requeuing reconciliation to replace pods")
-			return &requeue{message: "Requeueing reconciliation to replace pods"}
+			// Pods that must be recreated for a file system resize will be deleted, replacing those Pods would
+			// move all the data of the process group.
+			updates = getFileSystemResizeUpdates(cluster, updates)
+			if len(updates) == 0 {
+				logger.Info("Requeuing reconciliation to replace pods")
+				return &requeue{message: "Requeueing reconciliation to replace pods"}
+			}
 		}
 
 		if r.PodLifecycleManager.GetDeletionMode(cluster) == fdbv1beta2.PodUpdateModeNone {
@@ -354,15 +360,40 @@ func getPodsToUpdate(
 				continue
 			}
 
-			if updated {
+			// If the file system resize is pending for too long, the Pod must be recreated to resize the file system.
+			if updated && !fileSystemResizeRequiresRestart(processGroup) {
 				continue
 			}
 		}
 
+		// If only the resources were changed, the Pod will be resized in place by the resizePods reconciler.
+		spec, err := internal.GetPodSpec(cluster, processGroup)
+		if err != nil {
+			logger.Info("Skipping Pod due to error generating spec",
+				"processGroupID", processGroup.ProcessGroupID,
+				"error", err.Error())
+			continue
+		}
+
+		canBeResized, err := podresize.CanBeResizedInPlace(cluster, processGroup, pod, spec)
+		if err != nil {
+			logger.Info("Skipping Pod due to error checking if the Pod can be resized in place",
+				"processGroupID", processGroup.ProcessGroupID,
+				"error", err.Error())
+			continue
+		}
+
+		if canBeResized && !fileSystemResizeRequiresRestart(processGroup) {
+			logger.V(1).Info("Skip process group for deletion, Pod will be resized in place",
+				"processGroupID", processGroup.ProcessGroupID)
+			continue
+		}
+
 		needsReplacement, err := replacements.ProcessGroupNeedsReplacements(
 			ctx,
 			reconciler.PodLifecycleManager,
 			reconciler,
+			reconciler.getAPIReader(),
 			logger,
 			cluster,
 			processGroup,
@@ -381,16 +412,22 @@ func getPodsToUpdate(
 			continue
 		}
 
+		reason := fmt.Sprintf(
+			"specHash has changed from %s to %s",
+			specHash,
+			pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey],
+		)
+		if pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] == specHash &&
+			fileSystemResizeRequiresRestart(processGroup) {
+			reason = "file system resize is pending"
+		}
+
 		logger.Info(
 			"Update Pod",
 			"processGroupID",
 			processGroup.ProcessGroupID,
 			"reason",
-			fmt.Sprintf(
-				"specHash has changed from %s to %s",
-				specHash,
-				pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey],
-			),
+			reason,
 		)
 
 		podClient, message := reconciler.getPodClient(cluster, pod)
@@ -430,6 +467,40 @@ func getPodsToUpdate(
 	return updates, nil
 }
 
+// fileSystemResizeRequiresRestart returns true if the file system resize of the process group's PVC is pending for
+// longer than the fileSystemResizeGracePeriod. In this case the Pod must be recreated to resize the file system.
+func fileSystemResizeRequiresRestart(processGroup *fdbv1beta2.ProcessGroupStatus) bool {
+	pendingTime := processGroup.GetConditionTime(fdbv1beta2.FileSystemResizePending)
+	if pendingTime == nil {
+		return false
+	}
+
+	return time.Since(time.Unix(*pendingTime, 0)) > fileSystemResizeGracePeriod
+}
+
+// getFileSystemResizeUpdates returns only the Pods that must be recreated to resize the file system.
+func getFileSystemResizeUpdates(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	updates map[string][]*corev1.Pod,
+) map[string][]*corev1.Pod {
+	resizeUpdates := map[string][]*corev1.Pod{}
+	for zone, pods := range updates {
+		for _, pod := range pods {
+			processGroup := fdbv1beta2.FindProcessGroupByID(
+				cluster.Status.ProcessGroups,
+				internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta),
+			)
+			if processGroup == nil || !fileSystemResizeRequiresRestart(processGroup) {
+				continue
+			}
+
+			resizeUpdates[zone] = append(resizeUpdates[zone], pod)
+		}
+	}
+
+	return resizeUpdates
+}
+
 func shouldRequeueDueToTerminatingPod(
 	pod *corev1.Pod,
 	cluster *fdbv1beta2.FoundationDBCluster,
@@ -550,6 +621,11 @@ func deletePodsForUpdates(
 		}
 	}
 
+	err = r.checkRecoveryBudget(logger, cluster, fdbv1beta2.RecoveryActionUpdatePods)
+	if err != nil {
+		return getRecoveryBudgetRequeue(err)
+	}
+
 	// Only lock the cluster if we are not running in the delete "All" mode.
 	// Otherwise, we want to delete all Pods and don't require a lock to sync with other clusters.
 	if deletionMode != fdbv1beta2.PodUpdateModeAll {
@@ -628,5 +704,10 @@ func deletePodsForUpdates(
 		return &requeue{curError: err}
 	}
 
+	err = r.recordRecovery(ctx, cluster, fdbv1beta2.RecoveryActionUpdatePods)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
 	return &requeue{message: "Pods need to be recreated", delayedRequeue: true}
 }
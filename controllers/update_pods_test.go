@@ -189,6 +189,85 @@ var _ = Describe("update_pods", func() {
 				).To(Equal("Pod updates are skipped because of an ongoing version incompatible upgrade"))
 			})
 		})
+
+		When("the recovery budget is enabled", func() {
+			var req *requeue
+			var pod *corev1.Pod
+
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.RecoveryBudget = &fdbv1beta2.RecoveryBudgetOptions{
+					MaxRecoveries: pointer.Int(1),
+				}
+
+				pods := &corev1.PodList{}
+				Expect(k8sClient.List(context.TODO(), pods)).To(Succeed())
+				Expect(pods.Items).NotTo(BeEmpty())
+				pod = &pods.Items[0]
+				updates = map[string][]*corev1.Pod{
+					"zone1": {pod},
+				}
+			})
+
+			JustBeforeEach(func() {
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+				status, err := adminClient.GetStatus()
+				Expect(err).NotTo(HaveOccurred())
+
+				req = deletePodsForUpdates(
+					context.TODO(),
+					clusterReconciler,
+					cluster,
+					updates,
+					testLogger,
+					status,
+					adminClient,
+				)
+			})
+
+			It("should delete the Pods and record the recovery", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.curError).NotTo(HaveOccurred())
+				Expect(req.message).To(Equal("Pods need to be recreated"))
+				Expect(cluster.Status.RecoveryBudget).NotTo(BeNil())
+				Expect(cluster.Status.RecoveryBudget.Recoveries).To(HaveLen(1))
+				Expect(
+					cluster.Status.RecoveryBudget.Recoveries[0].Action,
+				).To(Equal(fdbv1beta2.RecoveryActionUpdatePods))
+			})
+
+			When("the recovery budget is exhausted", func() {
+				BeforeEach(func() {
+					cluster.Status.RecoveryBudget = &fdbv1beta2.RecoveryBudgetStatus{
+						Recoveries: []fdbv1beta2.RecoveryBudgetEntry{
+							{
+								Action: fdbv1beta2.RecoveryActionBounce,
+								Timestamp: metav1.Time{
+									Time: time.Now().Add(-10 * time.Minute),
+								},
+							},
+						},
+					}
+				})
+
+				It("should not delete the Pods", func() {
+					Expect(req).NotTo(BeNil())
+					Expect(req.curError).To(HaveOccurred())
+					Expect(req.delayedRequeue).To(BeTrue())
+					Expect(req.delay).To(BeNumerically("~", 50*time.Minute, time.Minute))
+
+					currentPod := &corev1.Pod{}
+					Expect(
+						k8sClient.Get(
+							context.TODO(),
+							ctrlClient.ObjectKeyFromObject(pod),
+							currentPod,
+						),
+					).To(Succeed())
+					Expect(currentPod.DeletionTimestamp).To(BeNil())
+				})
+			})
+		})
 	})
 
 	Context("Validating shouldRequeueDueToTerminatingPod", func() {
@@ -429,6 +508,51 @@ var _ = Describe("update_pods", func() {
 					Expect(updateErr).NotTo(HaveOccurred())
 				})
 			})
+
+			When("a process group has a pending file system resize", func() {
+				var picked *fdbv1beta2.ProcessGroupStatus
+
+				setPendingSince := func(pendingSince time.Time) {
+					picked.ProcessGroupConditions = append(
+						picked.ProcessGroupConditions,
+						&fdbv1beta2.ProcessGroupCondition{
+							ProcessGroupConditionType: fdbv1beta2.FileSystemResizePending,
+							Timestamp:                 pendingSince.Unix(),
+						},
+					)
+				}
+
+				BeforeEach(func() {
+					picked = internal.PickProcessGroups(cluster, fdbv1beta2.ProcessClassStorage, 1)[0]
+				})
+
+				When("the file system resize is pending for less than the grace period", func() {
+					BeforeEach(func() {
+						setPendingSince(time.Now())
+					})
+
+					It("should return no errors and an empty map", func() {
+						Expect(updates).To(HaveLen(0))
+						Expect(updateErr).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the file system resize is pending for more than the grace period", func() {
+					BeforeEach(func() {
+						setPendingSince(time.Now().Add(-2 * fileSystemResizeGracePeriod))
+					})
+
+					It("should return the Pod of the process group", func() {
+						Expect(updateErr).NotTo(HaveOccurred())
+						Expect(updates).To(HaveLen(1))
+						for _, pods := range updates {
+							Expect(pods).To(HaveLen(1))
+							Expect(pods[0].Name).To(Equal(picked.GetPodName(cluster)))
+						}
+						Expect(getFileSystemResizeUpdates(cluster, updates)).To(Equal(updates))
+					})
+				})
+			})
 		})
 
 		When("there is a spec change for all processes", func() {
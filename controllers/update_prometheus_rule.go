@@ -0,0 +1,133 @@
+/*
+ * update_prometheus_rule.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// updatePrometheusRule provides a reconciliation step for managing the PrometheusRule with the alerting rules for a
+// cluster.
+type updatePrometheusRule struct{}
+
+// reconcile runs the reconciler's work.
+func (u updatePrometheusRule) reconcile(
+	ctx context.Context,
+	r *FoundationDBClusterReconciler,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	_ *fdbv1beta2.FoundationDBStatus,
+	logger logr.Logger,
+) *requeue {
+	// If the PrometheusRule options are not set, we don't have to check for an existing PrometheusRule. This
+	// prevents additional requests for clusters that don't use this feature.
+	if cluster.Spec.PrometheusRuleOptions.Enabled == nil {
+		return nil
+	}
+
+	existing := internal.NewPrometheusRuleObject(cluster.Namespace, cluster.Name)
+	err := r.Get(
+		ctx,
+		types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name},
+		existing,
+	)
+	if err != nil {
+		// If the PrometheusRule custom resource definition is not installed, we skip the creation as the
+		// PrometheusRule is an optional feature.
+		if meta.IsNoMatchError(err) {
+			if cluster.ShouldCreatePrometheusRule() {
+				logger.Info(
+					"Skipping PrometheusRule creation because the PrometheusRule resource is not installed",
+				)
+			}
+			return nil
+		}
+
+		if !k8serrors.IsNotFound(err) {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		if !cluster.ShouldCreatePrometheusRule() {
+			return nil
+		}
+
+		prometheusRule := internal.GetPrometheusRule(cluster)
+		logger.V(1).Info("Creating PrometheusRule", "name", prometheusRule.GetName())
+		err = r.Create(ctx, prometheusRule)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		return nil
+	}
+
+	// Only modify the PrometheusRule if it's owned by this cluster.
+	if !metav1.IsControlledBy(existing, cluster) {
+		logger.Info(
+			"Ignoring PrometheusRule that is not owned by the cluster",
+			"name",
+			existing.GetName(),
+		)
+		return nil
+	}
+
+	if !cluster.ShouldCreatePrometheusRule() {
+		logger.Info("Deleting PrometheusRule", "name", existing.GetName())
+		err = r.Delete(ctx, existing)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		return nil
+	}
+
+	prometheusRule := internal.GetPrometheusRule(cluster)
+	existingMetadata := metav1.ObjectMeta{
+		Labels:      existing.GetLabels(),
+		Annotations: existing.GetAnnotations(),
+	}
+	metadataCorrect := !internal.MergeLabels(&existingMetadata, metav1.ObjectMeta{
+		Labels: prometheusRule.GetLabels(),
+	})
+
+	if !equality.Semantic.DeepEqual(existing.Object["spec"], prometheusRule.Object["spec"]) ||
+		!metadataCorrect {
+		logger.Info("Updating PrometheusRule", "name", existing.GetName())
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpdatingPrometheusRule", "")
+		existing.SetLabels(existingMetadata.Labels)
+		existing.Object["spec"] = prometheusRule.Object["spec"]
+		err = r.Update(ctx, existing)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+ * update_prometheus_rule_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("update_prometheus_rule", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var req *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		req = updatePrometheusRule{}.reconcile(
+			context.TODO(),
+			clusterReconciler,
+			cluster,
+			nil,
+			globalControllerLogger,
+		)
+	})
+
+	getPrometheusRule := func() (*unstructured.Unstructured, error) {
+		rule := internal.NewPrometheusRuleObject(cluster.Namespace, cluster.Name)
+		err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(rule), rule)
+
+		return rule, err
+	}
+
+	When("the PrometheusRule options are not set", func() {
+		It("should not create a PrometheusRule", func() {
+			Expect(req).To(BeNil())
+			_, err := getPrometheusRule()
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	When("the PrometheusRule is enabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.PrometheusRuleOptions.Enabled = pointer.Bool(true)
+			cluster.Spec.PrometheusRuleOptions.Labels = map[string]string{"prometheus": "fdb"}
+		})
+
+		It("should create the PrometheusRule", func() {
+			Expect(req).To(BeNil())
+			rule, err := getPrometheusRule()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule.GetLabels()).To(HaveKeyWithValue("prometheus", "fdb"))
+			Expect(rule.GetOwnerReferences()).To(HaveLen(1))
+			Expect(rule.GetOwnerReferences()[0].UID).To(Equal(cluster.UID))
+
+			groups, found, err := unstructured.NestedSlice(rule.Object, "spec", "groups")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(groups).To(HaveLen(1))
+		})
+
+		When("the thresholds are changed", func() {
+			JustBeforeEach(func() {
+				Expect(req).To(BeNil())
+				cluster.Spec.PrometheusRuleOptions.UnavailableSeconds = pointer.Int(120)
+				req = updatePrometheusRule{}.reconcile(
+					context.TODO(),
+					clusterReconciler,
+					cluster,
+					nil,
+					globalControllerLogger,
+				)
+			})
+
+			It("should update the PrometheusRule", func() {
+				Expect(req).To(BeNil())
+				rule, err := getPrometheusRule()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(
+					rule.Object["spec"],
+				).To(Equal(internal.GetPrometheusRule(cluster).Object["spec"]))
+			})
+		})
+
+		When("the PrometheusRule gets disabled", func() {
+			JustBeforeEach(func() {
+				Expect(req).To(BeNil())
+				cluster.Spec.PrometheusRuleOptions.Enabled = pointer.Bool(false)
+				req = updatePrometheusRule{}.reconcile(
+					context.TODO(),
+					clusterReconciler,
+					cluster,
+					nil,
+					globalControllerLogger,
+				)
+			})
+
+			It("should delete the PrometheusRule", func() {
+				Expect(req).To(BeNil())
+				_, err := getPrometheusRule()
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	When("a PrometheusRule exists that is not owned by the cluster", func() {
+		BeforeEach(func() {
+			rule := internal.NewPrometheusRuleObject(cluster.Namespace, cluster.Name)
+			rule.Object["spec"] = map[string]interface{}{}
+			Expect(k8sClient.Create(context.TODO(), rule)).To(Succeed())
+			cluster.Spec.PrometheusRuleOptions.Enabled = pointer.Bool(false)
+		})
+
+		It("should not delete the PrometheusRule", func() {
+			Expect(req).To(BeNil())
+			_, err := getPrometheusRule()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -31,8 +31,11 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/adoption"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/coordination"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/locality"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/recoverybudget"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/volumes"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/podmanager"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -60,6 +63,9 @@ func (c updateStatus) reconcile(
 	clusterStatus.Generations.Reconciled = cluster.Status.Generations.Reconciled
 	clusterStatus.ProcessGroups = cluster.Status.ProcessGroups
 	clusterStatus.ConnectionString = cluster.Status.ConnectionString
+	clusterStatus.Autoscaling = cluster.Status.Autoscaling
+	clusterStatus.StorageEngineMigration = cluster.Status.StorageEngineMigration
+	clusterStatus.StorageClassMigration = cluster.Status.StorageClassMigration
 	// Initialize with the current desired storage servers per Pod
 	clusterStatus.StorageServersPerDisk = []int{cluster.GetStorageServersPerPod()}
 	clusterStatus.LogServersPerDisk = []int{cluster.GetLogServersPerPod()}
@@ -77,6 +83,8 @@ func (c updateStatus) reconcile(
 		}
 	}
 
+	r.clusterInsights.setStatus(cluster, databaseStatus)
+
 	versionMap := map[string]int{}
 	for _, process := range databaseStatus.Cluster.Processes {
 		versionMap[process.Version]++
@@ -116,8 +124,14 @@ func (c updateStatus) reconcile(
 		clusterStatus.DatabaseConfiguration = databaseStatus.Cluster.DatabaseConfiguration.NormalizeConfiguration(
 			cluster,
 		)
+		clusterStatus.Adoption = adoption.GetStatus(cluster, databaseStatus)
+	} else if cluster.IsAdopting() {
+		// Keep the last known adoption status as the machine-readable status will contain no information about the
+		// processes.
+		clusterStatus.Adoption = cluster.Status.Adoption
 	}
 
+	clusterStatus.RecoveryBudget = recoverybudget.GetStatus(cluster, databaseStatus, time.Now())
 	clusterStatus.Configured = fdbstatus.ClusterIsConfigured(cluster, databaseStatus)
 	if cluster.Spec.MainContainer.EnableTLS {
 		clusterStatus.RequiredAddresses.TLS = true
@@ -759,6 +773,13 @@ func validateProcessGroup(
 
 	processGroupStatus.UpdateCondition(fdbv1beta2.IncorrectPodSpec, incorrectPodSpec)
 
+	// The Pod is resized in place if the in-place resize annotation is set and the resize is not yet completed.
+	resizeSpecHash, ok := pod.Annotations[fdbv1beta2.InPlaceResizeSpecKey]
+	processGroupStatus.UpdateCondition(
+		fdbv1beta2.PodResizeInProgress,
+		ok && resizeSpecHash != pod.Annotations[fdbv1beta2.LastSpecKey],
+	)
+
 	// Check the sidecar image, to ensure the sidecar is running with the desired image.
 	sidecarImage, err := internal.GetSidecarImage(cluster, processGroupStatus.ProcessClass)
 	if err != nil {
@@ -824,6 +845,19 @@ func validateProcessGroup(
 		}
 
 		if !incorrectPVC {
+			// If only the storage request was increased, the PVC will be expanded in place by the expandPVCs
+			// reconciler, so the PVC shouldn't be marked as incorrect. If the PVC can't be expanded the process
+			// group will be replaced because of the changed spec.
+			var onlyStorageIncreased bool
+			onlyStorageIncreased, err = volumes.OnlyStorageIncreased(currentPVC, desiredPvc)
+			if err != nil {
+				return err
+			}
+
+			if onlyStorageIncreased && cluster.UseVolumeExpansion() {
+				desiredPvc.Annotations[fdbv1beta2.LastSpecKey] = currentPVC.Annotations[fdbv1beta2.LastSpecKey]
+			}
+
 			incorrectPVC = !internal.MetadataMatches(currentPVC.ObjectMeta, desiredPvc.ObjectMeta)
 		}
 		if incorrectPVC {
@@ -837,6 +871,10 @@ func validateProcessGroup(
 		}
 
 		processGroupStatus.UpdateCondition(fdbv1beta2.MissingPVC, incorrectPVC)
+		processGroupStatus.UpdateCondition(
+			fdbv1beta2.FileSystemResizePending,
+			volumes.HasFileSystemResizePending(currentPVC),
+		)
 	}
 
 	if pod.Status.Phase == corev1.PodPending {
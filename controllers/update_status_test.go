@@ -26,6 +26,7 @@ import (
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient/mock"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/podmanager"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -776,6 +777,103 @@ var _ = Describe("update_status", func() {
 			})
 		})
 
+		When("the storage request of the PVC was increased", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.UseVolumeExpansion = pointer.Bool(true)
+				processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+					Spec: corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("256G"),
+							},
+						},
+					},
+				}
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+			})
+
+			JustBeforeEach(func() {
+				Expect(
+					validateProcessGroups(
+						context.TODO(),
+						clusterReconciler,
+						cluster,
+						&cluster.Status,
+						processMap,
+						configMap,
+						logger,
+						"",
+					),
+				).NotTo(HaveOccurred())
+			})
+
+			It("should not get a MissingPVC condition assigned", func() {
+				Expect(
+					fdbv1beta2.FilterByCondition(
+						cluster.Status.ProcessGroups,
+						fdbv1beta2.MissingPVC,
+						false,
+					),
+				).To(BeEmpty())
+			})
+
+			When("volume expansion is disabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.UseVolumeExpansion = pointer.Bool(false)
+				})
+
+				It("should get a MissingPVC condition assigned", func() {
+					Expect(
+						fdbv1beta2.FilterByCondition(
+							cluster.Status.ProcessGroups,
+							fdbv1beta2.MissingPVC,
+							false,
+						),
+					).NotTo(BeEmpty())
+				})
+			})
+		})
+
+		When("the file system resize of the PVC is pending", func() {
+			BeforeEach(func() {
+				pvc := &corev1.PersistentVolumeClaim{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{
+					Namespace: cluster.Namespace,
+					Name:      pickedProcessGroup.GetPvcName(cluster),
+				}, pvc)).To(Succeed())
+				pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+					{
+						Type:   corev1.PersistentVolumeClaimFileSystemResizePending,
+						Status: corev1.ConditionTrue,
+					},
+				}
+				Expect(k8sClient.Status().Update(context.TODO(), pvc)).To(Succeed())
+			})
+
+			It("should get a FileSystemResizePending condition assigned", func() {
+				Expect(
+					validateProcessGroups(
+						context.TODO(),
+						clusterReconciler,
+						cluster,
+						&cluster.Status,
+						processMap,
+						configMap,
+						logger,
+						"",
+					),
+				).NotTo(HaveOccurred())
+				Expect(
+					fdbv1beta2.FilterByCondition(
+						cluster.Status.ProcessGroups,
+						fdbv1beta2.FileSystemResizePending,
+						false,
+					),
+				).To(ConsistOf(pickedProcessGroup.ProcessGroupID))
+			})
+		})
+
 		When("the pod has the wrong config map hash", func() {
 			When("an annotation is missing", func() {
 				BeforeEach(func() {
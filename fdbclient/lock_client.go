@@ -49,6 +49,8 @@ type realLockClient struct {
 	log logr.Logger
 }
 
+var _ fdbadminclient.LockHolderReader = (*realLockClient)(nil)
+
 // Disabled determines if the client should automatically grant locks.
 func (client *realLockClient) Disabled() bool {
 	return client.disableLocks
@@ -404,6 +406,68 @@ func (client *realLockClient) ReleaseLock() error {
 	return err
 }
 
+// GetLockHolder returns the current holder of the lock. If no lock is present nil will be returned.
+func (client *realLockClient) GetLockHolder() (*fdbadminclient.LockHolder, error) {
+	if client.disableLocks {
+		return nil, nil
+	}
+
+	lockKey := fdb.Key(fmt.Sprintf("%s/global", client.cluster.GetLockPrefix()))
+	holder, err := client.database.ReadTransact(
+		func(transaction fdb.ReadTransaction) (interface{}, error) {
+			err := transaction.Options().SetReadSystemKeys()
+			if err != nil {
+				return nil, err
+			}
+
+			lockValue := transaction.Get(lockKey).MustGet()
+			if len(lockValue) == 0 {
+				return nil, nil
+			}
+
+			lockTuple, err := tuple.Unpack(lockValue)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(lockTuple) < 3 {
+				return nil, invalidLockValue{key: lockKey, value: lockValue}
+			}
+
+			currentLockOwnerID, valid := lockTuple[0].(string)
+			if !valid {
+				return nil, invalidLockValue{key: lockKey, value: lockValue}
+			}
+
+			currentLockStartTimestamp, valid := lockTuple[1].(int64)
+			if !valid {
+				return nil, invalidLockValue{key: lockKey, value: lockValue}
+			}
+
+			currentLockEndTimestamp, valid := lockTuple[2].(int64)
+			if !valid {
+				return nil, invalidLockValue{key: lockKey, value: lockValue}
+			}
+
+			return &fdbadminclient.LockHolder{
+				OwnerID: currentLockOwnerID,
+				Start:   time.Unix(currentLockStartTimestamp, 0),
+				End:     time.Unix(currentLockEndTimestamp, 0),
+			}, nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if holder == nil {
+		return nil, nil
+	}
+
+	return holder.(*fdbadminclient.LockHolder), nil
+}
+
 // invalidLockValue is an error we can return when we cannot parse the existing
 // values in the locking system.
 type invalidLockValue struct {
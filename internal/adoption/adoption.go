@@ -0,0 +1,132 @@
+/*
+ * adoption.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adoption
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+)
+
+// GetProcessGroupIDs returns the IDs of all process groups that are managed by the operator.
+func GetProcessGroupIDs(
+	cluster *fdbv1beta2.FoundationDBCluster,
+) map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None {
+	processGroupIDs := make(
+		map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None,
+		len(cluster.Status.ProcessGroups),
+	)
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		processGroupIDs[processGroup.ProcessGroupID] = fdbv1beta2.None{}
+	}
+
+	return processGroupIDs
+}
+
+// IsForeignProcess returns true if the process is not managed by the operator. A process is managed by the operator
+// if the instance ID locality matches the ID of a process group. Tester processes are never reported as foreign
+// processes.
+func IsForeignProcess(
+	processGroupIDs map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None,
+	process fdbv1beta2.FoundationDBStatusProcessInfo,
+) bool {
+	if process.ProcessClass == fdbv1beta2.ProcessClassTest {
+		return false
+	}
+
+	_, ok := processGroupIDs[fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])]
+
+	return !ok
+}
+
+// GetForeignProcesses returns all processes from the machine-readable status that are not managed by the operator.
+func GetForeignProcesses(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+) []fdbv1beta2.FoundationDBStatusProcessInfo {
+	processGroupIDs := GetProcessGroupIDs(cluster)
+	foreignProcesses := make([]fdbv1beta2.FoundationDBStatusProcessInfo, 0)
+	for _, process := range status.Cluster.Processes {
+		if !IsForeignProcess(processGroupIDs, process) {
+			continue
+		}
+
+		foreignProcesses = append(foreignProcesses, process)
+	}
+
+	return foreignProcesses
+}
+
+// IsCoordinator returns true if the process has the coordinator role.
+func IsCoordinator(process fdbv1beta2.FoundationDBStatusProcessInfo) bool {
+	for _, role := range process.Roles {
+		if role.Role == string(fdbv1beta2.ProcessRoleCoordinator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsFullyExcluded returns true if the process is excluded and has no roles assigned anymore.
+func IsFullyExcluded(process fdbv1beta2.FoundationDBStatusProcessInfo) bool {
+	return process.Excluded && len(process.Roles) == 0
+}
+
+// GetExclusionAddress returns the address that should be used to exclude the foreign process. Foreign processes
+// are always excluded by their address, as the localities might not be set.
+func GetExclusionAddress(
+	process fdbv1beta2.FoundationDBStatusProcessInfo,
+) fdbv1beta2.ProcessAddress {
+	return fdbv1beta2.ProcessAddress{
+		IPAddress:     process.Address.IPAddress,
+		StringAddress: process.Address.StringAddress,
+		Port:          process.Address.Port,
+	}
+}
+
+// GetStatus returns the adoption status based on the machine-readable status. If the cluster is not adopting an
+// existing cluster, nil will be returned.
+func GetStatus(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+) *fdbv1beta2.AdoptionStatus {
+	if !cluster.IsAdopting() {
+		return nil
+	}
+
+	adoptionStatus := &fdbv1beta2.AdoptionStatus{}
+	for _, process := range GetForeignProcesses(cluster, status) {
+		adoptionStatus.ForeignProcesses++
+
+		if IsCoordinator(process) {
+			adoptionStatus.ForeignCoordinators++
+		}
+
+		if process.Excluded {
+			adoptionStatus.ExcludedForeignProcesses++
+		}
+
+		if IsFullyExcluded(process) {
+			adoptionStatus.FullyExcludedForeignProcesses++
+		}
+	}
+
+	return adoptionStatus
+}
@@ -0,0 +1,118 @@
+/*
+ * adoption_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adoption
+
+import (
+	"net"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("adoption", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+
+	BeforeEach(func() {
+		cluster = &fdbv1beta2.FoundationDBCluster{
+			Spec: fdbv1beta2.FoundationDBClusterSpec{
+				Adoption: &fdbv1beta2.AdoptionConfig{},
+			},
+			Status: fdbv1beta2.FoundationDBClusterStatus{
+				ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{
+					{ProcessGroupID: "storage-1", ProcessClass: fdbv1beta2.ProcessClassStorage},
+				},
+			},
+		}
+
+		status = &fdbv1beta2.FoundationDBStatus{
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+					"1": {
+						ProcessClass: fdbv1beta2.ProcessClassStorage,
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+						},
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{Role: string(fdbv1beta2.ProcessRoleCoordinator)},
+						},
+					},
+					"2": {
+						ProcessClass: fdbv1beta2.ProcessClassStorage,
+						Address: fdbv1beta2.ProcessAddress{
+							IPAddress: net.ParseIP("192.168.0.2"),
+							Port:      4500,
+							Flags:     map[string]bool{"tls": true},
+						},
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{Role: string(fdbv1beta2.ProcessRoleCoordinator)},
+							{Role: string(fdbv1beta2.ProcessRoleStorage)},
+						},
+					},
+					"3": {
+						ProcessClass: fdbv1beta2.ProcessClassLog,
+						Excluded:     true,
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityInstanceIDKey: "vm-log-1",
+						},
+					},
+					"4": {
+						ProcessClass: fdbv1beta2.ProcessClassTest,
+					},
+				},
+			},
+		}
+	})
+
+	It("should return the foreign processes", func() {
+		foreignProcesses := GetForeignProcesses(cluster, status)
+		Expect(foreignProcesses).To(ConsistOf(
+			status.Cluster.Processes["2"],
+			status.Cluster.Processes["3"],
+		))
+	})
+
+	It("should return the adoption status", func() {
+		Expect(GetStatus(cluster, status)).To(Equal(&fdbv1beta2.AdoptionStatus{
+			ForeignProcesses:              2,
+			ForeignCoordinators:           1,
+			ExcludedForeignProcesses:      1,
+			FullyExcludedForeignProcesses: 1,
+		}))
+	})
+
+	It("should return the exclusion address without flags", func() {
+		Expect(
+			GetExclusionAddress(status.Cluster.Processes["2"]).String(),
+		).To(Equal("192.168.0.2:4500"))
+	})
+
+	When("the cluster is not adopting an existing cluster", func() {
+		BeforeEach(func() {
+			cluster.Spec.Adoption = nil
+		})
+
+		It("should not return a status", func() {
+			Expect(GetStatus(cluster, status)).To(BeNil())
+		})
+	})
+})
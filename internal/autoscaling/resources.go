@@ -0,0 +1,134 @@
+/*
+ * resources.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package autoscaling
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+)
+
+// GetResourceUsageSamples returns the highest CPU and memory usage of a single Pod for each process class based on
+// the CPU and memory statistics reported by the processes. The usage of processes running in the same Pod is summed
+// up, the Pod of a process is identified by the instance_id locality.
+func GetResourceUsageSamples(
+	status *fdbv1beta2.FoundationDBStatus,
+) map[fdbv1beta2.ProcessClass]fdbv1beta2.ResourceUsageSample {
+	samples := map[fdbv1beta2.ProcessClass]fdbv1beta2.ResourceUsageSample{}
+	if status == nil {
+		return samples
+	}
+
+	podUsage := map[string]fdbv1beta2.ResourceUsageSample{}
+	podClass := map[string]fdbv1beta2.ProcessClass{}
+	for _, process := range status.Cluster.Processes {
+		instanceID, ok := process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]
+		if !ok || process.ProcessClass == "" {
+			continue
+		}
+
+		usage := podUsage[instanceID]
+		usage.CPUMilliCores += int64(math.Ceil(process.CPU.UsageCores * 1000))
+		usage.MemoryBytes += process.Memory.UsedBytes
+		podUsage[instanceID] = usage
+		podClass[instanceID] = process.ProcessClass
+	}
+
+	for instanceID, usage := range podUsage {
+		processClass := podClass[instanceID]
+		sample := samples[processClass]
+		sample.CPUMilliCores = max(sample.CPUMilliCores, usage.CPUMilliCores)
+		sample.MemoryBytes = max(sample.MemoryBytes, usage.MemoryBytes)
+		samples[processClass] = sample
+	}
+
+	return samples
+}
+
+// GetResourceRecommendationStatus returns the updated resource recommendation status with the samples of the provided
+// status added. If the sample interval has not passed since the last sample, nil will be returned. Process classes
+// that are not reported in the provided status keep their previous samples.
+func GetResourceRecommendationStatus(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	now time.Time,
+) *fdbv1beta2.ResourceRecommendationStatus {
+	currentStatus := cluster.GetResourceRecommendationStatus()
+	if currentStatus != nil && currentStatus.LastSampleTimestamp != nil {
+		interval := time.Duration(
+			cluster.GetResourceRecommendationSampleIntervalSeconds(),
+		) * time.Second
+		if now.Sub(currentStatus.LastSampleTimestamp.Time) < interval {
+			return nil
+		}
+	}
+
+	samples := GetResourceUsageSamples(status)
+	if len(samples) == 0 {
+		return nil
+	}
+
+	recommendationStatus := &fdbv1beta2.ResourceRecommendationStatus{}
+	if currentStatus != nil {
+		recommendationStatus = currentStatus.DeepCopy()
+	}
+	recommendationStatus.LastSampleTimestamp = &metav1.Time{Time: now}
+
+	maxSamples := cluster.GetResourceRecommendationMaxSamples()
+	for processClass, sample := range samples {
+		idx := slices.IndexFunc(
+			recommendationStatus.ProcessClasses,
+			func(recommendation fdbv1beta2.ProcessClassResourceRecommendation) bool {
+				return recommendation.ProcessClass == processClass
+			},
+		)
+		if idx < 0 {
+			recommendationStatus.ProcessClasses = append(
+				recommendationStatus.ProcessClasses,
+				fdbv1beta2.ProcessClassResourceRecommendation{ProcessClass: processClass},
+			)
+			idx = len(recommendationStatus.ProcessClasses) - 1
+		}
+
+		recommendationStatus.ProcessClasses[idx].AddSample(sample, maxSamples)
+	}
+
+	for idx := range recommendationStatus.ProcessClasses {
+		recommendationStatus.ProcessClasses[idx].UpdateRecommendation(
+			cluster.GetResourceRecommendationRequestPercentile(),
+			cluster.GetResourceRecommendationHeadroomPercentage(),
+		)
+	}
+
+	slices.SortFunc(
+		recommendationStatus.ProcessClasses,
+		func(a, b fdbv1beta2.ProcessClassResourceRecommendation) int {
+			return strings.Compare(string(a.ProcessClass), string(b.ProcessClass))
+		},
+	)
+
+	return recommendationStatus
+}
@@ -0,0 +1,202 @@
+/*
+ * resources_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package autoscaling
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// createProcessWithUsage creates a process with the provided process class, instance ID and resource usage.
+func createProcessWithUsage(
+	processClass fdbv1beta2.ProcessClass,
+	instanceID string,
+	cpuCores float64,
+	memoryBytes int64,
+) fdbv1beta2.FoundationDBStatusProcessInfo {
+	return fdbv1beta2.FoundationDBStatusProcessInfo{
+		ProcessClass: processClass,
+		Locality: map[string]string{
+			fdbv1beta2.FDBLocalityInstanceIDKey: instanceID,
+		},
+		CPU: fdbv1beta2.FoundationDBStatusProcessCPU{
+			UsageCores: cpuCores,
+		},
+		Memory: fdbv1beta2.FoundationDBStatusProcessMemory{
+			UsedBytes: memoryBytes,
+		},
+	}
+}
+
+var _ = Describe("resource recommendations", func() {
+	var status *fdbv1beta2.FoundationDBStatus
+
+	BeforeEach(func() {
+		status = &fdbv1beta2.FoundationDBStatus{
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+					"storage-1-1": createProcessWithUsage(
+						fdbv1beta2.ProcessClassStorage,
+						"storage-1",
+						0.5,
+						1024,
+					),
+					"storage-1-2": createProcessWithUsage(
+						fdbv1beta2.ProcessClassStorage,
+						"storage-1",
+						0.25,
+						2048,
+					),
+					"storage-2-1": createProcessWithUsage(
+						fdbv1beta2.ProcessClassStorage,
+						"storage-2",
+						1.0,
+						1024,
+					),
+					"log-1": createProcessWithUsage(fdbv1beta2.ProcessClassLog, "log-1", 0.1, 4096),
+					"unknown": {
+						ProcessClass: fdbv1beta2.ProcessClassLog,
+						CPU: fdbv1beta2.FoundationDBStatusProcessCPU{
+							UsageCores: 10,
+						},
+					},
+				},
+			},
+		}
+	})
+
+	When("getting the resource usage samples", func() {
+		It("should return the highest usage of a single Pod per process class", func() {
+			samples := GetResourceUsageSamples(status)
+			Expect(samples).To(HaveLen(2))
+			Expect(samples).To(HaveKeyWithValue(
+				fdbv1beta2.ProcessClassStorage,
+				fdbv1beta2.ResourceUsageSample{CPUMilliCores: 1000, MemoryBytes: 3072},
+			))
+			Expect(samples).To(HaveKeyWithValue(
+				fdbv1beta2.ProcessClassLog,
+				fdbv1beta2.ResourceUsageSample{CPUMilliCores: 100, MemoryBytes: 4096},
+			))
+		})
+
+		It("should return no samples if no status is provided", func() {
+			Expect(GetResourceUsageSamples(nil)).To(BeEmpty())
+		})
+	})
+
+	When("getting the resource recommendation status", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+			cluster = internal.CreateDefaultCluster()
+			cluster.Spec.Autoscaling.Resources = &fdbv1beta2.ResourceRecommendationPolicy{
+				Enabled:    pointer.Bool(true),
+				MaxSamples: pointer.Int(2),
+			}
+		})
+
+		It("should add the samples and the recommendations sorted by process class", func() {
+			recommendationStatus := GetResourceRecommendationStatus(cluster, status, now)
+			Expect(recommendationStatus).NotTo(BeNil())
+			Expect(recommendationStatus.LastSampleTimestamp.Time).To(Equal(now))
+			Expect(recommendationStatus.ProcessClasses).To(HaveLen(2))
+			Expect(
+				recommendationStatus.ProcessClasses[0].ProcessClass,
+			).To(Equal(fdbv1beta2.ProcessClassLog))
+			Expect(
+				recommendationStatus.ProcessClasses[1].ProcessClass,
+			).To(Equal(fdbv1beta2.ProcessClassStorage))
+			Expect(recommendationStatus.ProcessClasses[1].Samples).To(HaveLen(1))
+			Expect(
+				recommendationStatus.ProcessClasses[1].Requests.Cpu().MilliValue(),
+			).To(BeNumerically("==", 1200))
+		})
+
+		When("the last sample was collected recently", func() {
+			BeforeEach(func() {
+				cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{
+					Resources: &fdbv1beta2.ResourceRecommendationStatus{
+						LastSampleTimestamp: &metav1.Time{Time: now.Add(-1 * time.Minute)},
+					},
+				}
+			})
+
+			It("should not collect a new sample", func() {
+				Expect(GetResourceRecommendationStatus(cluster, status, now)).To(BeNil())
+			})
+		})
+
+		When("the maximum number of samples is reached", func() {
+			BeforeEach(func() {
+				cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{
+					Resources: &fdbv1beta2.ResourceRecommendationStatus{
+						LastSampleTimestamp: &metav1.Time{Time: now.Add(-1 * time.Hour)},
+						ProcessClasses: []fdbv1beta2.ProcessClassResourceRecommendation{
+							{
+								ProcessClass: fdbv1beta2.ProcessClassStorage,
+								Samples: []fdbv1beta2.ResourceUsageSample{
+									{CPUMilliCores: 4000, MemoryBytes: 1024},
+									{CPUMilliCores: 2000, MemoryBytes: 1024},
+								},
+							},
+							{
+								ProcessClass: fdbv1beta2.ProcessClassStateless,
+								Samples: []fdbv1beta2.ResourceUsageSample{
+									{CPUMilliCores: 500, MemoryBytes: 1024},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It(
+				"should drop the oldest sample and keep the samples of other process classes",
+				func() {
+					recommendationStatus := GetResourceRecommendationStatus(cluster, status, now)
+					Expect(recommendationStatus).NotTo(BeNil())
+					Expect(recommendationStatus.ProcessClasses).To(HaveLen(3))
+
+					storage := recommendationStatus.ProcessClasses[2]
+					Expect(storage.ProcessClass).To(Equal(fdbv1beta2.ProcessClassStorage))
+					Expect(storage.Samples).To(Equal([]fdbv1beta2.ResourceUsageSample{
+						{CPUMilliCores: 2000, MemoryBytes: 1024},
+						{CPUMilliCores: 1000, MemoryBytes: 3072},
+					}))
+					Expect(storage.Limits.Cpu().MilliValue()).To(BeNumerically("==", 2400))
+
+					stateless := recommendationStatus.ProcessClasses[1]
+					Expect(stateless.ProcessClass).To(Equal(fdbv1beta2.ProcessClassStateless))
+					Expect(stateless.Samples).To(HaveLen(1))
+					Expect(stateless.Requests.Cpu().MilliValue()).To(BeNumerically("==", 600))
+				},
+			)
+		})
+	})
+})
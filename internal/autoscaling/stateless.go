@@ -0,0 +1,232 @@
+/*
+ * stateless.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package autoscaling
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+)
+
+// limitingReasonWorkload is the limiting reason reported by the ratekeeper if the cluster is not saturated.
+const limitingReasonWorkload = "workload"
+
+// StatelessScalingDecision represents a scaling decision of the stateless autoscaling.
+type StatelessScalingDecision struct {
+	// CommitProxies defines the desired number of commit proxies.
+	CommitProxies int
+	// GrvProxies defines the desired number of GRV proxies.
+	GrvProxies int
+	// Resolvers defines the desired number of resolvers.
+	Resolvers int
+	// Message describes the scaling decision.
+	Message string
+}
+
+// StatelessSignals contains the information from the machine-readable status that is used by the stateless
+// autoscaling.
+type StatelessSignals struct {
+	// CommitLatencyMilliseconds defines the commit latency reported by the latency probe.
+	CommitLatencyMilliseconds int
+	// GRVLatencyMilliseconds defines the transaction start latency reported by the latency probe.
+	GRVLatencyMilliseconds int
+	// StartedTransactionsPerSecond defines the rate of started transactions.
+	StartedTransactionsPerSecond int
+	// CommittedTransactionsPerSecond defines the rate of committed transactions.
+	CommittedTransactionsPerSecond int
+	// LimitingReason defines the reason the ratekeeper limits the transaction rate.
+	LimitingReason string
+}
+
+// GetStatelessSignals returns the information from the machine-readable status that is used by the stateless
+// autoscaling. If the status contains no latency probe information, false will be returned.
+func GetStatelessSignals(status *fdbv1beta2.FoundationDBStatus) (StatelessSignals, bool) {
+	if status == nil {
+		return StatelessSignals{}, false
+	}
+
+	latencyProbe := status.Cluster.LatencyProbe
+	if latencyProbe.CommitSeconds <= 0 && latencyProbe.TransactionStartSeconds <= 0 {
+		return StatelessSignals{}, false
+	}
+
+	return StatelessSignals{
+		CommitLatencyMilliseconds:      int(latencyProbe.CommitSeconds * 1000),
+		GRVLatencyMilliseconds:         int(latencyProbe.TransactionStartSeconds * 1000),
+		StartedTransactionsPerSecond:   int(status.Cluster.Workload.Transactions.Started.Hz),
+		CommittedTransactionsPerSecond: int(status.Cluster.Workload.Transactions.Committed.Hz),
+		LimitingReason:                 status.Cluster.Qos.PerformanceLimitedBy.Name,
+	}, true
+}
+
+// GetStatelessAutoscalingBounds returns the minimum and maximum role counts for the stateless autoscaling. The
+// minimum defaults to the role counts without autoscaling.
+func GetStatelessAutoscalingBounds(
+	cluster *fdbv1beta2.FoundationDBCluster,
+) (fdbv1beta2.RoleCounts, fdbv1beta2.RoleCounts) {
+	return cluster.GetStatelessAutoscalingBounds(
+		cluster.Spec.DatabaseConfiguration.GetRoleCountsWithDefaults(
+			cluster.DesiredFaultTolerance(),
+		),
+	)
+}
+
+// GetStatelessScalingDecision returns the scaling decision for the commit proxies, GRV proxies and resolvers based on
+// the latency probe and the transaction rates reported in the provided status. The currentRoleCounts define the
+// current desired role counts. If no scaling is required, the last scaling decision is still in the cooldown period
+// or the ratekeeper limits the transaction rate for a reason other than the workload, nil will be returned.
+func GetStatelessScalingDecision(
+	logger logr.Logger,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	currentRoleCounts fdbv1beta2.RoleCounts,
+	now time.Time,
+) *StatelessScalingDecision {
+	signals, ok := GetStatelessSignals(status)
+	if !ok {
+		logger.V(1).Info("No latency probe information available for stateless autoscaling")
+		return nil
+	}
+
+	autoscalingStatus := cluster.GetStatelessAutoscalingStatus()
+	if autoscalingStatus != nil && autoscalingStatus.LastScalingTimestamp != nil {
+		cooldown := time.Duration(cluster.GetStatelessAutoscalingCooldownSeconds()) * time.Second
+		if now.Before(autoscalingStatus.LastScalingTimestamp.Add(cooldown)) {
+			logger.V(1).Info(
+				"Stateless autoscaling is in cooldown",
+				"lastScalingTimestamp",
+				autoscalingStatus.LastScalingTimestamp.String(),
+			)
+			return nil
+		}
+	}
+
+	// If the ratekeeper limits the transaction rate because of the storage or log servers, changing the stateless
+	// roles will not help and would only cause additional recoveries.
+	if signals.LimitingReason != "" && signals.LimitingReason != limitingReasonWorkload {
+		logger.Info(
+			"Skipping stateless autoscaling as the ratekeeper limits the transaction rate",
+			"limitingReason",
+			signals.LimitingReason,
+		)
+		return nil
+	}
+
+	minimum, maximum := GetStatelessAutoscalingBounds(cluster)
+	targetCommitLatency := cluster.GetStatelessAutoscalingTargetCommitLatencyMilliseconds()
+	targetGRVLatency := cluster.GetStatelessAutoscalingTargetGRVLatencyMilliseconds()
+	transactionsPerProxy := cluster.GetStatelessAutoscalingTargetTransactionsPerSecondPerProxy()
+	scaleDownPercentage := cluster.GetStatelessAutoscalingScaleDownLatencyPercentage()
+
+	logger.V(1).Info(
+		"Checking stateless autoscaling",
+		"commitLatencyMilliseconds",
+		signals.CommitLatencyMilliseconds,
+		"grvLatencyMilliseconds",
+		signals.GRVLatencyMilliseconds,
+		"startedTransactionsPerSecond",
+		signals.StartedTransactionsPerSecond,
+		"committedTransactionsPerSecond",
+		signals.CommittedTransactionsPerSecond,
+	)
+
+	decision := &StatelessScalingDecision{
+		CommitProxies: currentRoleCounts.CommitProxies,
+		GrvProxies:    currentRoleCounts.GrvProxies,
+		Resolvers:     currentRoleCounts.Resolvers,
+	}
+	var messages []string
+
+	if signals.CommitLatencyMilliseconds > targetCommitLatency {
+		reason := fmt.Sprintf(
+			"commit latency of %dms is above the target of %dms",
+			signals.CommitLatencyMilliseconds,
+			targetCommitLatency,
+		)
+		if decision.CommitProxies < maximum.CommitProxies {
+			decision.CommitProxies++
+			messages = append(
+				messages,
+				fmt.Sprintf("%s, scaling up the commit proxies from %d to %d",
+					reason, currentRoleCounts.CommitProxies, decision.CommitProxies),
+			)
+		} else if decision.Resolvers < maximum.Resolvers {
+			decision.Resolvers++
+			messages = append(messages, fmt.Sprintf("%s, scaling up the resolvers from %d to %d",
+				reason, currentRoleCounts.Resolvers, decision.Resolvers))
+		}
+	} else if signals.CommitLatencyMilliseconds*100 < targetCommitLatency*scaleDownPercentage {
+		reason := fmt.Sprintf(
+			"commit latency of %dms is below %d%% of the target of %dms",
+			signals.CommitLatencyMilliseconds,
+			scaleDownPercentage,
+			targetCommitLatency,
+		)
+		// The resolvers are only added once the maximum of commit proxies is reached, so they will be removed first.
+		if decision.Resolvers > minimum.Resolvers {
+			decision.Resolvers--
+			messages = append(messages, fmt.Sprintf("%s, scaling down the resolvers from %d to %d",
+				reason, currentRoleCounts.Resolvers, decision.Resolvers))
+		} else if decision.CommitProxies > minimum.CommitProxies &&
+			signals.CommittedTransactionsPerSecond < transactionsPerProxy*(decision.CommitProxies-1) {
+			decision.CommitProxies--
+			messages = append(messages, fmt.Sprintf("%s, scaling down the commit proxies from %d to %d",
+				reason, currentRoleCounts.CommitProxies, decision.CommitProxies))
+		}
+	}
+
+	if signals.GRVLatencyMilliseconds > targetGRVLatency {
+		if decision.GrvProxies < maximum.GrvProxies {
+			decision.GrvProxies++
+			messages = append(messages, fmt.Sprintf(
+				"transaction start latency of %dms is above the target of %dms, scaling up the GRV proxies from %d to %d",
+				signals.GRVLatencyMilliseconds,
+				targetGRVLatency,
+				currentRoleCounts.GrvProxies,
+				decision.GrvProxies,
+			))
+		}
+	} else if signals.GRVLatencyMilliseconds*100 < targetGRVLatency*scaleDownPercentage &&
+		decision.GrvProxies > minimum.GrvProxies &&
+		signals.StartedTransactionsPerSecond < transactionsPerProxy*(decision.GrvProxies-1) {
+		decision.GrvProxies--
+		messages = append(messages, fmt.Sprintf(
+			"transaction start latency of %dms is below %d%% of the target of %dms, scaling down the GRV proxies from %d to %d",
+			signals.GRVLatencyMilliseconds,
+			scaleDownPercentage,
+			targetGRVLatency,
+			currentRoleCounts.GrvProxies,
+			decision.GrvProxies,
+		))
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	decision.Message = strings.Join(messages, "; ")
+
+	return decision
+}
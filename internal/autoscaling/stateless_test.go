@@ -0,0 +1,244 @@
+/*
+ * stateless_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package autoscaling
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// createStatusWithLatencies creates a machine-readable status with the provided latencies in milliseconds and the
+// provided transaction rate.
+func createStatusWithLatencies(
+	commitLatency float64,
+	grvLatency float64,
+	transactionsPerSecond float64,
+) *fdbv1beta2.FoundationDBStatus {
+	return &fdbv1beta2.FoundationDBStatus{
+		Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+			LatencyProbe: fdbv1beta2.FoundationDBStatusLatencyProbe{
+				CommitSeconds:           commitLatency / 1000,
+				TransactionStartSeconds: grvLatency / 1000,
+			},
+			Workload: fdbv1beta2.FoundationDBStatusWorkload{
+				Transactions: fdbv1beta2.FoundationDBStatusWorkloadTransactions{
+					Started:   fdbv1beta2.FoundationDBStatusRate{Hz: transactionsPerSecond},
+					Committed: fdbv1beta2.FoundationDBStatusRate{Hz: transactionsPerSecond},
+				},
+			},
+			Qos: fdbv1beta2.FoundationDBStatusQosInfo{
+				PerformanceLimitedBy: fdbv1beta2.FoundationDBStatusPerformanceLimitedBy{
+					Name: "workload",
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("stateless autoscaling", func() {
+	When("getting the stateless signals", func() {
+		It("should return false if no latency probe information is present", func() {
+			_, ok := GetStatelessSignals(&fdbv1beta2.FoundationDBStatus{})
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should return the signals from the status", func() {
+			signals, ok := GetStatelessSignals(createStatusWithLatencies(25, 5, 1000))
+			Expect(ok).To(BeTrue())
+			Expect(signals.CommitLatencyMilliseconds).To(Equal(25))
+			Expect(signals.GRVLatencyMilliseconds).To(Equal(5))
+			Expect(signals.StartedTransactionsPerSecond).To(Equal(1000))
+			Expect(signals.CommittedTransactionsPerSecond).To(Equal(1000))
+			Expect(signals.LimitingReason).To(Equal("workload"))
+		})
+	})
+
+	When("getting the scaling decision", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var status *fdbv1beta2.FoundationDBStatus
+		var decision *StatelessScalingDecision
+		var currentRoleCounts fdbv1beta2.RoleCounts
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			cluster.Spec.DatabaseConfiguration.CommitProxies = 2
+			cluster.Spec.DatabaseConfiguration.GrvProxies = 1
+			cluster.Spec.Autoscaling.Stateless = &fdbv1beta2.StatelessAutoscalingPolicy{
+				Enabled: pointer.Bool(true),
+				CommitProxies: &fdbv1beta2.RoleAutoscalingBounds{
+					Min: pointer.Int(2),
+					Max: pointer.Int(4),
+				},
+				GrvProxies: &fdbv1beta2.RoleAutoscalingBounds{
+					Min: pointer.Int(1),
+					Max: pointer.Int(3),
+				},
+				Resolvers: &fdbv1beta2.RoleAutoscalingBounds{
+					Min: pointer.Int(1),
+					Max: pointer.Int(2),
+				},
+			}
+			currentRoleCounts = cluster.GetRoleCountsWithDefaults()
+		})
+
+		JustBeforeEach(func() {
+			decision = GetStatelessScalingDecision(
+				logr.Discard(),
+				cluster,
+				status,
+				currentRoleCounts,
+				time.Now(),
+			)
+		})
+
+		When("no latency probe information is present", func() {
+			BeforeEach(func() {
+				status = &fdbv1beta2.FoundationDBStatus{}
+			})
+
+			It("should not make a decision", func() {
+				Expect(decision).To(BeNil())
+			})
+		})
+
+		When("the latencies are within the targets", func() {
+			BeforeEach(func() {
+				status = createStatusWithLatencies(40, 8, 1000)
+			})
+
+			It("should not make a decision", func() {
+				Expect(decision).To(BeNil())
+			})
+		})
+
+		When("the commit latency is above the target", func() {
+			BeforeEach(func() {
+				status = createStatusWithLatencies(80, 8, 1000)
+			})
+
+			It("should add a commit proxy", func() {
+				Expect(decision).NotTo(BeNil())
+				Expect(decision.CommitProxies).To(Equal(3))
+				Expect(decision.GrvProxies).To(Equal(1))
+				Expect(decision.Resolvers).To(Equal(1))
+			})
+
+			When("the maximum of commit proxies is reached", func() {
+				BeforeEach(func() {
+					currentRoleCounts.CommitProxies = 4
+				})
+
+				It("should add a resolver", func() {
+					Expect(decision).NotTo(BeNil())
+					Expect(decision.CommitProxies).To(Equal(4))
+					Expect(decision.Resolvers).To(Equal(2))
+				})
+			})
+
+			When(
+				"the ratekeeper limits the transaction rate because of the storage servers",
+				func() {
+					BeforeEach(func() {
+						status.Cluster.Qos.PerformanceLimitedBy.Name = "storage_server_write_queue_size"
+					})
+
+					It("should not make a decision", func() {
+						Expect(decision).To(BeNil())
+					})
+				},
+			)
+
+			When("the last scaling decision is in the cooldown period", func() {
+				BeforeEach(func() {
+					cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{
+						Stateless: &fdbv1beta2.StatelessAutoscalingStatus{
+							LastScalingTimestamp: &metav1.Time{
+								Time: time.Now().Add(-1 * time.Minute),
+							},
+						},
+					}
+				})
+
+				It("should not make a decision", func() {
+					Expect(decision).To(BeNil())
+				})
+			})
+		})
+
+		When("the GRV latency is above the target", func() {
+			BeforeEach(func() {
+				status = createStatusWithLatencies(40, 20, 1000)
+			})
+
+			It("should add a GRV proxy", func() {
+				Expect(decision).NotTo(BeNil())
+				Expect(decision.CommitProxies).To(Equal(2))
+				Expect(decision.GrvProxies).To(Equal(2))
+			})
+		})
+
+		When("the latencies are below the scale down threshold", func() {
+			BeforeEach(func() {
+				status = createStatusWithLatencies(10, 2, 1000)
+				currentRoleCounts.CommitProxies = 3
+				currentRoleCounts.GrvProxies = 2
+				currentRoleCounts.Resolvers = 2
+			})
+
+			It("should remove a resolver and a GRV proxy", func() {
+				Expect(decision).NotTo(BeNil())
+				Expect(decision.CommitProxies).To(Equal(3))
+				Expect(decision.GrvProxies).To(Equal(1))
+				Expect(decision.Resolvers).To(Equal(1))
+			})
+
+			When("the resolvers are at the minimum", func() {
+				BeforeEach(func() {
+					currentRoleCounts.Resolvers = 1
+				})
+
+				It("should remove a commit proxy", func() {
+					Expect(decision).NotTo(BeNil())
+					Expect(decision.CommitProxies).To(Equal(2))
+					Expect(decision.Resolvers).To(Equal(1))
+				})
+			})
+
+			When("the remaining proxies would handle too many transactions", func() {
+				BeforeEach(func() {
+					status = createStatusWithLatencies(10, 2, 25000)
+					currentRoleCounts.Resolvers = 1
+				})
+
+				It("should not make a decision", func() {
+					Expect(decision).To(BeNil())
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,217 @@
+/*
+ * storage.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package autoscaling
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+)
+
+// StorageScalingDecision represents a scaling decision of the storage autoscaling.
+type StorageScalingDecision struct {
+	// ProcessGroups defines the desired number of storage process groups.
+	ProcessGroups int
+	// VolumeSize defines the desired size of the storage volumes, if nil the volume size is unchanged.
+	VolumeSize *resource.Quantity
+	// Message describes the scaling decision.
+	Message string
+}
+
+// GetStorageDiskUsagePercentage returns the percentage of used disk space of all storage processes based on the
+// kvstore_used_bytes and kvstore_available_bytes reported by the storage roles. The usable capacity of a storage
+// process is the sum of both values, as the available bytes include the free space and the unused written space. If
+// no storage role reports those values, false will be returned.
+func GetStorageDiskUsagePercentage(status *fdbv1beta2.FoundationDBStatus) (int, bool) {
+	if status == nil {
+		return 0, false
+	}
+
+	var usedBytes, availableBytes int64
+	for _, process := range status.Cluster.Processes {
+		for _, role := range process.Roles {
+			if role.Role != string(fdbv1beta2.ProcessRoleStorage) {
+				continue
+			}
+
+			if role.KVStoreUsedBytes == nil || role.KVStoreAvailableBytes == nil {
+				continue
+			}
+
+			usedBytes += *role.KVStoreUsedBytes
+			availableBytes += *role.KVStoreAvailableBytes
+		}
+	}
+
+	if usedBytes+availableBytes <= 0 {
+		return 0, false
+	}
+
+	return int(usedBytes * 100 / (usedBytes + availableBytes)), true
+}
+
+// GetStorageAutoscalingBounds returns the minimum and maximum number of storage process groups for the storage
+// autoscaling. The minimum defaults to the storage process count without autoscaling.
+func GetStorageAutoscalingBounds(cluster *fdbv1beta2.FoundationDBCluster) (int, int, error) {
+	baseCluster := cluster.DeepCopy()
+	baseCluster.Status.Autoscaling = nil
+	baseCluster.Spec.Autoscaling.Storage = nil
+	processCounts, err := baseCluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	minimum, maximum := cluster.GetStorageAutoscalingBounds(processCounts.Storage)
+
+	return minimum, maximum, nil
+}
+
+// GetStorageScalingDecision returns the scaling decision for the storage processes based on the disk usage reported
+// in the provided status. If no scaling is required or the last scaling decision is still in the cooldown period,
+// nil will be returned. The currentProcessGroups defines the current desired number of storage process groups and
+// the currentVolumeSize the current desired size of the storage volumes. Volumes will only be grown if
+// canExpandVolumes is true.
+func GetStorageScalingDecision(
+	logger logr.Logger,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	currentProcessGroups int,
+	currentVolumeSize resource.Quantity,
+	canExpandVolumes bool,
+	now time.Time,
+) (*StorageScalingDecision, error) {
+	usage, ok := GetStorageDiskUsagePercentage(status)
+	if !ok {
+		logger.V(1).Info("No disk usage information available for storage autoscaling")
+		return nil, nil
+	}
+
+	autoscalingStatus := cluster.GetStorageAutoscalingStatus()
+	if autoscalingStatus != nil && autoscalingStatus.LastScalingTimestamp != nil {
+		cooldown := time.Duration(cluster.GetStorageAutoscalingCooldownSeconds()) * time.Second
+		if now.Before(autoscalingStatus.LastScalingTimestamp.Add(cooldown)) {
+			logger.V(1).Info(
+				"Storage autoscaling is in cooldown",
+				"lastScalingTimestamp",
+				autoscalingStatus.LastScalingTimestamp.String(),
+			)
+			return nil, nil
+		}
+	}
+
+	minimum, maximum, err := GetStorageAutoscalingBounds(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	target := cluster.GetStorageAutoscalingTargetDiskUsagePercentage()
+	step := cluster.GetStorageAutoscalingStepSize()
+	logger.V(1).Info(
+		"Checking storage autoscaling",
+		"usage",
+		usage,
+		"target",
+		target,
+		"currentProcessGroups",
+		currentProcessGroups,
+		"minimum",
+		minimum,
+		"maximum",
+		maximum,
+	)
+
+	if usage > target {
+		maxVolumeSize := cluster.Spec.Autoscaling.Storage.MaxVolumeSize
+		if canExpandVolumes && maxVolumeSize != nil && currentVolumeSize.Cmp(*maxVolumeSize) < 0 {
+			// Grow the volumes to reach the targeted disk usage.
+			desiredBytes := currentVolumeSize.Value()*int64(usage)/int64(target) + 1
+			volumeSize := resource.NewQuantity(desiredBytes, currentVolumeSize.Format)
+			if volumeSize.Cmp(*maxVolumeSize) > 0 {
+				volumeSize = maxVolumeSize
+			}
+
+			return &StorageScalingDecision{
+				ProcessGroups: currentProcessGroups,
+				VolumeSize:    volumeSize,
+				Message: fmt.Sprintf(
+					"disk usage of %d%% is above the target of %d%%, growing the storage volumes from %s to %s",
+					usage,
+					target,
+					currentVolumeSize.String(),
+					volumeSize.String(),
+				),
+			}, nil
+		}
+
+		desired := min(currentProcessGroups+step, maximum)
+		if desired <= currentProcessGroups {
+			logger.Info(
+				"Disk usage is above the target but the maximum number of storage process groups is reached",
+				"usage",
+				usage,
+				"target",
+				target,
+				"maximum",
+				maximum,
+			)
+			return nil, nil
+		}
+
+		return &StorageScalingDecision{
+			ProcessGroups: desired,
+			Message: fmt.Sprintf(
+				"disk usage of %d%% is above the target of %d%%, scaling up the storage process groups from %d to %d",
+				usage,
+				target,
+				currentProcessGroups,
+				desired,
+			),
+		}, nil
+	}
+
+	desired := max(currentProcessGroups-step, minimum)
+	if desired >= currentProcessGroups {
+		return nil, nil
+	}
+
+	// Only scale down if the disk usage after the scale down is still below the target to prevent flapping between
+	// scaling up and scaling down.
+	projectedUsage := (usage*currentProcessGroups + desired - 1) / desired
+	if projectedUsage >= target {
+		return nil, nil
+	}
+
+	return &StorageScalingDecision{
+		ProcessGroups: desired,
+		Message: fmt.Sprintf(
+			"disk usage of %d%% is below the target of %d%% (projected %d%%), scaling down the storage process groups from %d to %d",
+			usage,
+			target,
+			projectedUsage,
+			currentProcessGroups,
+			desired,
+		),
+	}, nil
+}
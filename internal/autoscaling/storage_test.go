@@ -0,0 +1,287 @@
+/*
+ * storage_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package autoscaling
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// createStatusWithDiskUsage creates a machine-readable status with a single storage process with the provided disk
+// usage in percent.
+func createStatusWithDiskUsage(usage int64) *fdbv1beta2.FoundationDBStatus {
+	return &fdbv1beta2.FoundationDBStatus{
+		Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+			Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+				"storage-1": {
+					Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+						{
+							Role:                  string(fdbv1beta2.ProcessRoleStorage),
+							KVStoreUsedBytes:      pointer.Int64(usage),
+							KVStoreAvailableBytes: pointer.Int64(100 - usage),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("storage autoscaling", func() {
+	When("getting the disk usage of the storage processes", func() {
+		DescribeTable("should return the expected disk usage",
+			func(status *fdbv1beta2.FoundationDBStatus, expectedUsage int, expectedOk bool) {
+				usage, ok := GetStorageDiskUsagePercentage(status)
+				Expect(ok).To(Equal(expectedOk))
+				Expect(usage).To(Equal(expectedUsage))
+			},
+			Entry("no status", nil, 0, false),
+			Entry("no storage processes", &fdbv1beta2.FoundationDBStatus{}, 0, false),
+			Entry("a single storage process", createStatusWithDiskUsage(42), 42, true),
+			Entry("multiple storage and log processes",
+				&fdbv1beta2.FoundationDBStatus{
+					Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+						Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+							"storage-1": {
+								Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+									{
+										Role: string(
+											fdbv1beta2.ProcessRoleStorage,
+										),
+										KVStoreUsedBytes:      pointer.Int64(60),
+										KVStoreAvailableBytes: pointer.Int64(40),
+									},
+								},
+							},
+							"storage-2": {
+								Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+									{
+										Role: string(
+											fdbv1beta2.ProcessRoleStorage,
+										),
+										KVStoreUsedBytes:      pointer.Int64(80),
+										KVStoreAvailableBytes: pointer.Int64(20),
+									},
+								},
+							},
+							"log-1": {
+								Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+									{
+										Role:                  string(fdbv1beta2.ProcessRoleLog),
+										KVStoreUsedBytes:      pointer.Int64(0),
+										KVStoreAvailableBytes: pointer.Int64(100),
+									},
+								},
+							},
+						},
+					},
+				}, 70, true),
+			Entry("a storage process that shares the disk with other data",
+				&fdbv1beta2.FoundationDBStatus{
+					Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+						Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+							"storage-1": {
+								Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+									{
+										Role: string(
+											fdbv1beta2.ProcessRoleStorage,
+										),
+										KVStoreUsedBytes:      pointer.Int64(30),
+										KVStoreTotalBytes:     pointer.Int64(100),
+										KVStoreAvailableBytes: pointer.Int64(30),
+									},
+								},
+							},
+						},
+					},
+				}, 50, true),
+		)
+	})
+
+	When("getting the scaling decision", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var status *fdbv1beta2.FoundationDBStatus
+		var decision *StorageScalingDecision
+		var canExpandVolumes bool
+		var currentProcessGroups int
+
+		BeforeEach(func() {
+			canExpandVolumes = false
+			cluster = internal.CreateDefaultCluster()
+			cluster.Spec.Autoscaling.Storage = &fdbv1beta2.StorageAutoscalingPolicy{
+				Enabled:          pointer.Bool(true),
+				MinProcessGroups: pointer.Int(2),
+				MaxProcessGroups: pointer.Int(6),
+			}
+
+			processCounts, err := cluster.GetProcessCountsWithDefaults()
+			Expect(err).NotTo(HaveOccurred())
+			currentProcessGroups = processCounts.Storage
+			Expect(currentProcessGroups).To(Equal(4))
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			decision, err = GetStorageScalingDecision(
+				logr.Discard(),
+				cluster,
+				status,
+				currentProcessGroups,
+				resource.MustParse("128G"),
+				canExpandVolumes,
+				time.Now(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("no disk usage is reported", func() {
+			BeforeEach(func() {
+				status = &fdbv1beta2.FoundationDBStatus{}
+			})
+
+			It("should not make a decision", func() {
+				Expect(decision).To(BeNil())
+			})
+		})
+
+		When("the disk usage is above the target", func() {
+			BeforeEach(func() {
+				status = createStatusWithDiskUsage(85)
+			})
+
+			It("should add a storage process group", func() {
+				Expect(decision).NotTo(BeNil())
+				Expect(decision.ProcessGroups).To(Equal(5))
+				Expect(decision.VolumeSize).To(BeNil())
+			})
+
+			When("the step size is bigger than the remaining process groups", func() {
+				BeforeEach(func() {
+					cluster.Spec.Autoscaling.Storage.StepSize = pointer.Int(3)
+				})
+
+				It("should scale up to the maximum", func() {
+					Expect(decision).NotTo(BeNil())
+					Expect(decision.ProcessGroups).To(Equal(6))
+				})
+			})
+
+			When("the maximum is reached", func() {
+				BeforeEach(func() {
+					currentProcessGroups = 6
+				})
+
+				It("should not make a decision", func() {
+					Expect(decision).To(BeNil())
+				})
+			})
+
+			When("the last scaling decision is in the cooldown period", func() {
+				BeforeEach(func() {
+					cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{
+						Storage: &fdbv1beta2.StorageAutoscalingStatus{
+							DesiredProcessGroups: 4,
+							LastScalingTimestamp: &metav1.Time{
+								Time: time.Now().Add(-1 * time.Minute),
+							},
+						},
+					}
+				})
+
+				It("should not make a decision", func() {
+					Expect(decision).To(BeNil())
+				})
+			})
+
+			When("the volumes can be grown", func() {
+				BeforeEach(func() {
+					canExpandVolumes = true
+					maxVolumeSize := resource.MustParse("256G")
+					cluster.Spec.Autoscaling.Storage.MaxVolumeSize = &maxVolumeSize
+				})
+
+				It("should grow the volumes", func() {
+					Expect(decision).NotTo(BeNil())
+					Expect(decision.ProcessGroups).To(Equal(4))
+					Expect(decision.VolumeSize).NotTo(BeNil())
+					Expect(
+						decision.VolumeSize.Cmp(resource.MustParse("155G")),
+					).To(BeNumerically(">", 0))
+					Expect(
+						decision.VolumeSize.Cmp(resource.MustParse("156G")),
+					).To(BeNumerically("<", 0))
+				})
+
+				When("the volumes would be grown above the maximum volume size", func() {
+					BeforeEach(func() {
+						status = createStatusWithDiskUsage(100)
+						cluster.Spec.Autoscaling.Storage.TargetDiskUsagePercentage = pointer.Int(10)
+					})
+
+					It("should grow the volumes to the maximum volume size", func() {
+						Expect(decision).NotTo(BeNil())
+						Expect(decision.VolumeSize).NotTo(BeNil())
+						Expect(decision.VolumeSize.Equal(resource.MustParse("256G"))).To(BeTrue())
+					})
+				})
+			})
+		})
+
+		When("the disk usage is below the target", func() {
+			BeforeEach(func() {
+				status = createStatusWithDiskUsage(30)
+			})
+
+			It("should remove a storage process group", func() {
+				Expect(decision).NotTo(BeNil())
+				Expect(decision.ProcessGroups).To(Equal(3))
+			})
+
+			When("the minimum is reached", func() {
+				BeforeEach(func() {
+					currentProcessGroups = 2
+				})
+
+				It("should not make a decision", func() {
+					Expect(decision).To(BeNil())
+				})
+			})
+		})
+
+		When("the disk usage after the scale down would be above the target", func() {
+			BeforeEach(func() {
+				status = createStatusWithDiskUsage(60)
+			})
+
+			It("should not make a decision", func() {
+				Expect(decision).To(BeNil())
+			})
+		})
+	})
+})
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/adoption"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/locality"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient"
 	"github.com/go-logr/logr"
@@ -70,11 +71,17 @@ func selectCandidates(
 	pendingRemovals map[fdbv1beta2.ProcessGroupID]time.Time,
 ) ([]locality.Info, error) {
 	candidates := make([]locality.Info, 0, len(status.Cluster.Processes))
+	processGroupIDs := adoption.GetProcessGroupIDs(cluster)
 	for _, process := range status.Cluster.Processes {
 		if process.Excluded || process.UnderMaintenance {
 			continue
 		}
 
+		// If an existing cluster is adopted, the coordinators should only be running on processes managed by the operator.
+		if cluster.IsAdopting() && adoption.IsForeignProcess(processGroupIDs, process) {
+			continue
+		}
+
 		if !cluster.IsEligibleAsCandidate(process.ProcessClass) {
 			continue
 		}
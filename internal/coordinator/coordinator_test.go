@@ -1068,6 +1068,61 @@ var _ = Describe("Change coordinators", func() {
 				"1": time.Now(),
 			},
 		),
+		Entry("An existing cluster is adopted and one process is not managed by the operator",
+			&fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					Version:  "7.1.57",
+					Adoption: &fdbv1beta2.AdoptionConfig{},
+				},
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					ProcessGroups: []*fdbv1beta2.ProcessGroupStatus{
+						{
+							ProcessGroupID: "2",
+							ProcessClass:   fdbv1beta2.ProcessClassStorage,
+						},
+					},
+				},
+			},
+			&fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"1": {
+							ProcessClass: fdbv1beta2.ProcessClassStorage,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "vm-1",
+								fdbv1beta2.FDBLocalityDNSNameKey:    "vm-1",
+							},
+							CommandLine: "--public_address=192.168.0.1:4500",
+							Version:     "7.1.57",
+						},
+						"2": {
+							ProcessClass: fdbv1beta2.ProcessClassStorage,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "2",
+								fdbv1beta2.FDBLocalityDNSNameKey:    "2",
+							},
+							CommandLine: "--public_address=192.168.0.2:4500",
+							Version:     "7.1.57",
+						},
+					},
+				},
+			},
+			[]locality.Info{
+				{
+					ID: "2",
+					Address: fdbv1beta2.ProcessAddress{
+						IPAddress: net.ParseIP("192.168.0.2"),
+						Port:      4500,
+					},
+					Class: fdbv1beta2.ProcessClassStorage,
+					LocalityData: map[string]string{
+						fdbv1beta2.FDBLocalityInstanceIDKey: "2",
+						fdbv1beta2.FDBLocalityDNSNameKey:    "2",
+					},
+				},
+			},
+			nil,
+		),
 	)
 })
 
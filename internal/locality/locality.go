@@ -29,6 +29,7 @@ import (
 	"strings"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/adoption"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/podclient"
 	"github.com/go-logr/logr"
 )
@@ -361,6 +362,7 @@ func CheckCoordinatorValidity(
 	allUsingCorrectAddress := true
 	hardLimits := GetHardLimits(cluster)
 	coordinatorLocalities := make(map[string]map[string]int)
+	processGroupIDs := adoption.GetProcessGroupIDs(cluster)
 	// Track what fields should be validated.
 	fieldsToValidate := make([]string, 0, len(hardLimits))
 	for field := range hardLimits {
@@ -481,6 +483,15 @@ func CheckCoordinatorValidity(
 				allEligible = false
 			}
 
+			if cluster.IsAdopting() && adoption.IsForeignProcess(processGroupIDs, process) {
+				pLogger.Info(
+					"Coordinator is running on a process that is not managed by the operator",
+					"address",
+					coordinatorAddress,
+				)
+				allEligible = false
+			}
+
 			useDNS := cluster.UseDNSInClusterFile() && dnsName != ""
 			if (isCoordinatorWithIP && useDNS) || (isCoordinatorWithDNS && !useDNS) {
 				pLogger.Info(
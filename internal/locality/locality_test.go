@@ -1431,6 +1431,45 @@ var _ = Describe("Localities", func() {
 			})
 		})
 
+		When("an existing cluster is adopted", func() {
+			BeforeEach(func() {
+				cluster.Spec.Adoption = &fdbv1beta2.AdoptionConfig{}
+			})
+
+			It("should report the coordinators as valid", func() {
+				coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(
+					logr.Discard(),
+					cluster,
+					status,
+					coordinatorStatus,
+				)
+				Expect(coordinatorsValid).To(BeTrue())
+				Expect(addressesValid).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			When(
+				"a coordinator is running on a process that is not managed by the operator",
+				func() {
+					BeforeEach(func() {
+						cluster.Status.ProcessGroups = cluster.Status.ProcessGroups[1:]
+					})
+
+					It("should report the coordinators as invalid", func() {
+						coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(
+							logr.Discard(),
+							cluster,
+							status,
+							coordinatorStatus,
+						)
+						Expect(coordinatorsValid).To(BeFalse())
+						Expect(addressesValid).To(BeTrue())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				},
+			)
+		})
+
 		When("one process is marked for exclusion", func() {
 			BeforeEach(func() {
 				process := status.Cluster.Processes["1"]
@@ -249,6 +249,29 @@ func GetMonitorProcessConfiguration(
 		monitorapi.Argument{Value: fmt.Sprintf("--loggroup=%s", logGroup)},
 	)
 
+	traceLogOptions := cluster.Spec.TraceLogOptions
+	if traceLogOptions.RollSizeBytes != nil {
+		configuration.Arguments = append(
+			configuration.Arguments,
+			monitorapi.Argument{Value: fmt.Sprintf("--logsize=%d", *traceLogOptions.RollSizeBytes)},
+		)
+	}
+
+	if traceLogOptions.MaxSizeBytes != nil {
+		configuration.Arguments = append(
+			configuration.Arguments,
+			monitorapi.Argument{Value: fmt.Sprintf("--maxlogs=%d", *traceLogOptions.MaxSizeBytes)},
+		)
+	}
+
+	// The trace log forwarder expects the trace events to be written as JSON.
+	if cluster.ShouldForwardTraceLogs() {
+		configuration.Arguments = append(
+			configuration.Arguments,
+			monitorapi.Argument{Value: "--trace_format=json"},
+		)
+	}
+
 	// If the unified image is used we will always make use of the more specific data directory and add the process_id
 	// locality.
 	if processCount > 1 || cluster.UseUnifiedImage() {
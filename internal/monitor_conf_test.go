@@ -840,6 +840,29 @@ var _ = Describe("monitor_conf", func() {
 			})
 		})
 
+		When("the spec has trace log options", func() {
+			BeforeEach(func() {
+				cluster.Spec.TraceLogOptions.RollSizeBytes = pointer.Int(1048576)
+				cluster.Spec.TraceLogOptions.MaxSizeBytes = pointer.Int(10485760)
+				cluster.Spec.TraceLogOptions.ForwardToStdout = pointer.Bool(true)
+			})
+
+			It("includes the trace log arguments", func() {
+				config := GetMonitorProcessConfiguration(
+					cluster,
+					fdbv1beta2.ProcessClassStorage,
+					1,
+					fdbv1beta2.ImageTypeUnified,
+				)
+				Expect(config.Arguments).To(HaveLen(baseArgumentLength + 3))
+				Expect(config.Arguments).To(ContainElements(
+					monitorapi.Argument{Value: "--logsize=1048576"},
+					monitorapi.Argument{Value: "--maxlogs=10485760"},
+					monitorapi.Argument{Value: "--trace_format=json"},
+				))
+			})
+		})
+
 		When("the spec has a data center", func() {
 			BeforeEach(func() {
 				cluster.Spec.DataCenter = "dc01"
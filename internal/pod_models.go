@@ -600,6 +600,7 @@ func GetPodSpec(
 	}
 
 	replaceContainers(podSpec.Containers, mainContainer, sidecarContainer)
+	configureTraceLogForwarder(cluster, podSpec, mainContainer.Image)
 
 	headlessService := GetHeadlessService(cluster)
 
@@ -611,6 +612,117 @@ func GetPodSpec(
 	return podSpec, nil
 }
 
+// traceLogForwarderScript is the script that runs in the trace log forwarder container. The script deletes trace log
+// files that are older than the max age and forwards the trace events of all JSON trace log files to stdout. Trace log
+// files that already exist when the container starts will only be forwarded from their current end, to prevent
+// duplicate trace events after a container restart.
+const traceLogForwarderScript = `trap 'kill $(jobs -p) 2>/dev/null; exit 0' TERM INT
+declare -A tails
+start_line=0
+while true; do
+	if [[ -n "${FDB_TRACE_LOG_MAX_AGE_MINUTES:-}" ]]; then
+		find /var/log/fdb-trace-logs -maxdepth 1 -type f -name 'trace.*' -mmin "+${FDB_TRACE_LOG_MAX_AGE_MINUTES}" -delete
+	fi
+	if [[ "${FDB_TRACE_LOG_FORWARD:-false}" == "true" ]]; then
+		for file in "${!tails[@]}"; do
+			if [[ ! -e "${file}" ]]; then
+				kill "${tails[${file}]}" 2>/dev/null
+				unset "tails[${file}]"
+			fi
+		done
+		for file in /var/log/fdb-trace-logs/trace.*.json; do
+			if [[ -e "${file}" && -z "${tails[${file}]:-}" ]]; then
+				tail -q -n "${start_line}" -F "${file}" &
+				tails["${file}"]=$!
+			fi
+		done
+		start_line=+1
+	fi
+	sleep 10 &
+	wait $!
+done`
+
+// configureTraceLogForwarder adds the trace log forwarder container to the pod spec if the cluster requires it. If
+// the pod template already contains a container with the trace log forwarder name, this container will be used as
+// base, e.g. to define the image or the resources. If the cluster doesn't require the trace log forwarder, the
+// container will be removed from the pod spec.
+func configureTraceLogForwarder(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	podSpec *corev1.PodSpec,
+	image string,
+) {
+	containerIndex := -1
+	for index, container := range podSpec.Containers {
+		if container.Name == fdbv1beta2.TraceLogForwarderContainerName {
+			containerIndex = index
+			break
+		}
+	}
+
+	if !cluster.NeedsTraceLogForwarder() {
+		if containerIndex >= 0 {
+			podSpec.Containers = append(
+				podSpec.Containers[:containerIndex],
+				podSpec.Containers[containerIndex+1:]...)
+		}
+
+		return
+	}
+
+	if containerIndex < 0 {
+		podSpec.Containers = append(
+			podSpec.Containers,
+			corev1.Container{Name: fdbv1beta2.TraceLogForwarderContainerName},
+		)
+		containerIndex = len(podSpec.Containers) - 1
+	}
+
+	container := &podSpec.Containers[containerIndex]
+	if container.Image == "" {
+		container.Image = image
+	}
+
+	container.Command = []string{"bash", "-c", traceLogForwarderScript}
+	container.Args = nil
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "FDB_TRACE_LOG_FORWARD",
+			Value: strconv.FormatBool(cluster.ShouldForwardTraceLogs()),
+		},
+	}
+
+	maxAgeSeconds := cluster.Spec.TraceLogOptions.MaxAgeSeconds
+	if maxAgeSeconds != nil {
+		// find only supports minutes, so we round up to the next full minute.
+		env = append(env, corev1.EnvVar{
+			Name:  "FDB_TRACE_LOG_MAX_AGE_MINUTES",
+			Value: strconv.Itoa((*maxAgeSeconds + 59) / 60),
+		})
+	}
+	extendEnv(container, env...)
+
+	// The trace log volume might already be mounted in a custom trace log forwarder container, adding it again would
+	// result in a duplicate mount path that is rejected by the API server.
+	var hasTraceLogMount bool
+	for _, volumeMount := range container.VolumeMounts {
+		if volumeMount.Name == "fdb-trace-logs" ||
+			volumeMount.MountPath == "/var/log/fdb-trace-logs" {
+			hasTraceLogMount = true
+			break
+		}
+	}
+
+	if !hasTraceLogMount {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "fdb-trace-logs",
+			MountPath: "/var/log/fdb-trace-logs",
+			ReadOnly:  maxAgeSeconds == nil,
+		})
+	}
+	ensureSecurityContextIsPresent(container)
+}
+
 // configureSidecarContainerForCluster sets up a sidecar container for a sidecar
 // in the FDB cluster.
 func configureSidecarContainerForCluster(
@@ -967,6 +1079,16 @@ func GetPvc(
 		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("128G")
 	}
 
+	// If the storage autoscaling has grown the storage volumes, use the autoscaled size.
+	if processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage {
+		autoscaledSize := cluster.GetAutoscaledVolumeSize(
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage],
+		)
+		if autoscaledSize != nil {
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *autoscaledSize
+		}
+	}
+
 	specHash, err := GetJSONHash(pvc.Spec)
 	if err != nil {
 		return nil, err
@@ -2384,6 +2384,173 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		Context("with trace log forwarding enabled", func() {
+			BeforeEach(func() {
+				cluster = CreateDefaultCluster()
+				cluster.Spec.TraceLogOptions.ForwardToStdout = pointer.Bool(true)
+				err = NormalizeClusterSpec(cluster, DeprecationOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				spec, err = GetPodSpec(
+					cluster,
+					GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1),
+				)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should add the trace log forwarder container", func() {
+				Expect(spec.Containers).To(HaveLen(3))
+
+				forwarder := spec.Containers[2]
+				Expect(forwarder.Name).To(Equal(fdbv1beta2.TraceLogForwarderContainerName))
+				Expect(forwarder.Image).To(Equal(spec.Containers[0].Image))
+				Expect(forwarder.Command).To(Equal([]string{"bash", "-c", traceLogForwarderScript}))
+				Expect(forwarder.Env).To(ConsistOf(corev1.EnvVar{
+					Name:  "FDB_TRACE_LOG_FORWARD",
+					Value: "true",
+				}))
+				Expect(forwarder.VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+					Name:      "fdb-trace-logs",
+					MountPath: "/var/log/fdb-trace-logs",
+					ReadOnly:  true,
+				}))
+			})
+		})
+
+		Context("with a max age for the trace logs and a custom trace log forwarder", func() {
+			BeforeEach(func() {
+				cluster = CreateDefaultCluster()
+				cluster.Spec.TraceLogOptions.MaxAgeSeconds = pointer.Int(3601)
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {PodTemplate: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:  fdbv1beta2.TraceLogForwarderContainerName,
+								Image: "example.com/bash:latest",
+							}},
+						},
+					}},
+				}
+				err = NormalizeClusterSpec(cluster, DeprecationOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				spec, err = GetPodSpec(
+					cluster,
+					GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1),
+				)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should configure the custom trace log forwarder container", func() {
+				var forwarder *corev1.Container
+				for index, container := range spec.Containers {
+					if container.Name == fdbv1beta2.TraceLogForwarderContainerName {
+						forwarder = &spec.Containers[index]
+					}
+				}
+
+				Expect(forwarder).NotTo(BeNil())
+				Expect(forwarder.Image).To(Equal("example.com/bash:latest"))
+				Expect(forwarder.Env).To(ConsistOf(
+					corev1.EnvVar{Name: "FDB_TRACE_LOG_FORWARD", Value: "false"},
+					corev1.EnvVar{Name: "FDB_TRACE_LOG_MAX_AGE_MINUTES", Value: "61"},
+				))
+				Expect(forwarder.VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+					Name:      "fdb-trace-logs",
+					MountPath: "/var/log/fdb-trace-logs",
+				}))
+			})
+		})
+
+		Context("with a custom trace log forwarder that already mounts the trace logs", func() {
+			BeforeEach(func() {
+				cluster = CreateDefaultCluster()
+				cluster.Spec.TraceLogOptions.ForwardToStdout = pointer.Bool(true)
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {PodTemplate: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:  fdbv1beta2.TraceLogForwarderContainerName,
+								Image: "example.com/bash:latest",
+								VolumeMounts: []corev1.VolumeMount{
+									{
+										Name:      "fdb-trace-logs",
+										MountPath: "/var/log/fdb-trace-logs",
+										ReadOnly:  true,
+									},
+									{
+										Name:      "forwarder-config",
+										MountPath: "/etc/forwarder",
+									},
+								},
+							}},
+						},
+					}},
+				}
+				err = NormalizeClusterSpec(cluster, DeprecationOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				spec, err = GetPodSpec(
+					cluster,
+					GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1),
+				)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not add the trace log volume mount again", func() {
+				var forwarder *corev1.Container
+				for index, container := range spec.Containers {
+					if container.Name == fdbv1beta2.TraceLogForwarderContainerName {
+						forwarder = &spec.Containers[index]
+					}
+				}
+
+				Expect(forwarder).NotTo(BeNil())
+				Expect(forwarder.Image).To(Equal("example.com/bash:latest"))
+				Expect(forwarder.VolumeMounts).To(ConsistOf(
+					corev1.VolumeMount{
+						Name:      "fdb-trace-logs",
+						MountPath: "/var/log/fdb-trace-logs",
+						ReadOnly:  true,
+					},
+					corev1.VolumeMount{
+						Name:      "forwarder-config",
+						MountPath: "/etc/forwarder",
+					},
+				))
+			})
+		})
+
+		Context("with a trace log forwarder container that is not required", func() {
+			BeforeEach(func() {
+				cluster = CreateDefaultCluster()
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {PodTemplate: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name: fdbv1beta2.TraceLogForwarderContainerName,
+							}},
+						},
+					}},
+				}
+				err = NormalizeClusterSpec(cluster, DeprecationOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				spec, err = GetPodSpec(
+					cluster,
+					GetProcessGroup(cluster, fdbv1beta2.ProcessClassStorage, 1),
+				)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should remove the trace log forwarder container", func() {
+				Expect(spec.Containers).To(HaveLen(2))
+				for _, container := range spec.Containers {
+					Expect(container.Name).NotTo(Equal(fdbv1beta2.TraceLogForwarderContainerName))
+				}
+			})
+		})
+
 		Context("with custom container images with tag", func() {
 			BeforeEach(func() {
 				cluster = CreateDefaultCluster()
@@ -0,0 +1,300 @@
+/*
+ * podresize.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podresize
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+)
+
+// resizeSubResource is the name of the Pod subresource to resize the resources of a Pod in place.
+const resizeSubResource = "resize"
+
+// isResizableContainer returns true if the resources of the container can be resized in place by the operator.
+func isResizableContainer(name string) bool {
+	return name == fdbv1beta2.MainContainerName || name == fdbv1beta2.SidecarContainerName
+}
+
+// findContainer returns the container with the provided name or nil if no container with this name exists.
+func findContainer(containers []corev1.Container, name string) *corev1.Container {
+	for idx, container := range containers {
+		if container.Name == name {
+			return &containers[idx]
+		}
+	}
+
+	return nil
+}
+
+// onlyResizableResourcesChanged returns true if the current and the desired resources only differ in the CPU and
+// memory values. Adding or removing a resource requires a new Pod.
+func onlyResizableResourcesChanged(
+	current corev1.ResourceRequirements,
+	desired corev1.ResourceRequirements,
+) bool {
+	for _, lists := range [][2]corev1.ResourceList{
+		{current.Requests, desired.Requests},
+		{current.Limits, desired.Limits},
+	} {
+		if len(lists[0]) != len(lists[1]) {
+			return false
+		}
+
+		for name, currentValue := range lists[0] {
+			desiredValue, ok := lists[1][name]
+			if !ok {
+				return false
+			}
+
+			if name == corev1.ResourceCPU || name == corev1.ResourceMemory {
+				continue
+			}
+
+			if !currentValue.Equal(desiredValue) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// getQOSClass returns the QoS class for the provided containers. In-place resizing is not allowed to change the QoS
+// class of a Pod.
+func getQOSClass(spec *corev1.PodSpec) corev1.PodQOSClass {
+	containers := make([]corev1.Container, 0, len(spec.Containers)+len(spec.InitContainers))
+	containers = append(containers, spec.Containers...)
+	containers = append(containers, spec.InitContainers...)
+
+	bestEffort := true
+	guaranteed := true
+	for _, container := range containers {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			request, hasRequest := container.Resources.Requests[name]
+			limit, hasLimit := container.Resources.Limits[name]
+			if hasRequest || hasLimit {
+				bestEffort = false
+			}
+
+			if !hasLimit || (hasRequest && !request.Equal(limit)) {
+				guaranteed = false
+			}
+		}
+	}
+
+	if bestEffort {
+		return corev1.PodQOSBestEffort
+	}
+
+	if guaranteed {
+		return corev1.PodQOSGuaranteed
+	}
+
+	return corev1.PodQOSBurstable
+}
+
+// OnlyResourcesChanged returns true if the only difference between the current Pod and the desired Pod spec are the
+// CPU and memory resources of the main container or the sidecar container. The current Pod spec is compared based on
+// the hash in the fdbv1beta2.LastSpecKey annotation, as Kubernetes will add default values to the Pod spec.
+func OnlyResourcesChanged(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroup *fdbv1beta2.ProcessGroupStatus,
+	pod *corev1.Pod,
+	desired *corev1.PodSpec,
+) (bool, error) {
+	if pod == nil || desired == nil {
+		return false, nil
+	}
+
+	// Compute the hash of the desired spec with the current resources, if this hash matches the current hash
+	// only the resources have changed.
+	spec := desired.DeepCopy()
+	var resourcesChanged bool
+	for idx, container := range spec.Containers {
+		if !isResizableContainer(container.Name) {
+			continue
+		}
+
+		currentContainer := findContainer(pod.Spec.Containers, container.Name)
+		if currentContainer == nil {
+			return false, nil
+		}
+
+		if equality.Semantic.DeepEqual(currentContainer.Resources, container.Resources) {
+			continue
+		}
+
+		if !onlyResizableResourcesChanged(currentContainer.Resources, container.Resources) {
+			return false, nil
+		}
+
+		resourcesChanged = true
+		spec.Containers[idx].Resources = *currentContainer.Resources.DeepCopy()
+	}
+
+	if !resourcesChanged {
+		return false, nil
+	}
+
+	if getQOSClass(spec) != getQOSClass(desired) {
+		return false, nil
+	}
+
+	specHash, err := internal.GetPodSpecHash(cluster, processGroup, spec)
+	if err != nil {
+		return false, err
+	}
+
+	return pod.Annotations[fdbv1beta2.LastSpecKey] == specHash, nil
+}
+
+// IsInfeasible returns true if the kubelet reported that the requested resize cannot be fulfilled by the node.
+func IsInfeasible(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodResizePending && condition.Status == corev1.ConditionTrue &&
+			condition.Reason == corev1.PodReasonInfeasible {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsResizing returns true if the kubelet reported that a resize of the Pod is pending or in progress.
+func IsResizing(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		if condition.Type == corev1.PodResizePending ||
+			condition.Type == corev1.PodResizeInProgress {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResourcesMatch returns true if the resources of the resizable containers of the Pod match the desired resources.
+func ResourcesMatch(pod *corev1.Pod, desired *corev1.PodSpec) bool {
+	for _, container := range desired.Containers {
+		if !isResizableContainer(container.Name) {
+			continue
+		}
+
+		currentContainer := findContainer(pod.Spec.Containers, container.Name)
+		if currentContainer == nil ||
+			!equality.Semantic.DeepEqual(currentContainer.Resources, container.Resources) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CanBeResizedInPlace returns true if the Pod can be updated to the desired spec by resizing the resources in place.
+// If a resize for the desired spec was already requested, the Pod can be resized as long as the kubelet doesn't
+// report the resize as infeasible.
+func CanBeResizedInPlace(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroup *fdbv1beta2.ProcessGroupStatus,
+	pod *corev1.Pod,
+	desired *corev1.PodSpec,
+) (bool, error) {
+	if !cluster.UseInPlacePodResize() || pod == nil || desired == nil {
+		return false, nil
+	}
+
+	specHash, err := internal.GetPodSpecHash(cluster, processGroup, desired)
+	if err != nil {
+		return false, err
+	}
+
+	if pod.Annotations[fdbv1beta2.LastSpecKey] == specHash {
+		return false, nil
+	}
+
+	if pod.Annotations[fdbv1beta2.InPlaceResizeSpecKey] == specHash {
+		return !IsInfeasible(pod), nil
+	}
+
+	return OnlyResourcesChanged(cluster, processGroup, pod, desired)
+}
+
+// Resize requests an in-place resize of the Pod to the desired resources. The hash of the desired spec is stored in
+// the fdbv1beta2.InPlaceResizeSpecKey annotation before the resize is requested, so the operator can track the resize
+// until it is completed.
+func Resize(
+	ctx context.Context,
+	writer client.Client,
+	pod *corev1.Pod,
+	desired *corev1.PodSpec,
+	specHash string,
+) error {
+	if pod.Annotations[fdbv1beta2.InPlaceResizeSpecKey] != specHash {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+
+		pod.Annotations[fdbv1beta2.InPlaceResizeSpecKey] = specHash
+		err := writer.Update(ctx, pod)
+		if err != nil {
+			return err
+		}
+	}
+
+	patch := client.StrategicMergeFrom(pod.DeepCopy())
+	for idx, container := range pod.Spec.Containers {
+		if !isResizableContainer(container.Name) {
+			continue
+		}
+
+		desiredContainer := findContainer(desired.Containers, container.Name)
+		if desiredContainer == nil {
+			continue
+		}
+
+		pod.Spec.Containers[idx].Resources = *desiredContainer.Resources.DeepCopy()
+	}
+
+	return writer.SubResource(resizeSubResource).Patch(ctx, pod, patch)
+}
+
+// CompleteResize marks the in-place resize of the Pod as completed by updating the fdbv1beta2.LastSpecKey annotation
+// to the hash of the desired spec.
+func CompleteResize(
+	ctx context.Context,
+	writer client.Writer,
+	pod *corev1.Pod,
+	specHash string,
+) error {
+	pod.Annotations[fdbv1beta2.LastSpecKey] = specHash
+	delete(pod.Annotations, fdbv1beta2.InPlaceResizeSpecKey)
+
+	return writer.Update(ctx, pod)
+}
@@ -0,0 +1,303 @@
+/*
+ * podresize_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podresize
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("podresize", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var processGroup *fdbv1beta2.ProcessGroupStatus
+	var pod *corev1.Pod
+	var desired *corev1.PodSpec
+
+	setResources := func(cpu string, memory string, withLimits bool) {
+		resources := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		}
+		if withLimits {
+			resources.Limits = resources.Requests.DeepCopy()
+		}
+
+		processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+		for idx, container := range processSettings.PodTemplate.Spec.Containers {
+			if container.Name != fdbv1beta2.MainContainerName {
+				continue
+			}
+
+			processSettings.PodTemplate.Spec.Containers[idx].Resources = resources
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(
+			internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{}),
+		).To(Succeed())
+		cluster.Spec.AutomationOptions.UseInPlacePodResize = pointer.Bool(true)
+		processGroup = fdbv1beta2.NewProcessGroupStatus(
+			"storage-1",
+			fdbv1beta2.ProcessClassStorage,
+			nil,
+		)
+
+		setResources("1", "1Gi", true)
+		var err error
+		pod, err = internal.GetPod(cluster, processGroup)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("checking if only the resources were changed", func() {
+		var onlyResourcesChanged bool
+
+		JustBeforeEach(func() {
+			var err error
+			desired, err = internal.GetPodSpec(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+			onlyResourcesChanged, err = OnlyResourcesChanged(cluster, processGroup, pod, desired)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("nothing was changed", func() {
+			It("should return false", func() {
+				Expect(onlyResourcesChanged).To(BeFalse())
+			})
+		})
+
+		When("the CPU and memory were increased", func() {
+			BeforeEach(func() {
+				setResources("2", "2Gi", true)
+			})
+
+			It("should return true", func() {
+				Expect(onlyResourcesChanged).To(BeTrue())
+			})
+		})
+
+		When("the CPU and memory were decreased", func() {
+			BeforeEach(func() {
+				setResources("500m", "512Mi", true)
+			})
+
+			It("should return true", func() {
+				Expect(onlyResourcesChanged).To(BeTrue())
+			})
+		})
+
+		When("the resources and the environment were changed", func() {
+			BeforeEach(func() {
+				setResources("2", "2Gi", true)
+				processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				for idx, container := range processSettings.PodTemplate.Spec.Containers {
+					if container.Name != fdbv1beta2.MainContainerName {
+						continue
+					}
+
+					processSettings.PodTemplate.Spec.Containers[idx].Env = append(
+						container.Env,
+						corev1.EnvVar{Name: "TEST", Value: "test"},
+					)
+				}
+			})
+
+			It("should return false", func() {
+				Expect(onlyResourcesChanged).To(BeFalse())
+			})
+		})
+
+		When("the QoS class would change", func() {
+			BeforeEach(func() {
+				setResources("2", "2Gi", false)
+			})
+
+			It("should return false", func() {
+				Expect(onlyResourcesChanged).To(BeFalse())
+			})
+		})
+
+		When("a new resource was added", func() {
+			BeforeEach(func() {
+				setResources("1", "1Gi", true)
+				processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				var resources corev1.ResourceRequirements
+				for _, container := range processSettings.PodTemplate.Spec.Containers {
+					if container.Name == fdbv1beta2.MainContainerName {
+						resources = container.Resources
+					}
+				}
+				resources.Requests[corev1.ResourceEphemeralStorage] = resource.MustParse("1Gi")
+				resources.Limits[corev1.ResourceEphemeralStorage] = resource.MustParse("1Gi")
+			})
+
+			It("should return false", func() {
+				Expect(onlyResourcesChanged).To(BeFalse())
+			})
+		})
+	})
+
+	When("checking if the Pod can be resized in place", func() {
+		var canBeResized bool
+
+		BeforeEach(func() {
+			setResources("2", "2Gi", true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			desired, err = internal.GetPodSpec(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+			canBeResized, err = CanBeResizedInPlace(cluster, processGroup, pod, desired)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return true", func() {
+			Expect(canBeResized).To(BeTrue())
+		})
+
+		When("in-place resizing is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.UseInPlacePodResize = pointer.Bool(false)
+			})
+
+			It("should return false", func() {
+				Expect(canBeResized).To(BeFalse())
+			})
+		})
+
+		When("the resize was already requested", func() {
+			BeforeEach(func() {
+				spec, err := internal.GetPodSpec(cluster, processGroup)
+				Expect(err).NotTo(HaveOccurred())
+				specHash, err := internal.GetPodSpecHash(cluster, processGroup, spec)
+				Expect(err).NotTo(HaveOccurred())
+				pod.Annotations[fdbv1beta2.InPlaceResizeSpecKey] = specHash
+				pod.Spec = *spec
+			})
+
+			It("should return true", func() {
+				Expect(canBeResized).To(BeTrue())
+			})
+
+			When("the kubelet reports the resize as infeasible", func() {
+				BeforeEach(func() {
+					pod.Status.Conditions = []corev1.PodCondition{
+						{
+							Type:   corev1.PodResizePending,
+							Status: corev1.ConditionTrue,
+							Reason: corev1.PodReasonInfeasible,
+						},
+					}
+				})
+
+				It("should return false", func() {
+					Expect(canBeResized).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	When("checking the resize conditions", func() {
+		DescribeTable("should return the expected result",
+			func(conditions []corev1.PodCondition, expectedResizing bool, expectedInfeasible bool) {
+				pod.Status.Conditions = conditions
+				Expect(IsResizing(pod)).To(Equal(expectedResizing))
+				Expect(IsInfeasible(pod)).To(Equal(expectedInfeasible))
+			},
+			Entry("no conditions", nil, false, false),
+			Entry("resize in progress", []corev1.PodCondition{
+				{
+					Type:   corev1.PodResizeInProgress,
+					Status: corev1.ConditionTrue,
+				},
+			}, true, false),
+			Entry("resize deferred", []corev1.PodCondition{
+				{
+					Type:   corev1.PodResizePending,
+					Status: corev1.ConditionTrue,
+					Reason: corev1.PodReasonDeferred,
+				},
+			}, true, false),
+			Entry("resize infeasible", []corev1.PodCondition{
+				{
+					Type:   corev1.PodResizePending,
+					Status: corev1.ConditionTrue,
+					Reason: corev1.PodReasonInfeasible,
+				},
+			}, true, true),
+		)
+	})
+
+	When("resizing the Pod", func() {
+		var specHash string
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(context.TODO(), pod)).To(Succeed())
+			setResources("2", "2Gi", true)
+
+			var err error
+			desired, err = internal.GetPodSpec(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+			specHash, err = internal.GetPodSpecHash(cluster, processGroup, desired)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(Resize(context.TODO(), k8sClient, pod, desired, specHash)).To(Succeed())
+		})
+
+		It("should update the resources and set the annotation", func() {
+			current := &corev1.Pod{}
+			Expect(
+				k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(pod), current),
+			).To(Succeed())
+			Expect(
+				current.Annotations,
+			).To(HaveKeyWithValue(fdbv1beta2.InPlaceResizeSpecKey, specHash))
+			Expect(current.Annotations[fdbv1beta2.LastSpecKey]).NotTo(Equal(specHash))
+			Expect(ResourcesMatch(current, desired)).To(BeTrue())
+		})
+
+		When("the resize is completed", func() {
+			BeforeEach(func() {
+				Expect(CompleteResize(context.TODO(), k8sClient, pod, specHash)).To(Succeed())
+			})
+
+			It("should update the spec hash", func() {
+				current := &corev1.Pod{}
+				Expect(
+					k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(pod), current),
+				).To(Succeed())
+				Expect(current.Annotations).NotTo(HaveKey(fdbv1beta2.InPlaceResizeSpecKey))
+				Expect(current.Annotations).To(HaveKeyWithValue(fdbv1beta2.LastSpecKey, specHash))
+			})
+		})
+	})
+})
@@ -0,0 +1,148 @@
+/*
+ * prometheus_rule.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// PrometheusRuleGVK is the GroupVersionKind of the PrometheusRule resource from the Prometheus operator. The resource
+// is handled as unstructured object to prevent a dependency on the Prometheus operator types.
+var PrometheusRuleGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PrometheusRule",
+}
+
+// NewPrometheusRuleObject returns an empty unstructured PrometheusRule with the provided namespace and name.
+func NewPrometheusRuleObject(namespace string, name string) *unstructured.Unstructured {
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(PrometheusRuleGVK)
+	rule.SetNamespace(namespace)
+	rule.SetName(name)
+
+	return rule
+}
+
+// GetPrometheusRule builds the PrometheusRule with the alerting rules for the provided cluster. The alerting rules
+// are based on the metrics exposed by the operator.
+func GetPrometheusRule(cluster *fdbv1beta2.FoundationDBCluster) *unstructured.Unstructured {
+	metadata := GetObjectMetadata(cluster, nil, "", "")
+	rule := NewPrometheusRuleObject(cluster.Namespace, cluster.Name)
+	labels := metadata.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key, value := range cluster.Spec.PrometheusRuleOptions.Labels {
+		labels[key] = value
+	}
+	rule.SetLabels(labels)
+	rule.SetOwnerReferences(BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta))
+
+	selector := fmt.Sprintf("namespace=%q,name=%q", cluster.Namespace, cluster.Name)
+	clusterName := fmt.Sprintf("%s/%s", cluster.Namespace, cluster.Name)
+
+	rules := []interface{}{
+		getPrometheusAlert(
+			cluster,
+			"FoundationDBClusterUnavailable",
+			fmt.Sprintf("fdb_operator_cluster_status{%s,status_type=\"available\"} == 0", selector),
+			cluster.GetPrometheusRuleUnavailableDuration(),
+			fmt.Sprintf("FoundationDB cluster %s is unavailable", clusterName),
+		),
+		getPrometheusAlert(
+			cluster,
+			"FoundationDBClusterFaultToleranceReduced",
+			fmt.Sprintf(
+				"fdb_operator_cluster_status{%s,status_type=\"replication\"} == 0",
+				selector,
+			),
+			cluster.GetPrometheusRuleFaultToleranceReducedDuration(),
+			fmt.Sprintf("FoundationDB cluster %s is not fully replicated", clusterName),
+		),
+		getPrometheusAlert(
+			cluster,
+			"FoundationDBClusterProcessGroupConditions",
+			fmt.Sprintf(
+				"sum by (process_class, condition) (fdb_operator_process_group_total{%s,condition!=\"%s\"}) > 0",
+				selector,
+				fdbv1beta2.ReadyCondition,
+			),
+			cluster.GetPrometheusRuleProcessGroupConditionDuration(),
+			fmt.Sprintf(
+				"FoundationDB cluster %s has {{ $value }} {{ $labels.process_class }} process groups with the condition {{ $labels.condition }}",
+				clusterName,
+			),
+		),
+		getPrometheusAlert(
+			cluster,
+			"FoundationDBClusterReconciliationStuck",
+			fmt.Sprintf("fdb_operator_cluster_reconciled_status{%s} == 0", selector),
+			cluster.GetPrometheusRuleReconciliationStuckDuration(),
+			fmt.Sprintf("FoundationDB cluster %s is not reconciled", clusterName),
+		),
+	}
+
+	rule.Object["spec"] = map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{
+				"name":  fmt.Sprintf("foundationdb-%s-%s", cluster.Namespace, cluster.Name),
+				"rules": rules,
+			},
+		},
+	}
+
+	return rule
+}
+
+// getPrometheusAlert returns a single alerting rule for the provided expression.
+func getPrometheusAlert(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	alert string,
+	expression string,
+	duration time.Duration,
+	summary string,
+) map[string]interface{} {
+	labels := map[string]interface{}{}
+	for key, value := range cluster.Spec.PrometheusRuleOptions.AlertLabels {
+		labels[key] = value
+	}
+
+	alertingRule := map[string]interface{}{
+		"alert": alert,
+		"expr":  expression,
+		"for":   fmt.Sprintf("%ds", int64(duration.Seconds())),
+		"annotations": map[string]interface{}{
+			"summary": summary,
+		},
+	}
+
+	if len(labels) > 0 {
+		alertingRule["labels"] = labels
+	}
+
+	return alertingRule
+}
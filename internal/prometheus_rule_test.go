@@ -0,0 +1,111 @@
+/*
+ * prometheus_rule_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("prometheus_rule", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+
+	BeforeEach(func() {
+		cluster = CreateDefaultCluster()
+		cluster.Spec.PrometheusRuleOptions.Enabled = pointer.Bool(true)
+	})
+
+	getAlerts := func(rule *unstructured.Unstructured) map[string]map[string]interface{} {
+		groups, found, err := unstructured.NestedSlice(rule.Object, "spec", "groups")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(groups).To(HaveLen(1))
+
+		rules, found, err := unstructured.NestedSlice(
+			groups[0].(map[string]interface{}),
+			"rules",
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		alerts := map[string]map[string]interface{}{}
+		for _, rule := range rules {
+			alert := rule.(map[string]interface{})
+			alerts[alert["alert"].(string)] = alert
+		}
+
+		return alerts
+	}
+
+	When("the default thresholds are used", func() {
+		It("should generate the alerts for the cluster", func() {
+			rule := GetPrometheusRule(cluster)
+			Expect(rule.GroupVersionKind()).To(Equal(PrometheusRuleGVK))
+			Expect(rule.GetName()).To(Equal(cluster.Name))
+			Expect(rule.GetNamespace()).To(Equal(cluster.Namespace))
+
+			alerts := getAlerts(rule)
+			Expect(alerts).To(HaveLen(4))
+			Expect(alerts["FoundationDBClusterUnavailable"]).To(HaveKeyWithValue("for", "60s"))
+			Expect(
+				alerts["FoundationDBClusterUnavailable"],
+			).To(HaveKeyWithValue("expr", "fdb_operator_cluster_status{namespace=\"my-ns\",name=\"operator-test-1\",status_type=\"available\"} == 0"))
+			Expect(
+				alerts["FoundationDBClusterFaultToleranceReduced"],
+			).To(HaveKeyWithValue("for", "300s"))
+			Expect(
+				alerts["FoundationDBClusterProcessGroupConditions"],
+			).To(HaveKeyWithValue("for", "1800s"))
+			Expect(
+				alerts["FoundationDBClusterReconciliationStuck"],
+			).To(HaveKeyWithValue("for", "3600s"))
+			Expect(alerts["FoundationDBClusterUnavailable"]).NotTo(HaveKey("labels"))
+		})
+	})
+
+	When("custom thresholds and labels are used", func() {
+		BeforeEach(func() {
+			cluster.Spec.PrometheusRuleOptions.UnavailableSeconds = pointer.Int(30)
+			cluster.Spec.PrometheusRuleOptions.ReconciliationStuckSeconds = pointer.Int(600)
+			cluster.Spec.PrometheusRuleOptions.Labels = map[string]string{"prometheus": "fdb"}
+			cluster.Spec.PrometheusRuleOptions.AlertLabels = map[string]string{
+				"severity": "critical",
+			}
+		})
+
+		It("should generate the alerts with the custom settings", func() {
+			rule := GetPrometheusRule(cluster)
+			Expect(rule.GetLabels()).To(HaveKeyWithValue("prometheus", "fdb"))
+
+			alerts := getAlerts(rule)
+			Expect(alerts["FoundationDBClusterUnavailable"]).To(HaveKeyWithValue("for", "30s"))
+			Expect(
+				alerts["FoundationDBClusterReconciliationStuck"],
+			).To(HaveKeyWithValue("for", "600s"))
+			Expect(
+				alerts["FoundationDBClusterUnavailable"],
+			).To(HaveKeyWithValue("labels", map[string]interface{}{"severity": "critical"}))
+		})
+	})
+})
@@ -0,0 +1,202 @@
+/*
+ * recoverybudget.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recoverybudget
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+)
+
+// lastRecoveryTolerance defines how much the last recovery timestamp calculated from the machine-readable status
+// can differ from the last recovery timestamp in the cluster status before it is updated. The seconds since the last
+// recovery are reported relative to the time the status was generated, so the calculated timestamp will vary slightly
+// between reconciliations.
+const lastRecoveryTolerance = 10 * time.Second
+
+// Ensure that the BudgetExhaustedError implements the error interface.
+var _ error = (*BudgetExhaustedError)(nil)
+
+// BudgetExhaustedError represents an error when the operator already performed the maximum number of actions that
+// can cause a recovery in the time window of the recovery budget.
+type BudgetExhaustedError struct {
+	// maxRecoveries represents the maximum number of actions that can cause a recovery in the time window.
+	maxRecoveries int
+	// window represents the time window of the recovery budget.
+	window time.Duration
+	// waitTime represents the time until the operator is allowed to perform the next action.
+	waitTime time.Duration
+}
+
+// Error returns the error string for this error.
+func (err BudgetExhaustedError) Error() string {
+	return fmt.Sprintf(
+		"recovery budget of %d recoveries in %s is exhausted, next recovery is allowed in %s",
+		err.maxRecoveries,
+		err.window.String(),
+		err.waitTime.String(),
+	)
+}
+
+// GetWaitTime returns the time until the operator is allowed to perform the next action that can cause a recovery.
+// The result can be used to delay the reconcile queue.
+func (err BudgetExhaustedError) GetWaitTime() time.Duration {
+	return err.waitTime
+}
+
+// getRecoveriesInWindow returns the recorded actions of the cluster that are inside the time window of the recovery
+// budget.
+func getRecoveriesInWindow(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	now time.Time,
+) []fdbv1beta2.RecoveryBudgetEntry {
+	if cluster.Status.RecoveryBudget == nil {
+		return nil
+	}
+
+	windowStart := now.Add(-cluster.GetRecoveryBudgetWindow())
+	var recoveries []fdbv1beta2.RecoveryBudgetEntry
+	for _, recovery := range cluster.Status.RecoveryBudget.Recoveries {
+		if recovery.Timestamp.After(windowStart) {
+			recoveries = append(recoveries, recovery)
+		}
+	}
+
+	return recoveries
+}
+
+// getNextRecoveryTime returns the time when the next action that can cause a recovery is allowed based on the provided
+// recoveries. If the budget is not exhausted, nil will be returned.
+func getNextRecoveryTime(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	recoveries []fdbv1beta2.RecoveryBudgetEntry,
+) *time.Time {
+	maxRecoveries := cluster.GetMaxRecoveries()
+	if len(recoveries) < maxRecoveries {
+		return nil
+	}
+
+	// The recoveries are recorded in chronological order, so the budget will allow the next recovery once enough of
+	// the oldest recoveries are outside the time window.
+	nextRecovery := recoveries[len(recoveries)-maxRecoveries].Timestamp.Add(
+		cluster.GetRecoveryBudgetWindow(),
+	)
+
+	return &nextRecovery
+}
+
+// setRecoveries updates the provided recovery budget status with the provided recoveries.
+func setRecoveries(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	budget *fdbv1beta2.RecoveryBudgetStatus,
+	recoveries []fdbv1beta2.RecoveryBudgetEntry,
+) {
+	budget.Recoveries = recoveries
+	budget.RemainingRecoveries = max(cluster.GetMaxRecoveries()-len(recoveries), 0)
+	budget.NextRecoveryTimestamp = nil
+
+	nextRecovery := getNextRecoveryTime(cluster, recoveries)
+	if nextRecovery != nil {
+		budget.NextRecoveryTimestamp = &metav1.Time{Time: *nextRecovery}
+	}
+}
+
+// CheckBudget returns a BudgetExhaustedError if the operator is not allowed to perform another action that can cause a
+// recovery. If the recovery budget is disabled, nil will be returned.
+func CheckBudget(cluster *fdbv1beta2.FoundationDBCluster, now time.Time) error {
+	if !cluster.UseRecoveryBudget() {
+		return nil
+	}
+
+	nextRecovery := getNextRecoveryTime(cluster, getRecoveriesInWindow(cluster, now))
+	if nextRecovery == nil {
+		return nil
+	}
+
+	return BudgetExhaustedError{
+		maxRecoveries: cluster.GetMaxRecoveries(),
+		window:        cluster.GetRecoveryBudgetWindow(),
+		waitTime:      nextRecovery.Sub(now),
+	}
+}
+
+// RecordRecovery adds the provided action to the recovery budget in the cluster status. The changes must be persisted
+// by the caller. If the recovery budget is disabled, the cluster status will not be changed.
+func RecordRecovery(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	action fdbv1beta2.RecoveryAction,
+	now time.Time,
+) {
+	if !cluster.UseRecoveryBudget() {
+		return
+	}
+
+	recoveries := append(getRecoveriesInWindow(cluster, now), fdbv1beta2.RecoveryBudgetEntry{
+		Action:    action,
+		Timestamp: metav1.Time{Time: now},
+	})
+
+	if cluster.Status.RecoveryBudget == nil {
+		cluster.Status.RecoveryBudget = &fdbv1beta2.RecoveryBudgetStatus{}
+	}
+
+	setRecoveries(cluster, cluster.Status.RecoveryBudget, recoveries)
+}
+
+// GetStatus returns the updated recovery budget status of the cluster. Recorded actions that are outside the time
+// window will be removed and the last recovery timestamp will be updated based on the recovery state of the provided
+// machine-readable status. If the recovery budget is disabled, nil will be returned.
+func GetStatus(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	now time.Time,
+) *fdbv1beta2.RecoveryBudgetStatus {
+	if !cluster.UseRecoveryBudget() {
+		return nil
+	}
+
+	budget := &fdbv1beta2.RecoveryBudgetStatus{}
+	if cluster.Status.RecoveryBudget != nil {
+		budget.LastRecoveryTimestamp = cluster.Status.RecoveryBudget.LastRecoveryTimestamp.DeepCopy()
+	}
+
+	setRecoveries(cluster, budget, getRecoveriesInWindow(cluster, now))
+
+	// Older versions of FoundationDB don't report the recovery state.
+	if status == nil || status.Cluster.RecoveryState.Name == "" {
+		return budget
+	}
+
+	lastRecovery := now.Add(
+		-time.Duration(
+			status.Cluster.RecoveryState.SecondsSinceLastRecovered * float64(time.Second),
+		),
+	).Truncate(time.Second)
+	if budget.LastRecoveryTimestamp == nil ||
+		lastRecovery.Sub(budget.LastRecoveryTimestamp.Time).Abs() > lastRecoveryTolerance {
+		budget.LastRecoveryTimestamp = &metav1.Time{Time: lastRecovery}
+	}
+
+	return budget
+}
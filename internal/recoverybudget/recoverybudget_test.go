@@ -0,0 +1,220 @@
+/*
+ * recoverybudget_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recoverybudget
+
+import (
+	"errors"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("recoverybudget", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	now := time.Now()
+
+	recordedAt := func(ago ...time.Duration) []fdbv1beta2.RecoveryBudgetEntry {
+		recoveries := make([]fdbv1beta2.RecoveryBudgetEntry, 0, len(ago))
+		for _, duration := range ago {
+			recoveries = append(recoveries, fdbv1beta2.RecoveryBudgetEntry{
+				Action:    fdbv1beta2.RecoveryActionExclude,
+				Timestamp: metav1.Time{Time: now.Add(-duration)},
+			})
+		}
+
+		return recoveries
+	}
+
+	BeforeEach(func() {
+		cluster = &fdbv1beta2.FoundationDBCluster{
+			Spec: fdbv1beta2.FoundationDBClusterSpec{
+				AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+					RecoveryBudget: &fdbv1beta2.RecoveryBudgetOptions{
+						MaxRecoveries: pointer.Int(2),
+					},
+				},
+			},
+		}
+	})
+
+	When("checking the budget", func() {
+		var err error
+
+		JustBeforeEach(func() {
+			err = CheckBudget(cluster, now)
+		})
+
+		When("the recovery budget is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.RecoveryBudget = nil
+				cluster.Status.RecoveryBudget = &fdbv1beta2.RecoveryBudgetStatus{
+					Recoveries: recordedAt(time.Minute, 2*time.Minute, 3*time.Minute),
+				}
+			})
+
+			It("should allow the recovery", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("no recoveries were recorded", func() {
+			It("should allow the recovery", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("fewer recoveries than allowed were recorded", func() {
+			BeforeEach(func() {
+				cluster.Status.RecoveryBudget = &fdbv1beta2.RecoveryBudgetStatus{
+					Recoveries: recordedAt(time.Minute),
+				}
+			})
+
+			It("should allow the recovery", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the budget is exhausted", func() {
+			BeforeEach(func() {
+				cluster.Status.RecoveryBudget = &fdbv1beta2.RecoveryBudgetStatus{
+					Recoveries: recordedAt(50*time.Minute, 10*time.Minute),
+				}
+			})
+
+			It("should not allow the recovery", func() {
+				Expect(err).To(HaveOccurred())
+				budgetErr := &BudgetExhaustedError{}
+				Expect(errors.As(err, budgetErr)).To(BeTrue())
+				Expect(budgetErr.GetWaitTime()).To(Equal(10 * time.Minute))
+			})
+
+			When("the oldest recovery is outside the time window", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.RecoveryBudget.WindowSeconds = pointer.Int(1800)
+				})
+
+				It("should allow the recovery", func() {
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+	})
+
+	When("recording a recovery", func() {
+		BeforeEach(func() {
+			cluster.Status.RecoveryBudget = &fdbv1beta2.RecoveryBudgetStatus{
+				Recoveries: recordedAt(2*time.Hour, 10*time.Minute),
+			}
+		})
+
+		JustBeforeEach(func() {
+			RecordRecovery(cluster, fdbv1beta2.RecoveryActionBounce, now)
+		})
+
+		It("should add the recovery and remove the recoveries outside the time window", func() {
+			budget := cluster.Status.RecoveryBudget
+			Expect(budget.Recoveries).To(HaveLen(2))
+			Expect(budget.Recoveries[1].Action).To(Equal(fdbv1beta2.RecoveryActionBounce))
+			Expect(budget.RemainingRecoveries).To(BeZero())
+			Expect(budget.NextRecoveryTimestamp).NotTo(BeNil())
+			Expect(budget.NextRecoveryTimestamp.Time).To(Equal(now.Add(50 * time.Minute)))
+		})
+
+		When("the recovery budget is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.RecoveryBudget = nil
+			})
+
+			It("should not change the status", func() {
+				Expect(cluster.Status.RecoveryBudget.Recoveries).To(HaveLen(2))
+			})
+		})
+	})
+
+	When("getting the status", func() {
+		var status *fdbv1beta2.FoundationDBStatus
+		var budget *fdbv1beta2.RecoveryBudgetStatus
+
+		BeforeEach(func() {
+			status = &fdbv1beta2.FoundationDBStatus{}
+			status.Cluster.RecoveryState = fdbv1beta2.RecoveryState{
+				Name:                      "fully_recovered",
+				SecondsSinceLastRecovered: 300,
+			}
+			cluster.Status.RecoveryBudget = &fdbv1beta2.RecoveryBudgetStatus{
+				Recoveries: recordedAt(2*time.Hour, 10*time.Minute),
+			}
+		})
+
+		JustBeforeEach(func() {
+			budget = GetStatus(cluster, status, now)
+		})
+
+		It("should return the current budget", func() {
+			Expect(budget).NotTo(BeNil())
+			Expect(budget.Recoveries).To(HaveLen(1))
+			Expect(budget.RemainingRecoveries).To(Equal(1))
+			Expect(budget.NextRecoveryTimestamp).To(BeNil())
+			Expect(budget.LastRecoveryTimestamp).NotTo(BeNil())
+			Expect(
+				budget.LastRecoveryTimestamp.Time,
+			).To(Equal(now.Add(-5 * time.Minute).Truncate(time.Second)))
+		})
+
+		When("the last recovery timestamp differs only slightly", func() {
+			var lastRecovery metav1.Time
+
+			BeforeEach(func() {
+				lastRecovery = metav1.Time{Time: now.Add(-5*time.Minute - 2*time.Second)}
+				cluster.Status.RecoveryBudget.LastRecoveryTimestamp = &lastRecovery
+			})
+
+			It("should keep the last recovery timestamp", func() {
+				Expect(budget.LastRecoveryTimestamp.Time).To(Equal(lastRecovery.Time))
+			})
+		})
+
+		When("the recovery state is not reported", func() {
+			BeforeEach(func() {
+				status.Cluster.RecoveryState = fdbv1beta2.RecoveryState{}
+			})
+
+			It("should not set the last recovery timestamp", func() {
+				Expect(budget.LastRecoveryTimestamp).To(BeNil())
+			})
+		})
+
+		When("the recovery budget is disabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.RecoveryBudget = nil
+			})
+
+			It("should return nil", func() {
+				Expect(budget).To(BeNil())
+			})
+		})
+	})
+})
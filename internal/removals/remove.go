@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
 
@@ -237,3 +238,56 @@ func RemovalAllowed(lastDeletion int64, currentTimestamp int64, waitTime int) (i
 
 	return 0, true
 }
+
+// FilterSuspendedProcessGroups returns the process groups that were suspended for at least the minimum suspension
+// duration of the cluster and the remaining time until the next suspended process group can be removed. If the
+// suspension is disabled the input list will be returned.
+func FilterSuspendedProcessGroups(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroupsToRemove []*fdbv1beta2.ProcessGroupStatus,
+	currentTime time.Time,
+) ([]*fdbv1beta2.ProcessGroupStatus, time.Duration) {
+	suspensionDuration := time.Duration(cluster.GetMinimumSuspensionDurationSeconds()) * time.Second
+	if suspensionDuration == 0 {
+		return processGroupsToRemove, 0
+	}
+
+	var remainingTime time.Duration
+	filteredList := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupsToRemove))
+	for _, processGroup := range processGroupsToRemove {
+		if !processGroup.IsSuspended() {
+			continue
+		}
+
+		suspendedFor := currentTime.Sub(processGroup.SuspensionTimestamp.Time)
+		if suspendedFor < suspensionDuration {
+			remaining := suspensionDuration - suspendedFor
+			if remainingTime == 0 || remaining < remainingTime {
+				remainingTime = remaining
+			}
+
+			continue
+		}
+
+		filteredList = append(filteredList, processGroup)
+	}
+
+	return filteredList, remainingTime
+}
+
+// GetLastSuspension returns the latest suspension timestamp of all process groups as Unix timestamp, if no process
+// group is suspended 0 will be returned.
+func GetLastSuspension(processGroups []*fdbv1beta2.ProcessGroupStatus) int64 {
+	var lastSuspension int64
+	for _, processGroup := range processGroups {
+		if !processGroup.IsSuspended() {
+			continue
+		}
+
+		if processGroup.SuspensionTimestamp.Unix() > lastSuspension {
+			lastSuspension = processGroup.SuspensionTimestamp.Unix()
+		}
+	}
+
+	return lastSuspension
+}
@@ -263,6 +263,69 @@ var _ = Describe("remove", func() {
 		)
 	})
 
+	When("filtering the suspended process groups", func() {
+		var currentTime time.Time
+		var processGroups []*fdbv1beta2.ProcessGroupStatus
+		var cluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			currentTime = time.Now()
+			processGroups = []*fdbv1beta2.ProcessGroupStatus{
+				{
+					ProcessGroupID: "storage-1",
+				},
+				{
+					ProcessGroupID: "storage-2",
+					SuspensionTimestamp: &metav1.Time{
+						Time: currentTime.Add(-10 * time.Minute),
+					},
+				},
+				{
+					ProcessGroupID: "storage-3",
+					SuspensionTimestamp: &metav1.Time{
+						Time: currentTime.Add(-1 * time.Minute),
+					},
+				},
+			}
+			cluster = &fdbv1beta2.FoundationDBCluster{}
+		})
+
+		When("the suspension is disabled", func() {
+			It("should return all process groups", func() {
+				filtered, remainingTime := FilterSuspendedProcessGroups(
+					cluster,
+					processGroups,
+					currentTime,
+				)
+				Expect(filtered).To(Equal(processGroups))
+				Expect(remainingTime).To(BeZero())
+			})
+		})
+
+		When("the suspension is enabled", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MinimumSuspensionDurationSeconds = pointer.Int(300)
+			})
+
+			It("should only return the process groups that are suspended long enough", func() {
+				filtered, remainingTime := FilterSuspendedProcessGroups(
+					cluster,
+					processGroups,
+					currentTime,
+				)
+				Expect(filtered).To(HaveLen(1))
+				Expect(filtered[0].ProcessGroupID).To(Equal(fdbv1beta2.ProcessGroupID("storage-2")))
+				Expect(remainingTime).To(Equal(4 * time.Minute))
+			})
+		})
+
+		It("should return the last suspension", func() {
+			Expect(
+				GetLastSuspension(processGroups),
+			).To(Equal(currentTime.Add(-1 * time.Minute).Unix()))
+		})
+	})
+
 	DescribeTable(
 		"when getting the addresses to validate before removal",
 		func(cluster *fdbv1beta2.FoundationDBCluster, expected []fdbv1beta2.ProcessAddress) {
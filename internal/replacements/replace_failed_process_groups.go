@@ -195,7 +195,15 @@ func ReplaceFailedProcessGroups(
 			continue
 		}
 
-		failureCondition, failureTime := processGroup.NeedsReplacement(
+		// Manually excluded process groups should not be replaced because of the exclusion, all other failure
+		// conditions are still taken into account.
+		replacementCandidate := processGroup
+		if cluster.ProcessGroupIsManuallyExcluded(processGroup.ProcessGroupID) {
+			replacementCandidate = processGroup.DeepCopy()
+			replacementCandidate.UpdateCondition(fdbv1beta2.ProcessIsMarkedAsExcluded, false)
+		}
+
+		failureCondition, failureTime := replacementCandidate.NeedsReplacement(
 			failureDetectionTimeSeconds,
 			taintReplacementTimeSeconds,
 		)
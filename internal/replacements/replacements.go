@@ -38,14 +38,18 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/podresize"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/volumes"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/podmanager"
 )
 
 // ReplaceMisconfiguredProcessGroups checks if the cluster has any misconfigured process groups that must be replaced.
+// The storageClassReader is used to read the storage classes, those are not cached by the controller manager.
 func ReplaceMisconfiguredProcessGroups(
 	ctx context.Context,
 	podManager podmanager.PodLifecycleManager,
 	ctrlClient client.Client,
+	storageClassReader client.Reader,
 	log logr.Logger,
 	cluster *fdbv1beta2.FoundationDBCluster,
 	replaceOnSecurityContextChange bool,
@@ -67,6 +71,7 @@ func ReplaceMisconfiguredProcessGroups(
 			ctx,
 			podManager,
 			ctrlClient,
+			storageClassReader,
 			log,
 			cluster,
 			processGroup,
@@ -87,11 +92,13 @@ func ReplaceMisconfiguredProcessGroups(
 	return hasReplacements, nil
 }
 
-// ProcessGroupNeedsReplacements checks if a process group needs to be replaced.
+// ProcessGroupNeedsReplacements checks if a process group needs to be replaced. The storageClassReader is used to read
+// the storage classes, those are not cached by the controller manager.
 func ProcessGroupNeedsReplacements(
 	ctx context.Context,
 	podManager podmanager.PodLifecycleManager,
 	ctrlClient client.Client,
+	storageClassReader client.Reader,
 	log logr.Logger,
 	cluster *fdbv1beta2.FoundationDBCluster,
 	processGroup *fdbv1beta2.ProcessGroupStatus,
@@ -111,7 +118,14 @@ func ProcessGroupNeedsReplacements(
 					"processGroupID", processGroup.ProcessGroupID)
 			}
 		} else {
-			needsPVCRemoval, err := processGroupNeedsRemovalForPVC(cluster, pvc, log, processGroup)
+			needsPVCRemoval, err := processGroupNeedsRemovalForPVC(
+				ctx,
+				storageClassReader,
+				cluster,
+				pvc,
+				log,
+				processGroup,
+			)
 			if err != nil {
 				return false, err
 			}
@@ -138,6 +152,8 @@ func ProcessGroupNeedsReplacements(
 }
 
 func processGroupNeedsRemovalForPVC(
+	ctx context.Context,
+	storageClassReader client.Reader,
 	cluster *fdbv1beta2.FoundationDBCluster,
 	pvc *corev1.PersistentVolumeClaim,
 	log logr.Logger,
@@ -178,7 +194,32 @@ func processGroupNeedsRemovalForPVC(
 		return false, err
 	}
 
+	// If the storage class was changed and the storage class migration is enabled, the process group will be replaced
+	// by the storage class migration.
+	if cluster.UseStorageClassMigration() && volumes.StorageClassChanged(pvc, desiredPVC) {
+		logger.V(1).Info("PVC will be replaced by the storage class migration")
+		return false, nil
+	}
+
 	if pvc.Annotations[fdbv1beta2.LastSpecKey] != pvcHash {
+		// If only the storage request was increased and the storage class allows volume expansion, the PVC will be
+		// expanded in place and the process group doesn't have to be replaced.
+		canBeExpanded, err := volumes.CanBeExpanded(
+			ctx,
+			storageClassReader,
+			cluster,
+			pvc,
+			desiredPVC,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		if canBeExpanded {
+			logger.V(1).Info("PVC will be expanded in place")
+			return false, nil
+		}
+
 		logger.Info(
 			"Replace process group",
 			"reason",
@@ -284,6 +325,18 @@ func processGroupNeedsRemovalForPod(
 		return false, err
 	}
 
+	// If only the resources were changed, the Pod will be resized in place and the process group doesn't have to be
+	// replaced.
+	canBeResized, err := podresize.CanBeResizedInPlace(cluster, processGroup, pod, spec)
+	if err != nil {
+		return false, err
+	}
+
+	if canBeResized {
+		logger.V(1).Info("Pod will be resized in place")
+		return false, nil
+	}
+
 	if pointer.BoolDeref(cluster.Spec.ReplaceInstancesWhenResourcesChange, false) {
 		if resourcesNeedsReplacement(spec.Containers, pod.Spec.Containers) {
 			logger.Info("Replace process group",
@@ -33,6 +33,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
@@ -314,6 +315,8 @@ var _ = Describe("replace_misconfigured_pods", func() {
 
 				JustBeforeEach(func() {
 					needsRemoval, err = processGroupNeedsRemovalForPVC(
+						context.Background(),
+						k8sClient,
 						cluster,
 						pvc,
 						log,
@@ -349,6 +352,115 @@ var _ = Describe("replace_misconfigured_pods", func() {
 						Expect(needsRemoval).To(BeTrue())
 					})
 				})
+
+				When("the storage class was changed", func() {
+					BeforeEach(func() {
+						pvc.Spec.StorageClassName = pointer.String("old")
+						processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+						processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								StorageClassName: pointer.String("new"),
+							},
+						}
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+					})
+
+					It("should need a removal", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(needsRemoval).To(BeTrue())
+					})
+
+					When("the storage class migration is enabled", func() {
+						BeforeEach(func() {
+							cluster.Spec.AutomationOptions.StorageClassMigration = &fdbv1beta2.StorageClassMigrationOptions{
+								Enabled: pointer.Bool(true),
+							}
+						})
+
+						It("should not need a removal", func() {
+							Expect(err).NotTo(HaveOccurred())
+							Expect(needsRemoval).To(BeFalse())
+						})
+					})
+				})
+
+				When("the storage request was increased", func() {
+					var allowVolumeExpansion *bool
+
+					BeforeEach(func() {
+						allowVolumeExpansion = pointer.Bool(true)
+						cluster.Spec.AutomationOptions.UseVolumeExpansion = pointer.Bool(true)
+						pvc.Spec.StorageClassName = pointer.String("expandable")
+						processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+						processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								StorageClassName: pointer.String("expandable"),
+								Resources: corev1.VolumeResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceStorage: resource.MustParse("128G"),
+									},
+								},
+							},
+						}
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+
+						pvc, err = internal.GetPvc(cluster, processGroup)
+						Expect(err).NotTo(HaveOccurred())
+
+						cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].VolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse(
+							"256G",
+						)
+					})
+
+					JustBeforeEach(func() {
+						Expect(k8sClient.Create(context.Background(), &storagev1.StorageClass{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "expandable",
+							},
+							AllowVolumeExpansion: allowVolumeExpansion,
+						})).To(Succeed())
+
+						needsRemoval, err = processGroupNeedsRemovalForPVC(
+							context.Background(),
+							k8sClient,
+							cluster,
+							pvc,
+							log,
+							processGroup,
+						)
+					})
+
+					When("the storage class allows volume expansion", func() {
+						It("should not need a removal", func() {
+							Expect(err).NotTo(HaveOccurred())
+							Expect(needsRemoval).To(BeFalse())
+						})
+
+						When("volume expansion is disabled", func() {
+							BeforeEach(func() {
+								cluster.Spec.AutomationOptions.UseVolumeExpansion = pointer.Bool(
+									false,
+								)
+							})
+
+							It("should need a removal", func() {
+								Expect(err).NotTo(HaveOccurred())
+								Expect(needsRemoval).To(BeTrue())
+							})
+						})
+					})
+
+					When("the storage class doesn't allow volume expansion", func() {
+						BeforeEach(func() {
+							allowVolumeExpansion = pointer.Bool(false)
+						})
+
+						It("should need a removal", func() {
+							Expect(err).NotTo(HaveOccurred())
+							Expect(needsRemoval).To(BeTrue())
+						})
+					})
+				})
 			})
 
 			When("replacement for resource changes is activated", func() {
@@ -1063,6 +1175,7 @@ var _ = Describe("replace_misconfigured_pods", func() {
 					context.Background(),
 					&podmanager.StandardPodLifecycleManager{},
 					k8sClient,
+					k8sClient,
 					log,
 					cluster,
 					true,
@@ -1093,6 +1206,7 @@ var _ = Describe("replace_misconfigured_pods", func() {
 					context.Background(),
 					&podmanager.StandardPodLifecycleManager{},
 					k8sClient,
+					k8sClient,
 					log,
 					cluster,
 					true,
@@ -1119,6 +1233,7 @@ var _ = Describe("replace_misconfigured_pods", func() {
 					context.Background(),
 					&podmanager.StandardPodLifecycleManager{},
 					k8sClient,
+					k8sClient,
 					log,
 					cluster,
 					true,
@@ -1177,6 +1292,7 @@ var _ = Describe("replace_misconfigured_pods", func() {
 						context.Background(),
 						&podmanager.StandardPodLifecycleManager{},
 						k8sClient,
+						k8sClient,
 						log,
 						cluster,
 						true,
@@ -0,0 +1,247 @@
+/*
+ * storage_class_migration.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/volumes"
+)
+
+// MigrateStorageClass marks the process groups for removal that must be replaced to use the storage class defined in
+// the VolumeClaimTemplate. The process groups are replaced one fault domain at a time, the process groups of the next
+// fault domain will only be replaced once all replaced process groups of the current fault domain are removed and the
+// in-flight bytes reported in the provided status are below the configured limit. The method returns true if process
+// groups were marked for removal and the updated status of the migration, which can be nil if no migration is
+// required.
+func MigrateStorageClass(
+	ctx context.Context,
+	ctrlClient client.Client,
+	log logr.Logger,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	now time.Time,
+) (bool, *fdbv1beta2.StorageClassMigrationStatus, error) {
+	currentStatus := cluster.Status.StorageClassMigration
+	pending := map[fdbv1beta2.FaultDomain][]*fdbv1beta2.ProcessGroupStatus{}
+	inProgress := map[fdbv1beta2.FaultDomain]int{}
+	var processGroups, migratedProcessGroups int
+	var source, target string
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !processGroup.ProcessClass.IsStateful() {
+			continue
+		}
+
+		desiredPVC, err := internal.GetPvc(cluster, processGroup)
+		if err != nil {
+			return false, currentStatus, err
+		}
+
+		currentPVC := &corev1.PersistentVolumeClaim{}
+		err = ctrlClient.Get(
+			ctx,
+			client.ObjectKey{Namespace: cluster.Namespace, Name: processGroup.GetPvcName(cluster)},
+			currentPVC,
+		)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return false, currentStatus, err
+		}
+
+		// If the PVC doesn't exist yet, it will be created with the desired storage class.
+		if err != nil || !volumes.StorageClassChanged(currentPVC, desiredPVC) {
+			if !processGroup.IsMarkedForRemoval() {
+				processGroups++
+				migratedProcessGroups++
+			}
+
+			continue
+		}
+
+		processGroups++
+		if target == "" {
+			source = pointer.StringDeref(currentPVC.Spec.StorageClassName, "")
+			target = pointer.StringDeref(desiredPVC.Spec.StorageClassName, "")
+		}
+
+		if processGroup.IsMarkedForRemoval() {
+			inProgress[processGroup.FaultDomain]++
+			continue
+		}
+
+		pending[processGroup.FaultDomain] = append(
+			pending[processGroup.FaultDomain],
+			processGroup,
+		)
+	}
+
+	if len(pending) == 0 && len(inProgress) == 0 {
+		if currentStatus == nil ||
+			currentStatus.Phase == fdbv1beta2.StorageClassMigrationPhaseCompleted {
+			return false, currentStatus, nil
+		}
+
+		migration := currentStatus.DeepCopy()
+		migration.Phase = fdbv1beta2.StorageClassMigrationPhaseCompleted
+		migration.CurrentFaultDomain = ""
+		migration.ProcessGroups = processGroups
+		migration.MigratedProcessGroups = migratedProcessGroups
+		migration.MigratedPercentage = 100
+		migration.EstimatedRemainingSeconds = nil
+		migration.CompletionTimestamp = &metav1.Time{Time: now}
+		migration.Message = fmt.Sprintf(
+			"All process groups use the storage class %s",
+			migration.TargetStorageClass,
+		)
+
+		return false, migration, nil
+	}
+
+	var migration *fdbv1beta2.StorageClassMigrationStatus
+	if currentStatus == nil ||
+		currentStatus.Phase == fdbv1beta2.StorageClassMigrationPhaseCompleted ||
+		currentStatus.TargetStorageClass != target {
+		migration = &fdbv1beta2.StorageClassMigrationStatus{
+			SourceStorageClass: source,
+			TargetStorageClass: target,
+			StartTimestamp:     &metav1.Time{Time: now},
+		}
+	} else {
+		migration = currentStatus.DeepCopy()
+	}
+
+	migration.ProcessGroups = processGroups
+	migration.MigratedProcessGroups = migratedProcessGroups
+	migration.MigratedPercentage = migratedProcessGroups * 100 / processGroups
+	migration.EstimatedRemainingSeconds = nil
+
+	// Estimate the remaining time based on the progress since the start of the migration.
+	if migration.StartTimestamp != nil && migratedProcessGroups > 0 {
+		elapsed := now.Sub(migration.StartTimestamp.Time).Seconds()
+		remaining := int64(
+			elapsed / float64(
+				migratedProcessGroups,
+			) * float64(
+				processGroups-migratedProcessGroups,
+			),
+		)
+		migration.EstimatedRemainingSeconds = &remaining
+	}
+
+	if cluster.IsStorageClassMigrationPaused() {
+		migration.Phase = fdbv1beta2.StorageClassMigrationPhasePaused
+		migration.Message = fmt.Sprintf(
+			"The migration is paused by the %s annotation",
+			fdbv1beta2.StorageClassMigrationPausedAnnotation,
+		)
+		return false, migration, nil
+	}
+
+	migration.Phase = fdbv1beta2.StorageClassMigrationPhaseMigrating
+	faultDomain := migration.CurrentFaultDomain
+	if len(pending[faultDomain]) == 0 {
+		// Only move to the next fault domain once all replaced process groups of the current fault domain are removed.
+		if inProgress[faultDomain] > 0 {
+			migration.Message = fmt.Sprintf(
+				"Waiting for %d process groups in fault domain %s to be removed",
+				inProgress[faultDomain],
+				faultDomain,
+			)
+			return false, migration, nil
+		}
+
+		if len(pending) == 0 {
+			var removals int
+			for _, count := range inProgress {
+				removals += count
+			}
+
+			migration.Message = fmt.Sprintf(
+				"Waiting for %d process groups to be removed",
+				removals,
+			)
+			return false, migration, nil
+		}
+
+		faultDomains := make([]fdbv1beta2.FaultDomain, 0, len(pending))
+		for pendingFaultDomain := range pending {
+			faultDomains = append(faultDomains, pendingFaultDomain)
+		}
+		slices.Sort(faultDomains)
+		faultDomain = faultDomains[0]
+	}
+
+	maxInFlightBytes := cluster.GetStorageClassMigrationMaxInFlightBytes()
+	if maxInFlightBytes > 0 && status != nil &&
+		int64(status.Cluster.Data.MovingData.InFlightBytes) > maxInFlightBytes {
+		migration.Message = fmt.Sprintf(
+			"Waiting for data movement, %d bytes are in flight and the limit is %d bytes",
+			status.Cluster.Data.MovingData.InFlightBytes,
+			maxInFlightBytes,
+		)
+		return false, migration, nil
+	}
+
+	migration.CurrentFaultDomain = faultDomain
+	migration.Message = fmt.Sprintf(
+		"Replacing process groups in fault domain %s, %d of %d process groups use the storage class %s",
+		faultDomain,
+		migratedProcessGroups,
+		processGroups,
+		migration.TargetStorageClass,
+	)
+
+	hasReplacements := false
+	maxReplacements, _ := getReplacementInformation(cluster, cluster.GetMaxConcurrentReplacements())
+	for _, processGroup := range pending[faultDomain] {
+		if maxReplacements <= 0 {
+			log.Info("Early abort, reached limit of concurrent replacements")
+			break
+		}
+
+		log.Info("Replace process group",
+			"processGroupID", processGroup.ProcessGroupID,
+			"faultDomain", faultDomain,
+			"reason", fmt.Sprintf(
+				"storage class migration from %s to %s",
+				migration.SourceStorageClass,
+				migration.TargetStorageClass,
+			),
+		)
+		processGroup.MarkForRemoval()
+		hasReplacements = true
+		maxReplacements--
+	}
+
+	return hasReplacements, migration, nil
+}
@@ -0,0 +1,287 @@
+/*
+ * storage_class_migration_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replacements
+
+import (
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("storage_class_migration", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+	var hasReplacements bool
+	var migration *fdbv1beta2.StorageClassMigrationStatus
+	var err error
+	now := time.Now()
+
+	setStorageClass := func(storageClass string) {
+		processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+		processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.String(storageClass),
+			},
+		}
+		cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+	}
+
+	createPVCs := func() {
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if !processGroup.ProcessClass.IsStateful() {
+				continue
+			}
+
+			pvc, err := internal.GetPvc(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(context.Background(), pvc)).To(Succeed())
+		}
+	}
+
+	getMarkedForRemoval := func() []fdbv1beta2.ProcessGroupID {
+		var processGroupIDs []fdbv1beta2.ProcessGroupID
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if processGroup.IsMarkedForRemoval() {
+				processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+			}
+		}
+
+		return processGroupIDs
+	}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(
+			internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{}),
+		).NotTo(HaveOccurred())
+		cluster.Spec.AutomationOptions.StorageClassMigration = &fdbv1beta2.StorageClassMigrationOptions{
+			Enabled: pointer.Bool(true),
+		}
+		setStorageClass("old")
+
+		for _, processGroup := range []struct {
+			id           fdbv1beta2.ProcessGroupID
+			processClass fdbv1beta2.ProcessClass
+			faultDomain  fdbv1beta2.FaultDomain
+		}{
+			{"storage-1", fdbv1beta2.ProcessClassStorage, "zone-a"},
+			{"storage-2", fdbv1beta2.ProcessClassStorage, "zone-b"},
+			{"storage-3", fdbv1beta2.ProcessClassStorage, "zone-c"},
+			{"log-1", fdbv1beta2.ProcessClassLog, "zone-a"},
+			{"stateless-1", fdbv1beta2.ProcessClassStateless, "zone-a"},
+		} {
+			processGroupStatus := fdbv1beta2.NewProcessGroupStatus(
+				processGroup.id,
+				processGroup.processClass,
+				nil,
+			)
+			processGroupStatus.FaultDomain = processGroup.faultDomain
+			cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroupStatus)
+		}
+
+		createPVCs()
+		status = &fdbv1beta2.FoundationDBStatus{}
+	})
+
+	JustBeforeEach(func() {
+		hasReplacements, migration, err = MigrateStorageClass(
+			context.Background(),
+			k8sClient,
+			logf.Log.WithName("replacements"),
+			cluster,
+			status,
+			now,
+		)
+	})
+
+	When("the storage class is unchanged", func() {
+		It("should not replace any process groups", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasReplacements).To(BeFalse())
+			Expect(migration).To(BeNil())
+		})
+	})
+
+	When("the storage class was changed", func() {
+		BeforeEach(func() {
+			setStorageClass("new")
+		})
+
+		It("should replace the process groups of the first fault domain", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasReplacements).To(BeTrue())
+			Expect(
+				getMarkedForRemoval(),
+			).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1"), fdbv1beta2.ProcessGroupID("log-1")))
+			Expect(migration).NotTo(BeNil())
+			Expect(migration.SourceStorageClass).To(Equal("old"))
+			Expect(migration.TargetStorageClass).To(Equal("new"))
+			Expect(migration.Phase).To(Equal(fdbv1beta2.StorageClassMigrationPhaseMigrating))
+			Expect(migration.CurrentFaultDomain).To(Equal(fdbv1beta2.FaultDomain("zone-a")))
+			Expect(migration.ProcessGroups).To(Equal(4))
+			Expect(migration.MigratedProcessGroups).To(BeZero())
+			Expect(migration.StartTimestamp.Time).To(Equal(now))
+			Expect(migration.EstimatedRemainingSeconds).To(BeNil())
+		})
+
+		When("the maximum concurrent replacements is limited", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.MaxConcurrentReplacements = pointer.Int(1)
+			})
+
+			It("should only replace a single process group", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacements).To(BeTrue())
+				Expect(getMarkedForRemoval()).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+			})
+		})
+
+		When("the migration is paused", func() {
+			BeforeEach(func() {
+				cluster.Annotations = map[string]string{
+					fdbv1beta2.StorageClassMigrationPausedAnnotation: "true",
+				}
+			})
+
+			It("should not replace any process groups", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacements).To(BeFalse())
+				Expect(getMarkedForRemoval()).To(BeEmpty())
+				Expect(migration.Phase).To(Equal(fdbv1beta2.StorageClassMigrationPhasePaused))
+			})
+		})
+
+		When("more data is in flight than allowed", func() {
+			BeforeEach(func() {
+				quantity := resource.MustParse("1Gi")
+				cluster.Spec.AutomationOptions.StorageClassMigration.MaxInFlightBytes = &quantity
+				status.Cluster.Data.MovingData.InFlightBytes = 2 * 1024 * 1024 * 1024
+			})
+
+			It("should not replace any process groups", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacements).To(BeFalse())
+				Expect(getMarkedForRemoval()).To(BeEmpty())
+				Expect(migration.Phase).To(Equal(fdbv1beta2.StorageClassMigrationPhaseMigrating))
+				Expect(migration.Message).To(HavePrefix("Waiting for data movement"))
+			})
+		})
+
+		When("the process groups of the current fault domain are not yet removed", func() {
+			BeforeEach(func() {
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					if processGroup.FaultDomain == "zone-a" {
+						processGroup.MarkForRemoval()
+					}
+				}
+
+				cluster.Status.StorageClassMigration = &fdbv1beta2.StorageClassMigrationStatus{
+					SourceStorageClass: "old",
+					TargetStorageClass: "new",
+					Phase:              fdbv1beta2.StorageClassMigrationPhaseMigrating,
+					CurrentFaultDomain: "zone-a",
+					StartTimestamp:     &metav1.Time{Time: now.Add(-1 * time.Hour)},
+				}
+			})
+
+			It("should wait for the removal", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacements).To(BeFalse())
+				Expect(getMarkedForRemoval()).To(HaveLen(3))
+				Expect(migration.CurrentFaultDomain).To(Equal(fdbv1beta2.FaultDomain("zone-a")))
+				Expect(migration.Message).To(HavePrefix("Waiting for 2 process groups"))
+			})
+		})
+
+		When("the process groups of the first fault domain are migrated", func() {
+			BeforeEach(func() {
+				k8sClient.Clear()
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					if processGroup.FaultDomain == "zone-a" {
+						setStorageClass("new")
+					} else {
+						setStorageClass("old")
+					}
+
+					if !processGroup.ProcessClass.IsStateful() {
+						continue
+					}
+
+					pvc, err := internal.GetPvc(cluster, processGroup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(k8sClient.Create(context.Background(), pvc)).To(Succeed())
+				}
+				setStorageClass("new")
+
+				cluster.Status.StorageClassMigration = &fdbv1beta2.StorageClassMigrationStatus{
+					SourceStorageClass: "old",
+					TargetStorageClass: "new",
+					Phase:              fdbv1beta2.StorageClassMigrationPhaseMigrating,
+					CurrentFaultDomain: "zone-a",
+					StartTimestamp:     &metav1.Time{Time: now.Add(-1 * time.Hour)},
+				}
+			})
+
+			It("should replace the process groups of the next fault domain", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacements).To(BeTrue())
+				Expect(getMarkedForRemoval()).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-2")))
+				Expect(migration.CurrentFaultDomain).To(Equal(fdbv1beta2.FaultDomain("zone-b")))
+				Expect(migration.MigratedProcessGroups).To(Equal(2))
+				Expect(migration.MigratedPercentage).To(Equal(50))
+				Expect(migration.EstimatedRemainingSeconds).To(HaveValue(BeNumerically("==", 3600)))
+			})
+		})
+
+		When("all process groups are migrated", func() {
+			BeforeEach(func() {
+				k8sClient.Clear()
+				createPVCs()
+
+				cluster.Status.StorageClassMigration = &fdbv1beta2.StorageClassMigrationStatus{
+					SourceStorageClass: "old",
+					TargetStorageClass: "new",
+					Phase:              fdbv1beta2.StorageClassMigrationPhaseMigrating,
+					CurrentFaultDomain: "zone-c",
+					StartTimestamp:     &metav1.Time{Time: now.Add(-1 * time.Hour)},
+				}
+			})
+
+			It("should complete the migration", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hasReplacements).To(BeFalse())
+				Expect(migration.Phase).To(Equal(fdbv1beta2.StorageClassMigrationPhaseCompleted))
+				Expect(migration.CurrentFaultDomain).To(BeEmpty())
+				Expect(migration.MigratedPercentage).To(Equal(100))
+				Expect(migration.CompletionTimestamp.Time).To(Equal(now))
+			})
+		})
+	})
+})
@@ -0,0 +1,206 @@
+/*
+ * storagemigration.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storagemigration
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+)
+
+// GetStorageEngines returns the number of storage servers per storage engine based on the storage metadata reported
+// by the storage roles. Storage servers that don't report their storage engine are ignored.
+func GetStorageEngines(status *fdbv1beta2.FoundationDBStatus) map[fdbv1beta2.StorageEngine]int {
+	engines := map[fdbv1beta2.StorageEngine]int{}
+	if status == nil {
+		return engines
+	}
+
+	for _, process := range status.Cluster.Processes {
+		for _, role := range process.Roles {
+			if role.Role != string(fdbv1beta2.ProcessRoleStorage) {
+				continue
+			}
+
+			if role.StorageMetadata == nil || role.StorageMetadata.StorageEngine == "" {
+				continue
+			}
+
+			engines[role.StorageMetadata.StorageEngine]++
+		}
+	}
+
+	return engines
+}
+
+// getSourceEngine returns the storage engine that is used by the most storage servers, ignoring the target storage
+// engine. If no storage server uses another storage engine, the provided default will be returned.
+func getSourceEngine(
+	engines map[fdbv1beta2.StorageEngine]int,
+	target fdbv1beta2.StorageEngine,
+	defaultEngine fdbv1beta2.StorageEngine,
+) fdbv1beta2.StorageEngine {
+	source := defaultEngine
+	var sourceCount int
+	keys := make([]fdbv1beta2.StorageEngine, 0, len(engines))
+	for engine := range engines {
+		keys = append(keys, engine)
+	}
+	slices.Sort(keys)
+
+	for _, engine := range keys {
+		if engine == target || engines[engine] <= sourceCount {
+			continue
+		}
+
+		source = engine
+		sourceCount = engines[engine]
+	}
+
+	return source
+}
+
+// migrationSettingsAreRemoved returns true if the perpetual storage wiggle and the storage migration type are disabled
+// in the provided database configuration.
+func migrationSettingsAreRemoved(configuration fdbv1beta2.DatabaseConfiguration) bool {
+	if pointer.IntDeref(configuration.PerpetualStorageWiggle, 0) != 0 {
+		return false
+	}
+
+	return configuration.StorageMigrationType == nil ||
+		*configuration.StorageMigrationType == fdbv1beta2.StorageMigrationTypeDisabled
+}
+
+// GetMigrationStatus returns the updated status of the storage engine migration based on the storage engines reported
+// by the storage servers in the provided status. If no migration is required, the current status of the migration
+// will be returned, which can be nil. The migration will only advance to the next phase if the cluster has the desired
+// fault tolerance, otherwise the migration will be paused.
+func GetMigrationStatus(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	hasDesiredFaultTolerance bool,
+	now time.Time,
+) *fdbv1beta2.StorageEngineMigrationStatus {
+	currentStatus := cluster.Status.StorageEngineMigration
+	if status == nil {
+		return currentStatus
+	}
+
+	target := cluster.DesiredDatabaseConfiguration().StorageEngine
+	configuredEngine := status.Cluster.DatabaseConfiguration.StorageEngine
+	engines := GetStorageEngines(status)
+	var storageServers int
+	for _, count := range engines {
+		storageServers += count
+	}
+	migratedStorageServers := engines[target]
+
+	var migration *fdbv1beta2.StorageEngineMigrationStatus
+	if currentStatus == nil || currentStatus.TargetEngine != target {
+		// If the database is not yet configured, the storage servers will be created with the target storage engine.
+		if configuredEngine == "" {
+			return currentStatus
+		}
+
+		if configuredEngine == target && migratedStorageServers == storageServers {
+			return currentStatus
+		}
+
+		migration = &fdbv1beta2.StorageEngineMigrationStatus{
+			SourceEngine:   getSourceEngine(engines, target, configuredEngine),
+			TargetEngine:   target,
+			StartTimestamp: &metav1.Time{Time: now},
+		}
+	} else {
+		migration = currentStatus.DeepCopy()
+	}
+
+	switch migration.Phase {
+	case fdbv1beta2.StorageEngineMigrationPhaseCompleted:
+		return migration
+	case fdbv1beta2.StorageEngineMigrationPhaseFinalizing:
+		if configuredEngine == target &&
+			migrationSettingsAreRemoved(status.Cluster.DatabaseConfiguration) {
+			migration.Phase = fdbv1beta2.StorageEngineMigrationPhaseCompleted
+			migration.CompletionTimestamp = &metav1.Time{Time: now}
+			migration.Message = fmt.Sprintf(
+				"All storage servers use the storage engine %s",
+				target,
+			)
+		}
+
+		return migration
+	}
+
+	migration.StorageServers = storageServers
+	migration.MigratedStorageServers = migratedStorageServers
+	migration.MigratedPercentage = 0
+	migration.EstimatedRemainingSeconds = nil
+	if storageServers > 0 {
+		migration.MigratedPercentage = migratedStorageServers * 100 / storageServers
+	}
+
+	// Estimate the remaining time based on the progress since the start of the migration.
+	if migration.StartTimestamp != nil && migratedStorageServers > 0 {
+		elapsed := now.Sub(migration.StartTimestamp.Time).Seconds()
+		remaining := int64(
+			elapsed / float64(
+				migratedStorageServers,
+			) * float64(
+				storageServers-migratedStorageServers,
+			),
+		)
+		migration.EstimatedRemainingSeconds = &remaining
+	}
+
+	if storageServers == 0 {
+		migration.Phase = fdbv1beta2.StorageEngineMigrationPhaseMigrating
+		migration.Message = "The storage servers don't report their storage engine"
+		return migration
+	}
+
+	if !hasDesiredFaultTolerance {
+		migration.Phase = fdbv1beta2.StorageEngineMigrationPhasePaused
+		migration.Message = "The cluster doesn't have the desired fault tolerance"
+		return migration
+	}
+
+	if configuredEngine == target && migratedStorageServers == storageServers {
+		migration.Phase = fdbv1beta2.StorageEngineMigrationPhaseFinalizing
+		migration.Message = "All storage servers are migrated, removing the migration settings"
+		return migration
+	}
+
+	migration.Phase = fdbv1beta2.StorageEngineMigrationPhaseMigrating
+	migration.Message = fmt.Sprintf(
+		"%d of %d storage servers use the storage engine %s",
+		migratedStorageServers,
+		storageServers,
+		target,
+	)
+
+	return migration
+}
@@ -0,0 +1,267 @@
+/*
+ * storagemigration_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package storagemigration
+
+import (
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// createStatusWithStorageEngines creates a machine-readable status with the provided configured storage engine and
+// one storage server per provided storage engine.
+func createStatusWithStorageEngines(
+	configuredEngine fdbv1beta2.StorageEngine,
+	engines ...fdbv1beta2.StorageEngine,
+) *fdbv1beta2.FoundationDBStatus {
+	status := &fdbv1beta2.FoundationDBStatus{
+		Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+			DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+				StorageEngine: configuredEngine,
+			},
+			Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{},
+		},
+	}
+
+	for idx, engine := range engines {
+		status.Cluster.Processes[fdbv1beta2.ProcessGroupID(fmt.Sprintf("storage-%d", idx))] = fdbv1beta2.FoundationDBStatusProcessInfo{
+			ProcessClass: fdbv1beta2.ProcessClassStorage,
+			Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+				{
+					Role: string(fdbv1beta2.ProcessRoleStorage),
+					StorageMetadata: &fdbv1beta2.FoundationDBStatusStorageMetadata{
+						StorageEngine: engine,
+					},
+				},
+			},
+		}
+	}
+
+	return status
+}
+
+var _ = Describe("storage engine migration", func() {
+	When("getting the storage engines", func() {
+		It("should count the storage servers per storage engine", func() {
+			status := createStatusWithStorageEngines(
+				fdbv1beta2.StorageEngineSSD2,
+				fdbv1beta2.StorageEngineSSD2,
+				fdbv1beta2.StorageEngineSSD2,
+				fdbv1beta2.StorageEngineRedwood1,
+			)
+			status.Cluster.Processes["log-1"] = fdbv1beta2.FoundationDBStatusProcessInfo{
+				Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+					{
+						Role: string(fdbv1beta2.ProcessRoleLog),
+					},
+				},
+			}
+
+			Expect(GetStorageEngines(status)).To(Equal(map[fdbv1beta2.StorageEngine]int{
+				fdbv1beta2.StorageEngineSSD2:     2,
+				fdbv1beta2.StorageEngineRedwood1: 1,
+			}))
+		})
+	})
+
+	When("getting the migration status", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var status *fdbv1beta2.FoundationDBStatus
+		var hasDesiredFaultTolerance bool
+		var now time.Time
+		var migration *fdbv1beta2.StorageEngineMigrationStatus
+
+		BeforeEach(func() {
+			now = time.Now()
+			hasDesiredFaultTolerance = true
+			cluster = internal.CreateDefaultCluster()
+			cluster.Spec.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineRedwood1
+			cluster.Spec.AutomationOptions.StorageEngineMigration = &fdbv1beta2.StorageEngineMigrationOptions{
+				Enabled: pointer.Bool(true),
+			}
+		})
+
+		JustBeforeEach(func() {
+			migration = GetMigrationStatus(cluster, status, hasDesiredFaultTolerance, now)
+		})
+
+		When("the database is not configured", func() {
+			BeforeEach(func() {
+				status = createStatusWithStorageEngines("")
+			})
+
+			It("should not start a migration", func() {
+				Expect(migration).To(BeNil())
+			})
+		})
+
+		When("all storage servers use the target storage engine", func() {
+			BeforeEach(func() {
+				status = createStatusWithStorageEngines(
+					fdbv1beta2.StorageEngineRedwood1,
+					fdbv1beta2.StorageEngineRedwood1,
+				)
+			})
+
+			It("should not start a migration", func() {
+				Expect(migration).To(BeNil())
+			})
+		})
+
+		When("the storage engine was changed", func() {
+			BeforeEach(func() {
+				status = createStatusWithStorageEngines(
+					fdbv1beta2.StorageEngineSSD2,
+					fdbv1beta2.StorageEngineSSD2,
+					fdbv1beta2.StorageEngineSSD2,
+				)
+			})
+
+			It("should start the migration", func() {
+				Expect(migration).NotTo(BeNil())
+				Expect(migration.SourceEngine).To(Equal(fdbv1beta2.StorageEngineSSD2))
+				Expect(migration.TargetEngine).To(Equal(fdbv1beta2.StorageEngineRedwood1))
+				Expect(migration.Phase).To(Equal(fdbv1beta2.StorageEngineMigrationPhaseMigrating))
+				Expect(migration.StorageServers).To(Equal(2))
+				Expect(migration.MigratedStorageServers).To(BeZero())
+				Expect(migration.StartTimestamp.Time).To(Equal(now))
+				Expect(migration.EstimatedRemainingSeconds).To(BeNil())
+			})
+
+			When("the cluster doesn't have the desired fault tolerance", func() {
+				BeforeEach(func() {
+					hasDesiredFaultTolerance = false
+				})
+
+				It("should pause the migration", func() {
+					Expect(migration).NotTo(BeNil())
+					Expect(migration.Phase).To(Equal(fdbv1beta2.StorageEngineMigrationPhasePaused))
+				})
+			})
+		})
+
+		When("the migration is in progress", func() {
+			BeforeEach(func() {
+				cluster.Status.StorageEngineMigration = &fdbv1beta2.StorageEngineMigrationStatus{
+					SourceEngine:   fdbv1beta2.StorageEngineSSD2,
+					TargetEngine:   fdbv1beta2.StorageEngineRedwood1,
+					Phase:          fdbv1beta2.StorageEngineMigrationPhaseMigrating,
+					StartTimestamp: &metav1.Time{Time: now.Add(-1 * time.Hour)},
+				}
+				status = createStatusWithStorageEngines(
+					fdbv1beta2.StorageEngineRedwood1,
+					fdbv1beta2.StorageEngineSSD2,
+					fdbv1beta2.StorageEngineSSD2,
+					fdbv1beta2.StorageEngineSSD2,
+					fdbv1beta2.StorageEngineRedwood1,
+				)
+			})
+
+			It("should update the progress", func() {
+				Expect(migration).NotTo(BeNil())
+				Expect(migration.Phase).To(Equal(fdbv1beta2.StorageEngineMigrationPhaseMigrating))
+				Expect(migration.StorageServers).To(Equal(4))
+				Expect(migration.MigratedStorageServers).To(Equal(1))
+				Expect(migration.MigratedPercentage).To(Equal(25))
+				Expect(
+					migration.EstimatedRemainingSeconds,
+				).To(HaveValue(BeNumerically("==", 3*3600)))
+				Expect(cluster.Status.StorageEngineMigration.MigratedStorageServers).To(BeZero())
+			})
+
+			When("all storage servers are migrated", func() {
+				BeforeEach(func() {
+					status = createStatusWithStorageEngines(
+						fdbv1beta2.StorageEngineRedwood1,
+						fdbv1beta2.StorageEngineRedwood1,
+						fdbv1beta2.StorageEngineRedwood1,
+					)
+				})
+
+				It("should finalize the migration", func() {
+					Expect(migration).NotTo(BeNil())
+					Expect(
+						migration.Phase,
+					).To(Equal(fdbv1beta2.StorageEngineMigrationPhaseFinalizing))
+					Expect(migration.MigratedPercentage).To(Equal(100))
+					Expect(migration.EstimatedRemainingSeconds).To(HaveValue(BeZero()))
+				})
+
+				When("the cluster doesn't have the desired fault tolerance", func() {
+					BeforeEach(func() {
+						hasDesiredFaultTolerance = false
+					})
+
+					It("should not finalize the migration", func() {
+						Expect(migration).NotTo(BeNil())
+						Expect(
+							migration.Phase,
+						).To(Equal(fdbv1beta2.StorageEngineMigrationPhasePaused))
+					})
+				})
+			})
+		})
+
+		When("the migration is finalizing", func() {
+			BeforeEach(func() {
+				cluster.Status.StorageEngineMigration = &fdbv1beta2.StorageEngineMigrationStatus{
+					SourceEngine: fdbv1beta2.StorageEngineSSD2,
+					TargetEngine: fdbv1beta2.StorageEngineRedwood1,
+					Phase:        fdbv1beta2.StorageEngineMigrationPhaseFinalizing,
+				}
+				status = createStatusWithStorageEngines(
+					fdbv1beta2.StorageEngineRedwood1,
+					fdbv1beta2.StorageEngineRedwood1,
+				)
+				migrationType := fdbv1beta2.StorageMigrationTypeGradual
+				status.Cluster.DatabaseConfiguration.StorageMigrationType = &migrationType
+				status.Cluster.DatabaseConfiguration.PerpetualStorageWiggle = pointer.Int(1)
+			})
+
+			It("should wait until the migration settings are removed", func() {
+				Expect(migration).NotTo(BeNil())
+				Expect(migration.Phase).To(Equal(fdbv1beta2.StorageEngineMigrationPhaseFinalizing))
+			})
+
+			When("the migration settings are removed", func() {
+				BeforeEach(func() {
+					migrationType := fdbv1beta2.StorageMigrationTypeDisabled
+					status.Cluster.DatabaseConfiguration.StorageMigrationType = &migrationType
+					status.Cluster.DatabaseConfiguration.PerpetualStorageWiggle = pointer.Int(0)
+				})
+
+				It("should complete the migration", func() {
+					Expect(migration).NotTo(BeNil())
+					Expect(
+						migration.Phase,
+					).To(Equal(fdbv1beta2.StorageEngineMigrationPhaseCompleted))
+					Expect(migration.CompletionTimestamp.Time).To(Equal(now))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,185 @@
+/*
+ * volumes.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volumes
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+)
+
+// OnlyStorageIncreased returns true if the only difference between the current PVC and the desired PVC is an increased
+// storage request. The current PVC spec is compared based on the hash in the fdbv1beta2.LastSpecKey annotation, as
+// Kubernetes will add default values to the PVC spec.
+func OnlyStorageIncreased(
+	current *corev1.PersistentVolumeClaim,
+	desired *corev1.PersistentVolumeClaim,
+) (bool, error) {
+	if current == nil || desired == nil {
+		return false, nil
+	}
+
+	currentStorage, ok := current.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return false, nil
+	}
+
+	desiredStorage, ok := desired.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok || desiredStorage.Cmp(currentStorage) <= 0 {
+		return false, nil
+	}
+
+	// Compute the hash of the desired spec with the current storage request, if this hash matches the current hash
+	// only the storage request has changed.
+	spec := desired.Spec.DeepCopy()
+	spec.Resources.Requests[corev1.ResourceStorage] = currentStorage
+	specHash, err := internal.GetJSONHash(spec)
+	if err != nil {
+		return false, err
+	}
+
+	return current.Annotations[fdbv1beta2.LastSpecKey] == specHash, nil
+}
+
+// StorageClassAllowsExpansion returns true if the storage class of the provided PVC allows volume expansion. If the
+// storage class is not set, doesn't exist or the operator is not allowed to read the storage class, false will be
+// returned.
+func StorageClassAllowsExpansion(
+	ctx context.Context,
+	reader client.Reader,
+	pvc *corev1.PersistentVolumeClaim,
+) (bool, error) {
+	storageClassName := pointer.StringDeref(pvc.Spec.StorageClassName, "")
+	if storageClassName == "" {
+		return false, nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	err := reader.Get(ctx, client.ObjectKey{Name: storageClassName}, storageClass)
+	if err != nil {
+		if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return pointer.BoolDeref(storageClass.AllowVolumeExpansion, false), nil
+}
+
+// CanBeExpanded returns true if the current PVC can be expanded in place to match the desired PVC. This is the case if
+// volume expansion is enabled for the cluster, only the storage request was increased and the storage class allows
+// volume expansion.
+func CanBeExpanded(
+	ctx context.Context,
+	reader client.Reader,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	current *corev1.PersistentVolumeClaim,
+	desired *corev1.PersistentVolumeClaim,
+) (bool, error) {
+	if !cluster.UseVolumeExpansion() {
+		return false, nil
+	}
+
+	onlyStorageIncreased, err := OnlyStorageIncreased(current, desired)
+	if err != nil || !onlyStorageIncreased {
+		return false, err
+	}
+
+	return StorageClassAllowsExpansion(ctx, reader, current)
+}
+
+// IsResizing returns true if the PVC is currently being resized. A PVC is being resized if the requested storage is
+// bigger than the current capacity or if the PVC has a resize condition.
+func IsResizing(pvc *corev1.PersistentVolumeClaim) bool {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		if condition.Type == corev1.PersistentVolumeClaimResizing ||
+			condition.Type == corev1.PersistentVolumeClaimFileSystemResizePending {
+			return true
+		}
+	}
+
+	capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+	if !ok {
+		return false
+	}
+
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+
+	return requested.Cmp(capacity) > 0
+}
+
+// HasFileSystemResizePending returns true if the volume of the PVC was expanded and the file system resize is pending.
+func HasFileSystemResizePending(pvc *corev1.PersistentVolumeClaim) bool {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == corev1.PersistentVolumeClaimFileSystemResizePending &&
+			condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Expand updates the storage request of the current PVC to the storage request of the desired PVC and updates the
+// fdbv1beta2.LastSpecKey annotation to the hash of the desired spec. The changes must be persisted by the caller.
+func Expand(current *corev1.PersistentVolumeClaim, desired *corev1.PersistentVolumeClaim) {
+	if current.Spec.Resources.Requests == nil {
+		current.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+
+	current.Spec.Resources.Requests[corev1.ResourceStorage] = desired.Spec.Resources.Requests[corev1.ResourceStorage]
+	if current.Annotations == nil {
+		current.Annotations = map[string]string{}
+	}
+
+	current.Annotations[fdbv1beta2.LastSpecKey] = desired.Annotations[fdbv1beta2.LastSpecKey]
+}
+
+// StorageClassChanged returns true if the desired PVC defines a storage class that differs from the storage class of
+// the current PVC. If the desired PVC doesn't define a storage class, the default storage class of the Kubernetes
+// cluster will be used, which will be set by Kubernetes on the current PVC, so false will be returned.
+func StorageClassChanged(
+	current *corev1.PersistentVolumeClaim,
+	desired *corev1.PersistentVolumeClaim,
+) bool {
+	if current == nil || desired == nil {
+		return false
+	}
+
+	desiredStorageClass := pointer.StringDeref(desired.Spec.StorageClassName, "")
+	if desiredStorageClass == "" {
+		return false
+	}
+
+	return pointer.StringDeref(current.Spec.StorageClassName, "") != desiredStorageClass
+}
@@ -0,0 +1,274 @@
+/*
+ * volumes_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package volumes
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("volumes", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var processGroup *fdbv1beta2.ProcessGroupStatus
+	var current, desired *corev1.PersistentVolumeClaim
+
+	setStorage := func(storage string) {
+		processSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+		processSettings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: pointer.String("expandable"),
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(storage),
+					},
+				},
+			},
+		}
+		cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = processSettings
+	}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		processGroup = fdbv1beta2.NewProcessGroupStatus(
+			"storage-1",
+			fdbv1beta2.ProcessClassStorage,
+			nil,
+		)
+
+		var err error
+		setStorage("128G")
+		current, err = internal.GetPvc(cluster, processGroup)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("checking if only the storage was increased", func() {
+		var onlyStorageIncreased bool
+
+		JustBeforeEach(func() {
+			var err error
+			desired, err = internal.GetPvc(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+			onlyStorageIncreased, err = OnlyStorageIncreased(current, desired)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the spec is unchanged", func() {
+			It("should return false", func() {
+				Expect(onlyStorageIncreased).To(BeFalse())
+			})
+		})
+
+		When("the storage was increased", func() {
+			BeforeEach(func() {
+				setStorage("256G")
+			})
+
+			It("should return true", func() {
+				Expect(onlyStorageIncreased).To(BeTrue())
+			})
+
+			When("the storage class was changed too", func() {
+				BeforeEach(func() {
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].VolumeClaimTemplate.Spec.StorageClassName = pointer.String(
+						"other",
+					)
+				})
+
+				It("should return false", func() {
+					Expect(onlyStorageIncreased).To(BeFalse())
+				})
+			})
+		})
+
+		When("the storage was decreased", func() {
+			BeforeEach(func() {
+				setStorage("64G")
+			})
+
+			It("should return false", func() {
+				Expect(onlyStorageIncreased).To(BeFalse())
+			})
+		})
+	})
+
+	When("checking if the storage class allows volume expansion", func() {
+		var allowsExpansion bool
+
+		JustBeforeEach(func() {
+			var err error
+			allowsExpansion, err = StorageClassAllowsExpansion(
+				context.Background(),
+				k8sClient,
+				current,
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the storage class doesn't exist", func() {
+			It("should return false", func() {
+				Expect(allowsExpansion).To(BeFalse())
+			})
+		})
+
+		When("the storage class allows volume expansion", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(context.Background(), &storagev1.StorageClass{
+					ObjectMeta:           metav1.ObjectMeta{Name: "expandable"},
+					AllowVolumeExpansion: pointer.Bool(true),
+				})).To(Succeed())
+			})
+
+			It("should return true", func() {
+				Expect(allowsExpansion).To(BeTrue())
+			})
+
+			When("the PVC has no storage class", func() {
+				BeforeEach(func() {
+					current.Spec.StorageClassName = nil
+				})
+
+				It("should return false", func() {
+					Expect(allowsExpansion).To(BeFalse())
+				})
+			})
+		})
+
+		When("the storage class doesn't allow volume expansion", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(context.Background(), &storagev1.StorageClass{
+					ObjectMeta: metav1.ObjectMeta{Name: "expandable"},
+				})).To(Succeed())
+			})
+
+			It("should return false", func() {
+				Expect(allowsExpansion).To(BeFalse())
+			})
+		})
+	})
+
+	When("checking if the PVC is resizing", func() {
+		When("the capacity matches the request", func() {
+			BeforeEach(func() {
+				current.Status.Capacity = corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("128G"),
+				}
+			})
+
+			It("should not be resizing", func() {
+				Expect(IsResizing(current)).To(BeFalse())
+				Expect(HasFileSystemResizePending(current)).To(BeFalse())
+			})
+		})
+
+		When("the capacity is smaller than the request", func() {
+			BeforeEach(func() {
+				current.Status.Capacity = corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("64G"),
+				}
+			})
+
+			It("should be resizing", func() {
+				Expect(IsResizing(current)).To(BeTrue())
+				Expect(HasFileSystemResizePending(current)).To(BeFalse())
+			})
+		})
+
+		When("the file system resize is pending", func() {
+			BeforeEach(func() {
+				current.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+					{
+						Type:   corev1.PersistentVolumeClaimFileSystemResizePending,
+						Status: corev1.ConditionTrue,
+					},
+				}
+			})
+
+			It("should be resizing", func() {
+				Expect(IsResizing(current)).To(BeTrue())
+				Expect(HasFileSystemResizePending(current)).To(BeTrue())
+			})
+		})
+	})
+
+	When("checking if the storage class was changed", func() {
+		JustBeforeEach(func() {
+			var err error
+			desired, err = internal.GetPvc(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the storage class is unchanged", func() {
+			It("should return false", func() {
+				Expect(StorageClassChanged(current, desired)).To(BeFalse())
+			})
+		})
+
+		When("the storage class was changed", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].VolumeClaimTemplate.Spec.StorageClassName = pointer.String(
+					"other",
+				)
+			})
+
+			It("should return true", func() {
+				Expect(StorageClassChanged(current, desired)).To(BeTrue())
+			})
+		})
+
+		When("the desired storage class is not set", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].VolumeClaimTemplate.Spec.StorageClassName = nil
+			})
+
+			It("should return false", func() {
+				Expect(StorageClassChanged(current, desired)).To(BeFalse())
+			})
+		})
+	})
+
+	When("expanding the PVC", func() {
+		BeforeEach(func() {
+			setStorage("256G")
+			var err error
+			desired, err = internal.GetPvc(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+			Expand(current, desired)
+		})
+
+		It("should update the storage request and the spec hash", func() {
+			Expect(
+				current.Spec.Resources.Requests.Storage().Equal(resource.MustParse("256G")),
+			).To(BeTrue())
+			Expect(
+				current.Annotations[fdbv1beta2.LastSpecKey],
+			).To(Equal(desired.Annotations[fdbv1beta2.LastSpecKey]))
+		})
+	})
+})
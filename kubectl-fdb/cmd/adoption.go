@@ -0,0 +1,488 @@
+/*
+ * adoption.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/adoption"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The steps of the adoption of an existing cluster, in the order they must be completed.
+const (
+	adoptionStepEnabled = iota
+	adoptionStepProcessGroupsRunning
+	adoptionStepCoordinatorsMigrated
+	adoptionStepForeignProcessesExcluded
+	adoptionStepDataMoved
+	adoptionStepForeignProcessesRemoved
+)
+
+// adoptionStep represents a single step of the adoption of an existing cluster.
+type adoptionStep struct {
+	description string
+	done        bool
+	details     string
+}
+
+// adoptionState contains the current state of the adoption of an existing cluster.
+type adoptionState struct {
+	cluster          *fdbv1beta2.FoundationDBCluster
+	foreignProcesses []fdbv1beta2.FoundationDBStatusProcessInfo
+	steps            []adoptionStep
+}
+
+func newAdoptionCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "adoption",
+		Short: "Subcommand to drive the adoption of an existing cluster that is not managed by the operator",
+		Long: "Subcommand to drive the adoption of an existing cluster that is not managed by the operator. The " +
+			"FoundationDBCluster resource must be created with the seedConnectionString of the existing cluster and " +
+			"the adoption settings.",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+		Example: `
+# Show the progress of the adoption
+kubectl fdb adoption status -c cluster
+
+# Exclude the processes that are not managed by the operator
+kubectl fdb adoption exclude -c cluster
+
+# Remove the adoption settings once all foreign processes are removed
+kubectl fdb adoption finish -c cluster
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.AddCommand(
+		newAdoptionStatusCmd(streams),
+		newAdoptionExcludeCmd(streams),
+		newAdoptionFinishCmd(streams),
+	)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// addAdoptionClusterFlag adds the required cluster flag to the provided adoption subcommand.
+func addAdoptionClusterFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("fdb-cluster", "c", "", "the cluster that adopts an existing cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newAdoptionStatusCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Verifies and shows the progress of each adoption step",
+		Long:  "Verifies and shows the progress of each adoption step and lists the processes that are not managed by the operator",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			state, _, err := fetchAdoptionState(cmd, o)
+			if err != nil {
+				return err
+			}
+
+			printAdoptionState(cmd, state)
+
+			return nil
+		},
+		Example: `
+# Show the progress of the adoption for the cluster in the current namespace
+kubectl fdb adoption status -c cluster
+`,
+	}
+
+	addAdoptionClusterFlag(cmd)
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newAdoptionExcludeCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "exclude",
+		Short: "Lets the operator exclude the processes that are not managed by the operator",
+		Long: "Lets the operator exclude the processes that are not managed by the operator. This is only possible " +
+			"once all process groups have their processes running and the coordinators are migrated to processes " +
+			"managed by the operator.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			state, kubeClient, err := fetchAdoptionState(cmd, o)
+			if err != nil {
+				return err
+			}
+
+			err = state.checkStepsDone(adoptionStepCoordinatorsMigrated)
+			if err != nil {
+				printAdoptionState(cmd, state)
+				return err
+			}
+
+			if state.cluster.ExcludeForeignProcesses() {
+				cmd.Printf(
+					"the operator already excludes the foreign processes of cluster %s/%s\n",
+					state.cluster.Namespace,
+					state.cluster.Name,
+				)
+				return nil
+			}
+
+			if wait {
+				if !confirmAction(
+					fmt.Sprintf(
+						"Excluding %d foreign processes of cluster %s/%s",
+						len(state.foreignProcesses),
+						state.cluster.Namespace,
+						state.cluster.Name,
+					),
+				) {
+					return fmt.Errorf("user aborted the exclusion of the foreign processes")
+				}
+			}
+
+			patch := client.MergeFrom(state.cluster.DeepCopy())
+			state.cluster.Spec.Adoption.ExcludeForeignProcesses = pointer.Bool(true)
+			err = kubeClient.Patch(cmd.Context(), state.cluster, patch)
+			if err != nil {
+				return err
+			}
+
+			printStatement(
+				cmd,
+				"the operator will exclude the foreign processes, use \"kubectl fdb adoption status\" to follow the progress",
+				goodMessage,
+			)
+
+			return nil
+		},
+		Example: `
+# Exclude the processes that are not managed by the operator for the cluster in the current namespace
+kubectl fdb adoption exclude -c cluster
+`,
+	}
+
+	addAdoptionClusterFlag(cmd)
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newAdoptionFinishCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "finish",
+		Short: "Removes the adoption settings and the seed connection string once all foreign processes are removed",
+		Long:  "Removes the adoption settings and the seed connection string once all foreign processes are removed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			state, kubeClient, err := fetchAdoptionState(cmd, o)
+			if err != nil {
+				return err
+			}
+
+			err = state.checkStepsDone(adoptionStepForeignProcessesRemoved)
+			if err != nil {
+				printAdoptionState(cmd, state)
+				return err
+			}
+
+			if wait {
+				if !confirmAction(
+					fmt.Sprintf(
+						"Finishing the adoption of cluster %s/%s",
+						state.cluster.Namespace,
+						state.cluster.Name,
+					),
+				) {
+					return fmt.Errorf("user aborted the adoption")
+				}
+			}
+
+			patch := client.MergeFrom(state.cluster.DeepCopy())
+			state.cluster.Spec.Adoption = nil
+			state.cluster.Spec.SeedConnectionString = ""
+			err = kubeClient.Patch(cmd.Context(), state.cluster, patch)
+			if err != nil {
+				return err
+			}
+
+			printStatement(
+				cmd,
+				fmt.Sprintf(
+					"cluster %s/%s is fully managed by the operator",
+					state.cluster.Namespace,
+					state.cluster.Name,
+				),
+				goodMessage,
+			)
+
+			return nil
+		},
+		Example: `
+# Finish the adoption for the cluster in the current namespace
+kubectl fdb adoption finish -c cluster
+`,
+	}
+
+	addAdoptionClusterFlag(cmd)
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// fetchAdoptionState loads the cluster and the machine-readable status and returns the current adoption state.
+func fetchAdoptionState(cmd *cobra.Command, o *fdbBOptions) (*adoptionState, client.Client, error) {
+	clusterName, err := cmd.Flags().GetString("fdb-cluster")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config, err := o.configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kubeClient, err := getKubeClient(cmd.Context(), o)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	namespace, err := getNamespace(*o.configFlags.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !cluster.IsAdopting() {
+		return nil, nil, fmt.Errorf(
+			"cluster %s/%s has no adoption settings defined",
+			namespace,
+			cluster.Name,
+		)
+	}
+
+	pod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, err := getStatus(cmd.Context(), kubeClient, config, pod)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return getAdoptionState(cluster, status), kubeClient, nil
+}
+
+// getAdoptionState returns the current adoption state based on the machine-readable status.
+func getAdoptionState(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+) *adoptionState {
+	foreignProcesses := adoption.GetForeignProcesses(cluster, status)
+	sort.Slice(foreignProcesses, func(i, j int) bool {
+		return foreignProcesses[i].Address.String() < foreignProcesses[j].Address.String()
+	})
+
+	adoptionStatus := adoption.GetStatus(cluster, status)
+	if adoptionStatus == nil {
+		adoptionStatus = &fdbv1beta2.AdoptionStatus{}
+	}
+
+	reportingProcessGroups := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	for _, process := range status.Cluster.Processes {
+		reportingProcessGroups[fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])] = fdbv1beta2.None{}
+	}
+
+	var missingProcessGroups []string
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if _, ok := reportingProcessGroups[processGroup.ProcessGroupID]; !ok {
+			missingProcessGroups = append(missingProcessGroups, string(processGroup.ProcessGroupID))
+		}
+	}
+
+	steps := []adoptionStep{
+		adoptionStepEnabled: {
+			description: "the cluster is adopting an existing cluster",
+			done:        cluster.IsAdopting(),
+		},
+		adoptionStepProcessGroupsRunning: {
+			description: "all process groups have their processes running",
+			done:        len(cluster.Status.ProcessGroups) > 0 && len(missingProcessGroups) == 0,
+		},
+		adoptionStepCoordinatorsMigrated: {
+			description: "all coordinators are running on processes managed by the operator",
+			done:        adoptionStatus.ForeignCoordinators == 0,
+		},
+		adoptionStepForeignProcessesExcluded: {
+			description: "all foreign processes are excluded",
+			done:        adoptionStatus.ExcludedForeignProcesses == adoptionStatus.ForeignProcesses,
+		},
+		adoptionStepDataMoved: {
+			description: "all roles are moved off the foreign processes",
+			done:        adoptionStatus.FullyExcludedForeignProcesses == adoptionStatus.ForeignProcesses,
+		},
+		adoptionStepForeignProcessesRemoved: {
+			description: "all foreign processes are shut down",
+			done:        adoptionStatus.ForeignProcesses == 0,
+		},
+	}
+
+	if len(missingProcessGroups) > 0 {
+		steps[adoptionStepProcessGroupsRunning].details = fmt.Sprintf(
+			"missing processes for: %s",
+			strings.Join(missingProcessGroups, ", "),
+		)
+	}
+
+	steps[adoptionStepCoordinatorsMigrated].details = fmt.Sprintf(
+		"%d coordinators on foreign processes",
+		adoptionStatus.ForeignCoordinators,
+	)
+	steps[adoptionStepForeignProcessesExcluded].details = fmt.Sprintf(
+		"%d/%d excluded",
+		adoptionStatus.ExcludedForeignProcesses,
+		adoptionStatus.ForeignProcesses,
+	)
+	steps[adoptionStepDataMoved].details = fmt.Sprintf(
+		"%d/%d fully excluded",
+		adoptionStatus.FullyExcludedForeignProcesses,
+		adoptionStatus.ForeignProcesses,
+	)
+	steps[adoptionStepForeignProcessesRemoved].details = fmt.Sprintf(
+		"%d foreign processes are still reporting",
+		adoptionStatus.ForeignProcesses,
+	)
+
+	return &adoptionState{
+		cluster:          cluster,
+		foreignProcesses: foreignProcesses,
+		steps:            steps,
+	}
+}
+
+// checkStepsDone returns an error if any step up to and including the provided step is not done.
+func (state *adoptionState) checkStepsDone(lastStep int) error {
+	for idx := 0; idx <= lastStep; idx++ {
+		if !state.steps[idx].done {
+			return fmt.Errorf("adoption step is not done: %s", state.steps[idx].description)
+		}
+	}
+
+	return nil
+}
+
+// printAdoptionState prints the adoption steps and the foreign processes.
+func printAdoptionState(cmd *cobra.Command, state *adoptionState) {
+	cmd.Printf("Adoption of cluster %s/%s:\n", state.cluster.Namespace, state.cluster.Name)
+	for _, step := range state.steps {
+		line := step.description
+		if !step.done && step.details != "" {
+			line += " (" + step.details + ")"
+		}
+
+		if step.done {
+			printStatement(cmd, line, goodMessage)
+			continue
+		}
+
+		printStatement(cmd, line, warnMessage)
+	}
+
+	if len(state.foreignProcesses) == 0 {
+		return
+	}
+
+	cmd.Println()
+	printForeignProcesses(cmd.OutOrStdout(), state.foreignProcesses)
+}
+
+// printForeignProcesses prints the processes that are not managed by the operator as a table.
+func printForeignProcesses(out io.Writer, processes []fdbv1beta2.FoundationDBStatusProcessInfo) {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "ADDRESS\tCLASS\tEXCLUDED\tROLES")
+	for _, process := range processes {
+		roles := make([]string, 0, len(process.Roles))
+		for _, role := range process.Roles {
+			roles = append(roles, role.Role)
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%t\t%s\n",
+			process.Address.String(),
+			process.ProcessClass,
+			process.Excluded,
+			strings.Join(roles, ","),
+		)
+	}
+	_ = writer.Flush()
+}
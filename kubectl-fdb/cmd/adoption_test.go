@@ -0,0 +1,150 @@
+/*
+ * adoption_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"net"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("[plugin] adoption command", func() {
+	var status *fdbv1beta2.FoundationDBStatus
+
+	BeforeEach(func() {
+		cluster.Spec.Adoption = &fdbv1beta2.AdoptionConfig{}
+		status = &fdbv1beta2.FoundationDBStatus{
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{},
+			},
+		}
+
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			status.Cluster.Processes[processGroup.ProcessGroupID] = fdbv1beta2.FoundationDBStatusProcessInfo{
+				ProcessClass: processGroup.ProcessClass,
+				Locality: map[string]string{
+					fdbv1beta2.FDBLocalityInstanceIDKey: string(processGroup.ProcessGroupID),
+				},
+			}
+		}
+
+		status.Cluster.Processes["foreign"] = fdbv1beta2.FoundationDBStatusProcessInfo{
+			ProcessClass: fdbv1beta2.ProcessClassStorage,
+			Address: fdbv1beta2.ProcessAddress{
+				IPAddress: net.ParseIP("192.168.0.2"),
+				Port:      4500,
+			},
+			Locality: map[string]string{
+				fdbv1beta2.FDBLocalityInstanceIDKey: "vm-storage-1",
+			},
+			Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+				{Role: string(fdbv1beta2.ProcessRoleCoordinator)},
+				{Role: string(fdbv1beta2.ProcessRoleStorage)},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		cluster.Spec.Adoption = nil
+	})
+
+	When("a foreign coordinator is present", func() {
+		It("should not allow the exclusion of the foreign processes", func() {
+			state := getAdoptionState(cluster, status)
+			Expect(state.foreignProcesses).To(HaveLen(1))
+			Expect(state.steps[adoptionStepEnabled].done).To(BeTrue())
+			Expect(state.steps[adoptionStepProcessGroupsRunning].done).To(BeTrue())
+			Expect(state.steps[adoptionStepCoordinatorsMigrated].done).To(BeFalse())
+			Expect(
+				state.checkStepsDone(adoptionStepCoordinatorsMigrated),
+			).To(MatchError("adoption step is not done: all coordinators are running on processes managed by the operator"))
+		})
+
+		It("should print the foreign processes", func() {
+			state := getAdoptionState(cluster, status)
+			out := &bytes.Buffer{}
+			printForeignProcesses(out, state.foreignProcesses)
+			Expect(out.String()).To(Equal(`ADDRESS           CLASS    EXCLUDED  ROLES
+192.168.0.2:4500  storage  false     coordinator,storage
+`))
+		})
+	})
+
+	When("a process group has no running processes", func() {
+		BeforeEach(func() {
+			delete(status.Cluster.Processes, "test-storage-2")
+		})
+
+		It("should report the missing process group", func() {
+			state := getAdoptionState(cluster, status)
+			Expect(state.steps[adoptionStepProcessGroupsRunning].done).To(BeFalse())
+			Expect(
+				state.steps[adoptionStepProcessGroupsRunning].details,
+			).To(Equal("missing processes for: test-storage-2"))
+		})
+	})
+
+	When("the coordinators are migrated", func() {
+		BeforeEach(func() {
+			foreignProcess := status.Cluster.Processes["foreign"]
+			foreignProcess.Roles = []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+				{Role: string(fdbv1beta2.ProcessRoleStorage)},
+			}
+			status.Cluster.Processes["foreign"] = foreignProcess
+		})
+
+		It("should allow the exclusion but not finishing the adoption", func() {
+			state := getAdoptionState(cluster, status)
+			Expect(state.checkStepsDone(adoptionStepCoordinatorsMigrated)).To(Succeed())
+			Expect(state.checkStepsDone(adoptionStepForeignProcessesRemoved)).To(HaveOccurred())
+		})
+
+		When("the foreign processes are excluded and have no roles", func() {
+			BeforeEach(func() {
+				foreignProcess := status.Cluster.Processes["foreign"]
+				foreignProcess.Excluded = true
+				foreignProcess.Roles = nil
+				status.Cluster.Processes["foreign"] = foreignProcess
+			})
+
+			It("should only wait for the foreign processes to be shut down", func() {
+				state := getAdoptionState(cluster, status)
+				Expect(state.checkStepsDone(adoptionStepDataMoved)).To(Succeed())
+				Expect(state.steps[adoptionStepForeignProcessesRemoved].done).To(BeFalse())
+			})
+		})
+
+		When("the foreign processes are shut down", func() {
+			BeforeEach(func() {
+				delete(status.Cluster.Processes, "foreign")
+			})
+
+			It("should allow finishing the adoption", func() {
+				state := getAdoptionState(cluster, status)
+				Expect(state.foreignProcesses).To(BeEmpty())
+				Expect(state.checkStepsDone(adoptionStepForeignProcessesRemoved)).To(Succeed())
+			})
+		})
+	})
+})
@@ -577,6 +577,30 @@ func getStatus(
 	return status, nil
 }
 
+// executeFdbCliCommand runs the provided fdbcli commands on the provided Pod and returns the stdout.
+func executeFdbCliCommand(
+	ctx context.Context,
+	kubeClient client.Client,
+	restConfig *rest.Config,
+	pod *corev1.Pod,
+	command string,
+) (string, error) {
+	stdout, stderr, err := kubeHelper.ExecuteCommandOnPod(
+		ctx,
+		kubeClient,
+		restConfig,
+		pod,
+		fdbv1beta2.MainContainerName,
+		fmt.Sprintf("fdbcli --timeout=40 --exec '%s'", command),
+		false,
+	)
+	if err != nil {
+		return "", fmt.Errorf("error running fdbcli command %q: %s, %w", command, stderr, err)
+	}
+
+	return stdout, nil
+}
+
 func analyzeStatus(
 	cmd *cobra.Command,
 	restConfig *rest.Config,
@@ -0,0 +1,963 @@
+/*
+ * backup.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	kubeHelper "github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/kubernetes"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	fdbBackupBinary  = "fdbbackup"
+	fdbRestoreBinary = "fdbrestore"
+)
+
+// backupStartOptions defines the options to create a new FoundationDBBackup resource.
+type backupStartOptions struct {
+	clusterName           string
+	version               string
+	accountName           string
+	bucket                string
+	backupName            string
+	urlParameters         []string
+	encryptionKeyPath     string
+	customParameters      []string
+	agentCount            int
+	snapshotPeriodSeconds int
+}
+
+// backupStatusOverview combines the information from the FoundationDBBackup resource with the live status reported by
+// the backup agents.
+type backupStatusOverview struct {
+	Namespace     string                                            `json:"namespace"`
+	Name          string                                            `json:"name"`
+	Cluster       string                                            `json:"cluster"`
+	DesiredState  fdbv1beta2.BackupState                            `json:"desiredState"`
+	URL           string                                            `json:"url"`
+	Reconciled    bool                                              `json:"reconciled"`
+	DesiredAgents int                                               `json:"desiredAgents"`
+	RunningAgents int                                               `json:"runningAgents"`
+	Details       *fdbv1beta2.FoundationDBBackupStatusBackupDetails `json:"details,omitempty"`
+	Live          *fdbv1beta2.FoundationDBLiveBackupStatus          `json:"live,omitempty"`
+}
+
+// backupDescription represents the output of "fdbbackup describe --json".
+type backupDescription struct {
+	URL                string                      `json:"URL"`
+	Restorable         bool                        `json:"Restorable"`
+	MinRestorablePoint *backupVersionPoint         `json:"MinRestorablePoint,omitempty"`
+	MaxRestorablePoint *backupVersionPoint         `json:"MaxRestorablePoint,omitempty"`
+	Snapshots          []backupSnapshotDescription `json:"Snapshots,omitempty"`
+}
+
+// backupVersionPoint represents a version in the backup and if known the matching timestamp.
+type backupVersionPoint struct {
+	Version   int64  `json:"Version"`
+	Timestamp string `json:"Timestamp,omitempty"`
+}
+
+// backupSnapshotDescription represents a single snapshot of the backup.
+type backupSnapshotDescription struct {
+	Start      backupVersionPoint `json:"Start"`
+	End        backupVersionPoint `json:"End"`
+	Restorable bool               `json:"Restorable"`
+	TotalBytes int64              `json:"TotalBytes"`
+}
+
+func newBackupCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Subcommand to manage the backups of a given cluster",
+		Long:  "Subcommand to manage the backups of a given cluster",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+		Example: `
+# Start a new backup for the cluster in the current namespace
+kubectl fdb backup start -c cluster --account-name account@s3.example.com backup
+
+# Pause the backup
+kubectl fdb backup pause backup
+
+# Get the live status of the backup
+kubectl fdb backup status backup
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.AddCommand(
+		newBackupStartCmd(streams),
+		newBackupStopCmd(streams),
+		newBackupPauseCmd(streams),
+		newBackupResumeCmd(streams),
+		newBackupStatusCmd(streams),
+		newBackupDescribeCmd(streams),
+		newBackupListRestorableCmd(streams),
+	)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newBackupStartCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+	opts := backupStartOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Starts the provided backup, the FoundationDBBackup resource will be created if it doesn't exist",
+		Long:  "Starts the provided backup, the FoundationDBBackup resource will be created if it doesn't exist",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			return startBackup(cmd, kubeClient, namespace, args[0], opts)
+		},
+		Example: `
+# Create and start a new backup for the cluster in the current namespace
+kubectl fdb backup start -c cluster --account-name account@s3.example.com --bucket backups backup
+
+# Start a backup that was previously stopped
+kubectl fdb backup start backup
+`,
+	}
+
+	cmd.Flags().
+		StringVarP(&opts.clusterName, "fdb-cluster", "c", "", "the cluster that should be backed up, only required if the backup doesn't exist.")
+	cmd.Flags().
+		StringVar(&opts.version, "version", "", "the version of the backup agents, defaults to the running version of the cluster.")
+	cmd.Flags().
+		StringVar(&opts.accountName, "account-name", "", "the account name of the blob store, only required if the backup doesn't exist.")
+	cmd.Flags().StringVar(&opts.bucket, "bucket", "", "the bucket of the blob store.")
+	cmd.Flags().
+		StringVar(&opts.backupName, "backup-name", "", "the name of the backup in the blob store, defaults to the name of the resource.")
+	cmd.Flags().
+		StringArrayVar(&opts.urlParameters, "url-parameter", nil, "additional parameters for the backup URL, e.g. \"secure_connection=0\".")
+	cmd.Flags().
+		StringVar(&opts.encryptionKeyPath, "encryption-key-path", "", "the path to the encryption key used to encrypt the backup.")
+	cmd.Flags().
+		StringArrayVar(&opts.customParameters, "custom-parameter", nil, "additional parameters for the backup agents, e.g. \"blob_credentials=/var/backup-credentials/credentials.json\".")
+	cmd.Flags().
+		IntVar(&opts.agentCount, "agent-count", 0, "the number of backup agents, defaults to the operator default.")
+	cmd.Flags().
+		IntVar(&opts.snapshotPeriodSeconds, "snapshot-period-seconds", 0, "the time in seconds to complete a snapshot, defaults to the operator default.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newBackupStopCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	return newBackupStateCmd(
+		streams,
+		"stop",
+		"Stops the provided backup",
+		fdbv1beta2.BackupStateStopped,
+	)
+}
+
+func newBackupPauseCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	return newBackupStateCmd(
+		streams,
+		"pause",
+		"Pauses the provided backup",
+		fdbv1beta2.BackupStatePaused,
+	)
+}
+
+func newBackupResumeCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	return newBackupStateCmd(
+		streams,
+		"resume",
+		"Resumes the provided paused backup",
+		fdbv1beta2.BackupStateRunning,
+	)
+}
+
+// newBackupStateCmd returns a command that changes the desired state of a backup.
+func newBackupStateCmd(
+	streams genericclioptions.IOStreams,
+	use string,
+	description string,
+	state fdbv1beta2.BackupState,
+) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: description,
+		Long:  description,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			backup, err := loadBackup(cmd.Context(), kubeClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			if state == fdbv1beta2.BackupStateStopped && wait {
+				if !confirmAction(fmt.Sprintf("Stopping backup %s/%s", namespace, backup.Name)) {
+					return fmt.Errorf("user aborted the stop")
+				}
+			}
+
+			return setBackupState(cmd, kubeClient, backup, state, use)
+		},
+		Example: fmt.Sprintf(`
+# %[2]s for the backup in the current namespace
+kubectl fdb backup %[1]s backup
+
+# %[2]s for the backup in the namespace default
+kubectl fdb -n default backup %[1]s backup
+`, use, description),
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newBackupStatusCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Gets the status of the provided backup, including the live status reported by the backup agents",
+		Long:  "Gets the status of the provided backup, including the live status reported by the backup agents",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+
+			err = validateOutputFormat(output)
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			backup, err := loadBackup(cmd.Context(), kubeClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			agentPods, err := getBackupAgentPods(cmd.Context(), kubeClient, backup)
+			if err != nil {
+				return err
+			}
+
+			var liveStatus *fdbv1beta2.FoundationDBLiveBackupStatus
+			agentPod, err := chooseRunningBackupAgentPod(backup, agentPods)
+			if err != nil {
+				cmd.PrintErrf("could not fetch the live status: %s\n", err.Error())
+			} else {
+				liveStatus, err = getLiveBackupStatus(cmd.Context(), kubeClient, config, agentPod, backup)
+				if err != nil {
+					return err
+				}
+			}
+
+			return printBackupStatusOverview(
+				cmd.OutOrStdout(),
+				getBackupStatusOverview(backup, agentPods.Items, liveStatus),
+				output,
+			)
+		},
+		Example: `
+# Get the status of the backup in the current namespace
+kubectl fdb backup status backup
+
+# Get the status of the backup as JSON
+kubectl fdb backup status --output json backup
+`,
+	}
+
+	cmd.Flags().
+		String("output", outputFormatText, "the output format, supported formats are: text, json and yaml.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newBackupDescribeCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Describes the content of the provided backup in the blob store",
+		Long:  "Describes the content of the provided backup in the blob store",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			backup, err := loadBackup(cmd.Context(), kubeClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			stdout, err := describeBackup(cmd.Context(), kubeClient, config, backup, false)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf(
+				"Backup %s/%s for cluster %s\n",
+				namespace,
+				backup.Name,
+				backup.Spec.ClusterName,
+			)
+			cmd.Print(stdout)
+
+			return nil
+		},
+		Example: `
+# Describe the backup in the current namespace
+kubectl fdb backup describe backup
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newBackupListRestorableCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "list-restorable",
+		Short: "Lists the restorable versions of the provided backup",
+		Long:  "Lists the restorable versions of the provided backup",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+
+			err = validateOutputFormat(output)
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			backup, err := loadBackup(cmd.Context(), kubeClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			stdout, err := describeBackup(cmd.Context(), kubeClient, config, backup, true)
+			if err != nil {
+				return err
+			}
+
+			description, err := parseBackupDescription(stdout)
+			if err != nil {
+				return err
+			}
+
+			return printRestorableVersions(cmd.OutOrStdout(), description, output)
+		},
+		Example: `
+# List the restorable versions of the backup in the current namespace
+kubectl fdb backup list-restorable backup
+`,
+	}
+
+	cmd.Flags().
+		String("output", outputFormatText, "the output format, supported formats are: text, json and yaml.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// loadBackup fetches the FoundationDBBackup resource.
+func loadBackup(
+	ctx context.Context,
+	kubeClient client.Client,
+	namespace string,
+	name string,
+) (*fdbv1beta2.FoundationDBBackup, error) {
+	backup := &fdbv1beta2.FoundationDBBackup{}
+	err := kubeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, backup)
+	if err != nil {
+		return nil, err
+	}
+
+	return backup, nil
+}
+
+// startBackup creates the FoundationDBBackup resource if it doesn't exist, otherwise the desired state of the backup
+// will be set to running.
+func startBackup(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	namespace string,
+	name string,
+	opts backupStartOptions,
+) error {
+	backup, err := loadBackup(cmd.Context(), kubeClient, namespace, name)
+	if err == nil {
+		return setBackupState(cmd, kubeClient, backup, fdbv1beta2.BackupStateRunning, "start")
+	}
+
+	if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	if opts.clusterName == "" {
+		return fmt.Errorf(
+			"backup %s/%s does not exist, the fdb-cluster flag is required to create it",
+			namespace,
+			name,
+		)
+	}
+
+	cluster, err := loadCluster(kubeClient, namespace, opts.clusterName)
+	if err != nil {
+		return err
+	}
+
+	backup, err = newBackupForCluster(cluster, name, opts)
+	if err != nil {
+		return err
+	}
+
+	err = kubeClient.Create(cmd.Context(), backup)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf(
+		"created backup %s/%s for cluster %s with URL %s\n",
+		namespace,
+		name,
+		cluster.Name,
+		backup.BackupURL(),
+	)
+
+	return nil
+}
+
+// newBackupForCluster returns a new FoundationDBBackup resource for the provided cluster.
+func newBackupForCluster(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	name string,
+	opts backupStartOptions,
+) (*fdbv1beta2.FoundationDBBackup, error) {
+	if opts.accountName == "" {
+		return nil, fmt.Errorf("the account-name flag is required to create a new backup")
+	}
+
+	version := opts.version
+	if version == "" {
+		version = cluster.GetRunningVersion()
+	}
+
+	urlParameters := make([]fdbv1beta2.URLParameter, 0, len(opts.urlParameters))
+	for _, parameter := range opts.urlParameters {
+		urlParameters = append(urlParameters, fdbv1beta2.URLParameter(parameter))
+	}
+
+	customParameters := make(fdbv1beta2.FoundationDBCustomParameters, 0, len(opts.customParameters))
+	for _, parameter := range opts.customParameters {
+		customParameters = append(
+			customParameters,
+			fdbv1beta2.FoundationDBCustomParameter(parameter),
+		)
+	}
+
+	err := customParameters.ValidateCustomParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	backup := &fdbv1beta2.FoundationDBBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.Namespace,
+		},
+		Spec: fdbv1beta2.FoundationDBBackupSpec{
+			Version:     version,
+			ClusterName: cluster.Name,
+			BackupState: fdbv1beta2.BackupStateRunning,
+			BlobStoreConfiguration: &fdbv1beta2.BlobStoreConfiguration{
+				BackupName:    opts.backupName,
+				AccountName:   opts.accountName,
+				Bucket:        opts.bucket,
+				URLParameters: urlParameters,
+			},
+			CustomParameters:  customParameters,
+			EncryptionKeyPath: opts.encryptionKeyPath,
+		},
+	}
+
+	if opts.agentCount > 0 {
+		backup.Spec.AgentCount = pointer.Int(opts.agentCount)
+	}
+
+	if opts.snapshotPeriodSeconds > 0 {
+		backup.Spec.SnapshotPeriodSeconds = pointer.Int(opts.snapshotPeriodSeconds)
+	}
+
+	return backup, nil
+}
+
+// setBackupState updates the desired state of the backup, the operator will perform the actual change.
+func setBackupState(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	backup *fdbv1beta2.FoundationDBBackup,
+	state fdbv1beta2.BackupState,
+	action string,
+) error {
+	currentState := backup.Spec.BackupState
+	if currentState == "" {
+		currentState = fdbv1beta2.BackupStateRunning
+	}
+
+	if currentState == state {
+		cmd.Printf(
+			"backup %s/%s is already in state %s\n",
+			backup.Namespace,
+			backup.Name,
+			currentState,
+		)
+		return nil
+	}
+
+	// Stopped backups must be started again, pausing or resuming them has no effect.
+	if currentState == fdbv1beta2.BackupStateStopped && action != "start" {
+		return fmt.Errorf(
+			"backup %s/%s is stopped and cannot be %sd, start the backup instead",
+			backup.Namespace,
+			backup.Name,
+			action,
+		)
+	}
+
+	patch := client.MergeFrom(backup.DeepCopy())
+	backup.Spec.BackupState = state
+	err := kubeClient.Patch(cmd.Context(), backup, patch)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("updated backup %s/%s to state %s\n", backup.Namespace, backup.Name, state)
+
+	return nil
+}
+
+// getBackupAgentPods returns the Pods of the backup agent deployment of the provided backup.
+func getBackupAgentPods(
+	ctx context.Context,
+	kubeClient client.Client,
+	backup *fdbv1beta2.FoundationDBBackup,
+) (*corev1.PodList, error) {
+	var podList corev1.PodList
+	err := kubeClient.List(
+		ctx,
+		&podList,
+		client.InNamespace(backup.Namespace),
+		client.MatchingLabels{
+			fdbv1beta2.BackupDeploymentPodLabel: internal.GetBackupDeploymentName(backup),
+		},
+	)
+
+	return &podList, err
+}
+
+// chooseRunningBackupAgentPod returns a random running backup agent Pod.
+func chooseRunningBackupAgentPod(
+	backup *fdbv1beta2.FoundationDBBackup,
+	pods *corev1.PodList,
+) (*corev1.Pod, error) {
+	runningPods := &corev1.PodList{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		runningPods.Items = append(runningPods.Items, pod)
+	}
+
+	if len(runningPods.Items) == 0 {
+		return nil, fmt.Errorf(
+			"no running backup agent pods found for backup %s/%s",
+			backup.Namespace,
+			backup.Name,
+		)
+	}
+
+	return chooseRandomPod(runningPods)
+}
+
+// getBackupToolCommand returns the command line for the provided backup tool. The tool will use the cluster file and
+// the binaries of the backup agent Pod.
+func getBackupToolCommand(
+	binary string,
+	args []string,
+	customParameters fdbv1beta2.FoundationDBCustomParameters,
+) string {
+	clusterFileFlag := "-C"
+	if binary == fdbRestoreBinary {
+		clusterFileFlag = "--dest_cluster_file"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(binary)
+	for _, arg := range append(args, customParameters.GetKnobsForCLI()...) {
+		sb.WriteString(" ")
+		sb.WriteString(shellQuote(arg))
+	}
+
+	sb.WriteString(" ")
+	sb.WriteString(clusterFileFlag)
+	sb.WriteString(" \"$")
+	sb.WriteString(fdbv1beta2.EnvNameClusterFile)
+	sb.WriteString("\"")
+
+	return sb.String()
+}
+
+// shellQuote quotes the provided value to make sure it will be passed as a single argument to the command.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
+
+// runBackupToolCommand runs the provided backup tool command on the Pod.
+func runBackupToolCommand(
+	ctx context.Context,
+	kubeClient client.Client,
+	config *rest.Config,
+	pod *corev1.Pod,
+	command string,
+) (string, error) {
+	stdout, stderr, err := kubeHelper.ExecuteCommandOnPod(
+		ctx,
+		kubeClient,
+		config,
+		pod,
+		fdbv1beta2.MainContainerName,
+		command,
+		false,
+	)
+	if err != nil {
+		return "", fmt.Errorf("error running %s: %s, %w", command, stderr, err)
+	}
+
+	return stdout, nil
+}
+
+// getLiveBackupStatus fetches the backup status from one of the backup agent Pods.
+func getLiveBackupStatus(
+	ctx context.Context,
+	kubeClient client.Client,
+	config *rest.Config,
+	pod *corev1.Pod,
+	backup *fdbv1beta2.FoundationDBBackup,
+) (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
+	stdout, err := runBackupToolCommand(
+		ctx,
+		kubeClient,
+		config,
+		pod,
+		getBackupToolCommand(
+			fdbBackupBinary,
+			[]string{"status", "--json"},
+			backup.Spec.CustomParameters,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := fdbstatus.RemoveWarningsInJSON(stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &fdbv1beta2.FoundationDBLiveBackupStatus{}
+	err = json.Unmarshal(content, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// describeBackup describes the backup content in the blob store from one of the backup agent Pods.
+func describeBackup(
+	ctx context.Context,
+	kubeClient client.Client,
+	config *rest.Config,
+	backup *fdbv1beta2.FoundationDBBackup,
+	jsonOutput bool,
+) (string, error) {
+	agentPods, err := getBackupAgentPods(ctx, kubeClient, backup)
+	if err != nil {
+		return "", err
+	}
+
+	agentPod, err := chooseRunningBackupAgentPod(backup, agentPods)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"describe", "-d", backup.BackupURL(), "--version_timestamps"}
+	if jsonOutput {
+		args = append(args, "--json")
+	}
+
+	return runBackupToolCommand(
+		ctx,
+		kubeClient,
+		config,
+		agentPod,
+		getBackupToolCommand(fdbBackupBinary, args, backup.Spec.CustomParameters),
+	)
+}
+
+// getBackupStatusOverview combines the provided information into a backupStatusOverview.
+func getBackupStatusOverview(
+	backup *fdbv1beta2.FoundationDBBackup,
+	agentPods []corev1.Pod,
+	liveStatus *fdbv1beta2.FoundationDBLiveBackupStatus,
+) *backupStatusOverview {
+	desiredState := backup.Spec.BackupState
+	if desiredState == "" {
+		desiredState = fdbv1beta2.BackupStateRunning
+	}
+
+	var runningAgents int
+	for _, pod := range agentPods {
+		if pod.Status.Phase == corev1.PodRunning {
+			runningAgents++
+		}
+	}
+
+	return &backupStatusOverview{
+		Namespace:     backup.Namespace,
+		Name:          backup.Name,
+		Cluster:       backup.Spec.ClusterName,
+		DesiredState:  desiredState,
+		URL:           backup.BackupURL(),
+		Reconciled:    backup.Status.Generations.Reconciled == backup.Generation,
+		DesiredAgents: backup.GetDesiredAgentCount(),
+		RunningAgents: runningAgents,
+		Details:       backup.Status.BackupDetails,
+		Live:          liveStatus,
+	}
+}
+
+// printBackupStatusOverview prints the provided overview in the requested output format.
+func printBackupStatusOverview(
+	out io.Writer,
+	overview *backupStatusOverview,
+	output string,
+) error {
+	if output != outputFormatText {
+		return printStructuredOutput(out, overview, output)
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "Backup:\t%s/%s\n", overview.Namespace, overview.Name)
+	_, _ = fmt.Fprintf(writer, "Cluster:\t%s\n", overview.Cluster)
+	_, _ = fmt.Fprintf(writer, "URL:\t%s\n", overview.URL)
+	_, _ = fmt.Fprintf(writer, "Desired state:\t%s\n", overview.DesiredState)
+	_, _ = fmt.Fprintf(writer, "Reconciled:\t%t\n", overview.Reconciled)
+	_, _ = fmt.Fprintf(
+		writer,
+		"Backup agents:\t%d/%d running\n",
+		overview.RunningAgents,
+		overview.DesiredAgents,
+	)
+
+	if overview.Live == nil {
+		_, _ = fmt.Fprintf(writer, "Live status:\t-\n")
+		return writer.Flush()
+	}
+
+	_, _ = fmt.Fprintf(writer, "Running:\t%t\n", overview.Live.Status.Running)
+	_, _ = fmt.Fprintf(writer, "Agents paused:\t%t\n", overview.Live.BackupAgentsPaused)
+	_, _ = fmt.Fprintf(writer, "Destination:\t%s\n", valueOrDash(overview.Live.DestinationURL))
+	_, _ = fmt.Fprintf(
+		writer,
+		"Snapshot interval:\t%ds\n",
+		overview.Live.SnapshotIntervalSeconds,
+	)
+
+	return writer.Flush()
+}
+
+// parseBackupDescription parses the output of "fdbbackup describe --json".
+func parseBackupDescription(output string) (*backupDescription, error) {
+	content, err := fdbstatus.RemoveWarningsInJSON(output)
+	if err != nil {
+		return nil, err
+	}
+
+	description := &backupDescription{}
+	err = json.Unmarshal(content, description)
+	if err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+// printRestorableVersions prints the restorable range and the restorable snapshots of the backup.
+func printRestorableVersions(out io.Writer, description *backupDescription, output string) error {
+	if output != outputFormatText {
+		return printStructuredOutput(out, description, output)
+	}
+
+	if !description.Restorable || description.MinRestorablePoint == nil ||
+		description.MaxRestorablePoint == nil {
+		_, err := fmt.Fprintf(out, "Backup %s is not restorable\n", description.URL)
+		return err
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "URL:\t%s\n", description.URL)
+	_, _ = fmt.Fprintf(
+		writer,
+		"Restorable versions:\t%d (%s) - %d (%s)\n\n",
+		description.MinRestorablePoint.Version,
+		valueOrDash(description.MinRestorablePoint.Timestamp),
+		description.MaxRestorablePoint.Version,
+		valueOrDash(description.MaxRestorablePoint.Timestamp),
+	)
+
+	_, _ = fmt.Fprintln(writer, "SNAPSHOT END VERSION\tSNAPSHOT END TIME\tSIZE")
+	for _, snapshot := range description.Snapshots {
+		if !snapshot.Restorable {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"%d\t%s\t%s\n",
+			snapshot.End.Version,
+			valueOrDash(snapshot.End.Timestamp),
+			fdbstatus.PrettyPrintBytes(snapshot.TotalBytes),
+		)
+	}
+
+	return writer.Flush()
+}
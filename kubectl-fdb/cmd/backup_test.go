@@ -0,0 +1,350 @@
+/*
+ * backup_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/utils/pointer"
+)
+
+func generateBackupStruct(
+	name string,
+	state fdbv1beta2.BackupState,
+) *fdbv1beta2.FoundationDBBackup {
+	return &fdbv1beta2.FoundationDBBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: fdbv1beta2.FoundationDBBackupSpec{
+			Version:     "7.1.26",
+			ClusterName: clusterName,
+			BackupState: state,
+			BlobStoreConfiguration: &fdbv1beta2.BlobStoreConfiguration{
+				AccountName: "account@s3.example.com",
+			},
+			CustomParameters: fdbv1beta2.FoundationDBCustomParameters{
+				"blob_credentials=/tmp/credentials.json",
+			},
+		},
+	}
+}
+
+var _ = Describe("[plugin] backup command", func() {
+	var outBuffer bytes.Buffer
+	var cmd *cobra.Command
+
+	BeforeEach(func() {
+		outBuffer = bytes.Buffer{}
+		cmd = NewRootCmd(
+			genericclioptions.IOStreams{
+				In:     &bytes.Buffer{},
+				Out:    &outBuffer,
+				ErrOut: &bytes.Buffer{},
+			},
+			&MockVersionChecker{},
+		)
+		cluster.Spec.Version = "7.1.26"
+	})
+
+	When("starting a backup", func() {
+		var opts backupStartOptions
+		var err error
+
+		BeforeEach(func() {
+			opts = backupStartOptions{
+				clusterName:   clusterName,
+				accountName:   "account@s3.example.com",
+				bucket:        "backups",
+				urlParameters: []string{"secure_connection=0"},
+				agentCount:    5,
+			}
+		})
+
+		JustBeforeEach(func() {
+			err = startBackup(cmd, k8sClient, namespace, "backup", opts)
+		})
+
+		When("the backup doesn't exist", func() {
+			It("should create the backup", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				backup, err := loadBackup(context.Background(), k8sClient, namespace, "backup")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(backup.Spec.ClusterName).To(Equal(clusterName))
+				Expect(backup.Spec.Version).To(Equal("7.1.26"))
+				Expect(backup.Spec.BackupState).To(Equal(fdbv1beta2.BackupStateRunning))
+				Expect(backup.Spec.AgentCount).To(Equal(pointer.Int(5)))
+				Expect(backup.Spec.SnapshotPeriodSeconds).To(BeNil())
+				Expect(
+					backup.BackupURL(),
+				).To(Equal("blobstore://account@s3.example.com:80/backup?bucket=backups&secure_connection=0"))
+				Expect(outBuffer.String()).To(ContainSubstring("created backup test/backup"))
+			})
+
+			When("no cluster is provided", func() {
+				BeforeEach(func() {
+					opts.clusterName = ""
+				})
+
+				It("should return an error", func() {
+					Expect(err).To(MatchError(ContainSubstring("the fdb-cluster flag is required")))
+				})
+			})
+
+			When("no account name is provided", func() {
+				BeforeEach(func() {
+					opts.accountName = ""
+				})
+
+				It("should return an error", func() {
+					Expect(
+						err,
+					).To(MatchError(ContainSubstring("the account-name flag is required")))
+				})
+			})
+		})
+
+		When("the backup is stopped", func() {
+			BeforeEach(func() {
+				Expect(
+					k8sClient.Create(
+						context.Background(),
+						generateBackupStruct("backup", fdbv1beta2.BackupStateStopped),
+					),
+				).To(Succeed())
+			})
+
+			It("should set the backup to running", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				backup, err := loadBackup(context.Background(), k8sClient, namespace, "backup")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(backup.Spec.BackupState).To(Equal(fdbv1beta2.BackupStateRunning))
+				Expect(backup.Spec.AgentCount).To(BeNil())
+			})
+		})
+	})
+
+	DescribeTable(
+		"changing the state of a backup",
+		func(current fdbv1beta2.BackupState, desired fdbv1beta2.BackupState, action string, expectedState fdbv1beta2.BackupState, expectedErr string) {
+			backup := generateBackupStruct("backup", current)
+			Expect(k8sClient.Create(context.Background(), backup)).To(Succeed())
+
+			err := setBackupState(cmd, k8sClient, backup, desired, action)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			fetchedBackup, err := loadBackup(context.Background(), k8sClient, namespace, "backup")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetchedBackup.Spec.BackupState).To(Equal(expectedState))
+		},
+		Entry(
+			"pausing a running backup",
+			fdbv1beta2.BackupStateRunning,
+			fdbv1beta2.BackupStatePaused,
+			"pause",
+			fdbv1beta2.BackupStatePaused,
+			"",
+		),
+		Entry(
+			"pausing a stopped backup",
+			fdbv1beta2.BackupStateStopped,
+			fdbv1beta2.BackupStatePaused,
+			"pause",
+			fdbv1beta2.BackupStateStopped,
+			"is stopped and cannot be paused",
+		),
+		Entry(
+			"resuming a paused backup",
+			fdbv1beta2.BackupStatePaused,
+			fdbv1beta2.BackupStateRunning,
+			"resume",
+			fdbv1beta2.BackupStateRunning,
+			"",
+		),
+		Entry(
+			"resuming a stopped backup",
+			fdbv1beta2.BackupStateStopped,
+			fdbv1beta2.BackupStateRunning,
+			"resume",
+			fdbv1beta2.BackupStateStopped,
+			"is stopped and cannot be resumed",
+		),
+		Entry(
+			"stopping a paused backup",
+			fdbv1beta2.BackupStatePaused,
+			fdbv1beta2.BackupStateStopped,
+			"stop",
+			fdbv1beta2.BackupStateStopped,
+			"",
+		),
+		Entry(
+			"stopping a backup without an explicit state",
+			fdbv1beta2.BackupState(""),
+			fdbv1beta2.BackupStateStopped,
+			"stop",
+			fdbv1beta2.BackupStateStopped,
+			"",
+		),
+	)
+
+	When("the backup is already in the desired state", func() {
+		It("should print a message", func() {
+			backup := generateBackupStruct("backup", "")
+			Expect(k8sClient.Create(context.Background(), backup)).To(Succeed())
+			Expect(
+				setBackupState(cmd, k8sClient, backup, fdbv1beta2.BackupStateRunning, "resume"),
+			).To(Succeed())
+			Expect(
+				outBuffer.String(),
+			).To(Equal("backup test/backup is already in state Running\n"))
+		})
+	})
+
+	When("building the command for the backup tools", func() {
+		It("should quote the arguments and use the cluster file of the backup agent", func() {
+			Expect(getBackupToolCommand(
+				fdbBackupBinary,
+				[]string{"describe", "-d", "blobstore://account/backup?bucket=a&sc=0"},
+				fdbv1beta2.FoundationDBCustomParameters{"blob_credentials=/tmp/it's.json"},
+			)).To(Equal(`fdbbackup 'describe' '-d' 'blobstore://account/backup?bucket=a&sc=0' '--blob_credentials=/tmp/it'"'"'s.json' -C "$FDB_CLUSTER_FILE"`))
+			Expect(
+				getBackupToolCommand(fdbRestoreBinary, []string{"status"}, nil),
+			).To(Equal(`fdbrestore 'status' --dest_cluster_file "$FDB_CLUSTER_FILE"`))
+		})
+	})
+
+	When("getting the status overview", func() {
+		var overview *backupStatusOverview
+
+		BeforeEach(func() {
+			backup := generateBackupStruct("backup", fdbv1beta2.BackupStatePaused)
+			backup.Generation = 2
+			backup.Status.Generations.Reconciled = 1
+			backup.Status.BackupDetails = &fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+				Running: true,
+				Paused:  true,
+			}
+
+			overview = getBackupStatusOverview(
+				backup,
+				[]corev1.Pod{
+					{Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+					{Status: corev1.PodStatus{Phase: corev1.PodPending}},
+				},
+				&fdbv1beta2.FoundationDBLiveBackupStatus{
+					DestinationURL:     backup.BackupURL(),
+					BackupAgentsPaused: true,
+					Status: fdbv1beta2.FoundationDBLiveBackupStatusState{
+						Running: true,
+					},
+				},
+			)
+		})
+
+		It("should combine the resource and the live status", func() {
+			Expect(overview.DesiredState).To(Equal(fdbv1beta2.BackupStatePaused))
+			Expect(overview.Reconciled).To(BeFalse())
+			Expect(overview.DesiredAgents).To(Equal(2))
+			Expect(overview.RunningAgents).To(Equal(1))
+			Expect(overview.Details.Paused).To(BeTrue())
+			Expect(overview.Live.BackupAgentsPaused).To(BeTrue())
+		})
+
+		It("should print the overview as text", func() {
+			Expect(printBackupStatusOverview(&outBuffer, overview, outputFormatText)).To(Succeed())
+			Expect(outBuffer.String()).To(MatchRegexp(`Desired state:\s+Paused`))
+			Expect(outBuffer.String()).To(MatchRegexp(`Backup agents:\s+1/2 running`))
+			Expect(outBuffer.String()).To(MatchRegexp(`Agents paused:\s+true`))
+		})
+
+		It("should print the overview as JSON", func() {
+			Expect(printBackupStatusOverview(&outBuffer, overview, outputFormatJSON)).To(Succeed())
+			result := &backupStatusOverview{}
+			Expect(json.Unmarshal(outBuffer.Bytes(), result)).To(Succeed())
+			Expect(result).To(Equal(overview))
+		})
+	})
+
+	When("listing the restorable versions", func() {
+		var description *backupDescription
+
+		BeforeEach(func() {
+			var err error
+			description, err = parseBackupDescription(`{
+  "SchemaVersion": "1.0.0",
+  "URL": "blobstore://account@s3.example.com:443/backup?bucket=fdb-backups",
+  "Restorable": true,
+  "MinRestorablePoint": {"Version": 100, "Timestamp": "2025/01/01.10:00:00+0000"},
+  "MaxRestorablePoint": {"Version": 500, "Timestamp": "2025/01/01.12:00:00+0000"},
+  "Snapshots": [
+    {"Start": {"Version": 10}, "End": {"Version": 100, "Timestamp": "2025/01/01.10:00:00+0000"}, "Restorable": true, "TotalBytes": 2048},
+    {"Start": {"Version": 400}, "End": {"Version": 600}, "Restorable": false, "TotalBytes": 1024}
+  ]
+}`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should parse the description", func() {
+			Expect(description.Restorable).To(BeTrue())
+			Expect(description.MaxRestorablePoint.Version).To(BeNumerically("==", 500))
+			Expect(description.Snapshots).To(HaveLen(2))
+		})
+
+		It("should only print the restorable snapshots", func() {
+			Expect(printRestorableVersions(&outBuffer, description, outputFormatText)).To(Succeed())
+			out := outBuffer.String()
+			Expect(
+				out,
+			).To(MatchRegexp(`Restorable versions:\s+100 \(2025/01/01\.10:00:00\+0000\) - 500 \(2025/01/01\.12:00:00\+0000\)`))
+			Expect(out).To(MatchRegexp(`100\s+2025/01/01\.10:00:00\+0000\s+2\.00Ki`))
+			Expect(out).NotTo(ContainSubstring("600"))
+		})
+
+		When("the backup is not restorable", func() {
+			BeforeEach(func() {
+				description.Restorable = false
+			})
+
+			It("should print a message", func() {
+				Expect(
+					printRestorableVersions(&outBuffer, description, outputFormatText),
+				).To(Succeed())
+				Expect(outBuffer.String()).To(ContainSubstring("is not restorable"))
+			})
+		})
+	})
+})
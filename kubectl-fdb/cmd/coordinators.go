@@ -0,0 +1,717 @@
+/*
+ * coordinators.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/coordinator"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/locality"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// connectionStringKey is the special key that contains the current connection string of the cluster.
+const connectionStringKey = `\xff\xff/connection_string`
+
+// coordinatorEntry contains the information about a single coordinator.
+type coordinatorEntry struct {
+	Address        string                    `json:"address"`
+	Reachable      bool                      `json:"reachable"`
+	ProcessGroupID fdbv1beta2.ProcessGroupID `json:"processGroupID,omitempty"`
+	ProcessClass   fdbv1beta2.ProcessClass   `json:"processClass,omitempty"`
+	Pod            string                    `json:"pod,omitempty"`
+	Node           string                    `json:"node,omitempty"`
+	Zone           string                    `json:"zone,omitempty"`
+	DataCenter     string                    `json:"dataCenter,omitempty"`
+	Excluded       bool                      `json:"excluded,omitempty"`
+	Missing        bool                      `json:"missing,omitempty"`
+}
+
+// coordinatorSetOverview contains the current coordinators of a cluster and the result of the validity check.
+type coordinatorSetOverview struct {
+	Namespace            string             `json:"namespace"`
+	Cluster              string             `json:"cluster"`
+	ConnectionString     string             `json:"connectionString"`
+	Valid                bool               `json:"valid"`
+	AllAddressesValid    bool               `json:"allAddressesValid"`
+	NeedsNewCoordinators bool               `json:"needsNewCoordinators"`
+	Coordinators         []coordinatorEntry `json:"coordinators"`
+}
+
+func newCoordinatorsCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "coordinators",
+		Short: "Subcommand to inspect and change the coordinators of a given cluster",
+		Long:  "Subcommand to inspect and change the coordinators of a given cluster",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+		Example: `
+# List the coordinators of the cluster in the current namespace
+kubectl fdb coordinators list -c cluster
+
+# Show the coordinators that would be selected by a coordinator change
+kubectl fdb coordinators change -c cluster --dry-run
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.AddCommand(
+		newCoordinatorsListCmd(streams),
+		newCoordinatorsCheckCmd(streams),
+		newCoordinatorsChangeCmd(streams),
+	)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// addCoordinatorsClusterFlag adds the required cluster flag to the provided coordinators subcommand.
+func addCoordinatorsClusterFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("fdb-cluster", "c", "", "the cluster to inspect or change.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newCoordinatorsListCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the coordinators with their Pod, node, zone and data center",
+		Long:  "Lists the coordinators with their Pod, node, zone and data center",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+
+			err = validateOutputFormat(output)
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			overview, _, err := fetchCoordinatorSetOverview(cmd, kubeClient, config, cluster)
+			if err != nil {
+				return err
+			}
+
+			return printCoordinatorSetOverview(cmd.OutOrStdout(), overview, output)
+		},
+		Example: `
+# List the coordinators of the cluster in the current namespace
+kubectl fdb coordinators list -c cluster
+
+# List the coordinators of the cluster as JSON
+kubectl fdb coordinators list -c cluster --output json
+`,
+	}
+
+	addCoordinatorsClusterFlag(cmd)
+	cmd.Flags().
+		String("output", outputFormatText, "the output format, supported formats are: text, json and yaml.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newCoordinatorsCheckCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Checks if the current coordinators meet the fault tolerance requirements",
+		Long:  "Checks if the current coordinators meet the fault tolerance requirements, the same way as the operator does it. The command returns an error if the coordinators are not valid.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			overview, _, err := fetchCoordinatorSetOverview(cmd, kubeClient, config, cluster)
+			if err != nil {
+				return err
+			}
+
+			for _, issue := range getCoordinatorIssues(overview) {
+				printStatement(cmd, issue, warnMessage)
+			}
+
+			if !overview.Valid {
+				return fmt.Errorf(
+					"coordinators of cluster %s/%s are not valid",
+					namespace,
+					cluster.Name,
+				)
+			}
+
+			printStatement(
+				cmd,
+				fmt.Sprintf("coordinators of cluster %s/%s are valid", namespace, cluster.Name),
+				goodMessage,
+			)
+
+			return nil
+		},
+		Example: `
+# Check the coordinators of the cluster in the current namespace
+kubectl fdb coordinators check -c cluster
+`,
+	}
+
+	addCoordinatorsClusterFlag(cmd)
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newCoordinatorsChangeCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "change",
+		Short: "Selects a new set of coordinators and changes the coordinators of the cluster",
+		Long:  "Selects a new set of coordinators with the same selection logic as the operator and changes the coordinators of the cluster. The connection string in the FoundationDBCluster status and the ConfigMap will be updated afterwards.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			overview, status, err := fetchCoordinatorSetOverview(cmd, kubeClient, config, cluster)
+			if err != nil {
+				return err
+			}
+
+			// The pending removals are only tracked in the global synchronization mode and require a direct
+			// connection to the cluster, so they are not taken into account here.
+			coordinators, err := coordinator.SelectCoordinators(
+				logr.Discard(),
+				cluster,
+				status,
+				nil,
+			)
+			if err != nil {
+				return fmt.Errorf("could not select new coordinators: %w", err)
+			}
+
+			pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			newCoordinators := make(
+				[]fdbv1beta2.FoundationDBStatusCoordinator,
+				0,
+				len(coordinators),
+			)
+			for _, address := range coordinators {
+				newCoordinators = append(
+					newCoordinators,
+					fdbv1beta2.FoundationDBStatusCoordinator{Address: address},
+				)
+			}
+
+			cmd.Println("Current coordinators:")
+			err = printCoordinatorEntries(cmd.OutOrStdout(), overview.Coordinators, true)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("\nNew coordinators:")
+			err = printCoordinatorEntries(
+				cmd.OutOrStdout(),
+				getCoordinatorEntries(cluster, status, pods.Items, newCoordinators),
+				false,
+			)
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				return nil
+			}
+
+			if wait {
+				validity := "valid"
+				if !overview.Valid {
+					validity = "not valid"
+				}
+
+				if !confirmAction(
+					fmt.Sprintf(
+						"Changing the coordinators of cluster %s/%s, the current coordinators are %s",
+						namespace,
+						cluster.Name,
+						validity,
+					),
+				) {
+					return fmt.Errorf("user aborted the coordinator change")
+				}
+			}
+
+			pod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			_, err = executeFdbCliCommand(
+				cmd.Context(),
+				kubeClient,
+				config,
+				pod,
+				getChangeCoordinatorsCommand(coordinators),
+			)
+			if err != nil {
+				return err
+			}
+
+			stdout, err := executeFdbCliCommand(
+				cmd.Context(),
+				kubeClient,
+				config,
+				pod,
+				"get "+connectionStringKey,
+			)
+			if err != nil {
+				return err
+			}
+
+			connectionString, err := parseFdbCliGetValue(stdout)
+			if err != nil {
+				return err
+			}
+
+			err = updateConnectionString(cmd.Context(), kubeClient, cluster, connectionString)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf(
+				"changed coordinators of cluster %s/%s, new connection string: %s\n",
+				namespace,
+				cluster.Name,
+				connectionString,
+			)
+
+			return nil
+		},
+		Example: `
+# Show the current coordinators and the coordinators that would be selected
+kubectl fdb coordinators change -c cluster --dry-run
+
+# Change the coordinators of the cluster in the current namespace
+kubectl fdb coordinators change -c cluster
+`,
+	}
+
+	addCoordinatorsClusterFlag(cmd)
+	cmd.Flags().
+		Bool("dry-run", false, "only print the new coordinators without changing the coordinators.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// fetchCoordinatorSetOverview reads the machine-readable status and the Pods of the cluster and returns the coordinator
+// overview together with the status.
+func fetchCoordinatorSetOverview(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	config *rest.Config,
+	cluster *fdbv1beta2.FoundationDBCluster,
+) (*coordinatorSetOverview, *fdbv1beta2.FoundationDBStatus, error) {
+	pod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, err := getStatus(cmd.Context(), kubeClient, config, pod)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	overview, err := getCoordinatorSetOverview(cluster, status, pods.Items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return overview, status, nil
+}
+
+// getCoordinatorSetOverview returns the coordinator overview for the provided status. The validity of the coordinators
+// is checked with the same logic as the operator uses.
+func getCoordinatorSetOverview(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	pods []corev1.Pod,
+) (*coordinatorSetOverview, error) {
+	coordinatorStatus := make(map[string]bool, len(status.Client.Coordinators.Coordinators))
+	for _, coord := range status.Client.Coordinators.Coordinators {
+		coordinatorStatus[coord.Address.String()] = false
+	}
+
+	valid, allAddressesValid, err := locality.CheckCoordinatorValidity(
+		logr.Discard(),
+		cluster,
+		status,
+		coordinatorStatus,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coordinatorSetOverview{
+		Namespace:            cluster.Namespace,
+		Cluster:              cluster.Name,
+		ConnectionString:     cluster.Status.ConnectionString,
+		Valid:                valid,
+		AllAddressesValid:    allAddressesValid,
+		NeedsNewCoordinators: cluster.Status.NeedsNewCoordinators,
+		Coordinators: getCoordinatorEntries(
+			cluster,
+			status,
+			pods,
+			status.Client.Coordinators.Coordinators,
+		),
+	}, nil
+}
+
+// getCoordinatorEntries returns an entry for every provided coordinator, the coordinators are matched against the
+// processes in the machine-readable status by their IP address or their DNS name.
+func getCoordinatorEntries(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	pods []corev1.Pod,
+	coordinators []fdbv1beta2.FoundationDBStatusCoordinator,
+) []coordinatorEntry {
+	podByProcessGroup := map[fdbv1beta2.ProcessGroupID]corev1.Pod{}
+	for _, pod := range pods {
+		podByProcessGroup[fdbv1beta2.ProcessGroupID(pod.Labels[cluster.GetProcessGroupIDLabel()])] = pod
+	}
+
+	processByAddress := map[string]fdbv1beta2.FoundationDBStatusProcessInfo{}
+	for _, process := range status.Cluster.Processes {
+		addresses, err := fdbv1beta2.ParseProcessAddressesFromCmdline(process.CommandLine)
+		if err != nil {
+			addresses = nil
+		}
+		addresses = append(addresses, process.Address)
+
+		dnsName := process.Locality[fdbv1beta2.FDBLocalityDNSNameKey]
+		for _, address := range addresses {
+			if address.IsEmpty() {
+				continue
+			}
+
+			processByAddress[address.StringWithoutFlags()] = process
+			if dnsName != "" {
+				processByAddress[fdbv1beta2.ProcessAddress{StringAddress: dnsName, Port: address.Port}.StringWithoutFlags()] = process
+			}
+		}
+	}
+
+	entries := make([]coordinatorEntry, 0, len(coordinators))
+	for _, coord := range coordinators {
+		entry := coordinatorEntry{
+			Address:   coord.Address.String(),
+			Reachable: coord.Reachable,
+		}
+
+		process, ok := processByAddress[coord.Address.StringWithoutFlags()]
+		if !ok {
+			entry.Missing = true
+			entries = append(entries, entry)
+			continue
+		}
+
+		entry.ProcessGroupID = fdbv1beta2.ProcessGroupID(
+			process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey],
+		)
+		entry.ProcessClass = process.ProcessClass
+		entry.Zone = process.Locality[fdbv1beta2.FDBLocalityZoneIDKey]
+		entry.DataCenter = process.Locality[fdbv1beta2.FDBLocalityDCIDKey]
+		entry.Excluded = process.Excluded
+
+		pod, ok := podByProcessGroup[entry.ProcessGroupID]
+		if ok {
+			entry.Pod = pod.Name
+			entry.Node = pod.Spec.NodeName
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Address < entries[j].Address
+	})
+
+	return entries
+}
+
+// getCoordinatorIssues returns a human-readable description of all issues found for the coordinators.
+func getCoordinatorIssues(overview *coordinatorSetOverview) []string {
+	var issues []string
+	for _, entry := range overview.Coordinators {
+		if !entry.Reachable {
+			issues = append(issues, fmt.Sprintf("coordinator %s is not reachable", entry.Address))
+		}
+
+		if entry.Missing {
+			issues = append(
+				issues,
+				fmt.Sprintf("coordinator %s has no matching process in the status", entry.Address),
+			)
+		}
+
+		if entry.Excluded {
+			issues = append(
+				issues,
+				fmt.Sprintf(
+					"coordinator %s of process group %s is excluded",
+					entry.Address,
+					entry.ProcessGroupID,
+				),
+			)
+		}
+	}
+
+	if !overview.AllAddressesValid {
+		issues = append(
+			issues,
+			"not all processes have an address that matches the TLS setting of the cluster, the operator will defer the coordinator change",
+		)
+	}
+
+	if !overview.Valid {
+		issues = append(
+			issues,
+			"coordinators don't meet the fault tolerance requirements of the cluster",
+		)
+	}
+
+	return issues
+}
+
+// getChangeCoordinatorsCommand returns the fdbcli command to change the coordinators to the provided addresses.
+func getChangeCoordinatorsCommand(addresses []fdbv1beta2.ProcessAddress) string {
+	return "coordinators " + fdbv1beta2.ProcessAddressesString(addresses, " ")
+}
+
+// parseFdbCliGetValue parses the output of the fdbcli get command and returns the value.
+func parseFdbCliGetValue(output string) (string, error) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		separator := "' is `"
+		idx := strings.Index(line, separator)
+		if idx == -1 || !strings.HasPrefix(line, "`") || !strings.HasSuffix(line, "'") {
+			continue
+		}
+
+		value, err := fdbCliUnprintable(line[idx+len(separator) : len(line)-1])
+		if err != nil {
+			return "", err
+		}
+
+		return string(value), nil
+	}
+
+	return "", fmt.Errorf("could not find value in fdbcli output: %q", output)
+}
+
+// printCoordinatorSetOverview prints the provided overview in the requested output format.
+func printCoordinatorSetOverview(
+	out io.Writer,
+	overview *coordinatorSetOverview,
+	output string,
+) error {
+	if output != outputFormatText {
+		return printStructuredOutput(out, overview, output)
+	}
+
+	var reachable int
+	for _, entry := range overview.Coordinators {
+		if entry.Reachable {
+			reachable++
+		}
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "Cluster:\t%s/%s\n", overview.Namespace, overview.Cluster)
+	_, _ = fmt.Fprintf(writer, "Connection string:\t%s\n", valueOrDash(overview.ConnectionString))
+	_, _ = fmt.Fprintf(
+		writer,
+		"Coordinators:\t%d (%d reachable)\n",
+		len(overview.Coordinators),
+		reachable,
+	)
+	_, _ = fmt.Fprintf(writer, "Valid:\t%t\n", overview.Valid)
+	_, _ = fmt.Fprintf(writer, "Needs new coordinators:\t%t\n", overview.NeedsNewCoordinators)
+	err := writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(out)
+
+	return printCoordinatorEntries(out, overview.Coordinators, true)
+}
+
+// printCoordinatorEntries prints the coordinators as a table, the reachability is only printed if showReachable is
+// true.
+func printCoordinatorEntries(out io.Writer, entries []coordinatorEntry, showReachable bool) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	header := "ADDRESS\tPROCESS GROUP\tPOD\tNODE\tZONE\tDC"
+	if showReachable {
+		header += "\tREACHABLE"
+	}
+	_, _ = fmt.Fprintln(writer, header)
+
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\t%s",
+			entry.Address,
+			valueOrDash(string(entry.ProcessGroupID)),
+			valueOrDash(entry.Pod),
+			valueOrDash(entry.Node),
+			valueOrDash(entry.Zone),
+			valueOrDash(entry.DataCenter),
+		)
+
+		if showReachable {
+			_, _ = fmt.Fprintf(writer, "\t%t", entry.Reachable)
+		}
+
+		_, _ = fmt.Fprintln(writer)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,192 @@
+/*
+ * coordinators_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("[plugin] coordinators command", func() {
+	var status *fdbv1beta2.FoundationDBStatus
+	var pods []corev1.Pod
+
+	BeforeEach(func() {
+		status = &fdbv1beta2.FoundationDBStatus{
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{},
+			},
+		}
+		pods = nil
+
+		for i := 1; i <= 3; i++ {
+			processGroupID := fmt.Sprintf("test-storage-%d", i)
+			address := fmt.Sprintf("1.1.1.%d:4501", i)
+			parsedAddress, err := fdbv1beta2.ParseProcessAddress(address)
+			Expect(err).NotTo(HaveOccurred())
+
+			status.Cluster.Processes[fdbv1beta2.ProcessGroupID(fmt.Sprintf("%d", i))] = fdbv1beta2.FoundationDBStatusProcessInfo{
+				Address:      parsedAddress,
+				ProcessClass: fdbv1beta2.ProcessClassStorage,
+				CommandLine:  "/usr/bin/fdbserver --public_address=" + address,
+				Locality: map[string]string{
+					fdbv1beta2.FDBLocalityInstanceIDKey: processGroupID,
+					fdbv1beta2.FDBLocalityZoneIDKey:     fmt.Sprintf("zone-%d", i),
+					fdbv1beta2.FDBLocalityDCIDKey:       "dc1",
+				},
+			}
+			status.Client.Coordinators.Coordinators = append(
+				status.Client.Coordinators.Coordinators,
+				fdbv1beta2.FoundationDBStatusCoordinator{
+					Address:   parsedAddress,
+					Reachable: i != 3,
+				},
+			)
+
+			pods = append(pods, corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: processGroupID,
+					Labels: map[string]string{
+						fdbv1beta2.FDBProcessGroupIDLabel: processGroupID,
+					},
+				},
+				Spec: corev1.PodSpec{
+					NodeName: fmt.Sprintf("node-%d", i),
+				},
+			})
+		}
+	})
+
+	When("getting the coordinator overview", func() {
+		It("should return the coordinators with their localities", func() {
+			overview, err := getCoordinatorSetOverview(cluster, status, pods)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(overview.Valid).To(BeTrue())
+			Expect(overview.AllAddressesValid).To(BeTrue())
+			Expect(overview.Coordinators).To(HaveLen(3))
+			Expect(overview.Coordinators[0]).To(Equal(coordinatorEntry{
+				Address:        "1.1.1.1:4501",
+				Reachable:      true,
+				ProcessGroupID: "test-storage-1",
+				ProcessClass:   fdbv1beta2.ProcessClassStorage,
+				Pod:            "test-storage-1",
+				Node:           "node-1",
+				Zone:           "zone-1",
+				DataCenter:     "dc1",
+			}))
+			Expect(getCoordinatorIssues(overview)).To(ConsistOf(
+				"coordinator 1.1.1.3:4501 is not reachable",
+			))
+		})
+
+		When("the coordinators are in the same zone", func() {
+			BeforeEach(func() {
+				for id, process := range status.Cluster.Processes {
+					process.Locality[fdbv1beta2.FDBLocalityZoneIDKey] = "zone-1"
+					status.Cluster.Processes[id] = process
+				}
+			})
+
+			It("should report the coordinators as not valid", func() {
+				overview, err := getCoordinatorSetOverview(cluster, status, pods)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(overview.Valid).To(BeFalse())
+				Expect(
+					getCoordinatorIssues(overview),
+				).To(ContainElement("coordinators don't meet the fault tolerance requirements of the cluster"))
+			})
+		})
+
+		When("a coordinator has no matching process", func() {
+			BeforeEach(func() {
+				delete(status.Cluster.Processes, "2")
+			})
+
+			It("should mark the coordinator as missing", func() {
+				overview, err := getCoordinatorSetOverview(cluster, status, pods)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(overview.Valid).To(BeFalse())
+				Expect(overview.Coordinators[1].Missing).To(BeTrue())
+				Expect(overview.Coordinators[1].ProcessGroupID).To(BeEmpty())
+				Expect(
+					getCoordinatorIssues(overview),
+				).To(ContainElement("coordinator 1.1.1.2:4501 has no matching process in the status"))
+			})
+		})
+
+		It("should print the overview as text", func() {
+			overview, err := getCoordinatorSetOverview(cluster, status, pods)
+			Expect(err).NotTo(HaveOccurred())
+
+			outBuffer := bytes.Buffer{}
+			Expect(
+				printCoordinatorSetOverview(&outBuffer, overview, outputFormatText),
+			).To(Succeed())
+
+			out := outBuffer.String()
+			Expect(out).To(MatchRegexp(`Coordinators:\s+3 \(2 reachable\)`))
+			Expect(out).To(MatchRegexp(`Valid:\s+true`))
+			Expect(
+				out,
+			).To(MatchRegexp(`1\.1\.1\.1:4501\s+test-storage-1\s+test-storage-1\s+node-1\s+zone-1\s+dc1\s+true`))
+		})
+	})
+
+	When("building the change coordinators command", func() {
+		It("should contain all addresses", func() {
+			addresses := []fdbv1beta2.ProcessAddress{
+				{StringAddress: "1.1.1.1", Port: 4501},
+				{StringAddress: "1.1.1.2", Port: 4501, Flags: map[string]bool{"tls": true}},
+			}
+
+			Expect(
+				getChangeCoordinatorsCommand(addresses),
+			).To(Equal("coordinators 1.1.1.1:4501 1.1.1.2:4501:tls"))
+		})
+	})
+
+	DescribeTable(
+		"parsing the value of the fdbcli get command",
+		func(output string, expected string, expectedErr bool) {
+			value, err := parseFdbCliGetValue(output)
+			if expectedErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal(expected))
+		},
+		Entry(
+			"a connection string",
+			"`\\xff\\xff/connection_string' is `test:abc@1.1.1.1:4501,1.1.1.2:4501'\n",
+			"test:abc@1.1.1.1:4501,1.1.1.2:4501",
+			false,
+		),
+		Entry("a missing key", "`\\xff\\xff/connection_string': not found\n", "", true),
+	)
+})
@@ -0,0 +1,944 @@
+/*
+ * dashboard.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// dashboardEventLimit defines how many events of the selected cluster will be shown.
+	dashboardEventLimit = 5
+	// dashboardActionOutputLimit defines how many lines of the output of the last action will be shown.
+	dashboardActionOutputLimit = 5
+	// dashboardFixedLines is the number of lines used by the header, the panes and the footer of the dashboard.
+	dashboardFixedLines = 12 + dashboardEventLimit + dashboardActionOutputLimit
+)
+
+// The keys supported by the dashboard.
+const (
+	dashboardKeyUp      = "up"
+	dashboardKeyDown    = "down"
+	dashboardKeyQuit    = "quit"
+	dashboardKeyRefresh = "refresh"
+	dashboardKeyRestart = "restart"
+	dashboardKeyRemove  = "remove"
+	dashboardKeyCordon  = "cordon"
+	dashboardKeyAnalyze = "analyze"
+	dashboardKeyConfirm = "confirm"
+	dashboardKeyOther   = "other"
+)
+
+// dashboardKeyBindings maps the input bytes to the keys of the dashboard.
+var dashboardKeyBindings = map[string]string{
+	"\x1b[A": dashboardKeyUp,
+	"k":      dashboardKeyUp,
+	"\x1b[B": dashboardKeyDown,
+	"j":      dashboardKeyDown,
+	"q":      dashboardKeyQuit,
+	"\x03":   dashboardKeyQuit,
+	"f":      dashboardKeyRefresh,
+	"r":      dashboardKeyRestart,
+	"x":      dashboardKeyRemove,
+	"c":      dashboardKeyCordon,
+	"a":      dashboardKeyAnalyze,
+	"y":      dashboardKeyConfirm,
+}
+
+// operatorLock contains the information about the lock that is used by the operator to coordinate global operations.
+type operatorLock struct {
+	owner string
+	start time.Time
+	end   time.Time
+}
+
+// dashboardClusterSnapshot contains the information about a single cluster that is shown in the dashboard.
+type dashboardClusterSnapshot struct {
+	cluster  *fdbv1beta2.FoundationDBCluster
+	overview *clusterStatusOverview
+	lock     *operatorLock
+	events   []corev1.Event
+	errors   []string
+}
+
+// dashboardRow represents a single line of the cluster tree, only rows with a process group can be selected.
+type dashboardRow struct {
+	text         string
+	snapshot     *dashboardClusterSnapshot
+	processGroup *processGroupOverview
+}
+
+// dashboardAction represents an action that was triggered in the dashboard and must be confirmed by the user.
+type dashboardAction struct {
+	key          string
+	cluster      *fdbv1beta2.FoundationDBCluster
+	processGroup *processGroupOverview
+}
+
+// dashboardState contains the state of the dashboard between two renderings.
+type dashboardState struct {
+	snapshots     []*dashboardClusterSnapshot
+	rows          []dashboardRow
+	selected      int
+	pendingAction *dashboardAction
+	actionOutput  []string
+	lastRefresh   time.Time
+	refreshing    bool
+}
+
+func newDashboardCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Shows an interactive dashboard for the provided clusters.",
+		Long: "Shows an interactive dashboard for the provided clusters with a tree of process classes and process " +
+			"groups, the data movement, the maintenance state, the lock holder and the recent events. Actions can " +
+			"be triggered with the following keys and must be confirmed with y:\n" +
+			"  j/k or arrow keys: select a process group\n" +
+			"  r: restart the processes of the selected process group\n" +
+			"  x: remove the selected process group with exclusion\n" +
+			"  c: cordon the node of the selected process group\n" +
+			"  a: analyze the cluster of the selected process group\n" +
+			"  f: refresh the dashboard\n" +
+			"  q: quit the dashboard",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			interval, err := cmd.Flags().GetDuration("interval")
+			if err != nil {
+				return err
+			}
+
+			stdin := int(os.Stdin.Fd())
+			if !term.IsTerminal(stdin) {
+				return errors.New("the dashboard requires an interactive terminal")
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			oldState, err := term.MakeRaw(stdin)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = term.Restore(stdin, oldState)
+			}()
+
+			// Switch to the alternate screen and hide the cursor, both will be reverted once the dashboard is closed.
+			cmd.Print("\x1b[?1049h\x1b[?25l")
+			defer cmd.Print("\x1b[?25h\x1b[?1049l")
+
+			return runDashboard(cmd, kubeClient, config, namespace, args, interval)
+		},
+		Example: `
+# Show the dashboard for cluster c1
+kubectl fdb dashboard c1
+
+# Show the dashboard for the clusters c1 and c2 and refresh the information every 5 seconds
+kubectl fdb dashboard c1 c2 --interval=5s
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().
+		Duration("interval", 10*time.Second, "defines in which interval new information should be fetched from the clusters.")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// runDashboard renders the dashboard until the user quits it or the context is cancelled.
+func runDashboard(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	config *rest.Config,
+	namespace string,
+	clusterNames []string,
+	interval time.Duration,
+) error {
+	keys := make(chan []byte)
+	go func() {
+		buffer := make([]byte, 16)
+		for {
+			n, err := os.Stdin.Read(buffer)
+			if err != nil {
+				close(keys)
+				return
+			}
+
+			input := make([]byte, n)
+			copy(input, buffer[:n])
+			keys <- input
+		}
+	}()
+
+	snapshots := make(chan []*dashboardClusterSnapshot, 1)
+	state := &dashboardState{}
+	refresh := func() {
+		if state.refreshing {
+			return
+		}
+
+		state.refreshing = true
+		go func() {
+			snapshots <- fetchDashboardSnapshots(cmd, kubeClient, config, namespace, clusterNames)
+		}()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	refresh()
+
+	for {
+		_, height, err := term.GetSize(int(os.Stdin.Fd()))
+		if err != nil {
+			height = 40
+		}
+		renderDashboard(cmd.OutOrStdout(), state, height)
+
+		select {
+		case <-cmd.Context().Done():
+			return nil
+		case <-ticker.C:
+			refresh()
+		case result := <-snapshots:
+			state.setSnapshots(result, time.Now())
+		case input, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			key := parseDashboardKey(input)
+			quit, action := state.handleKey(key)
+			if quit {
+				return nil
+			}
+
+			if action != nil {
+				state.actionOutput = runDashboardAction(cmd, kubeClient, config, action)
+				refresh()
+			}
+
+			if key == dashboardKeyRefresh {
+				refresh()
+			}
+		}
+	}
+}
+
+// fetchDashboardSnapshots fetches the information for the provided clusters. The machine-readable status is only
+// fetched once for clusters that share the same connection string. Errors are recorded in the snapshot, to make sure
+// the dashboard keeps running.
+func fetchDashboardSnapshots(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	config *rest.Config,
+	namespace string,
+	clusterNames []string,
+) []*dashboardClusterSnapshot {
+	var events []corev1.Event
+	eventList := &corev1.EventList{}
+	eventErr := kubeClient.List(cmd.Context(), eventList, client.InNamespace(namespace))
+	if eventErr == nil {
+		events = eventList.Items
+	}
+
+	statusByConnectionString := map[string]*fdbv1beta2.FoundationDBStatus{}
+	result := make([]*dashboardClusterSnapshot, 0, len(clusterNames))
+	for _, clusterName := range clusterNames {
+		snapshot := &dashboardClusterSnapshot{
+			cluster: &fdbv1beta2.FoundationDBCluster{},
+		}
+		snapshot.cluster.Namespace = namespace
+		snapshot.cluster.Name = clusterName
+		result = append(result, snapshot)
+
+		cluster, err := loadCluster(kubeClient, namespace, clusterName)
+		if err != nil {
+			snapshot.errors = append(snapshot.errors, err.Error())
+			continue
+		}
+		snapshot.cluster = cluster
+
+		pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+		if err != nil {
+			snapshot.errors = append(snapshot.errors, err.Error())
+			continue
+		}
+
+		podNames := map[string]fdbv1beta2.None{}
+		for _, pod := range pods.Items {
+			podNames[pod.Name] = fdbv1beta2.None{}
+		}
+
+		if eventErr != nil {
+			snapshot.errors = append(snapshot.errors, eventErr.Error())
+		} else {
+			snapshot.events = filterSupportBundleEvents(cluster, events, podNames)
+			if len(snapshot.events) > dashboardEventLimit {
+				snapshot.events = snapshot.events[len(snapshot.events)-dashboardEventLimit:]
+			}
+		}
+
+		pod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+		if err != nil {
+			snapshot.errors = append(snapshot.errors, err.Error())
+			continue
+		}
+
+		status, ok := statusByConnectionString[cluster.Status.ConnectionString]
+		if !ok {
+			status, err = getStatus(cmd.Context(), kubeClient, config, pod)
+			if err != nil {
+				snapshot.errors = append(snapshot.errors, err.Error())
+				continue
+			}
+			statusByConnectionString[cluster.Status.ConnectionString] = status
+		}
+
+		snapshot.overview = getClusterStatusOverview(
+			[]*fdbv1beta2.FoundationDBCluster{cluster},
+			map[string][]corev1.Pod{cluster.Name: pods.Items},
+			status,
+		)
+
+		if !cluster.ShouldUseLocks() {
+			continue
+		}
+
+		stdout, err := executeFdbCliCommand(
+			cmd.Context(),
+			kubeClient,
+			config,
+			pod,
+			getOperatorLockCommand(cluster),
+		)
+		if err != nil {
+			snapshot.errors = append(snapshot.errors, err.Error())
+			continue
+		}
+
+		snapshot.lock, err = parseOperatorLock(stdout)
+		if err != nil {
+			snapshot.errors = append(snapshot.errors, err.Error())
+		}
+	}
+
+	return result
+}
+
+// getOperatorLockCommand returns the fdbcli command to read the lock of the operator.
+func getOperatorLockCommand(cluster *fdbv1beta2.FoundationDBCluster) string {
+	return fmt.Sprintf(
+		"option on ACCESS_SYSTEM_KEYS; get %s",
+		fdbCliPrintable([]byte(cluster.GetLockPrefix()+"/global")),
+	)
+}
+
+// parseOperatorLock parses the output of the fdbcli get command for the lock of the operator. If no lock is present nil
+// will be returned.
+func parseOperatorLock(output string) (*operatorLock, error) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		separator := "' is `"
+		idx := strings.Index(line, separator)
+		if idx == -1 || !strings.HasPrefix(line, "`") || !strings.HasSuffix(line, "'") {
+			continue
+		}
+
+		value, err := fdbCliUnprintable(line[idx+len(separator) : len(line)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		// The lock is stored as a tuple of the owner ID, the start time and the end time.
+		elements, err := unpackTuple(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(elements) < 3 {
+			return nil, fmt.Errorf("invalid lock value %q", value)
+		}
+
+		owner, ok := elements[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid lock value %q", value)
+		}
+
+		start, ok := elements[1].(int64)
+		if !ok {
+			return nil, fmt.Errorf("invalid lock value %q", value)
+		}
+
+		end, ok := elements[2].(int64)
+		if !ok {
+			return nil, fmt.Errorf("invalid lock value %q", value)
+		}
+
+		return &operatorLock{
+			owner: owner,
+			start: time.Unix(start, 0),
+			end:   time.Unix(end, 0),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// unpackTuple decodes the tuple encoded value. Only strings and integers are supported, as those are the only types
+// used by the operator for the lock.
+func unpackTuple(value []byte) ([]interface{}, error) {
+	var elements []interface{}
+	for idx := 0; idx < len(value); {
+		code := value[idx]
+		idx++
+
+		switch {
+		case code == 0x02:
+			var element bytes.Buffer
+			for {
+				if idx >= len(value) {
+					return nil, fmt.Errorf("unterminated string in tuple %q", value)
+				}
+
+				if value[idx] != 0x00 {
+					element.WriteByte(value[idx])
+					idx++
+					continue
+				}
+
+				// A null byte inside the string is escaped as 0x00 0xff.
+				if idx+1 < len(value) && value[idx+1] == 0xff {
+					element.WriteByte(0x00)
+					idx += 2
+					continue
+				}
+
+				idx++
+				break
+			}
+			elements = append(elements, element.String())
+		case code >= 0x0c && code <= 0x1c:
+			length := int(code) - 0x14
+			negative := length < 0
+			if negative {
+				length = -length
+			}
+
+			if idx+length > len(value) {
+				return nil, fmt.Errorf("truncated integer in tuple %q", value)
+			}
+
+			var unsigned uint64
+			for _, b := range value[idx : idx+length] {
+				unsigned = unsigned<<8 | uint64(b)
+			}
+			idx += length
+
+			if !negative {
+				elements = append(elements, int64(unsigned))
+				continue
+			}
+
+			// Negative integers are stored as the one's complement.
+			mask := uint64(1)<<(8*uint(length)) - 1
+			if length == 8 {
+				mask = ^uint64(0)
+			}
+			elements = append(elements, -int64(mask-unsigned))
+		default:
+			return nil, fmt.Errorf("unsupported type code 0x%02x in tuple %q", code, value)
+		}
+	}
+
+	return elements, nil
+}
+
+// parseDashboardKey returns the dashboard key for the provided input.
+func parseDashboardKey(input []byte) string {
+	key, ok := dashboardKeyBindings[string(input)]
+	if !ok {
+		return dashboardKeyOther
+	}
+
+	return key
+}
+
+// setSnapshots updates the snapshots and the rows of the dashboard, the selected process group is kept if it still exists.
+func (state *dashboardState) setSnapshots(
+	snapshots []*dashboardClusterSnapshot,
+	timestamp time.Time,
+) {
+	var selectedCluster string
+	var selectedProcessGroup fdbv1beta2.ProcessGroupID
+	if state.selected < len(state.rows) && state.rows[state.selected].processGroup != nil {
+		selectedCluster = state.rows[state.selected].snapshot.cluster.Name
+		selectedProcessGroup = state.rows[state.selected].processGroup.ProcessGroupID
+	}
+
+	state.snapshots = snapshots
+	state.rows = getDashboardRows(snapshots)
+	state.lastRefresh = timestamp
+	state.refreshing = false
+	state.selected = 0
+
+	for idx, row := range state.rows {
+		if row.processGroup == nil {
+			continue
+		}
+
+		if selectedProcessGroup == "" {
+			state.selected = idx
+			return
+		}
+
+		if row.snapshot.cluster.Name == selectedCluster &&
+			row.processGroup.ProcessGroupID == selectedProcessGroup {
+			state.selected = idx
+			return
+		}
+	}
+}
+
+// handleKey updates the state based on the provided key. The returned action must be executed by the caller.
+func (state *dashboardState) handleKey(key string) (bool, *dashboardAction) {
+	if state.pendingAction != nil {
+		action := state.pendingAction
+		state.pendingAction = nil
+		if key == dashboardKeyConfirm {
+			return false, action
+		}
+
+		state.actionOutput = []string{"action was aborted"}
+		return key == dashboardKeyQuit, nil
+	}
+
+	switch key {
+	case dashboardKeyQuit:
+		return true, nil
+	case dashboardKeyUp:
+		state.moveSelection(-1)
+	case dashboardKeyDown:
+		state.moveSelection(1)
+	case dashboardKeyRestart, dashboardKeyRemove, dashboardKeyCordon, dashboardKeyAnalyze:
+		action, err := state.newAction(key)
+		if err != nil {
+			state.actionOutput = []string{err.Error()}
+			return false, nil
+		}
+
+		state.pendingAction = action
+	}
+
+	return false, nil
+}
+
+// moveSelection moves the selection to the next selectable row in the provided direction.
+func (state *dashboardState) moveSelection(direction int) {
+	for idx := state.selected + direction; idx >= 0 && idx < len(state.rows); idx += direction {
+		if state.rows[idx].processGroup != nil {
+			state.selected = idx
+			return
+		}
+	}
+}
+
+// newAction returns the action for the provided key and the selected process group.
+func (state *dashboardState) newAction(key string) (*dashboardAction, error) {
+	if state.selected >= len(state.rows) || state.rows[state.selected].processGroup == nil {
+		return nil, errors.New("no process group is selected")
+	}
+
+	row := state.rows[state.selected]
+	action := &dashboardAction{
+		key:          key,
+		cluster:      row.snapshot.cluster,
+		processGroup: row.processGroup,
+	}
+
+	if key == dashboardKeyRestart && row.processGroup.Pod == "" {
+		return nil, fmt.Errorf("process group %s has no Pod", row.processGroup.ProcessGroupID)
+	}
+
+	if key == dashboardKeyCordon && row.processGroup.Node == "" {
+		return nil, fmt.Errorf(
+			"process group %s is not running on a node",
+			row.processGroup.ProcessGroupID,
+		)
+	}
+
+	return action, nil
+}
+
+// description returns the description of the action that is shown to the user for the confirmation.
+func (action *dashboardAction) description() string {
+	switch action.key {
+	case dashboardKeyRestart:
+		return fmt.Sprintf(
+			"Restart the processes of Pod %s in cluster %s/%s",
+			action.processGroup.Pod,
+			action.cluster.Namespace,
+			action.cluster.Name,
+		)
+	case dashboardKeyRemove:
+		return fmt.Sprintf(
+			"Remove process group %s from cluster %s/%s with exclusion",
+			action.processGroup.ProcessGroupID,
+			action.cluster.Namespace,
+			action.cluster.Name,
+		)
+	case dashboardKeyCordon:
+		return fmt.Sprintf(
+			"Cordon node %s for cluster %s/%s with exclusion",
+			action.processGroup.Node,
+			action.cluster.Namespace,
+			action.cluster.Name,
+		)
+	default:
+		return fmt.Sprintf("Analyze cluster %s/%s", action.cluster.Namespace, action.cluster.Name)
+	}
+}
+
+// runDashboardAction executes the provided action with the same functions as the according commands. The actions
+// are already confirmed, so no further confirmation will be requested. The output of the action is returned.
+func runDashboardAction(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	config *rest.Config,
+	action *dashboardAction,
+) []string {
+	output := &bytes.Buffer{}
+	actionCmd := &cobra.Command{}
+	actionCmd.SetOut(output)
+	actionCmd.SetErr(output)
+	actionCmd.SetContext(cmd.Context())
+
+	// Reload the cluster to make sure the action is based on the latest spec.
+	cluster, err := loadCluster(kubeClient, action.cluster.Namespace, action.cluster.Name)
+	if err == nil {
+		switch action.key {
+		case dashboardKeyRestart:
+			err = restartProcesses(
+				actionCmd,
+				config,
+				kubeClient,
+				[]string{action.processGroup.Pod},
+				cluster.Namespace,
+				cluster.Name,
+				false,
+				0,
+			)
+		case dashboardKeyRemove:
+			_, err = replaceProcessGroupsFromCluster(
+				actionCmd,
+				kubeClient,
+				map[*fdbv1beta2.FoundationDBCluster][]fdbv1beta2.ProcessGroupID{
+					cluster: {action.processGroup.ProcessGroupID},
+				},
+				cluster.Namespace,
+				replaceProcessGroupsOptions{
+					withExclusion: true,
+				},
+			)
+		case dashboardKeyCordon:
+			err = cordonNode(
+				actionCmd,
+				kubeClient,
+				cluster.Name,
+				[]string{action.processGroup.Node},
+				cluster.Namespace,
+				true,
+				false,
+				fdbv1beta2.FDBClusterLabel,
+			)
+		case dashboardKeyAnalyze:
+			err = analyzeCluster(actionCmd, kubeClient, cluster, false, false, nil, false)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if err != nil {
+		lines = append(lines, "error: "+err.Error())
+	}
+
+	if len(lines) > dashboardActionOutputLimit {
+		lines = lines[len(lines)-dashboardActionOutputLimit:]
+	}
+
+	return lines
+}
+
+// getDashboardRows returns the rows for the tree of clusters, process classes and process groups.
+func getDashboardRows(snapshots []*dashboardClusterSnapshot) []dashboardRow {
+	var rows []dashboardRow
+	for _, snapshot := range snapshots {
+		rows = append(rows, dashboardRow{
+			text:     getDashboardClusterLine(snapshot),
+			snapshot: snapshot,
+		})
+
+		if snapshot.overview == nil {
+			continue
+		}
+
+		processGroupsByClass := map[fdbv1beta2.ProcessClass][]*processGroupOverview{}
+		for idx := range snapshot.overview.ProcessGroups {
+			processGroup := &snapshot.overview.ProcessGroups[idx]
+			processGroupsByClass[processGroup.ProcessClass] = append(
+				processGroupsByClass[processGroup.ProcessClass],
+				processGroup,
+			)
+		}
+
+		processClasses := make([]string, 0, len(processGroupsByClass))
+		for processClass := range processGroupsByClass {
+			processClasses = append(processClasses, string(processClass))
+		}
+		sort.Strings(processClasses)
+
+		for _, processClass := range processClasses {
+			processGroups := processGroupsByClass[fdbv1beta2.ProcessClass(processClass)]
+			rows = append(rows, dashboardRow{
+				text:     fmt.Sprintf("  %s (%d process groups)", processClass, len(processGroups)),
+				snapshot: snapshot,
+			})
+
+			for _, processGroup := range processGroups {
+				rows = append(rows, dashboardRow{
+					text:         getDashboardProcessGroupLine(processGroup),
+					snapshot:     snapshot,
+					processGroup: processGroup,
+				})
+			}
+		}
+	}
+
+	return rows
+}
+
+// getDashboardClusterLine returns the line for the provided cluster in the tree.
+func getDashboardClusterLine(snapshot *dashboardClusterSnapshot) string {
+	line := fmt.Sprintf("%s/%s", snapshot.cluster.Namespace, snapshot.cluster.Name)
+	if snapshot.overview == nil {
+		return line + " [unknown]"
+	}
+
+	health := "unavailable"
+	if snapshot.overview.Health.Available {
+		health = "available"
+		if snapshot.overview.Health.Healthy {
+			health = "healthy"
+		}
+	}
+
+	reconciled := "reconciling"
+	if len(snapshot.overview.Clusters) > 0 && snapshot.overview.Clusters[0].Reconciled {
+		reconciled = "reconciled"
+	}
+
+	return fmt.Sprintf(
+		"%s [%s, %s, version %s]",
+		line,
+		health,
+		reconciled,
+		valueOrDash(snapshot.cluster.GetRunningVersion()),
+	)
+}
+
+// getDashboardProcessGroupLine returns the line for the provided process group in the tree.
+func getDashboardProcessGroupLine(processGroup *processGroupOverview) string {
+	conditions := make([]string, 0, len(processGroup.Conditions))
+	for _, condition := range processGroup.Conditions {
+		conditions = append(conditions, string(condition))
+	}
+
+	health := "ok"
+	if len(conditions) > 0 {
+		health = strings.Join(conditions, ",")
+	}
+
+	if processGroup.Excluded {
+		health += " (excluded)"
+	}
+
+	return fmt.Sprintf(
+		"    %s  pod=%s  node=%s  roles=%s  %s",
+		processGroup.ProcessGroupID,
+		valueOrDash(processGroup.Pod),
+		valueOrDash(processGroup.Node),
+		valueOrDash(strings.Join(processGroup.Roles, ",")),
+		health,
+	)
+}
+
+// getDashboardLines returns the lines of the dashboard for the provided terminal height.
+func getDashboardLines(state *dashboardState, height int) []string {
+	lastRefresh := "never"
+	if !state.lastRefresh.IsZero() {
+		lastRefresh = state.lastRefresh.Format(time.RFC3339)
+	}
+
+	lines := []string{
+		fmt.Sprintf("FoundationDB dashboard - last refresh: %s", lastRefresh),
+		"",
+	}
+
+	// Only show the rows around the selection if the tree doesn't fit into the terminal.
+	treeHeight := height - dashboardFixedLines
+	if treeHeight < 1 {
+		treeHeight = 1
+	}
+
+	start := 0
+	if state.selected >= treeHeight {
+		start = state.selected - treeHeight + 1
+	}
+
+	for idx := start; idx < len(state.rows) && idx < start+treeHeight; idx++ {
+		prefix := "  "
+		if idx == state.selected && state.rows[idx].processGroup != nil {
+			prefix = "> "
+		}
+
+		lines = append(lines, prefix+state.rows[idx].text)
+	}
+
+	var snapshot *dashboardClusterSnapshot
+	if state.selected < len(state.rows) {
+		snapshot = state.rows[state.selected].snapshot
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, getDashboardPaneLines(snapshot)...)
+
+	lines = append(lines, "", "Last action:")
+	for _, line := range state.actionOutput {
+		lines = append(lines, "  "+line)
+	}
+
+	lines = append(lines, "")
+	if state.pendingAction != nil {
+		lines = append(lines, state.pendingAction.description()+"? [y/n]")
+	} else {
+		lines = append(
+			lines,
+			"j/k: select  r: restart  x: remove  c: cordon  a: analyze  f: refresh  q: quit",
+		)
+	}
+
+	return lines
+}
+
+// getDashboardPaneLines returns the lines for the data movement, the maintenance state, the lock holder and the recent
+// events of the provided cluster.
+func getDashboardPaneLines(snapshot *dashboardClusterSnapshot) []string {
+	if snapshot == nil {
+		return []string{"No cluster information available"}
+	}
+
+	lines := []string{
+		fmt.Sprintf("Cluster %s/%s:", snapshot.cluster.Namespace, snapshot.cluster.Name),
+	}
+
+	for _, err := range snapshot.errors {
+		lines = append(lines, "  Error: "+err)
+	}
+
+	if snapshot.overview != nil {
+		lines = append(lines,
+			fmt.Sprintf(
+				"  Data movement: %s in flight, %s in queue, highest priority %d",
+				fdbstatus.PrettyPrintBytes(int64(snapshot.overview.DataMovement.InFlightBytes)),
+				fdbstatus.PrettyPrintBytes(int64(snapshot.overview.DataMovement.InQueueBytes)),
+				snapshot.overview.DataMovement.HighestPriority,
+			),
+			fmt.Sprintf(
+				"  Maintenance zone: %s",
+				valueOrDash(snapshot.overview.Health.MaintenanceZone),
+			),
+		)
+	}
+
+	lock := "  Lock holder: -"
+	if !snapshot.cluster.ShouldUseLocks() {
+		lock = "  Lock holder: locks are disabled"
+	} else if snapshot.lock != nil {
+		lock = fmt.Sprintf(
+			"  Lock holder: %s (since %s, until %s)",
+			snapshot.lock.owner,
+			snapshot.lock.start.Format(time.RFC3339),
+			snapshot.lock.end.Format(time.RFC3339),
+		)
+	}
+	lines = append(lines, lock, "  Recent events:")
+
+	for _, event := range snapshot.events {
+		lines = append(lines, fmt.Sprintf(
+			"    %s %s %s %s/%s: %s",
+			event.LastTimestamp.Format(time.RFC3339),
+			event.Type,
+			event.Reason,
+			event.InvolvedObject.Kind,
+			event.InvolvedObject.Name,
+			event.Message,
+		))
+	}
+
+	return lines
+}
+
+// renderDashboard clears the terminal and prints the dashboard.
+func renderDashboard(out io.Writer, state *dashboardState, height int) {
+	// The terminal is in raw mode, so every line must return the carriage.
+	_, _ = fmt.Fprint(out, "\x1b[H\x1b[2J"+strings.Join(getDashboardLines(state, height), "\r\n"))
+}
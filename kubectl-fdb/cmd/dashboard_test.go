@@ -0,0 +1,226 @@
+/*
+ * dashboard_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("[plugin] dashboard command", func() {
+	When("parsing the lock of the operator", func() {
+		It("should return the lock holder", func() {
+			lock, err := parseOperatorLock(
+				"`\\xff\\x02/org.foundationdb.kubernetes-operator/global' is `\\x02owner\\x00\\x18eS\\xf1\\x00\\x18eS\\xf3X'\n",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock).To(Equal(&operatorLock{
+				owner: "owner",
+				start: time.Unix(1700000000, 0),
+				end:   time.Unix(1700000600, 0),
+			}))
+		})
+
+		It("should return nil if no lock is present", func() {
+			lock, err := parseOperatorLock(
+				"`\\xff\\x02/org.foundationdb.kubernetes-operator/global': not found\n",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock).To(BeNil())
+		})
+
+		It("should return an error if the lock value is invalid", func() {
+			_, err := parseOperatorLock(
+				"`\\xff\\x02/org.foundationdb.kubernetes-operator/global' is `\\x02owner\\x00'\n",
+			)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	DescribeTable("unpacking a tuple",
+		func(value []byte, expected []interface{}) {
+			Expect(unpackTuple(value)).To(Equal(expected))
+		},
+		Entry("a string with an escaped null byte",
+			[]byte("\x02a\x00\xffb\x00"),
+			[]interface{}{"a\x00b"},
+		),
+		Entry("zero",
+			[]byte{0x14},
+			[]interface{}{int64(0)},
+		),
+		Entry("a positive integer",
+			[]byte{0x16, 0x01, 0x00},
+			[]interface{}{int64(256)},
+		),
+		Entry("a negative integer",
+			[]byte{0x13, 0xfe},
+			[]interface{}{int64(-1)},
+		),
+	)
+
+	When("the dashboard shows a cluster", func() {
+		var state *dashboardState
+		var snapshot *dashboardClusterSnapshot
+
+		BeforeEach(func() {
+			cluster.Spec.LockOptions.DisableLocks = ptr.To(false)
+			snapshot = &dashboardClusterSnapshot{
+				cluster: cluster,
+				overview: getClusterStatusOverview(
+					[]*fdbv1beta2.FoundationDBCluster{cluster},
+					map[string][]corev1.Pod{
+						cluster.Name: {
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "test-storage-1",
+									Labels: map[string]string{
+										fdbv1beta2.FDBProcessGroupIDLabel: "test-storage-1",
+									},
+								},
+								Spec: corev1.PodSpec{
+									NodeName: "node-1",
+								},
+							},
+						},
+					},
+					&fdbv1beta2.FoundationDBStatus{},
+				),
+				lock: &operatorLock{
+					owner: "operator-1",
+					start: time.Unix(1700000000, 0).UTC(),
+					end:   time.Unix(1700000600, 0).UTC(),
+				},
+			}
+
+			state = &dashboardState{}
+			state.setSnapshots(
+				[]*dashboardClusterSnapshot{snapshot},
+				time.Unix(1700000000, 0).UTC(),
+			)
+		})
+
+		AfterEach(func() {
+			cluster.Spec.LockOptions.DisableLocks = nil
+		})
+
+		It("should show the tree of process classes and process groups", func() {
+			lines := getDashboardLines(state, 100)
+			Expect(lines[:8]).To(Equal([]string{
+				"FoundationDB dashboard - last refresh: 2023-11-14T22:13:20Z",
+				"",
+				"  test/test [unavailable, reconciled, version -]",
+				"    stateless (1 process groups)",
+				">     test-stateless-3  pod=-  node=-  roles=-  ok",
+				"    storage (2 process groups)",
+				"      test-storage-1  pod=test-storage-1  node=node-1  roles=-  PodFailing",
+				"      test-storage-2  pod=-  node=-  roles=-  PodFailing,MissingProcesses",
+			}))
+			Expect(
+				lines,
+			).To(ContainElement("  Lock holder: operator-1 (since 2023-11-14T22:13:20Z, until 2023-11-14T22:23:20Z)"))
+			Expect(lines[len(lines)-1]).To(HavePrefix("j/k: select"))
+		})
+
+		It("should only select process groups", func() {
+			Expect(state.selected).To(Equal(2))
+			state.handleKey(dashboardKeyUp)
+			Expect(state.selected).To(Equal(2))
+			state.handleKey(dashboardKeyDown)
+			Expect(
+				state.rows[state.selected].processGroup.ProcessGroupID,
+			).To(Equal(fdbv1beta2.ProcessGroupID("test-storage-1")))
+			state.handleKey(dashboardKeyDown)
+			state.handleKey(dashboardKeyDown)
+			Expect(
+				state.rows[state.selected].processGroup.ProcessGroupID,
+			).To(Equal(fdbv1beta2.ProcessGroupID("test-storage-2")))
+		})
+
+		It("should keep the selected process group after a refresh", func() {
+			state.handleKey(dashboardKeyDown)
+			state.setSnapshots([]*dashboardClusterSnapshot{snapshot}, time.Now())
+			Expect(
+				state.rows[state.selected].processGroup.ProcessGroupID,
+			).To(Equal(fdbv1beta2.ProcessGroupID("test-storage-1")))
+		})
+
+		When("an action is triggered", func() {
+			BeforeEach(func() {
+				state.handleKey(dashboardKeyDown)
+				quit, action := state.handleKey(dashboardKeyRestart)
+				Expect(quit).To(BeFalse())
+				Expect(action).To(BeNil())
+			})
+
+			It("should request a confirmation", func() {
+				lines := getDashboardLines(state, 100)
+				Expect(
+					lines[len(lines)-1],
+				).To(Equal("Restart the processes of Pod test-storage-1 in cluster test/test? [y/n]"))
+			})
+
+			It("should return the action once confirmed", func() {
+				quit, action := state.handleKey(dashboardKeyConfirm)
+				Expect(quit).To(BeFalse())
+				Expect(action).NotTo(BeNil())
+				Expect(action.key).To(Equal(dashboardKeyRestart))
+				Expect(action.processGroup.Pod).To(Equal("test-storage-1"))
+				Expect(state.pendingAction).To(BeNil())
+			})
+
+			It("should abort the action for any other key", func() {
+				quit, action := state.handleKey(dashboardKeyOther)
+				Expect(quit).To(BeFalse())
+				Expect(action).To(BeNil())
+				Expect(state.actionOutput).To(ConsistOf("action was aborted"))
+			})
+		})
+
+		When("the selected process group has no Pod", func() {
+			It("should not allow a restart or cordon", func() {
+				state.handleKey(dashboardKeyDown)
+				state.handleKey(dashboardKeyDown)
+				state.handleKey(dashboardKeyRestart)
+				Expect(state.pendingAction).To(BeNil())
+				Expect(state.actionOutput).To(ConsistOf("process group test-storage-2 has no Pod"))
+				state.handleKey(dashboardKeyCordon)
+				Expect(state.pendingAction).To(BeNil())
+			})
+		})
+	})
+
+	DescribeTable("parsing the keys",
+		func(input string, expected string) {
+			Expect(parseDashboardKey([]byte(input))).To(Equal(expected))
+		},
+		Entry("arrow up", "\x1b[A", dashboardKeyUp),
+		Entry("arrow down", "\x1b[B", dashboardKeyDown),
+		Entry("ctrl+c", "\x03", dashboardKeyQuit),
+		Entry("unknown key", "z", dashboardKeyOther),
+	)
+})
@@ -0,0 +1,725 @@
+/*
+ * diff.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	kubeHelper "github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/kubernetes"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/podclient"
+	monitorapi "github.com/apple/foundationdb/fdbkubernetesmonitor/api"
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// splitImageMonitorConfPath is the path of the monitor conf that was generated by the sidecar in the main container.
+const splitImageMonitorConfPath = "/var/dynamic-conf/fdbmonitor.conf"
+
+// processGroupDiff contains the differences between the desired and the current state of a process group.
+type processGroupDiff struct {
+	processGroupID   fdbv1beta2.ProcessGroupID
+	pod              string
+	desiredSpecHash  string
+	currentSpecHash  string
+	specDiff         string
+	metadataDiff     string
+	monitorConfDiff  string
+	commandLineDiffs map[int]string
+	notes            []string
+}
+
+// substitutionsPodClient implements the podclient.FdbPodClient interface with static substitutions, this allows to
+// use the same methods to generate the monitor conf as the operator.
+type substitutionsPodClient struct {
+	substitutions map[string]string
+}
+
+// IsPresent is not supported for the substitutionsPodClient.
+func (podClient *substitutionsPodClient) IsPresent(_ string) (bool, error) {
+	return false, errors.New("not supported")
+}
+
+// UpdateFile is not supported for the substitutionsPodClient.
+func (podClient *substitutionsPodClient) UpdateFile(_ string, _ string) (bool, error) {
+	return false, errors.New("not supported")
+}
+
+// GetVariableSubstitutions returns a copy of the static substitutions.
+func (podClient *substitutionsPodClient) GetVariableSubstitutions() (map[string]string, error) {
+	substitutions := make(map[string]string, len(podClient.substitutions))
+	for key, value := range podClient.substitutions {
+		substitutions[key] = value
+	}
+
+	return substitutions, nil
+}
+
+var _ podclient.FdbPodClient = &substitutionsPodClient{}
+
+func newDiffCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Shows the differences between the desired and the current state of process groups",
+		Long: "Shows the differences between the desired and the current state of process groups. The desired Pod spec, " +
+			"metadata, monitor conf and command line are generated with the same code as the operator uses. If no " +
+			"process groups are provided, all process groups with the IncorrectPodSpec, IncorrectPodMetadata, " +
+			"IncorrectConfigMap or IncorrectCommandLine condition are shown.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			processClass, err := cmd.Flags().GetString("process-class")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			processGroups, err := selectProcessGroupsForDiff(
+				cluster,
+				args,
+				fdbv1beta2.ProcessClass(processClass),
+			)
+			if err != nil {
+				return err
+			}
+
+			if len(processGroups) == 0 {
+				cmd.Printf(
+					"no process groups with incorrect Pods found in cluster %s/%s\n",
+					namespace,
+					cluster.Name,
+				)
+				return nil
+			}
+
+			pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			podByProcessGroup := map[fdbv1beta2.ProcessGroupID]corev1.Pod{}
+			for _, pod := range pods.Items {
+				podByProcessGroup[fdbv1beta2.ProcessGroupID(pod.Labels[cluster.GetProcessGroupIDLabel()])] = pod
+			}
+
+			var status *fdbv1beta2.FoundationDBStatus
+			clientPod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+			if err == nil {
+				status, err = getStatus(cmd.Context(), kubeClient, config, clientPod)
+			}
+			if err != nil {
+				printStatement(
+					cmd,
+					fmt.Sprintf(
+						"could not fetch the machine-readable status, command lines will not be compared: %s",
+						err,
+					),
+					warnMessage,
+				)
+			}
+
+			for idx, processGroup := range processGroups {
+				if idx > 0 {
+					cmd.Println()
+				}
+
+				pod, ok := podByProcessGroup[processGroup.ProcessGroupID]
+				if !ok {
+					cmd.Printf("Process group %s has no Pod\n", processGroup.ProcessGroupID)
+					continue
+				}
+
+				diff, err := getProcessGroupDiff(cluster, processGroup, &pod)
+				if err != nil {
+					return err
+				}
+
+				substitutions, currentConf, err := fetchMonitorConfState(
+					cmd.Context(),
+					kubeClient,
+					config,
+					&pod,
+				)
+				if err != nil {
+					diff.notes = append(
+						diff.notes,
+						fmt.Sprintf(
+							"could not fetch the monitor conf state, the monitor conf and the command lines will not be compared: %s",
+							err,
+						),
+					)
+				} else {
+					err = addMonitorConfDiffs(diff, cluster, processGroup, &pod, status, substitutions, currentConf)
+					if err != nil {
+						return err
+					}
+				}
+
+				printProcessGroupDiff(cmd.OutOrStdout(), diff)
+			}
+
+			return nil
+		},
+		Example: `
+# Show the differences for all process groups with an incorrect Pod in the cluster in the current namespace
+kubectl fdb diff -c cluster
+
+# Show the differences for specific process groups
+kubectl fdb diff -c cluster cluster-storage-1 cluster-storage-2
+
+# Show the differences for all log process groups
+kubectl fdb diff -c cluster --process-class log
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "the cluster to show the differences for.")
+	cmd.Flags().
+		String("process-class", "", "show the differences for all process groups of the provided process class.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// selectProcessGroupsForDiff returns the process groups that should be compared. If process group IDs are provided,
+// those process groups are returned. If a process class is provided, all process groups of this class are returned.
+// Otherwise, all process groups with a condition that indicates an incorrect Pod are returned.
+func selectProcessGroupsForDiff(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroupIDs []string,
+	processClass fdbv1beta2.ProcessClass,
+) ([]*fdbv1beta2.ProcessGroupStatus, error) {
+	if len(processGroupIDs) > 0 && processClass != "" {
+		return nil, errors.New("process groups and the process class cannot be combined")
+	}
+
+	selected := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupIDs))
+	if len(processGroupIDs) > 0 {
+		for _, processGroupID := range processGroupIDs {
+			processGroup := fdbv1beta2.FindProcessGroupByID(
+				cluster.Status.ProcessGroups,
+				fdbv1beta2.ProcessGroupID(processGroupID),
+			)
+			if processGroup == nil {
+				return nil, fmt.Errorf(
+					"process group %s not found in cluster %s/%s",
+					processGroupID,
+					cluster.Namespace,
+					cluster.Name,
+				)
+			}
+
+			selected = append(selected, processGroup)
+		}
+
+		return selected, nil
+	}
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if processClass != "" {
+			if processGroup.ProcessClass == processClass {
+				selected = append(selected, processGroup)
+			}
+			continue
+		}
+
+		for _, condition := range []fdbv1beta2.ProcessGroupConditionType{
+			fdbv1beta2.IncorrectPodSpec,
+			fdbv1beta2.IncorrectPodMetadata,
+			fdbv1beta2.IncorrectConfigMap,
+			fdbv1beta2.IncorrectCommandLine,
+		} {
+			if processGroup.GetConditionTime(condition) != nil {
+				selected = append(selected, processGroup)
+				break
+			}
+		}
+	}
+
+	return selected, nil
+}
+
+// getProcessGroupDiff returns the differences of the Pod spec and the Pod metadata for the provided process group.
+func getProcessGroupDiff(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroup *fdbv1beta2.ProcessGroupStatus,
+	pod *corev1.Pod,
+) (*processGroupDiff, error) {
+	desiredSpec, err := internal.GetPodSpec(cluster, processGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredSpecHash, err := internal.GetPodSpecHash(cluster, processGroup, desiredSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	specDiff, err := getDesiredStateDiff(pod.Spec, desiredSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredMetadata := internal.GetPodMetadata(
+		cluster,
+		processGroup.ProcessClass,
+		processGroup.ProcessGroupID,
+		desiredSpecHash,
+	)
+
+	metadataDiff, err := getDesiredStateDiff(
+		map[string]interface{}{
+			"labels":      pod.Labels,
+			"annotations": pod.Annotations,
+		},
+		map[string]interface{}{
+			"labels":      desiredMetadata.Labels,
+			"annotations": desiredMetadata.Annotations,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &processGroupDiff{
+		processGroupID:  processGroup.ProcessGroupID,
+		pod:             pod.Name,
+		desiredSpecHash: desiredSpecHash,
+		currentSpecHash: pod.Annotations[fdbv1beta2.LastSpecKey],
+		specDiff:        specDiff,
+		metadataDiff:    metadataDiff,
+	}
+
+	if diff.desiredSpecHash != diff.currentSpecHash && diff.specDiff == "" {
+		diff.notes = append(
+			diff.notes,
+			"the spec hash differs but all fields managed by the operator match, the difference is caused by a field that was removed from the desired spec",
+		)
+	}
+
+	return diff, nil
+}
+
+// addMonitorConfDiffs adds the differences of the monitor conf and the command lines to the provided diff. The
+// substitutions and the current monitor conf are the values reported by the sidecar or the Kubernetes monitor.
+func addMonitorConfDiffs(
+	diff *processGroupDiff,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroup *fdbv1beta2.ProcessGroupStatus,
+	pod *corev1.Pod,
+	status *fdbv1beta2.FoundationDBStatus,
+	substitutions map[string]string,
+	currentConf string,
+) error {
+	serversPerPod, err := internal.GetServersPerPodForPod(pod, processGroup.ProcessClass)
+	if err != nil {
+		return err
+	}
+
+	podClient := &substitutionsPodClient{substitutions: substitutions}
+	imageType := internal.GetImageType(pod)
+	var desiredConf string
+	if imageType == fdbv1beta2.ImageTypeUnified {
+		configData, err := json.Marshal(
+			internal.GetMonitorProcessConfiguration(
+				cluster,
+				processGroup.ProcessClass,
+				serversPerPod,
+				imageType,
+			),
+		)
+		if err != nil {
+			return err
+		}
+
+		desiredConf, err = formatJSON(string(configData))
+		if err != nil {
+			return err
+		}
+
+		currentConf, err = formatJSON(currentConf)
+		if err != nil {
+			return err
+		}
+	} else {
+		desiredConf, err = internal.GetMonitorConf(
+			cluster,
+			processGroup.ProcessClass,
+			podClient,
+			serversPerPod,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	diff.monitorConfDiff = cmp.Diff(currentConf, desiredConf)
+
+	if status == nil || len(substitutions) == 0 {
+		return nil
+	}
+
+	commandLines := getProcessCommandLines(status, processGroup.ProcessGroupID)
+	diff.commandLineDiffs = map[int]string{}
+	for processNumber := 1; processNumber <= serversPerPod; processNumber++ {
+		// The start command generation modifies the provided substitutions, so every call gets its own copy.
+		processSubstitutions, err := podClient.GetVariableSubstitutions()
+		if err != nil {
+			return err
+		}
+
+		desiredCommandLine, err := internal.GetStartCommandWithSubstitutions(
+			cluster,
+			processGroup.ProcessClass,
+			processSubstitutions,
+			processNumber,
+			serversPerPod,
+			imageType,
+		)
+		if err != nil {
+			return err
+		}
+
+		currentCommandLine, ok := commandLines[processNumber]
+		if !ok {
+			diff.notes = append(
+				diff.notes,
+				fmt.Sprintf("process %d is not reporting to the cluster", processNumber),
+			)
+			continue
+		}
+
+		commandLineDiff := cmp.Diff(
+			strings.Join(strings.Split(currentCommandLine, " "), "\n"),
+			strings.Join(strings.Split(desiredCommandLine, " "), "\n"),
+		)
+		if commandLineDiff != "" {
+			diff.commandLineDiffs[processNumber] = commandLineDiff
+		}
+	}
+
+	return nil
+}
+
+// getProcessCommandLines returns the command lines of all processes of the provided process group from the
+// machine-readable status, the key is the process number.
+func getProcessCommandLines(
+	status *fdbv1beta2.FoundationDBStatus,
+	processGroupID fdbv1beta2.ProcessGroupID,
+) map[int]string {
+	commandLines := map[int]string{}
+	for _, process := range status.Cluster.Processes {
+		if process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey] != string(processGroupID) {
+			continue
+		}
+
+		processNumber := 1
+		processID := process.Locality[fdbv1beta2.FDBLocalityProcessIDKey]
+		if strings.HasPrefix(processID, string(processGroupID)+"-") {
+			parsed, err := strconv.Atoi(strings.TrimPrefix(processID, string(processGroupID)+"-"))
+			if err == nil {
+				processNumber = parsed
+			}
+		}
+
+		commandLines[processNumber] = process.CommandLine
+	}
+
+	return commandLines
+}
+
+// fetchMonitorConfState returns the substitutions and the current monitor conf of the provided Pod. For the unified
+// image the values are read from the annotations of the Kubernetes monitor, for the split image the substitutions are
+// read from the sidecar and the monitor conf is read from the main container.
+func fetchMonitorConfState(
+	ctx context.Context,
+	kubeClient client.Client,
+	config *rest.Config,
+	pod *corev1.Pod,
+) (map[string]string, string, error) {
+	if internal.GetImageType(pod) == fdbv1beta2.ImageTypeUnified {
+		return getMonitorConfStateFromAnnotations(pod)
+	}
+
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, "", err
+	}
+
+	scheme := "http"
+	if internal.PodHasSidecarTLS(pod) {
+		scheme = "https"
+	}
+
+	content, err := clientSet.CoreV1().
+		Pods(pod.Namespace).
+		ProxyGet(scheme, pod.Name, "8080", "substitutions", nil).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not fetch the substitutions from the sidecar: %w", err)
+	}
+
+	substitutions := map[string]string{}
+	err = json.Unmarshal(content, &substitutions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var currentConf bytes.Buffer
+	err = kubeHelper.DownloadFile(
+		ctx,
+		kubeClient,
+		config,
+		pod,
+		fdbv1beta2.MainContainerName,
+		splitImageMonitorConfPath,
+		&currentConf,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return substitutions, currentConf.String(), nil
+}
+
+// getMonitorConfStateFromAnnotations returns the substitutions and the current configuration reported by the
+// Kubernetes monitor in the Pod annotations.
+func getMonitorConfStateFromAnnotations(pod *corev1.Pod) (map[string]string, string, error) {
+	currentConf, ok := pod.Annotations[monitorapi.CurrentConfigurationAnnotation]
+	if !ok {
+		return nil, "", fmt.Errorf(
+			"Pod %s has no %s annotation",
+			pod.Name,
+			monitorapi.CurrentConfigurationAnnotation,
+		)
+	}
+
+	environment, ok := pod.Annotations[monitorapi.EnvironmentAnnotation]
+	if !ok {
+		return nil, "", fmt.Errorf(
+			"Pod %s has no %s annotation",
+			pod.Name,
+			monitorapi.EnvironmentAnnotation,
+		)
+	}
+
+	substitutions := map[string]string{}
+	err := json.Unmarshal([]byte(environment), &substitutions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return substitutions, currentConf, nil
+}
+
+// getDesiredStateDiff returns a diff between the current and the desired state. Only fields that are present in the
+// desired state are compared, this prevents differences for fields that are defaulted by Kubernetes.
+func getDesiredStateDiff(current interface{}, desired interface{}) (string, error) {
+	currentGeneric, err := toGenericObject(current)
+	if err != nil {
+		return "", err
+	}
+
+	desiredGeneric, err := toGenericObject(desired)
+	if err != nil {
+		return "", err
+	}
+
+	currentYAML, err := getMinimalYAML(pruneToDesiredFields(currentGeneric, desiredGeneric))
+	if err != nil {
+		return "", err
+	}
+
+	desiredYAML, err := getMinimalYAML(desiredGeneric)
+	if err != nil {
+		return "", err
+	}
+
+	return cmp.Diff(string(currentYAML), string(desiredYAML)), nil
+}
+
+// toGenericObject converts the provided object into its generic JSON representation.
+func toGenericObject(object interface{}) (interface{}, error) {
+	content, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	err = json.Unmarshal(content, &generic)
+
+	return generic, err
+}
+
+// pruneToDesiredFields removes all fields from the current object that are not present in the desired object. Lists
+// are compared by their index and additional entries in the current object are kept.
+func pruneToDesiredFields(current interface{}, desired interface{}) interface{} {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		currentValue, ok := current.(map[string]interface{})
+		if !ok {
+			return current
+		}
+
+		result := make(map[string]interface{}, len(desiredValue))
+		for key, value := range desiredValue {
+			entry, present := currentValue[key]
+			if !present {
+				continue
+			}
+
+			result[key] = pruneToDesiredFields(entry, value)
+		}
+
+		return result
+	case []interface{}:
+		currentValue, ok := current.([]interface{})
+		if !ok {
+			return current
+		}
+
+		result := make([]interface{}, 0, len(currentValue))
+		for idx, entry := range currentValue {
+			if idx < len(desiredValue) {
+				result = append(result, pruneToDesiredFields(entry, desiredValue[idx]))
+				continue
+			}
+
+			result = append(result, entry)
+		}
+
+		return result
+	}
+
+	return current
+}
+
+// formatJSON returns the indented representation of the provided JSON document to allow a readable diff.
+func formatJSON(content string) (string, error) {
+	var generic interface{}
+	err := json.Unmarshal([]byte(content), &generic)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := yaml.Marshal(generic)
+	if err != nil {
+		return "", err
+	}
+
+	return string(result), nil
+}
+
+// printProcessGroupDiff prints the differences of a process group.
+func printProcessGroupDiff(out io.Writer, diff *processGroupDiff) {
+	_, _ = fmt.Fprintf(out, "Process group %s (Pod %s)\n", diff.processGroupID, diff.pod)
+	_, _ = fmt.Fprintf(
+		out,
+		"Spec hash: current %s, desired %s\n",
+		valueOrDash(diff.currentSpecHash),
+		diff.desiredSpecHash,
+	)
+
+	hasDiff := false
+	printSection := func(title string, content string) {
+		if content == "" {
+			return
+		}
+
+		hasDiff = true
+		_, _ = fmt.Fprintf(out, "%s (-current +desired):\n%s", title, content)
+	}
+
+	printSection("Pod spec", diff.specDiff)
+	printSection("Pod metadata", diff.metadataDiff)
+	printSection("Monitor conf", diff.monitorConfDiff)
+
+	processNumbers := make([]int, 0, len(diff.commandLineDiffs))
+	for processNumber := range diff.commandLineDiffs {
+		processNumbers = append(processNumbers, processNumber)
+	}
+	sort.Ints(processNumbers)
+
+	for _, processNumber := range processNumbers {
+		printSection(
+			fmt.Sprintf("Command line of process %d", processNumber),
+			diff.commandLineDiffs[processNumber],
+		)
+	}
+
+	for _, note := range diff.notes {
+		_, _ = fmt.Fprintf(out, "Note: %s\n", note)
+	}
+
+	if !hasDiff {
+		_, _ = fmt.Fprintln(out, "No differences found")
+	}
+}
@@ -0,0 +1,214 @@
+/*
+ * diff_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	monitorapi "github.com/apple/foundationdb/fdbkubernetesmonitor/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("[plugin] diff command", func() {
+	When("selecting the process groups", func() {
+		It("should select the process groups with an incorrect Pod", func() {
+			cluster.Status.ProcessGroups[0].UpdateCondition(fdbv1beta2.IncorrectPodSpec, true)
+			cluster.Status.ProcessGroups[2].UpdateCondition(fdbv1beta2.IncorrectCommandLine, true)
+
+			processGroups, err := selectProcessGroupsForDiff(cluster, nil, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processGroups).To(ConsistOf(
+				cluster.Status.ProcessGroups[0],
+				cluster.Status.ProcessGroups[2],
+			))
+		})
+
+		It("should select the process groups of the process class", func() {
+			processGroups, err := selectProcessGroupsForDiff(
+				cluster,
+				nil,
+				fdbv1beta2.ProcessClassStateless,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processGroups).To(ConsistOf(cluster.Status.ProcessGroups[2]))
+		})
+
+		It("should select the provided process groups", func() {
+			processGroups, err := selectProcessGroupsForDiff(
+				cluster,
+				[]string{"test-storage-2"},
+				"",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processGroups).To(ConsistOf(cluster.Status.ProcessGroups[1]))
+		})
+
+		It("should return an error for a missing process group", func() {
+			_, err := selectProcessGroupsForDiff(cluster, []string{"test-storage-42"}, "")
+			Expect(
+				err,
+			).To(MatchError("process group test-storage-42 not found in cluster test/test"))
+		})
+	})
+
+	When("comparing the desired and the current state", func() {
+		It("should ignore fields that are not present in the desired state", func() {
+			diff, err := getDesiredStateDiff(
+				map[string]interface{}{
+					"image":     "foundationdb:7.1.26",
+					"defaulted": "value",
+					"list":      []interface{}{map[string]interface{}{"a": "b", "c": "d"}},
+				},
+				map[string]interface{}{
+					"image": "foundationdb:7.1.26",
+					"list":  []interface{}{map[string]interface{}{"a": "b"}},
+				},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(BeEmpty())
+		})
+
+		It("should show the changed fields", func() {
+			diff, err := getDesiredStateDiff(
+				map[string]interface{}{"image": "foundationdb:7.1.26"},
+				map[string]interface{}{"image": "foundationdb:7.1.27"},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(ContainSubstring("-"))
+			Expect(diff).To(ContainSubstring("foundationdb:7.1.26"))
+			Expect(diff).To(ContainSubstring("foundationdb:7.1.27"))
+		})
+	})
+
+	When("getting the diff of a process group", func() {
+		var processGroup *fdbv1beta2.ProcessGroupStatus
+		var pod *corev1.Pod
+
+		BeforeEach(func() {
+			cluster.Spec.Version = fdbv1beta2.Versions.Default.String()
+			Expect(
+				internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{}),
+			).To(Succeed())
+			processGroup = cluster.Status.ProcessGroups[0]
+			var err error
+			pod, err = internal.GetPod(cluster, processGroup)
+			Expect(err).NotTo(HaveOccurred())
+			// Fields that are defaulted by Kubernetes should be ignored.
+			pod.Spec.SchedulerName = "default-scheduler"
+			pod.Spec.TerminationGracePeriodSeconds = ptr.To[int64](30)
+		})
+
+		It("should not report any differences for an up-to-date Pod", func() {
+			diff, err := getProcessGroupDiff(cluster, processGroup, pod)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.specDiff).To(BeEmpty())
+			Expect(diff.metadataDiff).To(BeEmpty())
+			Expect(diff.currentSpecHash).To(Equal(diff.desiredSpecHash))
+
+			out := bytes.Buffer{}
+			printProcessGroupDiff(&out, diff)
+			Expect(out.String()).To(ContainSubstring("No differences found"))
+		})
+
+		When("the Pod has a different image and is missing a label", func() {
+			BeforeEach(func() {
+				pod.Spec.Containers[0].Image = "foundationdb/foundationdb:7.1.0"
+				delete(pod.Labels, fdbv1beta2.FDBProcessClassLabel)
+			})
+
+			It("should report the differences", func() {
+				diff, err := getProcessGroupDiff(cluster, processGroup, pod)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diff.specDiff).To(ContainSubstring("foundationdb/foundationdb:7.1.0"))
+				Expect(diff.metadataDiff).To(ContainSubstring(fdbv1beta2.FDBProcessClassLabel))
+
+				out := bytes.Buffer{}
+				printProcessGroupDiff(&out, diff)
+				Expect(out.String()).To(ContainSubstring("Pod spec (-current +desired):"))
+				Expect(out.String()).To(ContainSubstring("Pod metadata (-current +desired):"))
+				Expect(out.String()).NotTo(ContainSubstring("No differences found"))
+			})
+		})
+	})
+
+	When("reading the monitor conf state from the annotations", func() {
+		It("should return the substitutions and the current configuration", func() {
+			substitutions, currentConf, err := getMonitorConfStateFromAnnotations(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						monitorapi.EnvironmentAnnotation:          `{"FDB_PUBLIC_IP":"1.1.1.1"}`,
+						monitorapi.CurrentConfigurationAnnotation: `{"version":"7.1.26"}`,
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(substitutions).To(HaveKeyWithValue("FDB_PUBLIC_IP", "1.1.1.1"))
+			Expect(currentConf).To(Equal(`{"version":"7.1.26"}`))
+		})
+
+		It("should return an error if the annotations are missing", func() {
+			_, _, err := getMonitorConfStateFromAnnotations(&corev1.Pod{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("getting the command lines from the status", func() {
+		It("should return the command lines by process number", func() {
+			status := &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"1": {
+							CommandLine: "/usr/bin/fdbserver --process_number=1",
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-1",
+								fdbv1beta2.FDBLocalityProcessIDKey:  "test-storage-1-1",
+							},
+						},
+						"2": {
+							CommandLine: "/usr/bin/fdbserver --process_number=2",
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-1",
+								fdbv1beta2.FDBLocalityProcessIDKey:  "test-storage-1-2",
+							},
+						},
+						"3": {
+							CommandLine: "/usr/bin/fdbserver",
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-2",
+							},
+						},
+					},
+				},
+			}
+
+			Expect(getProcessCommandLines(status, "test-storage-1")).To(Equal(map[int]string{
+				1: "/usr/bin/fdbserver --process_number=1",
+				2: "/usr/bin/fdbserver --process_number=2",
+			}))
+		})
+	})
+})
@@ -0,0 +1,837 @@
+/*
+ * exclude.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// exclusionSelectionOptions defines the process groups that should be excluded or included.
+type exclusionSelectionOptions struct {
+	ids        []string
+	nodes      []string
+	localities map[string]string
+}
+
+// exclusionProgress represents the progress of the exclusion of a set of process groups.
+type exclusionProgress struct {
+	processes      int
+	excluded       int
+	fullyExcluded  int
+	remainingBytes int64
+}
+
+// done returns true if all processes are excluded and don't serve any roles.
+func (progress exclusionProgress) done() bool {
+	return progress.processes > 0 && progress.fullyExcluded == progress.processes
+}
+
+func newExcludeCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "exclude",
+		Short: "Excludes process groups without removing them",
+		Long: "Excludes process groups without removing them. The process groups are added to the " +
+			"manuallyExcludedProcessGroups list of the cluster spec, so the operator doesn't replace them " +
+			"because of the exclusion. Process groups can be selected by their ID or Pod name, by node or by locality.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			waitForExclusion, err := cmd.Flags().GetBool("wait-for-exclusion")
+			if err != nil {
+				return err
+			}
+
+			interval, err := cmd.Flags().GetDuration("interval")
+			if err != nil {
+				return err
+			}
+
+			timeout, err := cmd.Flags().GetDuration("timeout")
+			if err != nil {
+				return err
+			}
+
+			opts, err := getExclusionSelectionOptions(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			clientPod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			status, err := getStatus(cmd.Context(), kubeClient, config, clientPod)
+			if err != nil {
+				return err
+			}
+
+			pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			processGroups, err := selectProcessGroupsForExclusion(cluster, pods.Items, status, opts)
+			if err != nil {
+				return err
+			}
+
+			processGroupIDs, err := excludeProcessGroups(
+				cmd,
+				kubeClient,
+				config,
+				clientPod,
+				cluster,
+				status,
+				processGroups,
+				wait,
+			)
+			if err != nil {
+				return err
+			}
+
+			if !waitForExclusion {
+				return nil
+			}
+
+			return waitForExclusionProgress(
+				cmd,
+				kubeClient,
+				config,
+				clientPod,
+				processGroupIDs,
+				interval,
+				timeout,
+			)
+		},
+		Example: `
+# Exclude process groups by their ID or Pod name and wait until the exclusion is done
+kubectl fdb exclude -c cluster cluster-storage-1 cluster-storage-2
+
+# Exclude all process groups running on a node
+kubectl fdb exclude -c cluster --node node-1
+
+# Exclude all process groups in a zone without waiting for the exclusion
+kubectl fdb exclude -c cluster --locality zoneid=zone-1 --wait-for-exclusion=false
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	addExclusionSelectionFlags(cmd)
+	cmd.Flags().
+		Bool("wait-for-exclusion", true, "defines if the command should wait until the process groups are fully excluded.")
+	cmd.Flags().
+		Duration("interval", 30*time.Second, "defines in which interval the exclusion progress should be fetched.")
+	cmd.Flags().
+		Duration("timeout", 0, "defines how long the command should wait for the exclusion to finish, 0 means no timeout.")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newIncludeCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "include",
+		Short: "Includes process groups that were excluded without removing them",
+		Long: "Includes process groups that were excluded without removing them and removes them from the " +
+			"manuallyExcludedProcessGroups list of the cluster spec. Process groups can be selected by their ID or " +
+			"Pod name, by node or by locality.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			includeAll, err := cmd.Flags().GetBool("all")
+			if err != nil {
+				return err
+			}
+
+			var opts exclusionSelectionOptions
+			if !includeAll {
+				opts, err = getExclusionSelectionOptions(cmd, args)
+				if err != nil {
+					return err
+				}
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			clientPod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			status, err := getStatus(cmd.Context(), kubeClient, config, clientPod)
+			if err != nil {
+				return err
+			}
+
+			var processGroups []*fdbv1beta2.ProcessGroupStatus
+			if includeAll {
+				processGroups = getManuallyExcludedProcessGroups(cluster)
+				if len(processGroups) == 0 {
+					cmd.Printf(
+						"cluster %s/%s has no manually excluded process groups\n",
+						namespace,
+						cluster.Name,
+					)
+					return nil
+				}
+			} else {
+				pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+				if err != nil {
+					return err
+				}
+
+				processGroups, err = selectProcessGroupsForExclusion(cluster, pods.Items, status, opts)
+				if err != nil {
+					return err
+				}
+			}
+
+			return includeProcessGroups(
+				cmd,
+				kubeClient,
+				config,
+				clientPod,
+				cluster,
+				status,
+				processGroups,
+				wait,
+			)
+		},
+		Example: `
+# Include process groups by their ID or Pod name
+kubectl fdb include -c cluster cluster-storage-1 cluster-storage-2
+
+# Include all process groups running on a node
+kubectl fdb include -c cluster --node node-1
+
+# Include all manually excluded process groups
+kubectl fdb include -c cluster --all
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	addExclusionSelectionFlags(cmd)
+	cmd.Flags().Bool("all", false, "include all manually excluded process groups of the cluster.")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// addExclusionSelectionFlags adds the flags to select the process groups for the exclude and include commands.
+func addExclusionSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("fdb-cluster", "c", "", "the cluster of the process groups.")
+	cmd.Flags().
+		StringArray("node", []string{}, "selects all process groups running on the provided node.")
+	cmd.Flags().
+		StringToString("locality", map[string]string{}, "selects all process groups with processes matching the provided localities, e.g. zoneid=zone-1.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// getExclusionSelectionOptions returns the exclusionSelectionOptions based on the provided flags and arguments.
+func getExclusionSelectionOptions(
+	cmd *cobra.Command,
+	args []string,
+) (exclusionSelectionOptions, error) {
+	nodes, err := cmd.Flags().GetStringArray("node")
+	if err != nil {
+		return exclusionSelectionOptions{}, err
+	}
+
+	localities, err := cmd.Flags().GetStringToString("locality")
+	if err != nil {
+		return exclusionSelectionOptions{}, err
+	}
+
+	if len(args) == 0 && len(nodes) == 0 && len(localities) == 0 {
+		return exclusionSelectionOptions{}, errors.New(
+			"no process groups selected, provide process group IDs, Pod names, nodes or localities",
+		)
+	}
+
+	return exclusionSelectionOptions{
+		ids:        args,
+		nodes:      nodes,
+		localities: localities,
+	}, nil
+}
+
+// selectProcessGroupsForExclusion returns all process groups that match the provided options. The process groups are
+// matched by their ID or Pod name, by the node the Pod is running on or by the localities reported by the processes.
+func selectProcessGroupsForExclusion(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	pods []corev1.Pod,
+	status *fdbv1beta2.FoundationDBStatus,
+	opts exclusionSelectionOptions,
+) ([]*fdbv1beta2.ProcessGroupStatus, error) {
+	selected := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+
+	for _, id := range opts.ids {
+		processGroupID := fdbv1beta2.ProcessGroupID(id)
+		if fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID) == nil &&
+			strings.HasPrefix(id, cluster.Name+"-") {
+			processGroupID = internal.GetProcessGroupIDFromPodName(cluster, id)
+		}
+
+		if fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, processGroupID) == nil {
+			return nil, fmt.Errorf(
+				"could not find process group or Pod %s in cluster %s/%s",
+				id,
+				cluster.Namespace,
+				cluster.Name,
+			)
+		}
+
+		selected[processGroupID] = fdbv1beta2.None{}
+	}
+
+	if len(opts.nodes) > 0 {
+		nodes := map[string]fdbv1beta2.None{}
+		for _, node := range opts.nodes {
+			nodes[node] = fdbv1beta2.None{}
+		}
+
+		matched := 0
+		for _, pod := range pods {
+			if _, ok := nodes[pod.Spec.NodeName]; !ok {
+				continue
+			}
+
+			processGroupID := fdbv1beta2.ProcessGroupID(
+				pod.Labels[cluster.GetProcessGroupIDLabel()],
+			)
+			if processGroupID == "" {
+				continue
+			}
+
+			selected[processGroupID] = fdbv1beta2.None{}
+			matched++
+		}
+
+		if matched == 0 {
+			return nil, fmt.Errorf("could not find any Pods on the nodes %v", opts.nodes)
+		}
+	}
+
+	if len(opts.localities) > 0 {
+		matched := 0
+		for _, process := range status.Cluster.Processes {
+			if !processMatchesLocalities(process, opts.localities) {
+				continue
+			}
+
+			processGroupID := fdbv1beta2.ProcessGroupID(
+				process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey],
+			)
+			if processGroupID == "" {
+				continue
+			}
+
+			selected[processGroupID] = fdbv1beta2.None{}
+			matched++
+		}
+
+		if matched == 0 {
+			return nil, fmt.Errorf(
+				"could not find any processes matching the localities %v",
+				opts.localities,
+			)
+		}
+	}
+
+	processGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(selected))
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if _, ok := selected[processGroup.ProcessGroupID]; !ok {
+			continue
+		}
+
+		if processGroup.IsMarkedForRemoval() {
+			return nil, fmt.Errorf(
+				"process group %s is marked for removal and will be excluded by the operator",
+				processGroup.ProcessGroupID,
+			)
+		}
+
+		processGroups = append(processGroups, processGroup)
+	}
+
+	sort.Slice(processGroups, func(i, j int) bool {
+		return processGroups[i].ProcessGroupID < processGroups[j].ProcessGroupID
+	})
+
+	return processGroups, nil
+}
+
+// processMatchesLocalities returns true if the process has all the provided localities.
+func processMatchesLocalities(
+	process fdbv1beta2.FoundationDBStatusProcessInfo,
+	localities map[string]string,
+) bool {
+	for key, value := range localities {
+		if process.Locality[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getExclusionAddresses returns the exclusion targets for the provided process groups. If the cluster uses localities
+// for exclusions the locality based exclusion string is used, otherwise the IP addresses of the process groups are
+// used, the same way the operator excludes processes.
+func getExclusionAddresses(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroups []*fdbv1beta2.ProcessGroupStatus,
+) ([]string, error) {
+	useLocalities := cluster.UseLocalitiesForExclusion()
+	addresses := make([]string, 0, len(processGroups))
+	for _, processGroup := range processGroups {
+		if useLocalities {
+			addresses = append(addresses, processGroup.GetExclusionString())
+			continue
+		}
+
+		if len(processGroup.Addresses) == 0 {
+			return nil, fmt.Errorf(
+				"process group %s has no addresses and the cluster doesn't use localities for exclusions",
+				processGroup.ProcessGroupID,
+			)
+		}
+
+		for _, address := range processGroup.Addresses {
+			addresses = append(
+				addresses,
+				fdbv1beta2.ProcessAddress{IPAddress: net.ParseIP(address)}.String(),
+			)
+		}
+	}
+
+	return addresses, nil
+}
+
+// getProcessGroupIDs returns the IDs of the provided process groups.
+func getProcessGroupIDs(
+	processGroups []*fdbv1beta2.ProcessGroupStatus,
+) []fdbv1beta2.ProcessGroupID {
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroups))
+	for _, processGroup := range processGroups {
+		processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+	}
+
+	return processGroupIDs
+}
+
+// excludeProcessGroups adds the provided process groups to the manuallyExcludedProcessGroups list of the cluster and
+// starts the exclusion of their processes without waiting for the exclusion to finish.
+func excludeProcessGroups(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	restConfig *rest.Config,
+	clientPod *corev1.Pod,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	processGroups []*fdbv1beta2.ProcessGroupStatus,
+	wait bool,
+) ([]fdbv1beta2.ProcessGroupID, error) {
+	addresses, err := getExclusionAddresses(cluster, processGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	err = fdbstatus.CanSafelyExcludeProcessesWithRecoveryState(cluster, status, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	processGroupIDs := getProcessGroupIDs(processGroups)
+	if wait {
+		if !confirmAction(
+			fmt.Sprintf(
+				"Exclude %v from cluster %s/%s with the exclusion targets %v",
+				processGroupIDs,
+				cluster.Namespace,
+				cluster.Name,
+				addresses,
+			),
+		) {
+			return nil, fmt.Errorf("user aborted the exclusion")
+		}
+	}
+
+	// The process groups are added to the spec before the exclusion is started to make sure the operator is not
+	// replacing them because of the exclusion.
+	err = updateManuallyExcludedProcessGroups(
+		cmd.Context(),
+		kubeClient,
+		cluster,
+		processGroupIDs,
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = executeFdbCliCommand(
+		cmd.Context(),
+		kubeClient,
+		restConfig,
+		clientPod,
+		fmt.Sprintf("exclude no_wait %s", strings.Join(addresses, " ")),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.Printf(
+		"started exclusion of %v in cluster %s/%s\n",
+		processGroupIDs,
+		cluster.Namespace,
+		cluster.Name,
+	)
+
+	return processGroupIDs, nil
+}
+
+// includeProcessGroups includes the processes of the provided process groups and removes the process groups from the
+// manuallyExcludedProcessGroups list of the cluster.
+func includeProcessGroups(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	restConfig *rest.Config,
+	clientPod *corev1.Pod,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	processGroups []*fdbv1beta2.ProcessGroupStatus,
+	wait bool,
+) error {
+	addresses, err := getExclusionAddresses(cluster, processGroups)
+	if err != nil {
+		return err
+	}
+
+	err = fdbstatus.CanSafelyIncludeProcesses(cluster, status, 0)
+	if err != nil {
+		return err
+	}
+
+	processGroupIDs := getProcessGroupIDs(processGroups)
+	if wait {
+		if !confirmAction(
+			fmt.Sprintf(
+				"Include %v in cluster %s/%s with the exclusion targets %v",
+				processGroupIDs,
+				cluster.Namespace,
+				cluster.Name,
+				addresses,
+			),
+		) {
+			return fmt.Errorf("user aborted the inclusion")
+		}
+	}
+
+	_, err = executeFdbCliCommand(
+		cmd.Context(),
+		kubeClient,
+		restConfig,
+		clientPod,
+		fmt.Sprintf("include %s", strings.Join(addresses, " ")),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = updateManuallyExcludedProcessGroups(
+		cmd.Context(),
+		kubeClient,
+		cluster,
+		processGroupIDs,
+		true,
+	)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf(
+		"included %v in cluster %s/%s\n",
+		processGroupIDs,
+		cluster.Namespace,
+		cluster.Name,
+	)
+
+	return nil
+}
+
+// getManuallyExcludedProcessGroups returns all process groups that are part of the manuallyExcludedProcessGroups list.
+func getManuallyExcludedProcessGroups(
+	cluster *fdbv1beta2.FoundationDBCluster,
+) []*fdbv1beta2.ProcessGroupStatus {
+	processGroups := make(
+		[]*fdbv1beta2.ProcessGroupStatus,
+		0,
+		len(cluster.Spec.ManuallyExcludedProcessGroups),
+	)
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if cluster.ProcessGroupIsManuallyExcluded(processGroup.ProcessGroupID) {
+			processGroups = append(processGroups, processGroup)
+		}
+	}
+
+	return processGroups
+}
+
+// updateManuallyExcludedProcessGroups adds the process groups to the manuallyExcludedProcessGroups list of the cluster
+// or removes them from the list if remove is true. The list is updated based on the latest version of the cluster and
+// the update is retried on conflicts, so concurrent updates of the list are not lost.
+func updateManuallyExcludedProcessGroups(
+	ctx context.Context,
+	kubeClient client.Client,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroupIDs []fdbv1beta2.ProcessGroupID,
+	remove bool,
+) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := kubeClient.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)
+		if err != nil {
+			return err
+		}
+
+		patch := client.MergeFromWithOptions(
+			cluster.DeepCopy(),
+			client.MergeFromWithOptimisticLock{},
+		)
+
+		toUpdate := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+		for _, processGroupID := range processGroupIDs {
+			toUpdate[processGroupID] = fdbv1beta2.None{}
+		}
+
+		manuallyExcluded := make(
+			[]fdbv1beta2.ProcessGroupID,
+			0,
+			len(cluster.Spec.ManuallyExcludedProcessGroups)+len(processGroupIDs),
+		)
+		for _, processGroupID := range cluster.Spec.ManuallyExcludedProcessGroups {
+			if _, ok := toUpdate[processGroupID]; ok {
+				if remove {
+					continue
+				}
+
+				delete(toUpdate, processGroupID)
+			}
+
+			manuallyExcluded = append(manuallyExcluded, processGroupID)
+		}
+
+		if !remove {
+			for _, processGroupID := range processGroupIDs {
+				if _, ok := toUpdate[processGroupID]; ok {
+					manuallyExcluded = append(manuallyExcluded, processGroupID)
+				}
+			}
+		}
+
+		cluster.Spec.ManuallyExcludedProcessGroups = manuallyExcluded
+
+		return kubeClient.Patch(ctx, cluster, patch)
+	})
+}
+
+// getExclusionProgress returns the exclusion progress of the processes of the provided process groups based on the
+// machine-readable status.
+func getExclusionProgress(
+	status *fdbv1beta2.FoundationDBStatus,
+	processGroupIDs []fdbv1beta2.ProcessGroupID,
+) exclusionProgress {
+	ids := make(map[string]fdbv1beta2.None, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		ids[string(processGroupID)] = fdbv1beta2.None{}
+	}
+
+	progress := exclusionProgress{}
+	for _, process := range status.Cluster.Processes {
+		if _, ok := ids[process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]]; !ok {
+			continue
+		}
+
+		progress.processes++
+		if !process.Excluded {
+			continue
+		}
+
+		progress.excluded++
+		if len(process.Roles) == 0 {
+			progress.fullyExcluded++
+			continue
+		}
+
+		for _, role := range process.Roles {
+			if fdbv1beta2.ProcessClass(role.Role) == fdbv1beta2.ProcessClassStorage {
+				progress.remainingBytes += int64(role.StoredBytes)
+			}
+		}
+	}
+
+	return progress
+}
+
+// waitForExclusionProgress prints the exclusion progress of the provided process groups until all processes are fully
+// excluded or the timeout is hit.
+func waitForExclusionProgress(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	restConfig *rest.Config,
+	clientPod *corev1.Pod,
+	processGroupIDs []fdbv1beta2.ProcessGroupID,
+	interval time.Duration,
+	timeout time.Duration,
+) error {
+	ctx := cmd.Context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := getStatus(ctx, kubeClient, restConfig, clientPod)
+		if err != nil {
+			// If an error occurs retry in the next interval.
+			cmd.PrintErrln(err)
+		} else {
+			progress := getExclusionProgress(status, processGroupIDs)
+			if progress.processes == 0 {
+				printStatement(
+					cmd,
+					"none of the processes are reporting to the cluster, the exclusion progress cannot be tracked",
+					warnMessage,
+				)
+				return nil
+			}
+
+			cmd.Printf(
+				"%d/%d processes excluded, %d/%d processes fully excluded, %s left on excluded storage servers, data movement: %s in flight, %s in queue\n",
+				progress.excluded,
+				progress.processes,
+				progress.fullyExcluded,
+				progress.processes,
+				fdbstatus.PrettyPrintBytes(progress.remainingBytes),
+				fdbstatus.PrettyPrintBytes(int64(status.Cluster.Data.MovingData.InFlightBytes)),
+				fdbstatus.PrettyPrintBytes(int64(status.Cluster.Data.MovingData.InQueueBytes)),
+			)
+
+			if progress.done() {
+				printStatement(cmd, fmt.Sprintf("%v are fully excluded", processGroupIDs), goodMessage)
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("exclusion of %v is not done: %w", processGroupIDs, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
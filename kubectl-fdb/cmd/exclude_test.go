@@ -0,0 +1,315 @@
+/*
+ * exclude_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] exclude and include commands", func() {
+	When("selecting the process groups", func() {
+		var pods []corev1.Pod
+		var status *fdbv1beta2.FoundationDBStatus
+
+		BeforeEach(func() {
+			pods = []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-storage-1",
+						Labels: map[string]string{
+							fdbv1beta2.FDBProcessGroupIDLabel: "test-storage-1",
+						},
+					},
+					Spec: corev1.PodSpec{NodeName: "node-1"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-storage-2",
+						Labels: map[string]string{
+							fdbv1beta2.FDBProcessGroupIDLabel: "test-storage-2",
+						},
+					},
+					Spec: corev1.PodSpec{NodeName: "node-2"},
+				},
+			}
+
+			status = &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"1": {
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-1",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-1",
+							},
+						},
+						"2": {
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-stateless-3",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-1",
+							},
+						},
+						"3": {
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-2",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-2",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		DescribeTable(
+			"should select the matching process groups",
+			func(opts exclusionSelectionOptions, expected []fdbv1beta2.ProcessGroupID) {
+				processGroups, err := selectProcessGroupsForExclusion(cluster, pods, status, opts)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getProcessGroupIDs(processGroups)).To(Equal(expected))
+			},
+			Entry("by process group ID",
+				exclusionSelectionOptions{ids: []string{"test-storage-2"}},
+				[]fdbv1beta2.ProcessGroupID{"test-storage-2"},
+			),
+			Entry("by node",
+				exclusionSelectionOptions{nodes: []string{"node-1"}},
+				[]fdbv1beta2.ProcessGroupID{"test-storage-1"},
+			),
+			Entry("by locality",
+				exclusionSelectionOptions{
+					localities: map[string]string{fdbv1beta2.FDBLocalityZoneIDKey: "zone-1"},
+				},
+				[]fdbv1beta2.ProcessGroupID{"test-stateless-3", "test-storage-1"},
+			),
+			Entry("by multiple options",
+				exclusionSelectionOptions{
+					ids:   []string{"test-stateless-3"},
+					nodes: []string{"node-1", "node-2"},
+				},
+				[]fdbv1beta2.ProcessGroupID{"test-stateless-3", "test-storage-1", "test-storage-2"},
+			),
+		)
+
+		It("should return an error for an unknown process group", func() {
+			_, err := selectProcessGroupsForExclusion(
+				cluster,
+				pods,
+				status,
+				exclusionSelectionOptions{ids: []string{"test-storage-42"}},
+			)
+			Expect(
+				err,
+			).To(MatchError("could not find process group or Pod test-storage-42 in cluster test/test"))
+		})
+
+		It("should return an error if no Pod is running on the node", func() {
+			_, err := selectProcessGroupsForExclusion(
+				cluster,
+				pods,
+				status,
+				exclusionSelectionOptions{nodes: []string{"node-3"}},
+			)
+			Expect(err).To(MatchError("could not find any Pods on the nodes [node-3]"))
+		})
+
+		When("the process group is marked for removal", func() {
+			BeforeEach(func() {
+				cluster.Status.ProcessGroups[0].MarkForRemoval()
+			})
+
+			It("should return an error", func() {
+				_, err := selectProcessGroupsForExclusion(
+					cluster,
+					pods,
+					status,
+					exclusionSelectionOptions{ids: []string{"test-storage-1"}},
+				)
+				Expect(
+					err,
+				).To(MatchError("process group test-storage-1 is marked for removal and will be excluded by the operator"))
+			})
+		})
+	})
+
+	When("getting the exclusion addresses", func() {
+		BeforeEach(func() {
+			cluster.Status.ProcessGroups[0].Addresses = []string{"1.1.1.1"}
+		})
+
+		It("should use the IP addresses if localities are not used", func() {
+			addresses, err := getExclusionAddresses(
+				cluster,
+				cluster.Status.ProcessGroups[:1],
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(addresses).To(ConsistOf("1.1.1.1"))
+		})
+
+		It("should return an error for process groups without addresses", func() {
+			_, err := getExclusionAddresses(cluster, cluster.Status.ProcessGroups[1:2])
+			Expect(err).To(HaveOccurred())
+		})
+
+		When("localities are used for exclusions", func() {
+			BeforeEach(func() {
+				cluster.Spec.Version = fdbv1beta2.Versions.SupportsLocalityBasedExclusions71.String()
+			})
+
+			It("should use the locality based exclusion string", func() {
+				addresses, err := getExclusionAddresses(cluster, cluster.Status.ProcessGroups[:2])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(addresses).To(ConsistOf(
+					"locality_instance_id:test-storage-1",
+					"locality_instance_id:test-storage-2",
+				))
+			})
+		})
+	})
+
+	When("updating the manually excluded process groups", func() {
+		BeforeEach(func() {
+			cluster.Spec.ManuallyExcludedProcessGroups = []fdbv1beta2.ProcessGroupID{
+				"test-storage-1",
+			}
+		})
+
+		It("should add and remove the process groups", func() {
+			ctx := context.Background()
+			Expect(updateManuallyExcludedProcessGroups(
+				ctx,
+				k8sClient,
+				cluster,
+				[]fdbv1beta2.ProcessGroupID{"test-storage-1", "test-storage-2"},
+				false,
+			)).To(Succeed())
+
+			fetchedCluster := &fdbv1beta2.FoundationDBCluster{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), fetchedCluster)).
+				To(Succeed())
+			Expect(fetchedCluster.Spec.ManuallyExcludedProcessGroups).To(Equal(
+				[]fdbv1beta2.ProcessGroupID{"test-storage-1", "test-storage-2"},
+			))
+			Expect(getProcessGroupIDs(getManuallyExcludedProcessGroups(fetchedCluster))).
+				To(ConsistOf(fdbv1beta2.ProcessGroupID("test-storage-1"), fdbv1beta2.ProcessGroupID("test-storage-2")))
+
+			Expect(updateManuallyExcludedProcessGroups(
+				ctx,
+				k8sClient,
+				fetchedCluster,
+				[]fdbv1beta2.ProcessGroupID{"test-storage-1"},
+				true,
+			)).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), fetchedCluster)).
+				To(Succeed())
+			Expect(fetchedCluster.Spec.ManuallyExcludedProcessGroups).To(Equal(
+				[]fdbv1beta2.ProcessGroupID{"test-storage-2"},
+			))
+		})
+
+		When("the list was changed concurrently", func() {
+			JustBeforeEach(func() {
+				ctx := context.Background()
+				concurrentCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), concurrentCluster)).
+					To(Succeed())
+				concurrentCluster.Spec.ManuallyExcludedProcessGroups = append(
+					concurrentCluster.Spec.ManuallyExcludedProcessGroups,
+					"test-storage-3",
+				)
+				Expect(k8sClient.Update(ctx, concurrentCluster)).To(Succeed())
+			})
+
+			It("should keep the concurrently added process groups", func() {
+				ctx := context.Background()
+				Expect(updateManuallyExcludedProcessGroups(
+					ctx,
+					k8sClient,
+					cluster,
+					[]fdbv1beta2.ProcessGroupID{"test-storage-2"},
+					false,
+				)).To(Succeed())
+
+				fetchedCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cluster), fetchedCluster)).
+					To(Succeed())
+				Expect(fetchedCluster.Spec.ManuallyExcludedProcessGroups).To(Equal(
+					[]fdbv1beta2.ProcessGroupID{"test-storage-1", "test-storage-3", "test-storage-2"},
+				))
+			})
+		})
+	})
+
+	When("getting the exclusion progress", func() {
+		It("should report the excluded and fully excluded processes", func() {
+			status := &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"1": {
+							Excluded: true,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-1",
+							},
+						},
+						"2": {
+							Excluded: true,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-2",
+							},
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role:        string(fdbv1beta2.ProcessRoleStorage),
+									StoredBytes: 1024,
+								},
+							},
+						},
+						"3": {
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-stateless-3",
+							},
+						},
+					},
+				},
+			}
+
+			progress := getExclusionProgress(
+				status,
+				[]fdbv1beta2.ProcessGroupID{"test-storage-1", "test-storage-2"},
+			)
+			Expect(progress).To(Equal(exclusionProgress{
+				processes:      2,
+				excluded:       2,
+				fullyExcluded:  1,
+				remainingBytes: 1024,
+			}))
+			Expect(progress.done()).To(BeFalse())
+
+			progress = getExclusionProgress(status, []fdbv1beta2.ProcessGroupID{"test-storage-1"})
+			Expect(progress.done()).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,627 @@
+/*
+ * export.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// lastAppliedConfigurationAnnotation is the annotation that is added by kubectl apply, this annotation will not be
+// exported.
+const lastAppliedConfigurationAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+func newExportCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Generates a FoundationDBCluster manifest from a running cluster",
+		Long: "Generates a FoundationDBCluster manifest from a running cluster. The manifest is based on the cluster " +
+			"spec and is updated with the observed state of the Pods, PVCs and the database configuration. Fields " +
+			"that match the defaults of the operator and transient fields like the process groups to remove will be " +
+			"removed, so the output can be committed to a git repository.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			outputFile, err := cmd.Flags().GetString("output-file")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			// The cluster is not loaded with loadCluster, as we want to start from the spec defined by the user
+			// and not from the normalized spec.
+			cluster := &fdbv1beta2.FoundationDBCluster{}
+			err = kubeClient.Get(
+				cmd.Context(),
+				types.NamespacedName{Namespace: namespace, Name: clusterName},
+				cluster,
+			)
+			if err != nil {
+				return err
+			}
+
+			pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			pvcs := &corev1.PersistentVolumeClaimList{}
+			err = kubeClient.List(
+				cmd.Context(),
+				pvcs,
+				client.MatchingLabels(cluster.GetMatchLabels()),
+				client.InNamespace(cluster.GetNamespace()),
+			)
+			if err != nil {
+				return err
+			}
+
+			exportedCluster, warnings, err := getExportedCluster(cluster, pods.Items, pvcs.Items)
+			if err != nil {
+				return err
+			}
+
+			for _, warning := range warnings {
+				printStatement(cmd, warning, warnMessage)
+			}
+
+			manifest, err := getExportManifest(exportedCluster)
+			if err != nil {
+				return err
+			}
+
+			if outputFile == "" {
+				cmd.Print(string(manifest))
+				return nil
+			}
+
+			err = os.WriteFile(outputFile, manifest, 0600)
+			if err != nil {
+				return err
+			}
+
+			printStatement(
+				cmd,
+				fmt.Sprintf("exported cluster %s/%s to %s", namespace, clusterName, outputFile),
+				goodMessage,
+			)
+
+			return nil
+		},
+		Example: `
+# Print the manifest of the cluster c1 in the current namespace
+kubectl fdb export -c c1
+
+# Write the manifest of the cluster c1 in the namespace default to c1.yaml
+kubectl fdb -n default export -c c1 --output-file c1.yaml
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "export the manifest of the provided cluster.")
+	cmd.Flags().
+		String("output-file", "", "write the manifest to the provided file instead of stdout.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getExportedCluster returns a copy of the provided cluster with the observed state of the Pods, PVCs and the
+// database configuration. Transient fields are removed from the spec. The returned warnings contain all differences
+// between the spec and the observed state.
+func getExportedCluster(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	pods []corev1.Pod,
+	pvcs []corev1.PersistentVolumeClaim,
+) (*fdbv1beta2.FoundationDBCluster, []string, error) {
+	var warnings []string
+	exportedCluster := cluster.DeepCopy()
+
+	if cluster.Status.RunningVersion != "" &&
+		cluster.Status.RunningVersion != cluster.Spec.Version {
+		warnings = append(
+			warnings,
+			fmt.Sprintf(
+				"cluster is running version %s but the desired version is %s, the desired version will be exported",
+				cluster.Status.RunningVersion,
+				cluster.Spec.Version,
+			),
+		)
+	}
+
+	warnings = append(warnings, updateExportedDatabaseConfiguration(exportedCluster)...)
+
+	processCountWarnings, err := updateExportedProcessCounts(exportedCluster, pods)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings = append(warnings, processCountWarnings...)
+
+	volumeClaimWarnings, err := updateExportedVolumeClaimTemplates(exportedCluster, pvcs)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnings = append(warnings, volumeClaimWarnings...)
+
+	// Those fields are only used for operations and will be reset once the operator is done.
+	exportedCluster.Spec.ProcessGroupsToRemove = nil
+	exportedCluster.Spec.ProcessGroupsToRemoveWithoutExclusion = nil
+	exportedCluster.Spec.Skip = false
+
+	return exportedCluster, warnings, nil
+}
+
+// updateExportedDatabaseConfiguration updates the database configuration of the cluster spec to the observed database
+// configuration, if they differ.
+func updateExportedDatabaseConfiguration(cluster *fdbv1beta2.FoundationDBCluster) []string {
+	// The database configuration will only be set once the database is available.
+	if cluster.Status.DatabaseConfiguration.RedundancyMode == "" {
+		return nil
+	}
+
+	currentConfiguration := cluster.Status.DatabaseConfiguration.DeepCopy()
+	currentConfiguration.Storage = 0
+	if equality.Semantic.DeepEqual(cluster.DesiredDatabaseConfiguration(), *currentConfiguration) {
+		return nil
+	}
+
+	cluster.ClearUnsetDatabaseConfigurationKnobs(currentConfiguration)
+	roleCounts := currentConfiguration.RoleCounts
+	// Only keep the role counts if they differ from the defaults for the current configuration.
+	currentConfiguration.RoleCounts = fdbv1beta2.RoleCounts{}
+	cluster.Spec.DatabaseConfiguration = *currentConfiguration
+	defaultRoleCounts := cluster.GetRoleCountsWithDefaults()
+	defaultRoleCounts.Storage = 0
+	if !equality.Semantic.DeepEqual(defaultRoleCounts, roleCounts) {
+		cluster.Spec.DatabaseConfiguration.RoleCounts = roleCounts
+	}
+
+	return []string{
+		"the current database configuration differs from the desired database configuration, the current database configuration will be exported",
+	}
+}
+
+// updateExportedProcessCounts updates the process counts of the cluster spec to the number of Pods per process class,
+// if they differ. Pods of process groups that are marked for removal are ignored.
+func updateExportedProcessCounts(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	pods []corev1.Pod,
+) ([]string, error) {
+	// If no Pods are present, the cluster was not yet created and the spec will be used.
+	if len(pods) == 0 {
+		return nil, nil
+	}
+
+	desiredCounts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	currentCounts := fdbv1beta2.ProcessCounts{}
+	for _, pod := range pods {
+		processGroupID := fdbv1beta2.ProcessGroupID(pod.Labels[cluster.GetProcessGroupIDLabel()])
+		if processGroupID == "" || cluster.ProcessGroupIsBeingRemoved(processGroupID) {
+			continue
+		}
+
+		currentCounts.IncreaseCount(
+			fdbv1beta2.ProcessClass(pod.Labels[cluster.GetProcessClassLabel()]),
+			1,
+		)
+	}
+
+	diff := desiredCounts.Diff(currentCounts)
+	if len(diff) == 0 {
+		return nil, nil
+	}
+
+	specCounts, err := getProcessCountsMap(cluster.Spec.ProcessCounts)
+	if err != nil {
+		return nil, err
+	}
+
+	currentCountsMap := currentCounts.Map()
+	processClasses := make([]fdbv1beta2.ProcessClass, 0, len(diff))
+	for processClass := range diff {
+		processClasses = append(processClasses, processClass)
+	}
+	sort.Slice(processClasses, func(i, j int) bool {
+		return processClasses[i] < processClasses[j]
+	})
+
+	warnings := make([]string, 0, len(processClasses))
+	for _, processClass := range processClasses {
+		count := currentCountsMap[processClass]
+		warnings = append(
+			warnings,
+			fmt.Sprintf(
+				"cluster has %d %s processes but %d are desired, the current process count will be exported",
+				count,
+				processClass,
+				count+int(diff[processClass]),
+			),
+		)
+
+		// A count of -1 disables the process class.
+		if count == 0 {
+			count = -1
+		}
+		specCounts[processClass] = count
+	}
+
+	cluster.Spec.ProcessCounts, err = getProcessCountsFromMap(specCounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return warnings, nil
+}
+
+// getProcessCountsMap returns the process counts as map. In contrast to ProcessCounts.Map, negative counts are
+// included.
+func getProcessCountsMap(counts fdbv1beta2.ProcessCounts) (map[fdbv1beta2.ProcessClass]int, error) {
+	rawCounts, err := json.Marshal(counts)
+	if err != nil {
+		return nil, err
+	}
+
+	countsMap := map[fdbv1beta2.ProcessClass]int{}
+	err = json.Unmarshal(rawCounts, &countsMap)
+
+	return countsMap, err
+}
+
+// getProcessCountsFromMap converts the provided map back into process counts.
+func getProcessCountsFromMap(
+	countsMap map[fdbv1beta2.ProcessClass]int,
+) (fdbv1beta2.ProcessCounts, error) {
+	counts := fdbv1beta2.ProcessCounts{}
+	rawCounts, err := json.Marshal(countsMap)
+	if err != nil {
+		return counts, err
+	}
+
+	err = json.Unmarshal(rawCounts, &counts)
+
+	return counts, err
+}
+
+// updateExportedVolumeClaimTemplates updates the volume claim templates of the cluster spec to the observed size and
+// storage class of the PVCs, if they differ. If the PVCs of a process class have different sizes or storage classes,
+// the most common value will be used.
+func updateExportedVolumeClaimTemplates(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	pvcs []corev1.PersistentVolumeClaim,
+) ([]string, error) {
+	pvcsByProcessClass := map[fdbv1beta2.ProcessClass][]corev1.PersistentVolumeClaim{}
+	processGroupIDs := map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessGroupID{}
+	for _, pvc := range pvcs {
+		processGroupID := fdbv1beta2.ProcessGroupID(pvc.Labels[cluster.GetProcessGroupIDLabel()])
+		if processGroupID == "" || cluster.ProcessGroupIsBeingRemoved(processGroupID) {
+			continue
+		}
+
+		processClass := fdbv1beta2.ProcessClass(pvc.Labels[cluster.GetProcessClassLabel()])
+		pvcsByProcessClass[processClass] = append(pvcsByProcessClass[processClass], pvc)
+		processGroupIDs[processClass] = processGroupID
+	}
+
+	processClasses := make([]fdbv1beta2.ProcessClass, 0, len(pvcsByProcessClass))
+	for processClass := range pvcsByProcessClass {
+		processClasses = append(processClasses, processClass)
+	}
+	sort.Slice(processClasses, func(i, j int) bool {
+		return processClasses[i] < processClasses[j]
+	})
+
+	var warnings []string
+	for _, processClass := range processClasses {
+		desiredPvc, err := internal.GetPvc(cluster, &fdbv1beta2.ProcessGroupStatus{
+			ProcessGroupID: processGroupIDs[processClass],
+			ProcessClass:   processClass,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if desiredPvc == nil {
+			continue
+		}
+
+		sizes := map[string]int{}
+		storageClasses := map[string]int{}
+		for _, pvc := range pvcsByProcessClass[processClass] {
+			size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+			sizes[size.String()]++
+			if pvc.Spec.StorageClassName != nil {
+				storageClasses[*pvc.Spec.StorageClassName]++
+			}
+		}
+
+		size, sizeConsistent := getMostCommonValue(sizes)
+		storageClass, storageClassConsistent := getMostCommonValue(storageClasses)
+		if !sizeConsistent || !storageClassConsistent {
+			warnings = append(
+				warnings,
+				fmt.Sprintf(
+					"PVCs of process class %s have different sizes or storage classes, the most common values will be exported",
+					processClass,
+				),
+			)
+		}
+
+		desiredSize := desiredPvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		sizeChanged := size != "" && size != desiredSize.String()
+		// If no storage class is defined the default storage class will be used, so we only compare the storage
+		// class if it's explicitly defined.
+		storageClassChanged := desiredPvc.Spec.StorageClassName != nil &&
+			storageClass != "" &&
+			storageClass != *desiredPvc.Spec.StorageClassName
+		if !sizeChanged && !storageClassChanged {
+			continue
+		}
+
+		warnings = append(
+			warnings,
+			fmt.Sprintf(
+				"PVCs of process class %s differ from the volume claim template, the current size and storage class will be exported",
+				processClass,
+			),
+		)
+
+		settings := cluster.Spec.Processes[processClass]
+		processSettings := cluster.GetProcessSettings(processClass)
+		volumeClaimTemplate := &corev1.PersistentVolumeClaim{}
+		if processSettings.VolumeClaimTemplate != nil {
+			volumeClaimTemplate = processSettings.VolumeClaimTemplate.DeepCopy()
+		}
+
+		if sizeChanged {
+			if volumeClaimTemplate.Spec.Resources.Requests == nil {
+				volumeClaimTemplate.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+			for _, pvc := range pvcsByProcessClass[processClass] {
+				pvcSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+				if pvcSize.String() == size {
+					volumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage] = pvcSize
+					break
+				}
+			}
+		}
+
+		if storageClassChanged {
+			volumeClaimTemplate.Spec.StorageClassName = &storageClass
+		}
+
+		settings.VolumeClaimTemplate = volumeClaimTemplate
+		if cluster.Spec.Processes == nil {
+			cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{}
+		}
+		cluster.Spec.Processes[processClass] = settings
+	}
+
+	return warnings, nil
+}
+
+// getMostCommonValue returns the value with the highest count and whether all entries share the same value. If
+// multiple values have the same count, the lexicographically smallest value is returned.
+func getMostCommonValue(counts map[string]int) (string, bool) {
+	var result string
+	maxCount := 0
+	for value, count := range counts {
+		if count > maxCount || (count == maxCount && value < result) {
+			result = value
+			maxCount = count
+		}
+	}
+
+	return result, len(counts) <= 1
+}
+
+// getMinimalClusterSpec returns the spec of the provided cluster without the fields that match the defaults of the
+// operator. If the minimal spec doesn't result in the same normalized spec, the normalized spec will be returned.
+func getMinimalClusterSpec(
+	cluster *fdbv1beta2.FoundationDBCluster,
+) (map[string]interface{}, error) {
+	normalizedCluster := cluster.DeepCopy()
+	err := internal.NormalizeClusterSpec(normalizedCluster, internal.DeprecationOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	defaultCluster := &fdbv1beta2.FoundationDBCluster{
+		ObjectMeta: cluster.ObjectMeta,
+		Spec: fdbv1beta2.FoundationDBClusterSpec{
+			Version: cluster.Spec.Version,
+		},
+	}
+	err = internal.NormalizeClusterSpec(defaultCluster, internal.DeprecationOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedSpec, err := toGenericMap(normalizedCluster.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultSpec, err := toGenericMap(defaultCluster.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	minimalSpec, err := toGenericMap(normalizedCluster.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	removeDefaultValues(minimalSpec, defaultSpec)
+	// The version is always required.
+	minimalSpec["version"] = cluster.Spec.Version
+
+	// Make sure that the minimal spec results in the same spec once the defaults are applied.
+	rawSpec, err := json.Marshal(minimalSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	validationCluster := cluster.DeepCopy()
+	validationCluster.Spec = fdbv1beta2.FoundationDBClusterSpec{}
+	err = json.Unmarshal(rawSpec, &validationCluster.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = internal.NormalizeClusterSpec(validationCluster, internal.DeprecationOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if !equality.Semantic.DeepEqual(validationCluster.Spec, normalizedCluster.Spec) {
+		return normalizedSpec, nil
+	}
+
+	return minimalSpec, nil
+}
+
+// toGenericMap converts the provided object into a generic map.
+func toGenericMap(object interface{}) (map[string]interface{}, error) {
+	genericObject, err := toGenericObject(object)
+	if err != nil {
+		return nil, err
+	}
+
+	genericMap, ok := genericObject.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("could not convert %T into a map", object)
+	}
+
+	return genericMap, nil
+}
+
+// removeDefaultValues removes all fields from the object that have the same value in the defaults. Lists are only
+// removed if all entries are equal.
+func removeDefaultValues(object map[string]interface{}, defaults map[string]interface{}) {
+	for field, value := range object {
+		defaultValue, ok := defaults[field]
+		if !ok {
+			continue
+		}
+
+		mapValue, isMap := value.(map[string]interface{})
+		defaultMapValue, defaultIsMap := defaultValue.(map[string]interface{})
+		if isMap && defaultIsMap {
+			removeDefaultValues(mapValue, defaultMapValue)
+			if len(mapValue) == 0 {
+				delete(object, field)
+			}
+			continue
+		}
+
+		if equality.Semantic.DeepEqual(value, defaultValue) {
+			delete(object, field)
+		}
+	}
+}
+
+// getExportManifest returns the YAML manifest of the provided cluster. Only the fields that are required to create
+// the cluster are included.
+func getExportManifest(cluster *fdbv1beta2.FoundationDBCluster) ([]byte, error) {
+	spec, err := getMinimalClusterSpec(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{
+		"name":      cluster.Name,
+		"namespace": cluster.Namespace,
+	}
+
+	if len(cluster.Labels) > 0 {
+		metadata["labels"] = cluster.Labels
+	}
+
+	annotations := map[string]string{}
+	for key, value := range cluster.Annotations {
+		if key == lastAppliedConfigurationAnnotation {
+			continue
+		}
+
+		annotations[key] = value
+	}
+
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+
+	manifest, err := getMinimalYAML(map[string]interface{}{
+		"apiVersion": fdbv1beta2.GroupVersion.String(),
+		"kind":       "FoundationDBCluster",
+		"metadata":   metadata,
+		"spec":       spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure that the manifest can be parsed as a FoundationDBCluster.
+	parsedCluster := &fdbv1beta2.FoundationDBCluster{}
+	err = yaml.UnmarshalStrict(manifest, parsedCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
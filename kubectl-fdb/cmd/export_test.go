@@ -0,0 +1,308 @@
+/*
+ * export_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("[plugin] export command", func() {
+	BeforeEach(func() {
+		cluster.Spec.Version = fdbv1beta2.Versions.Default.String()
+	})
+
+	When("generating the manifest", func() {
+		BeforeEach(func() {
+			cluster.Labels = map[string]string{"team": "storage"}
+			cluster.Annotations = map[string]string{
+				lastAppliedConfigurationAnnotation: "{}",
+				"owner":                            "storage-team",
+			}
+			cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{"test-storage-1"}
+		})
+
+		It("should only contain the non-default fields", func() {
+			exportedCluster, warnings, err := getExportedCluster(cluster, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+
+			manifest, err := getExportManifest(exportedCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(manifest)).To(Equal(`apiVersion: apps.foundationdb.org/v1beta2
+kind: FoundationDBCluster
+metadata:
+  annotations:
+    owner: storage-team
+  labels:
+    team: storage
+  name: test
+  namespace: test
+spec:
+  processCounts:
+    storage: 1
+  processGroupIDPrefix: test
+  version: ` + fdbv1beta2.Versions.Default.String() + `
+`))
+		})
+
+		When("the Pod template contains custom settings", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						PodTemplate: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: fdbv1beta2.MainContainerName,
+										Resources: corev1.ResourceRequirements{
+											Requests: corev1.ResourceList{
+												corev1.ResourceCPU: resource.MustParse("4"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("should result in the same spec once the defaults are applied", func() {
+				exportedCluster, _, err := getExportedCluster(cluster, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				manifest, err := getExportManifest(exportedCluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(manifest)).NotTo(ContainSubstring("processGroupsToRemove"))
+
+				parsedCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(yaml.Unmarshal(manifest, parsedCluster)).To(Succeed())
+				Expect(parsedCluster.Spec.Processes).To(HaveKey(fdbv1beta2.ProcessClassGeneral))
+				Expect(
+					internal.NormalizeClusterSpec(parsedCluster, internal.DeprecationOptions{}),
+				).To(Succeed())
+				Expect(
+					internal.NormalizeClusterSpec(exportedCluster, internal.DeprecationOptions{}),
+				).To(Succeed())
+				Expect(parsedCluster.Spec).To(Equal(exportedCluster.Spec))
+			})
+		})
+	})
+
+	When("the Pods differ from the process counts", func() {
+		var pods []corev1.Pod
+
+		BeforeEach(func() {
+			cluster.Spec.ProcessCounts.Log = -1
+			cluster.Spec.ProcessCounts.Stateless = -1
+			cluster.Status.ProcessGroups[0].MarkForRemoval()
+
+			pods = make([]corev1.Pod, 0, 3)
+			for _, id := range []string{"test-storage-1", "test-storage-2", "test-storage-4"} {
+				pods = append(pods, corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: id,
+						Labels: map[string]string{
+							fdbv1beta2.FDBProcessGroupIDLabel: id,
+							fdbv1beta2.FDBProcessClassLabel: string(
+								fdbv1beta2.ProcessClassStorage,
+							),
+						},
+					},
+				})
+			}
+		})
+
+		It("should export the current process counts", func() {
+			exportedCluster, warnings, err := getExportedCluster(cluster, pods, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				"cluster has 2 storage processes but 1 are desired, the current process count will be exported",
+			))
+			Expect(exportedCluster.Spec.ProcessCounts).To(Equal(fdbv1beta2.ProcessCounts{
+				Storage:   2,
+				Log:       -1,
+				Stateless: -1,
+			}))
+		})
+	})
+
+	When("the PVCs differ from the volume claim template", func() {
+		var pvcs []corev1.PersistentVolumeClaim
+
+		BeforeEach(func() {
+			pvcs = make([]corev1.PersistentVolumeClaim, 0, 2)
+			for _, id := range []string{"test-storage-1", "test-storage-2"} {
+				pvcs = append(pvcs, corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: id + "-data",
+						Labels: map[string]string{
+							fdbv1beta2.FDBProcessGroupIDLabel: id,
+							fdbv1beta2.FDBProcessClassLabel: string(
+								fdbv1beta2.ProcessClassStorage,
+							),
+						},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						StorageClassName: ptr.To("standard"),
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("256G"),
+							},
+						},
+					},
+				})
+			}
+		})
+
+		It("should export the current size", func() {
+			exportedCluster, warnings, err := getExportedCluster(cluster, nil, pvcs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				"PVCs of process class storage differ from the volume claim template, the current size and storage class will be exported",
+			))
+
+			volumeClaimTemplate := exportedCluster.Spec.Processes[fdbv1beta2.ProcessClassStorage].VolumeClaimTemplate
+			Expect(volumeClaimTemplate).NotTo(BeNil())
+			Expect(volumeClaimTemplate.Spec.Resources.Requests).To(HaveKeyWithValue(
+				corev1.ResourceStorage,
+				resource.MustParse("256G"),
+			))
+			// The storage class is not defined in the spec, so the default storage class is used.
+			Expect(volumeClaimTemplate.Spec.StorageClassName).To(BeNil())
+		})
+
+		When("the PVCs have different sizes", func() {
+			BeforeEach(func() {
+				pvcs[1].Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("128G")
+			})
+
+			It("should add a warning", func() {
+				_, warnings, err := getExportedCluster(cluster, nil, pvcs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(ContainElement(
+					"PVCs of process class storage have different sizes or storage classes, the most common values will be exported",
+				))
+			})
+		})
+
+		When("the PVCs match the volume claim template", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								StorageClassName: ptr.To("standard"),
+								Resources: corev1.VolumeResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceStorage: resource.MustParse("256G"),
+									},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("should not change the spec", func() {
+				exportedCluster, warnings, err := getExportedCluster(cluster, nil, pvcs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+				Expect(exportedCluster.Spec.Processes).To(Equal(cluster.Spec.Processes))
+			})
+		})
+	})
+
+	When("the database configuration differs", func() {
+		BeforeEach(func() {
+			cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeDouble
+			cluster.Status.DatabaseConfiguration = cluster.DesiredDatabaseConfiguration()
+			cluster.Status.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeTriple
+		})
+
+		It("should export the current database configuration", func() {
+			exportedCluster, warnings, err := getExportedCluster(cluster, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+			Expect(exportedCluster.Spec.DatabaseConfiguration.RedundancyMode).To(Equal(
+				fdbv1beta2.RedundancyModeTriple,
+			))
+			// The role counts match the defaults and should not be exported.
+			Expect(exportedCluster.Spec.DatabaseConfiguration.RoleCounts).To(Equal(
+				fdbv1beta2.RoleCounts{},
+			))
+		})
+
+		When("the current database configuration matches the desired one", func() {
+			BeforeEach(func() {
+				cluster.Status.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeDouble
+			})
+
+			It("should not change the spec", func() {
+				exportedCluster, warnings, err := getExportedCluster(cluster, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+				Expect(exportedCluster.Spec.DatabaseConfiguration).To(Equal(
+					cluster.Spec.DatabaseConfiguration,
+				))
+			})
+		})
+	})
+
+	When("removing the default values", func() {
+		It("should only keep the changed values", func() {
+			object := map[string]interface{}{
+				"version": "7.1.57",
+				"nested": map[string]interface{}{
+					"a": "b",
+					"c": "changed",
+				},
+				"list":  []interface{}{"a", "b"},
+				"other": true,
+			}
+
+			removeDefaultValues(object, map[string]interface{}{
+				"version": "7.1.57",
+				"nested": map[string]interface{}{
+					"a": "b",
+					"c": "d",
+				},
+				"list": []interface{}{"a"},
+			})
+
+			Expect(object).To(Equal(map[string]interface{}{
+				"nested": map[string]interface{}{
+					"c": "changed",
+				},
+				"list":  []interface{}{"a", "b"},
+				"other": true,
+			}))
+		})
+	})
+})
@@ -42,6 +42,9 @@ kubectl fdb get configuration c1
 
 # Get the configuration string from cluster c1 in the namespace default
 kubectl fdb -n default get configuration c1
+
+# Get the resource recommendations from cluster c1 as a patch for the cluster spec
+kubectl fdb get recommendations c1
 `,
 	}
 	cmd.SetOut(o.Out)
@@ -50,6 +53,7 @@ kubectl fdb -n default get configuration c1
 
 	cmd.AddCommand(newConfigurationCmd(streams))
 	cmd.AddCommand(newExclusionStatusCmd(streams))
+	cmd.AddCommand(newRecommendationsCmd(streams))
 	o.configFlags.AddFlags(cmd.Flags())
 
 	return cmd
@@ -0,0 +1,1050 @@
+/*
+ * maintenance.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/maintenance"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maintenanceListLimit defines the maximum number of entries that will be read from the maintenance list.
+const maintenanceListLimit = 10000
+
+// maintenanceEntryState describes the state of an entry in the maintenance list.
+type maintenanceEntryState string
+
+const (
+	// maintenanceEntryStatePending means the process was not yet restarted since the maintenance started.
+	maintenanceEntryStatePending maintenanceEntryState = "Pending"
+	// maintenanceEntryStateFinished means the process was restarted since the maintenance started.
+	maintenanceEntryStateFinished maintenanceEntryState = "Finished"
+	// maintenanceEntryStateStale means the entry is old or the process group doesn't exist anymore.
+	maintenanceEntryStateStale maintenanceEntryState = "Stale"
+	// maintenanceEntryStateOtherZone means the process is in a different zone than the current maintenance zone and
+	// will not block the reset of the maintenance zone.
+	maintenanceEntryStateOtherZone maintenanceEntryState = "OtherZone"
+)
+
+// maintenanceOptions defines the options used to evaluate the maintenance list.
+type maintenanceOptions struct {
+	staleDuration time.Duration
+	waitDuration  time.Duration
+}
+
+// maintenanceEntry represents a single process group in the maintenance list.
+type maintenanceEntry struct {
+	ProcessGroupID fdbv1beta2.ProcessGroupID `json:"processGroupID"`
+	Zone           string                    `json:"zone,omitempty"`
+	Start          time.Time                 `json:"start"`
+	State          maintenanceEntryState     `json:"state"`
+}
+
+// maintenanceOverview contains the current maintenance zone and the entries of the maintenance list.
+type maintenanceOverview struct {
+	Namespace string             `json:"namespace"`
+	Cluster   string             `json:"cluster"`
+	Zone      string             `json:"zone,omitempty"`
+	Expiry    *time.Time         `json:"expiry,omitempty"`
+	Entries   []maintenanceEntry `json:"entries"`
+}
+
+func newMaintenanceCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "maintenance",
+		Short: "Subcommand to inspect and change the maintenance mode of a given cluster",
+		Long:  "Subcommand to inspect and change the maintenance mode of a given cluster",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+		Example: `
+# Show the current maintenance zone and the maintenance list of the cluster in the current namespace
+kubectl fdb maintenance status -c cluster
+
+# Set the maintenance zone for one hour
+kubectl fdb maintenance set -c cluster --duration 1h zone-1
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.AddCommand(
+		newMaintenanceStatusCmd(streams),
+		newMaintenanceListCmd(streams),
+		newMaintenanceSetCmd(streams),
+		newMaintenanceResetCmd(streams),
+		newMaintenanceClearCmd(streams),
+	)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// addMaintenanceFlags adds the flags that are shared between the maintenance subcommands.
+func addMaintenanceFlags(cmd *cobra.Command, opts *maintenanceOptions) {
+	cmd.Flags().StringP("fdb-cluster", "c", "", "the cluster to inspect or change.")
+	cmd.Flags().
+		DurationVar(&opts.staleDuration, "stale-duration", 4*time.Hour, "the duration after entries in the maintenance list are considered stale, should match the maintenance-list-stale-duration of the operator.")
+	cmd.Flags().
+		DurationVar(&opts.waitDuration, "wait-duration", 5*time.Minute, "the duration where entries in a different zone block the reset of the maintenance zone, should match the maintenance-list-wait-duration of the operator.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// newMaintenanceOverviewCmd returns a command that prints the maintenance overview, if entriesOnly is true only the
+// entries of the maintenance list will be printed.
+func newMaintenanceOverviewCmd(
+	streams genericclioptions.IOStreams,
+	use string,
+	description string,
+	entriesOnly bool,
+) *cobra.Command {
+	o := newFDBOptions(streams)
+	opts := maintenanceOptions{}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: description,
+		Long:  description,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+
+			err = validateOutputFormat(output)
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			overview, err := fetchMaintenanceOverview(cmd, kubeClient, config, cluster, opts)
+			if err != nil {
+				return err
+			}
+
+			if entriesOnly {
+				if output != outputFormatText {
+					return printStructuredOutput(cmd.OutOrStdout(), overview.Entries, output)
+				}
+
+				return printMaintenanceEntries(cmd.OutOrStdout(), overview.Entries)
+			}
+
+			return printMaintenanceOverview(cmd.OutOrStdout(), overview, output, time.Now())
+		},
+		Example: fmt.Sprintf(`
+# %[2]s of the cluster in the current namespace
+kubectl fdb maintenance %[1]s -c cluster
+
+# %[2]s of the cluster as JSON
+kubectl fdb maintenance %[1]s -c cluster --output json
+`, use, description),
+	}
+
+	addMaintenanceFlags(cmd, &opts)
+	cmd.Flags().
+		String("output", outputFormatText, "the output format, supported formats are: text, json and yaml.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newMaintenanceStatusCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	return newMaintenanceOverviewCmd(
+		streams,
+		"status",
+		"Shows the current maintenance zone and the maintenance list",
+		false,
+	)
+}
+
+func newMaintenanceListCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	return newMaintenanceOverviewCmd(
+		streams,
+		"list",
+		"Lists the process groups in the maintenance list",
+		true,
+	)
+}
+
+func newMaintenanceSetCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+	opts := maintenanceOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Sets the maintenance zone and adds the storage processes of the zone to the maintenance list",
+		Long:  "Sets the maintenance zone and adds the storage processes of the zone to the maintenance list",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			duration, err := cmd.Flags().GetDuration("duration")
+			if err != nil {
+				return err
+			}
+
+			skipMaintenanceList, err := cmd.Flags().GetBool("skip-maintenance-list")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			if duration == 0 {
+				duration = time.Duration(cluster.GetMaintenaceModeTimeoutSeconds()) * time.Second
+			}
+
+			pod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			status, err := getStatus(cmd.Context(), kubeClient, config, pod)
+			if err != nil {
+				return err
+			}
+
+			zone := args[0]
+			var processGroupIDs []fdbv1beta2.ProcessGroupID
+			if !skipMaintenanceList {
+				processGroupIDs = getStorageProcessGroupsInZone(status, zone)
+			}
+
+			if wait {
+				message := fmt.Sprintf(
+					"Setting maintenance zone %s for %s and adding %d storage process groups to the maintenance list for cluster %s/%s",
+					zone,
+					duration.String(),
+					len(processGroupIDs),
+					namespace,
+					cluster.Name,
+				)
+				if status.Cluster.MaintenanceZone != "" &&
+					string(status.Cluster.MaintenanceZone) != zone {
+					message += fmt.Sprintf(
+						", this will replace the current maintenance zone %s",
+						status.Cluster.MaintenanceZone,
+					)
+				}
+
+				if !confirmAction(message) {
+					return fmt.Errorf("user aborted the maintenance change")
+				}
+			}
+
+			_, err = executeFdbCliCommand(
+				cmd.Context(),
+				kubeClient,
+				config,
+				pod,
+				getSetMaintenanceZoneCommand(
+					cluster,
+					zone,
+					duration,
+					processGroupIDs,
+					time.Now(),
+				),
+			)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf(
+				"set maintenance zone %s for %s for cluster %s/%s\n",
+				zone,
+				duration.String(),
+				namespace,
+				cluster.Name,
+			)
+
+			return nil
+		},
+		Example: `
+# Set the maintenance zone for the cluster in the current namespace with the default duration of the cluster
+kubectl fdb maintenance set -c cluster zone-1
+
+# Set the maintenance zone for one hour without adding the storage processes to the maintenance list
+kubectl fdb maintenance set -c cluster --duration 1h --skip-maintenance-list zone-1
+`,
+	}
+
+	addMaintenanceFlags(cmd, &opts)
+	cmd.Flags().
+		Duration("duration", 0, "the duration of the maintenance zone, defaults to the maintenance mode timeout of the cluster.")
+	cmd.Flags().
+		Bool("skip-maintenance-list", false, "don't add the storage processes of the zone to the maintenance list.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newMaintenanceResetCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+	opts := maintenanceOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "reset",
+		Short: "Resets the maintenance zone",
+		Long:  "Resets the maintenance zone",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			overview, err := fetchMaintenanceOverview(cmd, kubeClient, config, cluster, opts)
+			if err != nil {
+				return err
+			}
+
+			if overview.Zone == "" {
+				cmd.Printf("cluster %s/%s has no maintenance zone\n", namespace, cluster.Name)
+				return nil
+			}
+
+			if wait {
+				pending := overview.getProcessGroupIDs(maintenanceEntryStatePending)
+				if !confirmAction(
+					fmt.Sprintf(
+						"Resetting maintenance zone %s for cluster %s/%s with %d pending process groups in the maintenance list",
+						overview.Zone,
+						namespace,
+						cluster.Name,
+						len(pending),
+					),
+				) {
+					return fmt.Errorf("user aborted the maintenance change")
+				}
+			}
+
+			pod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			_, err = executeFdbCliCommand(
+				cmd.Context(),
+				kubeClient,
+				config,
+				pod,
+				"maintenance off",
+			)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf(
+				"reset maintenance zone %s for cluster %s/%s\n",
+				overview.Zone,
+				namespace,
+				cluster.Name,
+			)
+
+			return nil
+		},
+		Example: `
+# Reset the maintenance zone for the cluster in the current namespace
+kubectl fdb maintenance reset -c cluster
+`,
+	}
+
+	addMaintenanceFlags(cmd, &opts)
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newMaintenanceClearCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+	opts := maintenanceOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Removes process groups from the maintenance list",
+		Long:  "Removes process groups from the maintenance list, if no process groups are provided all entries will be removed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			staleOnly, err := cmd.Flags().GetBool("stale")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			overview, err := fetchMaintenanceOverview(cmd, kubeClient, config, cluster, opts)
+			if err != nil {
+				return err
+			}
+
+			processGroupIDs, err := getProcessGroupIDsToClear(overview, args, staleOnly)
+			if err != nil {
+				return err
+			}
+
+			if len(processGroupIDs) == 0 {
+				cmd.Printf(
+					"no process groups to remove from the maintenance list of cluster %s/%s\n",
+					namespace,
+					cluster.Name,
+				)
+				return nil
+			}
+
+			if wait {
+				if !confirmAction(
+					fmt.Sprintf(
+						"Removing %v from the maintenance list of cluster %s/%s",
+						processGroupIDs,
+						namespace,
+						cluster.Name,
+					),
+				) {
+					return fmt.Errorf("user aborted the maintenance change")
+				}
+			}
+
+			pod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			_, err = executeFdbCliCommand(
+				cmd.Context(),
+				kubeClient,
+				config,
+				pod,
+				getRemoveFromMaintenanceListCommand(cluster, processGroupIDs),
+			)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf(
+				"removed %d process groups from the maintenance list of cluster %s/%s\n",
+				len(processGroupIDs),
+				namespace,
+				cluster.Name,
+			)
+
+			return nil
+		},
+		Example: `
+# Remove all stale entries from the maintenance list of the cluster in the current namespace
+kubectl fdb maintenance clear -c cluster --stale
+
+# Remove specific process groups from the maintenance list
+kubectl fdb maintenance clear -c cluster storage-1 storage-2
+`,
+	}
+
+	addMaintenanceFlags(cmd, &opts)
+	cmd.Flags().Bool("stale", false, "only remove the stale entries from the maintenance list.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// chooseRunningClusterPod returns a random running Pod of the provided cluster.
+func chooseRunningClusterPod(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	cluster *fdbv1beta2.FoundationDBCluster,
+) (*corev1.Pod, error) {
+	pods, err := getRunningPodsForCluster(cmd.Context(), kubeClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return chooseRandomPod(pods)
+}
+
+// fetchMaintenanceOverview reads the machine-readable status and the maintenance list of the cluster.
+func fetchMaintenanceOverview(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	config *rest.Config,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	opts maintenanceOptions,
+) (*maintenanceOverview, error) {
+	pod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := getStatus(cmd.Context(), kubeClient, config, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := executeFdbCliCommand(
+		cmd.Context(),
+		kubeClient,
+		config,
+		pod,
+		getMaintenanceListCommand(cluster),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	processesUnderMaintenance, err := parseMaintenanceList(stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	return getMaintenanceOverview(cluster, status, processesUnderMaintenance, opts, time.Now()), nil
+}
+
+// getMaintenanceOverview evaluates the maintenance list the same way as the operator does.
+func getMaintenanceOverview(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	status *fdbv1beta2.FoundationDBStatus,
+	processesUnderMaintenance map[fdbv1beta2.ProcessGroupID]int64,
+	opts maintenanceOptions,
+	now time.Time,
+) *maintenanceOverview {
+	overview := &maintenanceOverview{
+		Namespace: cluster.Namespace,
+		Cluster:   cluster.Name,
+		Zone:      string(status.Cluster.MaintenanceZone),
+		Entries:   make([]maintenanceEntry, 0, len(processesUnderMaintenance)),
+	}
+
+	if overview.Zone != "" {
+		expiry := now.Add(
+			time.Duration(status.Cluster.MaintenanceSecondsRemaining * float64(time.Second)),
+		).Truncate(time.Second)
+		overview.Expiry = &expiry
+	}
+
+	zones := map[fdbv1beta2.ProcessGroupID]string{}
+	for _, process := range status.Cluster.Processes {
+		processGroupID, ok := process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]
+		if !ok {
+			continue
+		}
+
+		zones[fdbv1beta2.ProcessGroupID(processGroupID)] = process.Locality[fdbv1beta2.FDBLocalityZoneIDKey]
+	}
+
+	// GetMaintenanceInformation modifies the provided map, so we pass in a copy.
+	processesCopy := make(map[fdbv1beta2.ProcessGroupID]int64, len(processesUnderMaintenance))
+	for processGroupID, timestamp := range processesUnderMaintenance {
+		processesCopy[processGroupID] = timestamp
+	}
+
+	finished, stale, pending := maintenance.GetMaintenanceInformation(
+		logr.Discard(),
+		cluster,
+		status,
+		processesCopy,
+		opts.staleDuration,
+		opts.waitDuration,
+	)
+
+	states := map[fdbv1beta2.ProcessGroupID]maintenanceEntryState{}
+	for _, processGroupID := range pending {
+		states[processGroupID] = maintenanceEntryStatePending
+	}
+	for _, processGroupID := range finished {
+		states[processGroupID] = maintenanceEntryStateFinished
+	}
+	// An entry can be pending and stale at the same time if it was recently added, the stale state takes precedence as
+	// the operator will remove it.
+	for _, processGroupID := range stale {
+		states[processGroupID] = maintenanceEntryStateStale
+	}
+
+	for processGroupID, timestamp := range processesUnderMaintenance {
+		state, ok := states[processGroupID]
+		if !ok {
+			state = maintenanceEntryStateOtherZone
+		}
+
+		overview.Entries = append(overview.Entries, maintenanceEntry{
+			ProcessGroupID: processGroupID,
+			Zone:           zones[processGroupID],
+			Start:          time.Unix(timestamp, 0).UTC(),
+			State:          state,
+		})
+	}
+
+	sort.Slice(overview.Entries, func(i, j int) bool {
+		return overview.Entries[i].ProcessGroupID < overview.Entries[j].ProcessGroupID
+	})
+
+	return overview
+}
+
+// getProcessGroupIDs returns the process group IDs of all entries with the provided state.
+func (overview *maintenanceOverview) getProcessGroupIDs(
+	state maintenanceEntryState,
+) []fdbv1beta2.ProcessGroupID {
+	var processGroupIDs []fdbv1beta2.ProcessGroupID
+	for _, entry := range overview.Entries {
+		if entry.State != state {
+			continue
+		}
+
+		processGroupIDs = append(processGroupIDs, entry.ProcessGroupID)
+	}
+
+	return processGroupIDs
+}
+
+// getProcessGroupIDsToClear returns the process group IDs that should be removed from the maintenance list.
+func getProcessGroupIDsToClear(
+	overview *maintenanceOverview,
+	args []string,
+	staleOnly bool,
+) ([]fdbv1beta2.ProcessGroupID, error) {
+	if len(args) > 0 && staleOnly {
+		return nil, fmt.Errorf("process groups and the stale flag cannot be combined")
+	}
+
+	if staleOnly {
+		return overview.getProcessGroupIDs(maintenanceEntryStateStale), nil
+	}
+
+	if len(args) == 0 {
+		processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(overview.Entries))
+		for _, entry := range overview.Entries {
+			processGroupIDs = append(processGroupIDs, entry.ProcessGroupID)
+		}
+
+		return processGroupIDs, nil
+	}
+
+	entries := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	for _, entry := range overview.Entries {
+		entries[entry.ProcessGroupID] = fdbv1beta2.None{}
+	}
+
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(args))
+	for _, arg := range args {
+		if _, ok := entries[fdbv1beta2.ProcessGroupID(arg)]; !ok {
+			return nil, fmt.Errorf("process group %s is not in the maintenance list", arg)
+		}
+
+		processGroupIDs = append(processGroupIDs, fdbv1beta2.ProcessGroupID(arg))
+	}
+
+	return processGroupIDs, nil
+}
+
+// getStorageProcessGroupsInZone returns the process group IDs of all storage processes in the provided zone.
+func getStorageProcessGroupsInZone(
+	status *fdbv1beta2.FoundationDBStatus,
+	zone string,
+) []fdbv1beta2.ProcessGroupID {
+	processGroups := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	for _, process := range status.Cluster.Processes {
+		if process.ProcessClass != fdbv1beta2.ProcessClassStorage ||
+			process.Locality[fdbv1beta2.FDBLocalityZoneIDKey] != zone {
+			continue
+		}
+
+		processGroupID, ok := process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey]
+		if !ok {
+			continue
+		}
+
+		processGroups[fdbv1beta2.ProcessGroupID(processGroupID)] = fdbv1beta2.None{}
+	}
+
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroups))
+	for processGroupID := range processGroups {
+		processGroupIDs = append(processGroupIDs, processGroupID)
+	}
+	sort.Slice(processGroupIDs, func(i, j int) bool {
+		return processGroupIDs[i] < processGroupIDs[j]
+	})
+
+	return processGroupIDs
+}
+
+// getMaintenanceListCommand returns the fdbcli command to read the maintenance list.
+func getMaintenanceListCommand(cluster *fdbv1beta2.FoundationDBCluster) string {
+	prefix := cluster.GetMaintenancePrefix()
+
+	// The end key is the prefix followed by the byte after "/", which covers all keys in the maintenance list.
+	return fmt.Sprintf(
+		"option on ACCESS_SYSTEM_KEYS; getrange %s %s %d",
+		fdbCliPrintable([]byte(prefix+"/")),
+		fdbCliPrintable([]byte(prefix+"0")),
+		maintenanceListLimit,
+	)
+}
+
+// getSetMaintenanceZoneCommand returns the fdbcli command to add the provided process groups to the maintenance list
+// and to set the maintenance zone afterwards. The process groups are added first, the same way as the operator does it.
+func getSetMaintenanceZoneCommand(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	zone string,
+	duration time.Duration,
+	processGroupIDs []fdbv1beta2.ProcessGroupID,
+	timestamp time.Time,
+) string {
+	var sb strings.Builder
+	if len(processGroupIDs) > 0 {
+		timestampBuffer := new(bytes.Buffer)
+		// Writing into a buffer will never fail.
+		_ = binary.Write(timestampBuffer, binary.LittleEndian, timestamp.Unix())
+
+		sb.WriteString("writemode on; option on ACCESS_SYSTEM_KEYS;")
+		for _, processGroupID := range processGroupIDs {
+			sb.WriteString(" set ")
+			sb.WriteString(
+				fdbCliPrintable(
+					[]byte(path.Join(cluster.GetMaintenancePrefix(), string(processGroupID))),
+				),
+			)
+			sb.WriteString(" ")
+			sb.WriteString(fdbCliPrintable(timestampBuffer.Bytes()))
+			sb.WriteString(";")
+		}
+		sb.WriteString(" ")
+	}
+
+	sb.WriteString("maintenance on ")
+	sb.WriteString(fdbCliPrintable([]byte(zone)))
+	sb.WriteString(" ")
+	sb.WriteString(strconv.Itoa(int(duration.Seconds())))
+
+	return sb.String()
+}
+
+// getRemoveFromMaintenanceListCommand returns the fdbcli command to remove the provided process groups from the
+// maintenance list.
+func getRemoveFromMaintenanceListCommand(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroupIDs []fdbv1beta2.ProcessGroupID,
+) string {
+	var sb strings.Builder
+	sb.WriteString("writemode on; option on ACCESS_SYSTEM_KEYS;")
+	for _, processGroupID := range processGroupIDs {
+		sb.WriteString(" clear ")
+		sb.WriteString(
+			fdbCliPrintable(
+				[]byte(path.Join(cluster.GetMaintenancePrefix(), string(processGroupID))),
+			),
+		)
+		sb.WriteString(";")
+	}
+
+	return strings.TrimSuffix(sb.String(), ";")
+}
+
+// parseMaintenanceList parses the output of the fdbcli getrange command for the maintenance list. The result is a map
+// with the process group ID as key and the start of the maintenance as value, entries with a value that cannot be
+// parsed will have a value of 0, the same way as the operator handles those entries.
+func parseMaintenanceList(output string) (map[fdbv1beta2.ProcessGroupID]int64, error) {
+	processesUnderMaintenance := map[fdbv1beta2.ProcessGroupID]int64{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		separator := "' is `"
+		idx := strings.Index(line, separator)
+		if idx == -1 || !strings.HasPrefix(line, "`") || !strings.HasSuffix(line, "'") {
+			continue
+		}
+
+		key, err := fdbCliUnprintable(line[1:idx])
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := fdbCliUnprintable(line[idx+len(separator) : len(line)-1])
+		if err != nil {
+			return nil, err
+		}
+
+		var timestamp int64
+		err = binary.Read(bytes.NewBuffer(value), binary.LittleEndian, &timestamp)
+		if err != nil {
+			timestamp = 0
+		}
+
+		processesUnderMaintenance[fdbv1beta2.ProcessGroupID(path.Base(string(key)))] = timestamp
+	}
+
+	return processesUnderMaintenance, nil
+}
+
+// fdbCliPrintable returns a representation of the provided bytes that can be used as a single token in fdbcli. All
+// bytes that are not printable ASCII characters or that have a special meaning for fdbcli or the shell are escaped.
+func fdbCliPrintable(data []byte) string {
+	var sb strings.Builder
+	for _, b := range data {
+		if b > 32 && b < 127 && !strings.ContainsRune(`\'";`, rune(b)) {
+			sb.WriteByte(b)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(&sb, "\\x%02x", b)
+	}
+
+	return sb.String()
+}
+
+// fdbCliUnprintable converts the escaped representation printed by fdbcli back to the raw bytes.
+func fdbCliUnprintable(value string) ([]byte, error) {
+	var result bytes.Buffer
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		if i+1 < len(value) && value[i+1] == '\\' {
+			result.WriteByte('\\')
+			i++
+			continue
+		}
+
+		if i+3 >= len(value) || value[i+1] != 'x' {
+			return nil, fmt.Errorf("invalid escape sequence in %q", value)
+		}
+
+		b, err := strconv.ParseUint(value[i+2:i+4], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid escape sequence in %q: %w", value, err)
+		}
+
+		result.WriteByte(byte(b))
+		i += 3
+	}
+
+	return result.Bytes(), nil
+}
+
+// printMaintenanceOverview prints the provided overview in the requested output format.
+func printMaintenanceOverview(
+	out io.Writer,
+	overview *maintenanceOverview,
+	output string,
+	now time.Time,
+) error {
+	if output != outputFormatText {
+		return printStructuredOutput(out, overview, output)
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "Cluster:\t%s/%s\n", overview.Namespace, overview.Cluster)
+	if overview.Zone == "" {
+		_, _ = fmt.Fprintf(writer, "Maintenance zone:\t-\n")
+	} else {
+		_, _ = fmt.Fprintf(writer, "Maintenance zone:\t%s\n", overview.Zone)
+		if overview.Expiry != nil {
+			_, _ = fmt.Fprintf(
+				writer,
+				"Expires:\t%s (in %s)\n",
+				overview.Expiry.UTC().Format(time.RFC3339),
+				overview.Expiry.Sub(now).Truncate(time.Second).String(),
+			)
+		}
+	}
+
+	counts := map[maintenanceEntryState]int{}
+	for _, entry := range overview.Entries {
+		counts[entry.State]++
+	}
+
+	_, _ = fmt.Fprintf(
+		writer,
+		"Maintenance list:\t%d entries (%d pending, %d finished, %d stale, %d in other zones)\n",
+		len(overview.Entries),
+		counts[maintenanceEntryStatePending],
+		counts[maintenanceEntryStateFinished],
+		counts[maintenanceEntryStateStale],
+		counts[maintenanceEntryStateOtherZone],
+	)
+
+	err := writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	if len(overview.Entries) == 0 {
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(out)
+
+	return printMaintenanceEntries(out, overview.Entries)
+}
+
+// printMaintenanceEntries prints the entries of the maintenance list as a table.
+func printMaintenanceEntries(out io.Writer, entries []maintenanceEntry) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "PROCESS GROUP\tZONE\tSTART\tSTATE")
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\n",
+			entry.ProcessGroupID,
+			valueOrDash(entry.Zone),
+			entry.Start.UTC().Format(time.RFC3339),
+			entry.State,
+		)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,277 @@
+/*
+ * maintenance_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("[plugin] maintenance command", func() {
+	When("converting keys for fdbcli", func() {
+		It("should escape all special characters and be reversible", func() {
+			key := []byte("\xff\x02/prefix/it's a;test\\")
+			printable := fdbCliPrintable(key)
+			Expect(printable).To(Equal(`\xff\x02/prefix/it\x27s\x20a\x3btest\x5c`))
+
+			result, err := fdbCliUnprintable(printable)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(key))
+		})
+
+		It("should parse escaped backslashes", func() {
+			result, err := fdbCliUnprintable(`a\\b`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal([]byte(`a\b`)))
+		})
+
+		It("should return an error for invalid escape sequences", func() {
+			_, err := fdbCliUnprintable(`\x1`)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("building the fdbcli commands", func() {
+		It("should build the command to read the maintenance list", func() {
+			Expect(
+				getMaintenanceListCommand(cluster),
+			).To(Equal(`option on ACCESS_SYSTEM_KEYS; getrange \xff\x02/org.foundationdb.kubernetes-operator/maintenance/ \xff\x02/org.foundationdb.kubernetes-operator/maintenance0 10000`))
+		})
+
+		It("should add the process groups before setting the maintenance zone", func() {
+			Expect(getSetMaintenanceZoneCommand(
+				cluster,
+				"zone-1",
+				time.Hour,
+				[]fdbv1beta2.ProcessGroupID{"test-storage-1"},
+				time.Unix(1, 0),
+			)).To(Equal(`writemode on; option on ACCESS_SYSTEM_KEYS; set \xff\x02/org.foundationdb.kubernetes-operator/maintenance/test-storage-1 \x01\x00\x00\x00\x00\x00\x00\x00; maintenance on zone-1 3600`))
+		})
+
+		It("should only set the maintenance zone if no process groups are provided", func() {
+			Expect(
+				getSetMaintenanceZoneCommand(cluster, "zone-1", time.Minute, nil, time.Unix(1, 0)),
+			).To(Equal("maintenance on zone-1 60"))
+		})
+
+		It("should build the command to remove process groups from the maintenance list", func() {
+			Expect(getRemoveFromMaintenanceListCommand(
+				cluster,
+				[]fdbv1beta2.ProcessGroupID{"test-storage-1", "test-storage-2"},
+			)).To(Equal(`writemode on; option on ACCESS_SYSTEM_KEYS; clear \xff\x02/org.foundationdb.kubernetes-operator/maintenance/test-storage-1; clear \xff\x02/org.foundationdb.kubernetes-operator/maintenance/test-storage-2`))
+		})
+	})
+
+	When("parsing the maintenance list", func() {
+		It("should return the process groups with the timestamps", func() {
+			processes, err := parseMaintenanceList(`
+Range limited to 10000 keys
+` + "`" + `\xff\x02/org.foundationdb.kubernetes-operator/maintenance/test-storage-1' is ` + "`" + `\x01\x00\x00\x00\x00\x00\x00\x00'
+` + "`" + `\xff\x02/org.foundationdb.kubernetes-operator/maintenance/test-storage-2' is ` + "`" + `bad'
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processes).To(Equal(map[fdbv1beta2.ProcessGroupID]int64{
+				"test-storage-1": 1,
+				"test-storage-2": 0,
+			}))
+		})
+	})
+
+	When("getting the maintenance overview", func() {
+		var overview *maintenanceOverview
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+			status := &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					MaintenanceZone:             "zone-1",
+					MaintenanceSecondsRemaining: 120,
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"1": {
+							ProcessClass:  fdbv1beta2.ProcessClassStorage,
+							UptimeSeconds: 3600,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-1",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-1",
+							},
+						},
+						"2": {
+							ProcessClass:  fdbv1beta2.ProcessClassStorage,
+							UptimeSeconds: 10,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-2",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-1",
+							},
+						},
+						"3": {
+							ProcessClass:  fdbv1beta2.ProcessClassStorage,
+							UptimeSeconds: 3600,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "other-storage-1",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-2",
+							},
+						},
+					},
+				},
+			}
+
+			processesUnderMaintenance := map[fdbv1beta2.ProcessGroupID]int64{
+				"test-storage-1":  now.Add(-1 * time.Minute).Unix(),
+				"test-storage-2":  now.Add(-1 * time.Minute).Unix(),
+				"other-storage-1": now.Add(-10 * time.Minute).Unix(),
+				"test-storage-99": now.Add(-5 * time.Hour).Unix(),
+			}
+
+			overview = getMaintenanceOverview(
+				cluster,
+				status,
+				processesUnderMaintenance,
+				maintenanceOptions{staleDuration: 4 * time.Hour, waitDuration: 5 * time.Minute},
+				now,
+			)
+			Expect(processesUnderMaintenance).To(HaveLen(4))
+		})
+
+		It("should evaluate the maintenance list like the operator", func() {
+			Expect(overview.Zone).To(Equal("zone-1"))
+			Expect(*overview.Expiry).To(Equal(now.Add(2 * time.Minute).Truncate(time.Second)))
+
+			states := map[fdbv1beta2.ProcessGroupID]maintenanceEntryState{}
+			for _, entry := range overview.Entries {
+				states[entry.ProcessGroupID] = entry.State
+			}
+			Expect(states).To(Equal(map[fdbv1beta2.ProcessGroupID]maintenanceEntryState{
+				"other-storage-1": maintenanceEntryStateOtherZone,
+				"test-storage-1":  maintenanceEntryStatePending,
+				"test-storage-2":  maintenanceEntryStateFinished,
+				"test-storage-99": maintenanceEntryStateStale,
+			}))
+			Expect(
+				overview.Entries[0].ProcessGroupID,
+			).To(Equal(fdbv1beta2.ProcessGroupID("other-storage-1")))
+			Expect(overview.Entries[0].Zone).To(Equal("zone-2"))
+		})
+
+		DescribeTable(
+			"getting the process groups to clear",
+			func(args []string, staleOnly bool, expected []fdbv1beta2.ProcessGroupID, expectedErr string) {
+				processGroupIDs, err := getProcessGroupIDsToClear(overview, args, staleOnly)
+				if expectedErr != "" {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+					return
+				}
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(processGroupIDs).To(Equal(expected))
+			},
+			Entry("all entries", nil, false, []fdbv1beta2.ProcessGroupID{
+				"other-storage-1",
+				"test-storage-1",
+				"test-storage-2",
+				"test-storage-99",
+			}, ""),
+			Entry(
+				"only stale entries",
+				nil,
+				true,
+				[]fdbv1beta2.ProcessGroupID{"test-storage-99"},
+				"",
+			),
+			Entry(
+				"the provided entries",
+				[]string{"test-storage-1"},
+				false,
+				[]fdbv1beta2.ProcessGroupID{"test-storage-1"},
+				"",
+			),
+			Entry(
+				"an entry that is not in the list",
+				[]string{"test-storage-3"},
+				false,
+				nil,
+				"is not in the maintenance list",
+			),
+			Entry(
+				"the provided entries and the stale flag",
+				[]string{"test-storage-1"},
+				true,
+				nil,
+				"cannot be combined",
+			),
+		)
+
+		It("should print the overview as text", func() {
+			outBuffer := bytes.Buffer{}
+			Expect(
+				printMaintenanceOverview(&outBuffer, overview, outputFormatText, now),
+			).To(Succeed())
+
+			out := outBuffer.String()
+			Expect(out).To(MatchRegexp(`Maintenance zone:\s+zone-1`))
+			Expect(out).To(MatchRegexp(`Expires:\s+.* \(in 1m\d+s\)`))
+			Expect(
+				out,
+			).To(MatchRegexp(`Maintenance list:\s+4 entries \(1 pending, 1 finished, 1 stale, 1 in other zones\)`))
+			Expect(out).To(MatchRegexp(`test-storage-99\s+-\s+\S+\s+Stale`))
+		})
+	})
+
+	When("getting the storage process groups in a zone", func() {
+		It("should only return the storage processes of the zone", func() {
+			status := &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"1": {
+							ProcessClass: fdbv1beta2.ProcessClassStorage,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-1",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-1",
+							},
+						},
+						"2": {
+							ProcessClass: fdbv1beta2.ProcessClassStateless,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-stateless-1",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-1",
+							},
+						},
+						"3": {
+							ProcessClass: fdbv1beta2.ProcessClassStorage,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-2",
+								fdbv1beta2.FDBLocalityZoneIDKey:     "zone-2",
+							},
+						},
+					},
+				},
+			}
+
+			Expect(
+				getStorageProcessGroupsInZone(status, "zone-1"),
+			).To(Equal([]fdbv1beta2.ProcessGroupID{"test-storage-1"}))
+		})
+	})
+})
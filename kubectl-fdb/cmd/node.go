@@ -0,0 +1,789 @@
+/*
+ * node.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// nodeState describes where a node stands in the drain, maintenance and return cycle.
+type nodeState string
+
+const (
+	// nodeStateActive means the process groups on the node are neither removed, excluded nor under maintenance.
+	nodeStateActive nodeState = "Active"
+	// nodeStateCordoned means at least one process group on the node is marked for removal.
+	nodeStateCordoned nodeState = "Cordoned"
+	// nodeStateInMaintenance means the zone of the node is the current maintenance zone.
+	nodeStateInMaintenance nodeState = "InMaintenance"
+	// nodeStateExcluding means the process groups on the node are manually excluded, but not all processes are
+	// fully excluded.
+	nodeStateExcluding nodeState = "Excluding"
+	// nodeStateDrained means the process groups on the node are manually excluded and all processes are fully
+	// excluded.
+	nodeStateDrained nodeState = "Drained"
+)
+
+// nodeDrainMethod describes how a node will be drained.
+type nodeDrainMethod string
+
+const (
+	// nodeDrainMethodMaintenance means the zone of the node will be set as maintenance zone.
+	nodeDrainMethodMaintenance nodeDrainMethod = "maintenance"
+	// nodeDrainMethodExclusion means the process groups on the node will be excluded.
+	nodeDrainMethodExclusion nodeDrainMethod = "exclusion"
+)
+
+// nodeOverview contains the state of a single node for a cluster.
+type nodeOverview struct {
+	Node                  string                      `json:"node"`
+	Zones                 []string                    `json:"zones,omitempty"`
+	ProcessGroups         []fdbv1beta2.ProcessGroupID `json:"processGroups"`
+	State                 nodeState                   `json:"state"`
+	MarkedForRemoval      []fdbv1beta2.ProcessGroupID `json:"markedForRemoval,omitempty"`
+	ManuallyExcluded      []fdbv1beta2.ProcessGroupID `json:"manuallyExcluded,omitempty"`
+	FullyExcludedProcess  int                         `json:"fullyExcludedProcesses,omitempty"`
+	ReportingProcesses    int                         `json:"reportingProcesses,omitempty"`
+	MaintenanceZoneActive bool                        `json:"maintenanceZoneActive,omitempty"`
+}
+
+func newNodeCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Subcommand to drain nodes and return them to a cluster",
+		Long: "Subcommand to drain nodes and return them to a cluster. A node is drained by setting its zone as " +
+			"maintenance zone if this is safe, otherwise the process groups on the node will be excluded.",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+		Example: `
+# Show where each node of the cluster stands
+kubectl fdb node status -c cluster
+
+# Prepare node-1 for a drain
+kubectl fdb node drain -c cluster node-1
+
+# Return node-1 to the cluster after the drain
+kubectl fdb node return -c cluster node-1
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.AddCommand(
+		newNodeStatusCmd(streams),
+		newNodeDrainCmd(streams),
+		newNodeReturnCmd(streams),
+	)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// addNodeClusterFlag adds the required cluster flag to the provided node subcommand.
+func addNodeClusterFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("fdb-cluster", "c", "", "the cluster that has process groups on the nodes.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newNodeStatusCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows where each node stands in the drain, maintenance and return cycle",
+		Long: "Shows where each node stands in the drain, maintenance and return cycle. If no nodes are provided " +
+			"all nodes with Pods of the cluster are shown.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+
+			err = validateOutputFormat(output)
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			clientPod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			status, err := getStatus(cmd.Context(), kubeClient, config, clientPod)
+			if err != nil {
+				return err
+			}
+
+			overviews := getNodeOverviews(cluster, pods.Items, status)
+			if len(args) > 0 {
+				overviews, err = filterNodeOverviews(overviews, args)
+				if err != nil {
+					return err
+				}
+			}
+
+			if output != outputFormatText {
+				return printStructuredOutput(cmd.OutOrStdout(), overviews, output)
+			}
+
+			return printNodeOverviews(cmd.OutOrStdout(), overviews)
+		},
+		Example: `
+# Show where each node with Pods of the cluster stands
+kubectl fdb node status -c cluster
+
+# Show the state of node-1 as JSON
+kubectl fdb node status -c cluster node-1 --output json
+`,
+	}
+
+	addNodeClusterFlag(cmd)
+	cmd.Flags().
+		String("output", outputFormatText, "the output format, supported formats are: text, json and yaml.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newNodeDrainCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "drain",
+		Short: "Prepares a node to be drained",
+		Long: "Prepares a node to be drained. If the process groups on the node are in a single zone, no other " +
+			"maintenance zone is active and the cluster can tolerate the loss of a zone, the zone will be set as " +
+			"maintenance zone. Otherwise the process groups on the node will be excluded.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			duration, err := cmd.Flags().GetDuration("duration")
+			if err != nil {
+				return err
+			}
+
+			forceExclusion, err := cmd.Flags().GetBool("exclusion")
+			if err != nil {
+				return err
+			}
+
+			waitForExclusion, err := cmd.Flags().GetBool("wait-for-exclusion")
+			if err != nil {
+				return err
+			}
+
+			interval, err := cmd.Flags().GetDuration("interval")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			clientPod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			status, err := getStatus(cmd.Context(), kubeClient, config, clientPod)
+			if err != nil {
+				return err
+			}
+
+			overviews, err := filterNodeOverviews(
+				getNodeOverviews(cluster, pods.Items, status),
+				args,
+			)
+			if err != nil {
+				return err
+			}
+
+			overview := overviews[0]
+			method, reason, err := getNodeDrainMethod(overview, status, forceExclusion)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Draining node %s with %s: %s\n", overview.Node, method, reason)
+			if method == nodeDrainMethodExclusion {
+				processGroups, err := selectProcessGroupsForExclusion(
+					cluster,
+					pods.Items,
+					status,
+					exclusionSelectionOptions{nodes: []string{overview.Node}},
+				)
+				if err != nil {
+					return err
+				}
+
+				processGroupIDs, err := excludeProcessGroups(
+					cmd,
+					kubeClient,
+					config,
+					clientPod,
+					cluster,
+					status,
+					processGroups,
+					wait,
+				)
+				if err != nil {
+					return err
+				}
+
+				if waitForExclusion {
+					err = waitForExclusionProgress(
+						cmd,
+						kubeClient,
+						config,
+						clientPod,
+						processGroupIDs,
+						interval,
+						0,
+					)
+					if err != nil {
+						return err
+					}
+				}
+			} else {
+				if duration == 0 {
+					duration = time.Duration(cluster.GetMaintenaceModeTimeoutSeconds()) * time.Second
+				}
+
+				zone := overview.Zones[0]
+				processGroupIDs := getStorageProcessGroupsInZone(status, zone)
+				if wait {
+					if !confirmAction(
+						fmt.Sprintf(
+							"Setting maintenance zone %s for %s and adding %d storage process groups to the maintenance list for cluster %s/%s",
+							zone,
+							duration.String(),
+							len(processGroupIDs),
+							namespace,
+							cluster.Name,
+						),
+					) {
+						return fmt.Errorf("user aborted the drain")
+					}
+				}
+
+				_, err = executeFdbCliCommand(
+					cmd.Context(),
+					kubeClient,
+					config,
+					clientPod,
+					getSetMaintenanceZoneCommand(
+						cluster,
+						zone,
+						duration,
+						processGroupIDs,
+						time.Now(),
+					),
+				)
+				if err != nil {
+					return err
+				}
+
+				cmd.Printf(
+					"set maintenance zone %s for %s for cluster %s/%s\n",
+					zone,
+					duration.String(),
+					namespace,
+					cluster.Name,
+				)
+			}
+
+			printStatement(
+				cmd,
+				fmt.Sprintf(
+					"node %s can be drained, run \"kubectl fdb node return -c %s %s\" once the node is back",
+					overview.Node,
+					cluster.Name,
+					overview.Node,
+				),
+				goodMessage,
+			)
+
+			return nil
+		},
+		Example: `
+# Prepare node-1 for a drain, the command decides if maintenance mode or exclusion is used
+kubectl fdb node drain -c cluster node-1
+
+# Prepare node-1 for a drain with exclusion and don't wait until the exclusion is done
+kubectl fdb node drain -c cluster node-1 --exclusion --wait-for-exclusion=false
+`,
+	}
+
+	addNodeClusterFlag(cmd)
+	cmd.Flags().
+		Duration("duration", 0, "the duration of the maintenance zone, defaults to the maintenance mode timeout of the cluster.")
+	cmd.Flags().
+		Bool("exclusion", false, "exclude the process groups on the node even if maintenance mode could be used.")
+	cmd.Flags().
+		Bool("wait-for-exclusion", true, "defines if the command should wait until the process groups are fully excluded.")
+	cmd.Flags().
+		Duration("interval", 30*time.Second, "defines in which interval the exclusion progress should be fetched.")
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newNodeReturnCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "return",
+		Short: "Returns a drained node to the cluster",
+		Long: "Returns a drained node to the cluster by resetting the maintenance zone of the node and including the " +
+			"manually excluded process groups on the node.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			clientPod, err := chooseRunningClusterPod(cmd, kubeClient, cluster)
+			if err != nil {
+				return err
+			}
+
+			status, err := getStatus(cmd.Context(), kubeClient, config, clientPod)
+			if err != nil {
+				return err
+			}
+
+			overviews, err := filterNodeOverviews(
+				getNodeOverviews(cluster, pods.Items, status),
+				args,
+			)
+			if err != nil {
+				return err
+			}
+
+			overview := overviews[0]
+			if !overview.MaintenanceZoneActive && len(overview.ManuallyExcluded) == 0 {
+				cmd.Printf(
+					"node %s is not drained for cluster %s/%s\n",
+					overview.Node,
+					namespace,
+					cluster.Name,
+				)
+				return nil
+			}
+
+			if overview.MaintenanceZoneActive {
+				if wait {
+					if !confirmAction(
+						fmt.Sprintf(
+							"Resetting maintenance zone %s for cluster %s/%s",
+							status.Cluster.MaintenanceZone,
+							namespace,
+							cluster.Name,
+						),
+					) {
+						return fmt.Errorf("user aborted the return of the node")
+					}
+				}
+
+				_, err = executeFdbCliCommand(
+					cmd.Context(),
+					kubeClient,
+					config,
+					clientPod,
+					"maintenance off",
+				)
+				if err != nil {
+					return err
+				}
+
+				cmd.Printf(
+					"reset maintenance zone %s for cluster %s/%s\n",
+					status.Cluster.MaintenanceZone,
+					namespace,
+					cluster.Name,
+				)
+			}
+
+			if len(overview.ManuallyExcluded) > 0 {
+				processGroups := make(
+					[]*fdbv1beta2.ProcessGroupStatus,
+					0,
+					len(overview.ManuallyExcluded),
+				)
+				for _, processGroupID := range overview.ManuallyExcluded {
+					processGroup := fdbv1beta2.FindProcessGroupByID(
+						cluster.Status.ProcessGroups,
+						processGroupID,
+					)
+					if processGroup != nil {
+						processGroups = append(processGroups, processGroup)
+					}
+				}
+
+				err = includeProcessGroups(
+					cmd,
+					kubeClient,
+					config,
+					clientPod,
+					cluster,
+					status,
+					processGroups,
+					wait,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			printStatement(cmd, fmt.Sprintf("node %s is returned", overview.Node), goodMessage)
+
+			return nil
+		},
+		Example: `
+# Return node-1 to the cluster after the drain
+kubectl fdb node return -c cluster node-1
+`,
+	}
+
+	addNodeClusterFlag(cmd)
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getNodeOverviews returns the state of every node that hosts a Pod of the provided cluster, sorted by the node name.
+func getNodeOverviews(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	pods []corev1.Pod,
+	status *fdbv1beta2.FoundationDBStatus,
+) []nodeOverview {
+	processGroupsByNode := map[string][]fdbv1beta2.ProcessGroupID{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+
+		processGroupID := fdbv1beta2.ProcessGroupID(pod.Labels[cluster.GetProcessGroupIDLabel()])
+		if processGroupID == "" {
+			continue
+		}
+
+		processGroupsByNode[pod.Spec.NodeName] = append(
+			processGroupsByNode[pod.Spec.NodeName],
+			processGroupID,
+		)
+	}
+
+	overviews := make([]nodeOverview, 0, len(processGroupsByNode))
+	for node, processGroupIDs := range processGroupsByNode {
+		sort.Slice(processGroupIDs, func(i, j int) bool {
+			return processGroupIDs[i] < processGroupIDs[j]
+		})
+
+		overview := nodeOverview{
+			Node:          node,
+			ProcessGroups: processGroupIDs,
+			State:         nodeStateActive,
+		}
+
+		zones := map[string]fdbv1beta2.None{}
+		for _, processGroupID := range processGroupIDs {
+			processGroup := fdbv1beta2.FindProcessGroupByID(
+				cluster.Status.ProcessGroups,
+				processGroupID,
+			)
+			if processGroup != nil && processGroup.FaultDomain != "" {
+				zones[string(processGroup.FaultDomain)] = fdbv1beta2.None{}
+			}
+
+			if cluster.ProcessGroupIsBeingRemoved(processGroupID) {
+				overview.MarkedForRemoval = append(overview.MarkedForRemoval, processGroupID)
+			}
+
+			if cluster.ProcessGroupIsManuallyExcluded(processGroupID) {
+				overview.ManuallyExcluded = append(overview.ManuallyExcluded, processGroupID)
+			}
+		}
+		overview.Zones = getSortedKeys(zones)
+
+		if status.Cluster.MaintenanceZone != "" {
+			_, overview.MaintenanceZoneActive = zones[string(status.Cluster.MaintenanceZone)]
+		}
+
+		if len(overview.ManuallyExcluded) > 0 {
+			progress := getExclusionProgress(status, overview.ManuallyExcluded)
+			overview.ReportingProcesses = progress.processes
+			overview.FullyExcludedProcess = progress.fullyExcluded
+		}
+
+		// The states are ordered by their impact, e.g. a removal cannot be reverted by returning the node.
+		switch {
+		case len(overview.MarkedForRemoval) > 0:
+			overview.State = nodeStateCordoned
+		case len(overview.ManuallyExcluded) > 0 &&
+			overview.FullyExcludedProcess == overview.ReportingProcesses:
+			overview.State = nodeStateDrained
+		case len(overview.ManuallyExcluded) > 0:
+			overview.State = nodeStateExcluding
+		case overview.MaintenanceZoneActive:
+			overview.State = nodeStateInMaintenance
+		}
+
+		overviews = append(overviews, overview)
+	}
+
+	sort.Slice(overviews, func(i, j int) bool {
+		return overviews[i].Node < overviews[j].Node
+	})
+
+	return overviews
+}
+
+// filterNodeOverviews returns the overviews for the provided nodes, an error is returned if a node has no Pods of the
+// cluster.
+func filterNodeOverviews(overviews []nodeOverview, nodes []string) ([]nodeOverview, error) {
+	overviewByNode := make(map[string]nodeOverview, len(overviews))
+	for _, overview := range overviews {
+		overviewByNode[overview.Node] = overview
+	}
+
+	result := make([]nodeOverview, 0, len(nodes))
+	for _, node := range nodes {
+		overview, ok := overviewByNode[node]
+		if !ok {
+			return nil, fmt.Errorf("could not find any Pods of the cluster on node %s", node)
+		}
+
+		result = append(result, overview)
+	}
+
+	return result, nil
+}
+
+// getNodeDrainMethod returns how the provided node should be drained and the reason for this decision. Maintenance mode
+// is only used if all process groups on the node are in the same zone, no other maintenance zone is active and the
+// cluster can tolerate the loss of a zone.
+func getNodeDrainMethod(
+	overview nodeOverview,
+	status *fdbv1beta2.FoundationDBStatus,
+	forceExclusion bool,
+) (nodeDrainMethod, string, error) {
+	if overview.State == nodeStateCordoned {
+		return "", "", fmt.Errorf(
+			"process groups %v on node %s are marked for removal, use \"kubectl fdb uncordon\" to cancel the removal",
+			overview.MarkedForRemoval,
+			overview.Node,
+		)
+	}
+
+	if overview.State == nodeStateExcluding || overview.State == nodeStateDrained {
+		return "", "", fmt.Errorf(
+			"process groups %v on node %s are already excluded",
+			overview.ManuallyExcluded,
+			overview.Node,
+		)
+	}
+
+	if forceExclusion {
+		return nodeDrainMethodExclusion, "exclusion was requested", nil
+	}
+
+	if len(overview.Zones) != 1 {
+		return nodeDrainMethodExclusion, fmt.Sprintf(
+			"the process groups on the node are in %d zones",
+			len(overview.Zones),
+		), nil
+	}
+
+	if status.Cluster.MaintenanceZone != "" &&
+		string(status.Cluster.MaintenanceZone) != overview.Zones[0] {
+		return nodeDrainMethodExclusion, fmt.Sprintf(
+			"the maintenance zone %s is already active",
+			status.Cluster.MaintenanceZone,
+		), nil
+	}
+
+	faultTolerance := status.Cluster.FaultTolerance
+	if faultTolerance.MaxZoneFailuresWithoutLosingData < 1 ||
+		faultTolerance.MaxZoneFailuresWithoutLosingAvailability < 1 {
+		return nodeDrainMethodExclusion, "the cluster cannot tolerate the loss of a zone", nil
+	}
+
+	return nodeDrainMethodMaintenance, fmt.Sprintf(
+		"zone %s can be set as maintenance zone",
+		overview.Zones[0],
+	), nil
+}
+
+// printNodeOverviews prints the provided node overviews as a table.
+func printNodeOverviews(out io.Writer, overviews []nodeOverview) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "NODE\tZONES\tSTATE\tPROCESS GROUPS\tDETAILS")
+	for _, overview := range overviews {
+		processGroupIDs := make([]string, 0, len(overview.ProcessGroups))
+		for _, processGroupID := range overview.ProcessGroups {
+			processGroupIDs = append(processGroupIDs, string(processGroupID))
+		}
+
+		var details string
+		switch overview.State {
+		case nodeStateCordoned:
+			details = fmt.Sprintf("%d marked for removal", len(overview.MarkedForRemoval))
+		case nodeStateExcluding, nodeStateDrained:
+			details = fmt.Sprintf(
+				"%d/%d processes fully excluded",
+				overview.FullyExcludedProcess,
+				overview.ReportingProcesses,
+			)
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\n",
+			overview.Node,
+			valueOrDash(strings.Join(overview.Zones, ",")),
+			overview.State,
+			strings.Join(processGroupIDs, ","),
+			valueOrDash(details),
+		)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,223 @@
+/*
+ * node_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("[plugin] node command", func() {
+	var pods []corev1.Pod
+	var status *fdbv1beta2.FoundationDBStatus
+
+	BeforeEach(func() {
+		pods = nil
+		for idx, processGroup := range cluster.Status.ProcessGroups {
+			processGroup.FaultDomain = fdbv1beta2.FaultDomain(
+				"zone-" + string(rune('a'+idx)),
+			)
+
+			nodeName := "node-1"
+			if processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage {
+				nodeName = "node-2"
+			}
+
+			pods = append(pods, corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: string(processGroup.ProcessGroupID),
+					Labels: map[string]string{
+						fdbv1beta2.FDBProcessGroupIDLabel: string(processGroup.ProcessGroupID),
+					},
+				},
+				Spec: corev1.PodSpec{
+					NodeName: nodeName,
+				},
+			})
+		}
+
+		status = &fdbv1beta2.FoundationDBStatus{
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				FaultTolerance: fdbv1beta2.FaultTolerance{
+					MaxZoneFailuresWithoutLosingData:         1,
+					MaxZoneFailuresWithoutLosingAvailability: 1,
+				},
+			},
+		}
+	})
+
+	When("getting the node overviews", func() {
+		var overviews []nodeOverview
+
+		JustBeforeEach(func() {
+			overviews = getNodeOverviews(cluster, pods, status)
+		})
+
+		When("no process group is removed, excluded or under maintenance", func() {
+			It("should report all nodes as active", func() {
+				Expect(overviews).To(HaveLen(2))
+				Expect(overviews[0].Node).To(Equal("node-1"))
+				Expect(overviews[0].Zones).To(ConsistOf("zone-c"))
+				Expect(overviews[0].State).To(Equal(nodeStateActive))
+				Expect(overviews[1].Node).To(Equal("node-2"))
+				Expect(overviews[1].Zones).To(ConsistOf("zone-a", "zone-b"))
+				Expect(overviews[1].ProcessGroups).To(HaveLen(2))
+				Expect(overviews[1].State).To(Equal(nodeStateActive))
+			})
+		})
+
+		When("a process group on the node is marked for removal", func() {
+			BeforeEach(func() {
+				cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{
+					cluster.Status.ProcessGroups[0].ProcessGroupID,
+				}
+			})
+
+			It("should report the node as cordoned", func() {
+				Expect(overviews[1].State).To(Equal(nodeStateCordoned))
+				Expect(
+					overviews[1].MarkedForRemoval,
+				).To(ConsistOf(cluster.Status.ProcessGroups[0].ProcessGroupID))
+			})
+		})
+
+		When("the zone of the node is the maintenance zone", func() {
+			BeforeEach(func() {
+				status.Cluster.MaintenanceZone = "zone-c"
+			})
+
+			It("should report the node as in maintenance", func() {
+				Expect(overviews[0].State).To(Equal(nodeStateInMaintenance))
+				Expect(overviews[0].MaintenanceZoneActive).To(BeTrue())
+				Expect(overviews[1].State).To(Equal(nodeStateActive))
+			})
+		})
+
+		When("the process groups on the node are manually excluded", func() {
+			BeforeEach(func() {
+				processGroupID := cluster.Status.ProcessGroups[2].ProcessGroupID
+				cluster.Spec.ManuallyExcludedProcessGroups = []fdbv1beta2.ProcessGroupID{
+					processGroupID,
+				}
+				status.Cluster.Processes = map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+					processGroupID: {
+						Excluded: true,
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityInstanceIDKey: string(processGroupID),
+						},
+					},
+				}
+			})
+
+			When("the processes are fully excluded", func() {
+				It("should report the node as drained", func() {
+					Expect(overviews[0].State).To(Equal(nodeStateDrained))
+					Expect(overviews[0].FullyExcludedProcess).To(Equal(1))
+				})
+			})
+
+			When("the processes still have roles", func() {
+				BeforeEach(func() {
+					process := status.Cluster.Processes[cluster.Status.ProcessGroups[2].ProcessGroupID]
+					process.Roles = []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+						{Role: string(fdbv1beta2.ProcessRoleLog)},
+					}
+					status.Cluster.Processes[cluster.Status.ProcessGroups[2].ProcessGroupID] = process
+				})
+
+				It("should report the node as excluding", func() {
+					Expect(overviews[0].State).To(Equal(nodeStateExcluding))
+
+					var out bytes.Buffer
+					Expect(printNodeOverviews(&out, overviews)).To(Succeed())
+					Expect(out.String()).To(ContainSubstring("0/1 processes fully excluded"))
+				})
+			})
+		})
+	})
+
+	DescribeTable(
+		"getting the drain method",
+		func(overview nodeOverview, maintenanceZone fdbv1beta2.FaultDomain, zoneFailures int, forceExclusion bool, expectedMethod nodeDrainMethod, expectedError string) {
+			status := &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					MaintenanceZone: maintenanceZone,
+					FaultTolerance: fdbv1beta2.FaultTolerance{
+						MaxZoneFailuresWithoutLosingData:         zoneFailures,
+						MaxZoneFailuresWithoutLosingAvailability: zoneFailures,
+					},
+				},
+			}
+
+			method, _, err := getNodeDrainMethod(overview, status, forceExclusion)
+			if expectedError != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+				return
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(method).To(Equal(expectedMethod))
+		},
+		Entry("single zone on a healthy cluster",
+			nodeOverview{Node: "node-1", Zones: []string{"zone-a"}, State: nodeStateActive},
+			fdbv1beta2.FaultDomain(""), 1, false, nodeDrainMethodMaintenance, ""),
+		Entry("exclusion is forced",
+			nodeOverview{Node: "node-1", Zones: []string{"zone-a"}, State: nodeStateActive},
+			fdbv1beta2.FaultDomain(""), 1, true, nodeDrainMethodExclusion, ""),
+		Entry(
+			"multiple zones on the node",
+			nodeOverview{
+				Node:  "node-1",
+				Zones: []string{"zone-a", "zone-b"},
+				State: nodeStateActive,
+			},
+			fdbv1beta2.FaultDomain(""),
+			1,
+			false,
+			nodeDrainMethodExclusion,
+			"",
+		),
+		Entry("another maintenance zone is active",
+			nodeOverview{Node: "node-1", Zones: []string{"zone-a"}, State: nodeStateActive},
+			fdbv1beta2.FaultDomain("zone-b"), 1, false, nodeDrainMethodExclusion, ""),
+		Entry("the cluster cannot tolerate the loss of a zone",
+			nodeOverview{Node: "node-1", Zones: []string{"zone-a"}, State: nodeStateActive},
+			fdbv1beta2.FaultDomain(""), 0, false, nodeDrainMethodExclusion, ""),
+		Entry("the node is cordoned",
+			nodeOverview{Node: "node-1", Zones: []string{"zone-a"}, State: nodeStateCordoned},
+			fdbv1beta2.FaultDomain(""), 1, false, nodeDrainMethod(""), "marked for removal"),
+		Entry("the node is already drained",
+			nodeOverview{Node: "node-1", Zones: []string{"zone-a"}, State: nodeStateDrained},
+			fdbv1beta2.FaultDomain(""), 1, false, nodeDrainMethod(""), "already excluded"),
+	)
+
+	When("filtering the node overviews", func() {
+		It("should return an error for unknown nodes", func() {
+			_, err := filterNodeOverviews([]nodeOverview{{Node: "node-1"}}, []string{"node-2"})
+			Expect(err).To(MatchError(ContainSubstring("node-2")))
+		})
+	})
+})
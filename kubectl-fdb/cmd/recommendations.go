@@ -0,0 +1,202 @@
+/*
+ * recommendations.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newRecommendationsCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "recommendations",
+		Short: "Get the resource recommendations of the cluster as a patch for the cluster spec.",
+		Long:  "Get the resource recommendations of the cluster as a patch for the cluster spec.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			processClass, err := cmd.Flags().GetString("process-class")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			patch, err := getRecommendationsPatch(
+				kubeClient,
+				args[0],
+				namespace,
+				fdbv1beta2.ProcessClass(processClass),
+			)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println(patch)
+
+			return nil
+		},
+		Example: `
+This command will print the resource recommendations published by the operator in the cluster status as a merge patch
+for the pod templates in the processes section of the cluster spec. The operator only publishes recommendations if
+"autoscaling.resources.enabled" is set to true. The recommendations are only applied to the main container.
+
+# Get the resource recommendations for all process classes of cluster c1
+kubectl fdb get recommendations c1
+
+# Get the resource recommendations for the storage processes of cluster c1 in the namespace default
+kubectl fdb -n default get recommendations --process-class storage c1
+
+# Apply the resource recommendations for the log processes of cluster c1
+kubectl patch fdb c1 --type merge --patch "$(kubectl fdb get recommendations --process-class log c1)"
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().
+		String("process-class", "", "only print the recommendation for the provided process class.")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getRecommendationsPatch returns a merge patch that applies the resource recommendations to the pod templates of the
+// cluster spec. If the process class is empty, the recommendations of all process classes will be included.
+func getRecommendationsPatch(
+	kubeClient client.Client,
+	clusterName string,
+	namespace string,
+	processClass fdbv1beta2.ProcessClass,
+) (string, error) {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return "", err
+	}
+
+	recommendationStatus := cluster.GetResourceRecommendationStatus()
+	if recommendationStatus == nil {
+		return "", fmt.Errorf(
+			"cluster %s/%s has no resource recommendations",
+			namespace,
+			clusterName,
+		)
+	}
+
+	processes := map[fdbv1beta2.ProcessClass]map[string]*corev1.PodTemplateSpec{}
+	for _, recommendation := range recommendationStatus.ProcessClasses {
+		if processClass != "" && recommendation.ProcessClass != processClass {
+			continue
+		}
+
+		if len(recommendation.Requests) == 0 {
+			continue
+		}
+
+		processes[recommendation.ProcessClass] = map[string]*corev1.PodTemplateSpec{
+			"podTemplate": getRecommendedPodTemplate(cluster, recommendation),
+		}
+	}
+
+	if len(processes) == 0 {
+		return "", fmt.Errorf(
+			"cluster %s/%s has no resource recommendations for process class \"%s\"",
+			namespace,
+			clusterName,
+			processClass,
+		)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"processes": processes,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(patch), nil
+}
+
+// getRecommendedPodTemplate returns the pod template that is currently used for the process class with the
+// recommended resources applied to the main container. All other resources of the main container are kept.
+func getRecommendedPodTemplate(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	recommendation fdbv1beta2.ProcessClassResourceRecommendation,
+) *corev1.PodTemplateSpec {
+	podTemplate := &corev1.PodTemplateSpec{}
+	currentTemplate := cluster.GetProcessSettings(recommendation.ProcessClass).PodTemplate
+	if currentTemplate != nil {
+		podTemplate = currentTemplate.DeepCopy()
+	}
+
+	mainContainerIdx := -1
+	for idx, container := range podTemplate.Spec.Containers {
+		if container.Name == fdbv1beta2.MainContainerName {
+			mainContainerIdx = idx
+			break
+		}
+	}
+
+	if mainContainerIdx < 0 {
+		podTemplate.Spec.Containers = append(
+			podTemplate.Spec.Containers,
+			corev1.Container{Name: fdbv1beta2.MainContainerName},
+		)
+		mainContainerIdx = len(podTemplate.Spec.Containers) - 1
+	}
+
+	resources := &podTemplate.Spec.Containers[mainContainerIdx].Resources
+	if resources.Requests == nil {
+		resources.Requests = corev1.ResourceList{}
+	}
+
+	if resources.Limits == nil {
+		resources.Limits = corev1.ResourceList{}
+	}
+
+	for name, quantity := range recommendation.Requests {
+		resources.Requests[name] = quantity
+	}
+
+	for name, quantity := range recommendation.Limits {
+		resources.Limits[name] = quantity
+	}
+
+	return podTemplate
+}
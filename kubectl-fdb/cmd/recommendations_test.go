@@ -0,0 +1,143 @@
+/*
+ * recommendations_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var _ = Describe("[plugin] recommendations command", func() {
+	When("getting the resource recommendations", func() {
+		var patch string
+		var err error
+		var processClass fdbv1beta2.ProcessClass
+		var parsedPatch fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			processClass = ""
+			parsedPatch = fdbv1beta2.FoundationDBCluster{}
+			cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+				fdbv1beta2.ProcessClassGeneral: {
+					PodTemplate: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name: fdbv1beta2.MainContainerName,
+									Resources: corev1.ResourceRequirements{
+										Requests: corev1.ResourceList{
+											corev1.ResourceCPU: resource.MustParse(
+												"1",
+											),
+											corev1.ResourceMemory: resource.MustParse(
+												"1Gi",
+											),
+											corev1.ResourceEphemeralStorage: resource.MustParse(
+												"1Gi",
+											),
+										},
+									},
+								},
+								{
+									Name: fdbv1beta2.SidecarContainerName,
+								},
+							},
+						},
+					},
+				},
+			}
+			cluster.Status.Autoscaling = &fdbv1beta2.AutoscalingStatus{
+				Resources: &fdbv1beta2.ResourceRecommendationStatus{
+					ProcessClasses: []fdbv1beta2.ProcessClassResourceRecommendation{
+						{
+							ProcessClass: fdbv1beta2.ProcessClassLog,
+						},
+						{
+							ProcessClass: fdbv1beta2.ProcessClassStorage,
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("2Gi"),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("2"),
+								corev1.ResourceMemory: resource.MustParse("4Gi"),
+							},
+						},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			patch, err = getRecommendationsPatch(k8sClient, clusterName, namespace, processClass)
+			if err == nil {
+				Expect(json.Unmarshal([]byte(patch), &parsedPatch)).To(Succeed())
+			}
+		})
+
+		It("should return a patch for the process classes with recommendations", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsedPatch.Spec.Processes).To(HaveLen(1))
+			Expect(parsedPatch.Spec.Processes).To(HaveKey(fdbv1beta2.ProcessClassStorage))
+
+			podTemplate := parsedPatch.Spec.Processes[fdbv1beta2.ProcessClassStorage].PodTemplate
+			Expect(podTemplate).NotTo(BeNil())
+			Expect(podTemplate.Spec.Containers).To(HaveLen(2))
+
+			mainContainer := podTemplate.Spec.Containers[0]
+			Expect(mainContainer.Name).To(Equal(fdbv1beta2.MainContainerName))
+			Expect(mainContainer.Resources.Requests.Cpu().String()).To(Equal("500m"))
+			Expect(mainContainer.Resources.Requests.Memory().String()).To(Equal("2Gi"))
+			Expect(
+				mainContainer.Resources.Requests.StorageEphemeral().String(),
+			).To(Equal("1Gi"))
+			Expect(mainContainer.Resources.Limits.Cpu().String()).To(Equal("2"))
+			Expect(mainContainer.Resources.Limits.Memory().String()).To(Equal("4Gi"))
+
+			Expect(podTemplate.Spec.Containers[1].Name).To(Equal(fdbv1beta2.SidecarContainerName))
+		})
+
+		When("a process class without recommendations is selected", func() {
+			BeforeEach(func() {
+				processClass = fdbv1beta2.ProcessClassLog
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		When("the cluster has no recommendations", func() {
+			BeforeEach(func() {
+				cluster.Status.Autoscaling = nil
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
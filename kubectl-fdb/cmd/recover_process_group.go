@@ -0,0 +1,352 @@
+/*
+ * recover_process_group.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newRecoverProcessGroupCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "process-groups",
+		Short: "Recovers suspended process groups by recreating their Pods on the retained PVCs",
+		Long: "Recovers suspended process groups by recreating their Pods on the retained PVCs. The process groups " +
+			"will be removed from the removal lists and the removal, exclusion and suspension timestamps will be reset.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			all, err := cmd.Flags().GetBool("all")
+			if err != nil {
+				return err
+			}
+
+			if all && len(args) > 0 {
+				return errors.New("process group IDs can't be provided together with --all")
+			}
+
+			if !all && len(args) == 0 {
+				return errors.New("no process group IDs were provided")
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(args))
+			for _, arg := range args {
+				processGroupIDs = append(processGroupIDs, fdbv1beta2.ProcessGroupID(arg))
+			}
+
+			if all {
+				processGroupIDs = getSuspendedProcessGroups(cluster)
+				if len(processGroupIDs) == 0 {
+					cmd.Printf(
+						"no suspended process groups in cluster %s/%s\n",
+						namespace,
+						cluster.Name,
+					)
+					return nil
+				}
+			}
+
+			return recoverProcessGroups(cmd, kubeClient, cluster, processGroupIDs, wait)
+		},
+		Example: `
+# Recover the suspended process groups storage-1 and storage-2
+kubectl fdb recover process-groups -c cluster storage-1 storage-2
+
+# Recover all suspended process groups of the cluster
+kubectl fdb recover process-groups -c cluster --all
+`,
+	}
+
+	cmd.Flags().
+		StringP("fdb-cluster", "c", "", "recover process group(s) from the provided cluster.")
+	cmd.Flags().Bool("all", false, "recover all suspended process groups of the cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getSuspendedProcessGroups returns the IDs of all suspended process groups of the cluster.
+func getSuspendedProcessGroups(
+	cluster *fdbv1beta2.FoundationDBCluster,
+) []fdbv1beta2.ProcessGroupID {
+	var processGroupIDs []fdbv1beta2.ProcessGroupID
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if !processGroup.IsSuspended() {
+			continue
+		}
+
+		processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+	}
+
+	return processGroupIDs
+}
+
+// recoverProcessGroups removes the provided suspended process groups from the removal lists and resets their removal,
+// exclusion and suspension timestamps, the operator will recreate the Pods on the retained PVCs afterwards. Process
+// groups that were excluded are added to the manually excluded process groups until they are included again.
+func recoverProcessGroups(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroupIDs []fdbv1beta2.ProcessGroupID,
+	wait bool,
+) error {
+	toRecover := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(processGroupIDs))
+	var excluded []fdbv1beta2.ProcessGroupID
+	for _, processGroupID := range processGroupIDs {
+		processGroup := fdbv1beta2.FindProcessGroupByID(
+			cluster.Status.ProcessGroups,
+			processGroupID,
+		)
+		if processGroup == nil {
+			return fmt.Errorf(
+				"could not find process group %s in cluster %s/%s",
+				processGroupID,
+				cluster.Namespace,
+				cluster.Name,
+			)
+		}
+
+		if !processGroup.IsSuspended() {
+			return fmt.Errorf("process group %s is not suspended", processGroupID)
+		}
+
+		// Without the PVC the data is gone and recreating the Pod would not bring it back.
+		if processGroup.ProcessClass.IsStateful() {
+			err := kubeClient.Get(cmd.Context(), client.ObjectKey{
+				Namespace: cluster.Namespace,
+				Name:      processGroup.GetPvcName(cluster),
+			}, &corev1.PersistentVolumeClaim{})
+			if err != nil {
+				if k8serrors.IsNotFound(err) {
+					return fmt.Errorf(
+						"PVC of process group %s was already deleted and cannot be recovered",
+						processGroupID,
+					)
+				}
+
+				return err
+			}
+		}
+
+		toRecover[processGroupID] = fdbv1beta2.None{}
+		if processGroup.IsExcluded() && !processGroup.ExclusionSkipped {
+			excluded = append(excluded, processGroupID)
+		}
+	}
+
+	if wait {
+		if !confirmAction(
+			fmt.Sprintf(
+				"Recover %v in cluster %s/%s",
+				processGroupIDs,
+				cluster.Namespace,
+				cluster.Name,
+			),
+		) {
+			return fmt.Errorf("user aborted the recovery")
+		}
+	}
+
+	// The status must be reset before the process groups are removed from the removal lists, otherwise the operator
+	// could continue the removal of the suspended process groups and delete the retained PVCs.
+	err := resetRecoveredProcessGroups(cmd.Context(), kubeClient, cluster, toRecover)
+	if err != nil {
+		return err
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := kubeClient.Get(cmd.Context(), client.ObjectKeyFromObject(cluster), cluster)
+		if err != nil {
+			return err
+		}
+
+		patch := client.MergeFromWithOptions(
+			cluster.DeepCopy(),
+			client.MergeFromWithOptimisticLock{},
+		)
+		cluster.Spec.ProcessGroupsToRemove = filterProcessGroupIDs(
+			cluster.Spec.ProcessGroupsToRemove,
+			toRecover,
+		)
+		cluster.Spec.ProcessGroupsToRemoveWithoutExclusion = filterProcessGroupIDs(
+			cluster.Spec.ProcessGroupsToRemoveWithoutExclusion,
+			toRecover,
+		)
+		// The processes might still be excluded in FoundationDB, e.g. if locality based exclusions are used. Adding
+		// them to the manually excluded process groups prevents the operator from replacing them because of the
+		// exclusion.
+		for _, processGroupID := range excluded {
+			if cluster.ProcessGroupIsManuallyExcluded(processGroupID) {
+				continue
+			}
+
+			cluster.Spec.ManuallyExcludedProcessGroups = append(
+				cluster.Spec.ManuallyExcludedProcessGroups,
+				processGroupID,
+			)
+		}
+
+		return kubeClient.Patch(cmd.Context(), cluster, patch)
+	})
+	if err != nil {
+		return err
+	}
+
+	// The operator could have marked the process groups for removal again before the removal lists were updated.
+	err = resetRecoveredProcessGroups(cmd.Context(), kubeClient, cluster, toRecover)
+	if err != nil {
+		return err
+	}
+
+	printStatement(
+		cmd,
+		fmt.Sprintf(
+			"recovered %v in cluster %s/%s, the operator will recreate the Pods",
+			processGroupIDs,
+			cluster.Namespace,
+			cluster.Name,
+		),
+		goodMessage,
+	)
+
+	if len(excluded) == 0 {
+		return nil
+	}
+
+	printStatement(
+		cmd,
+		fmt.Sprintf(
+			"%v are still excluded and were added to the manuallyExcludedProcessGroups list, they will not serve "+
+				"any data until they are included again. Once the Pods are running, include them and remove them "+
+				"from the list with: kubectl fdb include -c %s %s",
+			excluded,
+			cluster.Name,
+			strings.Join(processGroupIDStrings(excluded), " "),
+		),
+		warnMessage,
+	)
+
+	return nil
+}
+
+// resetRecoveredProcessGroups resets the removal, exclusion and suspension timestamps of the provided process groups.
+// The status is only updated if the cluster wasn't changed since it was read, in case of a conflict the update will be
+// retried with the latest version of the cluster.
+func resetRecoveredProcessGroups(
+	ctx context.Context,
+	kubeClient client.Client,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	toRecover map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None,
+) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := kubeClient.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)
+		if err != nil {
+			return err
+		}
+
+		patch := client.MergeFromWithOptions(
+			cluster.DeepCopy(),
+			client.MergeFromWithOptimisticLock{},
+		)
+		var changed bool
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if _, ok := toRecover[processGroup.ProcessGroupID]; !ok {
+				continue
+			}
+
+			if !processGroup.IsMarkedForRemoval() && !processGroup.IsSuspended() &&
+				!processGroup.IsExcluded() &&
+				processGroup.GetConditionTime(fdbv1beta2.ResourcesTerminating) == nil {
+				continue
+			}
+
+			processGroup.RemovalTimestamp = nil
+			processGroup.ExclusionTimestamp = nil
+			processGroup.ExclusionSkipped = false
+			processGroup.SuspensionTimestamp = nil
+			// The operator doesn't reset this condition, so it must be removed to make sure the process group is not
+			// handled as terminating once the new Pod is created.
+			processGroup.UpdateCondition(fdbv1beta2.ResourcesTerminating, false)
+			changed = true
+		}
+
+		if !changed {
+			return nil
+		}
+
+		return kubeClient.Status().Patch(ctx, cluster, patch)
+	})
+}
+
+// processGroupIDStrings converts the provided process group IDs to strings.
+func processGroupIDStrings(processGroupIDs []fdbv1beta2.ProcessGroupID) []string {
+	result := make([]string, 0, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		result = append(result, string(processGroupID))
+	}
+
+	return result
+}
@@ -0,0 +1,209 @@
+/*
+ * recover_process_group_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] recover process groups command", func() {
+	var suspendedProcessGroup *fdbv1beta2.ProcessGroupStatus
+	var createPVC bool
+	var outBuffer bytes.Buffer
+	var err error
+
+	BeforeEach(func() {
+		createPVC = true
+		outBuffer.Reset()
+
+		suspendedProcessGroup = cluster.Status.ProcessGroups[0]
+		suspendedProcessGroup.MarkForRemoval()
+		suspendedProcessGroup.SetExclude()
+		suspendedProcessGroup.Suspend()
+		suspendedProcessGroup.UpdateCondition(fdbv1beta2.ResourcesTerminating, true)
+		cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{
+			suspendedProcessGroup.ProcessGroupID,
+		}
+	})
+
+	When("recovering a suspended process group", func() {
+		JustBeforeEach(func() {
+			if createPVC {
+				Expect(k8sClient.Create(context.Background(), &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      suspendedProcessGroup.GetPvcName(cluster),
+						Namespace: namespace,
+					},
+				})).To(Succeed())
+			}
+
+			cmd := newRecoverProcessGroupCmd(genericclioptions.IOStreams{})
+			cmd.SetOut(&outBuffer)
+			cmd.SetErr(&outBuffer)
+			err = recoverProcessGroups(
+				cmd,
+				k8sClient,
+				cluster,
+				[]fdbv1beta2.ProcessGroupID{suspendedProcessGroup.ProcessGroupID},
+				false,
+			)
+		})
+
+		When("the PVC of the process group exists", func() {
+			It("should reset the process group", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				resCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: namespace,
+					Name:      clusterName,
+				}, resCluster)).To(Succeed())
+
+				Expect(resCluster.Spec.ProcessGroupsToRemove).To(BeEmpty())
+				Expect(
+					resCluster.Spec.ManuallyExcludedProcessGroups,
+				).To(ConsistOf(suspendedProcessGroup.ProcessGroupID))
+
+				processGroup := fdbv1beta2.FindProcessGroupByID(
+					resCluster.Status.ProcessGroups,
+					suspendedProcessGroup.ProcessGroupID,
+				)
+				Expect(processGroup).NotTo(BeNil())
+				Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				Expect(processGroup.IsExcluded()).To(BeFalse())
+				Expect(processGroup.IsSuspended()).To(BeFalse())
+				Expect(processGroup.GetConditionTime(fdbv1beta2.ResourcesTerminating)).To(BeNil())
+				Expect(outBuffer.String()).To(ContainSubstring("kubectl fdb include -c test"))
+			})
+		})
+
+		When("the exclusion of the process group was skipped", func() {
+			BeforeEach(func() {
+				suspendedProcessGroup.ExclusionTimestamp = nil
+				suspendedProcessGroup.ExclusionSkipped = true
+			})
+
+			It("should not add the process group to the manually excluded process groups", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				resCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(k8sClient.Get(context.Background(), client.ObjectKey{
+					Namespace: namespace,
+					Name:      clusterName,
+				}, resCluster)).To(Succeed())
+
+				Expect(resCluster.Spec.ProcessGroupsToRemove).To(BeEmpty())
+				Expect(resCluster.Spec.ManuallyExcludedProcessGroups).To(BeEmpty())
+
+				processGroup := fdbv1beta2.FindProcessGroupByID(
+					resCluster.Status.ProcessGroups,
+					suspendedProcessGroup.ProcessGroupID,
+				)
+				Expect(processGroup).NotTo(BeNil())
+				Expect(processGroup.IsExcluded()).To(BeFalse())
+				Expect(processGroup.IsSuspended()).To(BeFalse())
+				Expect(outBuffer.String()).NotTo(ContainSubstring("kubectl fdb include"))
+			})
+		})
+
+		When("the PVC of the process group was already deleted", func() {
+			BeforeEach(func() {
+				createPVC = false
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("cannot be recovered")))
+			})
+		})
+
+		When("the process group is not suspended", func() {
+			BeforeEach(func() {
+				suspendedProcessGroup.SuspensionTimestamp = nil
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("is not suspended")))
+			})
+		})
+	})
+
+	When("resetting the recovered process groups", func() {
+		When("the operator marked the process group for removal again", func() {
+			JustBeforeEach(func() {
+				stored := &fdbv1beta2.FoundationDBCluster{}
+				Expect(
+					k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cluster), stored),
+				).To(Succeed())
+
+				processGroup := fdbv1beta2.FindProcessGroupByID(
+					stored.Status.ProcessGroups,
+					suspendedProcessGroup.ProcessGroupID,
+				)
+				processGroup.SuspensionTimestamp = nil
+				processGroup.MarkForRemoval()
+				processGroup.SetExclude()
+				Expect(k8sClient.Status().Update(context.Background(), stored)).To(Succeed())
+			})
+
+			It("should reset the process group based on the latest cluster", func() {
+				Expect(resetRecoveredProcessGroups(
+					context.Background(),
+					k8sClient,
+					cluster,
+					map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{
+						suspendedProcessGroup.ProcessGroupID: {},
+					},
+				)).To(Succeed())
+
+				resCluster := &fdbv1beta2.FoundationDBCluster{}
+				Expect(
+					k8sClient.Get(context.Background(), client.ObjectKeyFromObject(cluster), resCluster),
+				).To(Succeed())
+
+				processGroup := fdbv1beta2.FindProcessGroupByID(
+					resCluster.Status.ProcessGroups,
+					suspendedProcessGroup.ProcessGroupID,
+				)
+				Expect(processGroup).NotTo(BeNil())
+				Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				Expect(processGroup.IsExcluded()).To(BeFalse())
+				Expect(processGroup.IsSuspended()).To(BeFalse())
+			})
+		})
+	})
+
+	When("getting all suspended process groups", func() {
+		It("should return the suspended process group", func() {
+			Expect(
+				getSuspendedProcessGroups(cluster),
+			).To(ConsistOf(suspendedProcessGroup.ProcessGroupID))
+		})
+	})
+})
@@ -0,0 +1,505 @@
+/*
+ * restore.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// restoreStartOptions defines the options to create a new FoundationDBRestore resource.
+type restoreStartOptions struct {
+	clusterName       string
+	backup            string
+	accountName       string
+	bucket            string
+	backupName        string
+	urlParameters     []string
+	encryptionKeyPath string
+	customParameters  []string
+	keyRanges         []string
+}
+
+func newRestoreCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Subcommand to manage the restores into a given cluster",
+		Long:  "Subcommand to manage the restores into a given cluster",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return c.Help()
+		},
+		Example: `
+# Restore the data of the backup into the cluster in the current namespace
+kubectl fdb restore start -c cluster --backup backup restore
+
+# Get the live status of the restore
+kubectl fdb restore status restore
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.AddCommand(
+		newRestoreStartCmd(streams),
+		newRestoreStatusCmd(streams),
+		newRestoreAbortCmd(streams),
+	)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newRestoreStartCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+	opts := restoreStartOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Creates a FoundationDBRestore resource to restore a backup into the provided cluster",
+		Long:  "Creates a FoundationDBRestore resource to restore a backup into the provided cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			var backup *fdbv1beta2.FoundationDBBackup
+			if opts.backup != "" {
+				backup, err = loadBackup(cmd.Context(), kubeClient, namespace, opts.backup)
+				if err != nil {
+					return err
+				}
+			}
+
+			restore, err := newRestore(namespace, args[0], backup, opts)
+			if err != nil {
+				return err
+			}
+
+			if wait {
+				if !confirmAction(
+					fmt.Sprintf(
+						"Restoring %s into cluster %s/%s",
+						restore.BackupURL(),
+						namespace,
+						restore.Spec.DestinationClusterName,
+					),
+				) {
+					return fmt.Errorf("user aborted the restore")
+				}
+			}
+
+			err = kubeClient.Create(cmd.Context(), restore)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf(
+				"created restore %s/%s for cluster %s from %s\n",
+				namespace,
+				restore.Name,
+				restore.Spec.DestinationClusterName,
+				restore.BackupURL(),
+			)
+
+			return nil
+		},
+		Example: `
+# Restore the data of the backup into the cluster in the current namespace
+kubectl fdb restore start -c cluster --backup backup restore
+
+# Restore only a key range from a blob store without a FoundationDBBackup resource
+kubectl fdb restore start -c cluster --account-name account@s3.example.com --backup-name backup --key-range a:b restore
+`,
+	}
+
+	cmd.Flags().
+		StringVarP(&opts.clusterName, "fdb-cluster", "c", "", "the cluster the backup should be restored into.")
+	cmd.Flags().
+		StringVar(&opts.backup, "backup", "", "the FoundationDBBackup resource to restore, the blob store configuration will be copied from this backup.")
+	cmd.Flags().
+		StringVar(&opts.accountName, "account-name", "", "the account name of the blob store, overrides the value from the backup.")
+	cmd.Flags().
+		StringVar(&opts.bucket, "bucket", "", "the bucket of the blob store, overrides the value from the backup.")
+	cmd.Flags().
+		StringVar(&opts.backupName, "backup-name", "", "the name of the backup in the blob store, overrides the value from the backup.")
+	cmd.Flags().
+		StringArrayVar(&opts.urlParameters, "url-parameter", nil, "additional parameters for the backup URL, overrides the values from the backup.")
+	cmd.Flags().
+		StringVar(&opts.encryptionKeyPath, "encryption-key-path", "", "the path to the encryption key used to encrypt the backup, overrides the value from the backup.")
+	cmd.Flags().
+		StringArrayVar(&opts.customParameters, "custom-parameter", nil, "additional parameters for the restore, overrides the values from the backup.")
+	cmd.Flags().
+		StringArrayVar(&opts.keyRanges, "key-range", nil, "the key range to restore in the format \"start:end\", can be specified multiple times. Defaults to all keys.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newRestoreStatusCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Gets the status of the provided restore, including the live status reported by fdbrestore",
+		Long:  "Gets the status of the provided restore, including the live status reported by fdbrestore",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			restore, err := loadRestore(cmd.Context(), kubeClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Restore:    %s/%s\n", namespace, restore.Name)
+			cmd.Printf("Cluster:    %s\n", restore.Spec.DestinationClusterName)
+			cmd.Printf("URL:        %s\n", restore.BackupURL())
+			cmd.Printf("Running:    %t\n", restore.Status.Running)
+			cmd.Printf("State:      %s\n\n", valueOrDash(string(restore.Status.State)))
+
+			stdout, err := runRestoreCommand(
+				cmd.Context(),
+				kubeClient,
+				config,
+				restore,
+				[]string{"status"},
+			)
+			if err != nil {
+				return err
+			}
+
+			cmd.Print(stdout)
+
+			return nil
+		},
+		Example: `
+# Get the status of the restore in the current namespace
+kubectl fdb restore status restore
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func newRestoreAbortCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "abort",
+		Short: "Aborts the provided restore",
+		Long:  "Aborts the provided restore",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			restore, err := loadRestore(cmd.Context(), kubeClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			if wait {
+				if !confirmAction(
+					fmt.Sprintf(
+						"Aborting restore %s/%s into cluster %s",
+						namespace,
+						restore.Name,
+						restore.Spec.DestinationClusterName,
+					),
+				) {
+					return fmt.Errorf("user aborted the abort")
+				}
+			}
+
+			stdout, err := runRestoreCommand(
+				cmd.Context(),
+				kubeClient,
+				config,
+				restore,
+				[]string{"abort"},
+			)
+			if err != nil {
+				return err
+			}
+
+			cmd.Print(stdout)
+
+			return nil
+		},
+		Example: `
+# Abort the restore in the current namespace
+kubectl fdb restore abort restore
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// loadRestore fetches the FoundationDBRestore resource.
+func loadRestore(
+	ctx context.Context,
+	kubeClient client.Client,
+	namespace string,
+	name string,
+) (*fdbv1beta2.FoundationDBRestore, error) {
+	restore := &fdbv1beta2.FoundationDBRestore{}
+	err := kubeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, restore)
+	if err != nil {
+		return nil, err
+	}
+
+	return restore, nil
+}
+
+// newRestore returns a new FoundationDBRestore resource. If a backup is provided the blob store configuration, the
+// custom parameters and the encryption key path are copied from the backup and can be overridden with the options.
+func newRestore(
+	namespace string,
+	name string,
+	backup *fdbv1beta2.FoundationDBBackup,
+	opts restoreStartOptions,
+) (*fdbv1beta2.FoundationDBRestore, error) {
+	restore := &fdbv1beta2.FoundationDBRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: fdbv1beta2.FoundationDBRestoreSpec{
+			DestinationClusterName: opts.clusterName,
+			BlobStoreConfiguration: &fdbv1beta2.BlobStoreConfiguration{},
+		},
+	}
+
+	if backup != nil {
+		if backup.Spec.BlobStoreConfiguration != nil {
+			restore.Spec.BlobStoreConfiguration = backup.Spec.BlobStoreConfiguration.DeepCopy()
+		}
+		// The restore must read the backup from the same location that the backup was written to.
+		restore.Spec.BlobStoreConfiguration.BackupName = backup.BackupName()
+		restore.Spec.BlobStoreConfiguration.Bucket = backup.Bucket()
+		restore.Spec.CustomParameters = backup.Spec.CustomParameters
+		restore.Spec.EncryptionKeyPath = backup.Spec.EncryptionKeyPath
+	}
+
+	if opts.accountName != "" {
+		restore.Spec.BlobStoreConfiguration.AccountName = opts.accountName
+	}
+
+	if opts.bucket != "" {
+		restore.Spec.BlobStoreConfiguration.Bucket = opts.bucket
+	}
+
+	if opts.backupName != "" {
+		restore.Spec.BlobStoreConfiguration.BackupName = opts.backupName
+	}
+
+	if len(opts.urlParameters) > 0 {
+		urlParameters := make([]fdbv1beta2.URLParameter, 0, len(opts.urlParameters))
+		for _, parameter := range opts.urlParameters {
+			urlParameters = append(urlParameters, fdbv1beta2.URLParameter(parameter))
+		}
+		restore.Spec.BlobStoreConfiguration.URLParameters = urlParameters
+	}
+
+	if opts.encryptionKeyPath != "" {
+		restore.Spec.EncryptionKeyPath = opts.encryptionKeyPath
+	}
+
+	if len(opts.customParameters) > 0 {
+		customParameters := make(
+			fdbv1beta2.FoundationDBCustomParameters,
+			0,
+			len(opts.customParameters),
+		)
+		for _, parameter := range opts.customParameters {
+			customParameters = append(
+				customParameters,
+				fdbv1beta2.FoundationDBCustomParameter(parameter),
+			)
+		}
+
+		err := customParameters.ValidateCustomParameters()
+		if err != nil {
+			return nil, err
+		}
+
+		restore.Spec.CustomParameters = customParameters
+	}
+
+	if restore.Spec.BlobStoreConfiguration.AccountName == "" {
+		return nil, fmt.Errorf(
+			"no account name for the blob store provided, either use the backup or the account-name flag",
+		)
+	}
+
+	for _, keyRange := range opts.keyRanges {
+		start, end, found := strings.Cut(keyRange, ":")
+		if !found || start == "" || end == "" {
+			return nil, fmt.Errorf(
+				"invalid key range %q, expected the format \"start:end\"",
+				keyRange,
+			)
+		}
+
+		restore.Spec.KeyRanges = append(restore.Spec.KeyRanges, fdbv1beta2.FoundationDBKeyRange{
+			Start: start,
+			End:   end,
+		})
+	}
+
+	return restore, nil
+}
+
+// getBackupAgentPodForCluster returns a running backup agent Pod from any backup of the provided cluster. The backup
+// agents are used to perform the restore and ship the fdbrestore binary.
+func getBackupAgentPodForCluster(
+	ctx context.Context,
+	kubeClient client.Client,
+	namespace string,
+	clusterName string,
+) (*corev1.Pod, error) {
+	backups := &fdbv1beta2.FoundationDBBackupList{}
+	err := kubeClient.List(ctx, backups, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, backup := range backups.Items {
+		if backup.Spec.ClusterName != clusterName {
+			continue
+		}
+
+		agentPods, err := getBackupAgentPods(ctx, kubeClient, &backup)
+		if err != nil {
+			return nil, err
+		}
+
+		agentPod, err := chooseRunningBackupAgentPod(&backup, agentPods)
+		if err != nil {
+			continue
+		}
+
+		return agentPod, nil
+	}
+
+	return nil, fmt.Errorf(
+		"no running backup agent pods found for cluster %s/%s",
+		namespace,
+		clusterName,
+	)
+}
+
+// runRestoreCommand runs fdbrestore with the provided arguments in a backup agent Pod of the destination cluster.
+func runRestoreCommand(
+	ctx context.Context,
+	kubeClient client.Client,
+	config *rest.Config,
+	restore *fdbv1beta2.FoundationDBRestore,
+	args []string,
+) (string, error) {
+	agentPod, err := getBackupAgentPodForCluster(
+		ctx,
+		kubeClient,
+		restore.Namespace,
+		restore.Spec.DestinationClusterName,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return runBackupToolCommand(
+		ctx,
+		kubeClient,
+		config,
+		agentPod,
+		getBackupToolCommand(fdbRestoreBinary, args, restore.Spec.CustomParameters),
+	)
+}
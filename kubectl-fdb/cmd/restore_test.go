@@ -0,0 +1,153 @@
+/*
+ * restore_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("[plugin] restore command", func() {
+	When("creating a new restore", func() {
+		var backup *fdbv1beta2.FoundationDBBackup
+		var opts restoreStartOptions
+		var restore *fdbv1beta2.FoundationDBRestore
+		var err error
+
+		BeforeEach(func() {
+			backup = generateBackupStruct("backup", fdbv1beta2.BackupStateRunning)
+			opts = restoreStartOptions{
+				clusterName: clusterName,
+			}
+		})
+
+		JustBeforeEach(func() {
+			restore, err = newRestore(namespace, "restore", backup, opts)
+		})
+
+		When("the restore is based on a backup", func() {
+			It("should use the configuration of the backup", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restore.Spec.DestinationClusterName).To(Equal(clusterName))
+				Expect(restore.BackupURL()).To(Equal(backup.BackupURL()))
+				Expect(restore.Spec.CustomParameters).To(Equal(backup.Spec.CustomParameters))
+				Expect(restore.Spec.KeyRanges).To(BeEmpty())
+			})
+
+			When("the options override the backup configuration", func() {
+				BeforeEach(func() {
+					opts.backupName = "other"
+					opts.bucket = "other-bucket"
+					opts.keyRanges = []string{"a:b", "c:d"}
+				})
+
+				It("should use the provided options", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(
+						restore.BackupURL(),
+					).To(Equal("blobstore://account@s3.example.com:443/other?bucket=other-bucket"))
+					Expect(restore.Spec.KeyRanges).To(Equal([]fdbv1beta2.FoundationDBKeyRange{
+						{Start: "a", End: "b"},
+						{Start: "c", End: "d"},
+					}))
+					// The backup must not be modified.
+					Expect(backup.Spec.BlobStoreConfiguration.BackupName).To(BeEmpty())
+				})
+			})
+
+			When("the key range is invalid", func() {
+				BeforeEach(func() {
+					opts.keyRanges = []string{"a"}
+				})
+
+				It("should return an error", func() {
+					Expect(err).To(MatchError(ContainSubstring("invalid key range \"a\"")))
+				})
+			})
+		})
+
+		When("no backup and no account name is provided", func() {
+			BeforeEach(func() {
+				backup = nil
+			})
+
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("no account name for the blob store")))
+			})
+		})
+	})
+
+	When("getting a backup agent pod for the destination cluster", func() {
+		var pod *corev1.Pod
+		var err error
+
+		JustBeforeEach(func() {
+			pod, err = getBackupAgentPodForCluster(
+				context.Background(),
+				k8sClient,
+				namespace,
+				clusterName,
+			)
+		})
+
+		When("no backup exists for the cluster", func() {
+			It("should return an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("no running backup agent pods found")))
+				Expect(pod).To(BeNil())
+			})
+		})
+
+		When("a backup with a running agent exists for the cluster", func() {
+			BeforeEach(func() {
+				backup := generateBackupStruct("backup", fdbv1beta2.BackupStateRunning)
+				Expect(k8sClient.Create(context.Background(), backup)).To(Succeed())
+
+				for _, phase := range []corev1.PodPhase{corev1.PodPending, corev1.PodRunning} {
+					agentPod := &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "backup-agent-" + string(phase),
+							Namespace: namespace,
+							Labels: map[string]string{
+								fdbv1beta2.BackupDeploymentPodLabel: internal.GetBackupDeploymentName(
+									backup,
+								),
+							},
+						},
+					}
+					Expect(k8sClient.Create(context.Background(), agentPod)).To(Succeed())
+					agentPod.Status.Phase = phase
+					Expect(k8sClient.Status().Update(context.Background(), agentPod)).To(Succeed())
+				}
+			})
+
+			It("should return the running pod", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pod.Name).To(Equal("backup-agent-Running"))
+			})
+		})
+	})
+})
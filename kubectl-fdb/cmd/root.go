@@ -101,6 +101,22 @@ func NewRootCmd(
 		newBuggifyCmd(streams),
 		newRecoverMultiRegionClusterCmd(streams),
 		newUpdateCmd(streams),
+		newStatusCmd(streams),
+		newTopCmd(streams),
+		newBackupCmd(streams),
+		newRestoreCmd(streams),
+		newMaintenanceCmd(streams),
+		newCoordinatorsCmd(streams),
+		newSupportBundleCmd(streams),
+		newDiffCmd(streams),
+		newExcludeCmd(streams),
+		newIncludeCmd(streams),
+		newExportCmd(streams),
+		newAdoptionCmd(streams),
+		newDashboardCmd(streams),
+		newUncordonCmd(streams),
+		newNodeCmd(streams),
+		newRecoverCmd(streams),
 	)
 
 	return cmd
@@ -0,0 +1,562 @@
+/*
+ * status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+	outputFormatYAML = "yaml"
+)
+
+// clusterStatusOverview represents the status of a FoundationDB cluster enriched with the information from the
+// Kubernetes resources. For HA clusters, all FoundationDBCluster resources sharing the same connection string
+// are combined into a single overview.
+type clusterStatusOverview struct {
+	ConnectionString string                    `json:"connectionString"`
+	Clusters         []clusterResourceOverview `json:"clusters"`
+	Health           clusterHealthOverview     `json:"health"`
+	DataMovement     dataMovementOverview      `json:"dataMovement"`
+	Coordinators     []coordinatorOverview     `json:"coordinators"`
+	ProcessGroups    []processGroupOverview    `json:"processGroups"`
+}
+
+// clusterResourceOverview contains the information of a single FoundationDBCluster resource.
+type clusterResourceOverview struct {
+	Namespace      string `json:"namespace"`
+	Name           string `json:"name"`
+	Reconciled     bool   `json:"reconciled"`
+	RunningVersion string `json:"runningVersion"`
+	DesiredVersion string `json:"desiredVersion"`
+}
+
+// clusterHealthOverview contains the health information from the machine-readable status.
+type clusterHealthOverview struct {
+	Available                                bool   `json:"available"`
+	Healthy                                  bool   `json:"healthy"`
+	FullReplication                          bool   `json:"fullReplication"`
+	DataState                                string `json:"dataState"`
+	RecoveryState                            string `json:"recoveryState"`
+	MaxZoneFailuresWithoutLosingData         int    `json:"maxZoneFailuresWithoutLosingData"`
+	MaxZoneFailuresWithoutLosingAvailability int    `json:"maxZoneFailuresWithoutLosingAvailability"`
+	MaintenanceZone                          string `json:"maintenanceZone,omitempty"`
+}
+
+// dataMovementOverview contains the information about the ongoing data movement.
+type dataMovementOverview struct {
+	InFlightBytes   int `json:"inFlightBytes"`
+	InQueueBytes    int `json:"inQueueBytes"`
+	HighestPriority int `json:"highestPriority"`
+}
+
+// coordinatorOverview contains the information about a single coordinator.
+type coordinatorOverview struct {
+	Address        string                    `json:"address"`
+	Reachable      bool                      `json:"reachable"`
+	ProcessGroupID fdbv1beta2.ProcessGroupID `json:"processGroupID,omitempty"`
+	Pod            string                    `json:"pod,omitempty"`
+}
+
+// processGroupOverview contains the information about a single process group and the processes running in it.
+type processGroupOverview struct {
+	Cluster        string                                 `json:"cluster"`
+	ProcessGroupID fdbv1beta2.ProcessGroupID              `json:"processGroupID"`
+	ProcessClass   fdbv1beta2.ProcessClass                `json:"processClass"`
+	Addresses      []string                               `json:"addresses,omitempty"`
+	Roles          []string                               `json:"roles,omitempty"`
+	Pod            string                                 `json:"pod,omitempty"`
+	Node           string                                 `json:"node,omitempty"`
+	Versions       []string                               `json:"versions,omitempty"`
+	Excluded       bool                                   `json:"excluded"`
+	Conditions     []fdbv1beta2.ProcessGroupConditionType `json:"conditions,omitempty"`
+}
+
+func newStatusCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Get the status of the provided clusters enriched with the information from Kubernetes.",
+		Long:  "Get the status of the provided clusters enriched with the information from Kubernetes.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+
+			err = validateOutputFormat(output)
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			// Clusters that share the same connection string are part of the same FoundationDB cluster, e.g. for
+			// HA clusters, so the machine-readable status must only be fetched once.
+			var connectionStrings []string
+			clustersByConnectionString := map[string][]*fdbv1beta2.FoundationDBCluster{}
+			podsByCluster := map[string][]corev1.Pod{}
+			for _, clusterName := range args {
+				cluster, err := loadCluster(kubeClient, namespace, clusterName)
+				if err != nil {
+					return err
+				}
+
+				pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+				if err != nil {
+					return err
+				}
+				podsByCluster[cluster.Name] = pods.Items
+
+				connectionString := cluster.Status.ConnectionString
+				if _, ok := clustersByConnectionString[connectionString]; !ok {
+					connectionStrings = append(connectionStrings, connectionString)
+				}
+				clustersByConnectionString[connectionString] = append(
+					clustersByConnectionString[connectionString],
+					cluster,
+				)
+			}
+
+			overviews := make([]*clusterStatusOverview, 0, len(connectionStrings))
+			for _, connectionString := range connectionStrings {
+				clusters := clustersByConnectionString[connectionString]
+
+				var status *fdbv1beta2.FoundationDBStatus
+				for _, cluster := range clusters {
+					pods, err := getRunningPodsForCluster(cmd.Context(), kubeClient, cluster)
+					if err != nil {
+						return err
+					}
+
+					if len(pods.Items) == 0 {
+						continue
+					}
+
+					clientPod, err := chooseRandomPod(pods)
+					if err != nil {
+						return err
+					}
+
+					status, err = getStatus(cmd.Context(), kubeClient, config, clientPod)
+					if err != nil {
+						return err
+					}
+
+					break
+				}
+
+				if status == nil {
+					return fmt.Errorf(
+						"could not find a running pod to fetch the status for cluster %s",
+						clusters[0].Name,
+					)
+				}
+
+				overviews = append(
+					overviews,
+					getClusterStatusOverview(clusters, podsByCluster, status),
+				)
+			}
+
+			return printClusterStatusOverviews(cmd.OutOrStdout(), overviews, output)
+		},
+		Example: `
+# Get the status of cluster c1
+kubectl fdb status c1
+
+# Get the status of cluster c1 in the namespace default
+kubectl fdb -n default status c1
+
+# Get the status of the HA cluster that consists of c1, c2 and c3, the status will only be fetched once.
+kubectl fdb status c1 c2 c3
+
+# Get the status of cluster c1 as JSON
+kubectl fdb status c1 --output json
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().
+		String("output", outputFormatText, "defines the output format, supported formats are: text, json and yaml.")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getClusterStatusOverview builds the status overview based on the provided clusters, which must share the same
+// connection string, the pods of those clusters and the machine-readable status.
+func getClusterStatusOverview(
+	clusters []*fdbv1beta2.FoundationDBCluster,
+	podsByCluster map[string][]corev1.Pod,
+	status *fdbv1beta2.FoundationDBStatus,
+) *clusterStatusOverview {
+	overview := &clusterStatusOverview{
+		ConnectionString: status.Cluster.ConnectionString,
+		Health: clusterHealthOverview{
+			Available:                                status.Client.DatabaseStatus.Available,
+			Healthy:                                  status.Client.DatabaseStatus.Healthy,
+			FullReplication:                          status.Cluster.FullReplication,
+			DataState:                                status.Cluster.Data.State.Name,
+			RecoveryState:                            status.Cluster.RecoveryState.Name,
+			MaxZoneFailuresWithoutLosingData:         status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingData,
+			MaxZoneFailuresWithoutLosingAvailability: status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability,
+			MaintenanceZone:                          string(status.Cluster.MaintenanceZone),
+		},
+		DataMovement: dataMovementOverview{
+			InFlightBytes:   status.Cluster.Data.MovingData.InFlightBytes,
+			InQueueBytes:    status.Cluster.Data.MovingData.InQueueBytes,
+			HighestPriority: status.Cluster.Data.MovingData.HighestPriority,
+		},
+	}
+
+	if overview.ConnectionString == "" && len(clusters) > 0 {
+		overview.ConnectionString = clusters[0].Status.ConnectionString
+	}
+
+	processesByProcessGroup := map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.FoundationDBStatusProcessInfo{}
+	processGroupByAddress := map[string]fdbv1beta2.ProcessGroupID{}
+	for _, process := range status.Cluster.Processes {
+		processGroupID := fdbv1beta2.ProcessGroupID(
+			process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey],
+		)
+		processesByProcessGroup[processGroupID] = append(
+			processesByProcessGroup[processGroupID],
+			process,
+		)
+		processGroupByAddress[process.Address.StringWithoutFlags()] = processGroupID
+	}
+
+	podByProcessGroup := map[fdbv1beta2.ProcessGroupID]corev1.Pod{}
+	for _, cluster := range clusters {
+		overview.Clusters = append(overview.Clusters, clusterResourceOverview{
+			Namespace:      cluster.Namespace,
+			Name:           cluster.Name,
+			Reconciled:     cluster.Status.Generations.Reconciled == cluster.Generation,
+			RunningVersion: cluster.GetRunningVersion(),
+			DesiredVersion: cluster.Spec.Version,
+		})
+
+		for _, pod := range podsByCluster[cluster.Name] {
+			processGroupID := fdbv1beta2.ProcessGroupID(
+				pod.Labels[cluster.GetProcessGroupIDLabel()],
+			)
+			podByProcessGroup[processGroupID] = pod
+		}
+
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			processGroupOverview := processGroupOverview{
+				Cluster:        cluster.Name,
+				ProcessGroupID: processGroup.ProcessGroupID,
+				ProcessClass:   processGroup.ProcessClass,
+			}
+
+			pod, ok := podByProcessGroup[processGroup.ProcessGroupID]
+			if ok {
+				processGroupOverview.Pod = pod.Name
+				processGroupOverview.Node = pod.Spec.NodeName
+			}
+
+			for _, condition := range processGroup.ProcessGroupConditions {
+				processGroupOverview.Conditions = append(
+					processGroupOverview.Conditions,
+					condition.ProcessGroupConditionType,
+				)
+			}
+
+			roles := map[string]fdbv1beta2.None{}
+			versions := map[string]fdbv1beta2.None{}
+			for _, process := range processesByProcessGroup[processGroup.ProcessGroupID] {
+				processGroupOverview.Addresses = append(
+					processGroupOverview.Addresses,
+					process.Address.StringWithoutFlags(),
+				)
+				processGroupOverview.Excluded = processGroupOverview.Excluded || process.Excluded
+
+				for _, role := range process.Roles {
+					roles[role.Role] = fdbv1beta2.None{}
+				}
+
+				if process.Version != "" {
+					versions[process.Version] = fdbv1beta2.None{}
+				}
+			}
+
+			processGroupOverview.Roles = getSortedKeys(roles)
+			processGroupOverview.Versions = getSortedKeys(versions)
+			sort.Strings(processGroupOverview.Addresses)
+			overview.ProcessGroups = append(overview.ProcessGroups, processGroupOverview)
+		}
+	}
+
+	sort.SliceStable(overview.ProcessGroups, func(i, j int) bool {
+		return overview.ProcessGroups[i].ProcessGroupID < overview.ProcessGroups[j].ProcessGroupID
+	})
+
+	for _, coordinator := range status.Client.Coordinators.Coordinators {
+		address := coordinator.Address.StringWithoutFlags()
+		coordinatorOverview := coordinatorOverview{
+			Address:        address,
+			Reachable:      coordinator.Reachable,
+			ProcessGroupID: processGroupByAddress[address],
+		}
+
+		pod, ok := podByProcessGroup[coordinatorOverview.ProcessGroupID]
+		if ok {
+			coordinatorOverview.Pod = pod.Name
+		}
+
+		overview.Coordinators = append(overview.Coordinators, coordinatorOverview)
+	}
+
+	return overview
+}
+
+// getSortedKeys returns the sorted keys of the provided set.
+func getSortedKeys(set map[string]fdbv1beta2.None) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// validateOutputFormat returns an error if the provided output format is not supported.
+func validateOutputFormat(output string) error {
+	if output == outputFormatText || output == outputFormatJSON || output == outputFormatYAML {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"unsupported output format %q, supported formats are: %s, %s, %s",
+		output,
+		outputFormatText,
+		outputFormatJSON,
+		outputFormatYAML,
+	)
+}
+
+// printStructuredOutput prints the provided value either as JSON or as YAML.
+func printStructuredOutput(out io.Writer, value interface{}, output string) error {
+	if output == outputFormatYAML {
+		content, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprint(out, string(content))
+		return err
+	}
+
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(out, string(content))
+	return err
+}
+
+// printClusterStatusOverviews prints the provided overviews in the requested output format.
+func printClusterStatusOverviews(
+	out io.Writer,
+	overviews []*clusterStatusOverview,
+	output string,
+) error {
+	if output != outputFormatText {
+		return printStructuredOutput(out, overviews, output)
+	}
+
+	for index, overview := range overviews {
+		if index > 0 {
+			_, _ = fmt.Fprintln(out)
+		}
+
+		err := printClusterStatusOverviewText(out, overview)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printClusterStatusOverviewText prints a human-readable representation of the overview.
+func printClusterStatusOverviewText(out io.Writer, overview *clusterStatusOverview) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(writer, "Connection string:\t%s\n", overview.ConnectionString)
+	for _, cluster := range overview.Clusters {
+		version := cluster.RunningVersion
+		if cluster.DesiredVersion != "" && cluster.DesiredVersion != cluster.RunningVersion {
+			version = fmt.Sprintf(
+				"%s (pending: %s)",
+				cluster.RunningVersion,
+				cluster.DesiredVersion,
+			)
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"Cluster:\t%s/%s\treconciled: %t\tversion: %s\n",
+			cluster.Namespace,
+			cluster.Name,
+			cluster.Reconciled,
+			version,
+		)
+	}
+
+	maintenanceZone := overview.Health.MaintenanceZone
+	if maintenanceZone == "" {
+		maintenanceZone = "-"
+	}
+
+	_, _ = fmt.Fprintln(writer)
+	_, _ = fmt.Fprintln(writer, "Health:")
+	_, _ = fmt.Fprintf(writer, "  Available:\t%t\n", overview.Health.Available)
+	_, _ = fmt.Fprintf(writer, "  Healthy:\t%t\n", overview.Health.Healthy)
+	_, _ = fmt.Fprintf(writer, "  Full replication:\t%t\n", overview.Health.FullReplication)
+	_, _ = fmt.Fprintf(writer, "  Data state:\t%s\n", overview.Health.DataState)
+	_, _ = fmt.Fprintf(writer, "  Recovery state:\t%s\n", overview.Health.RecoveryState)
+	_, _ = fmt.Fprintf(
+		writer,
+		"  Fault tolerance:\t%d zone failures without losing data, %d zone failures without losing availability\n",
+		overview.Health.MaxZoneFailuresWithoutLosingData,
+		overview.Health.MaxZoneFailuresWithoutLosingAvailability,
+	)
+	_, _ = fmt.Fprintf(writer, "  Maintenance zone:\t%s\n", maintenanceZone)
+
+	_, _ = fmt.Fprintln(writer)
+	_, _ = fmt.Fprintln(writer, "Data movement:")
+	_, _ = fmt.Fprintf(
+		writer,
+		"  In flight:\t%s\n",
+		fdbstatus.PrettyPrintBytes(int64(overview.DataMovement.InFlightBytes)),
+	)
+	_, _ = fmt.Fprintf(
+		writer,
+		"  In queue:\t%s\n",
+		fdbstatus.PrettyPrintBytes(int64(overview.DataMovement.InQueueBytes)),
+	)
+	_, _ = fmt.Fprintf(writer, "  Highest priority:\t%d\n", overview.DataMovement.HighestPriority)
+
+	err := writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	writer = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer)
+	_, _ = fmt.Fprintln(writer, "Coordinators:")
+	_, _ = fmt.Fprintln(writer, "ADDRESS\tREACHABLE\tPROCESS GROUP\tPOD")
+	for _, coordinator := range overview.Coordinators {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%t\t%s\t%s\n",
+			coordinator.Address,
+			coordinator.Reachable,
+			valueOrDash(string(coordinator.ProcessGroupID)),
+			valueOrDash(coordinator.Pod),
+		)
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	writer = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer)
+	_, _ = fmt.Fprintln(writer, "Process groups:")
+	_, _ = fmt.Fprintln(
+		writer,
+		"CLUSTER\tPROCESS GROUP\tCLASS\tADDRESSES\tROLES\tPOD\tNODE\tVERSION\tEXCLUDED\tCONDITIONS",
+	)
+	for _, processGroup := range overview.ProcessGroups {
+		conditions := make([]string, 0, len(processGroup.Conditions))
+		for _, condition := range processGroup.Conditions {
+			conditions = append(conditions, string(condition))
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
+			processGroup.Cluster,
+			processGroup.ProcessGroupID,
+			processGroup.ProcessClass,
+			valueOrDash(strings.Join(processGroup.Addresses, ",")),
+			valueOrDash(strings.Join(processGroup.Roles, ",")),
+			valueOrDash(processGroup.Pod),
+			valueOrDash(processGroup.Node),
+			valueOrDash(strings.Join(processGroup.Versions, ",")),
+			processGroup.Excluded,
+			valueOrDash(strings.Join(conditions, ",")),
+		)
+	}
+
+	return writer.Flush()
+}
+
+// valueOrDash returns the provided value or a dash if the value is empty.
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
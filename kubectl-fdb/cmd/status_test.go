@@ -0,0 +1,228 @@
+/*
+ * status_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("[plugin] status command", func() {
+	var status *fdbv1beta2.FoundationDBStatus
+	var pods map[string][]corev1.Pod
+	var overview *clusterStatusOverview
+
+	BeforeEach(func() {
+		cluster.Spec.Version = "7.1.57"
+		cluster.Status.RunningVersion = "7.1.26"
+		cluster.Status.Generations.Reconciled = 1
+		storageAddress := fdbv1beta2.ProcessAddress{
+			IPAddress: net.ParseIP("192.168.0.1"),
+			Port:      4501,
+		}
+		statelessAddress := fdbv1beta2.ProcessAddress{
+			IPAddress: net.ParseIP("192.168.0.3"),
+			Port:      4501,
+		}
+
+		status = &fdbv1beta2.FoundationDBStatus{
+			Client: fdbv1beta2.FoundationDBStatusLocalClientInfo{
+				Coordinators: fdbv1beta2.FoundationDBStatusCoordinatorInfo{
+					Coordinators: []fdbv1beta2.FoundationDBStatusCoordinator{
+						{Address: storageAddress, Reachable: true},
+					},
+				},
+				DatabaseStatus: fdbv1beta2.FoundationDBStatusClientDBStatus{
+					Available: true,
+				},
+			},
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				ConnectionString: cluster.Status.ConnectionString,
+				FaultTolerance: fdbv1beta2.FaultTolerance{
+					MaxZoneFailuresWithoutLosingData:         1,
+					MaxZoneFailuresWithoutLosingAvailability: 1,
+				},
+				Data: fdbv1beta2.FoundationDBStatusDataStatistics{
+					MovingData: fdbv1beta2.FoundationDBStatusMovingData{
+						InFlightBytes: 1024,
+					},
+					State: fdbv1beta2.FoundationDBStatusDataState{
+						Name: "healthy_repartitioning",
+					},
+				},
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+					"1": {
+						Address:  storageAddress,
+						Excluded: true,
+						Version:  "7.1.26",
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-1",
+						},
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{Role: string(fdbv1beta2.ProcessRoleStorage)},
+							{Role: string(fdbv1beta2.ProcessRoleCoordinator)},
+						},
+					},
+					"2": {
+						Address: statelessAddress,
+						Version: "7.1.26",
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityInstanceIDKey: "test-stateless-3",
+						},
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{Role: string(fdbv1beta2.ProcessRoleClusterController)},
+						},
+					},
+				},
+			},
+		}
+
+		pods = map[string][]corev1.Pod{
+			clusterName: {
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-storage-1",
+						Labels: map[string]string{
+							fdbv1beta2.FDBProcessGroupIDLabel: "test-storage-1",
+						},
+					},
+					Spec: corev1.PodSpec{NodeName: "node-1"},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		overview = getClusterStatusOverview(
+			[]*fdbv1beta2.FoundationDBCluster{cluster},
+			pods,
+			status,
+		)
+	})
+
+	When("building the status overview", func() {
+		It("should combine the machine-readable status with the Kubernetes information", func() {
+			Expect(overview.ConnectionString).To(Equal(cluster.Status.ConnectionString))
+			Expect(overview.Clusters).To(ConsistOf(clusterResourceOverview{
+				Namespace:      namespace,
+				Name:           clusterName,
+				Reconciled:     true,
+				RunningVersion: "7.1.26",
+				DesiredVersion: "7.1.57",
+			}))
+			Expect(overview.Health.Available).To(BeTrue())
+			Expect(overview.Health.DataState).To(Equal("healthy_repartitioning"))
+			Expect(overview.Health.MaxZoneFailuresWithoutLosingData).To(Equal(1))
+			Expect(overview.DataMovement.InFlightBytes).To(Equal(1024))
+			Expect(overview.Coordinators).To(ConsistOf(coordinatorOverview{
+				Address:        "192.168.0.1:4501",
+				Reachable:      true,
+				ProcessGroupID: "test-storage-1",
+				Pod:            "test-storage-1",
+			}))
+
+			Expect(overview.ProcessGroups).To(HaveLen(3))
+			Expect(overview.ProcessGroups[0]).To(Equal(processGroupOverview{
+				Cluster:        clusterName,
+				ProcessGroupID: "test-stateless-3",
+				ProcessClass:   fdbv1beta2.ProcessClassStateless,
+				Addresses:      []string{"192.168.0.3:4501"},
+				Roles:          []string{string(fdbv1beta2.ProcessRoleClusterController)},
+				Versions:       []string{"7.1.26"},
+			}))
+			Expect(overview.ProcessGroups[1]).To(Equal(processGroupOverview{
+				Cluster:        clusterName,
+				ProcessGroupID: "test-storage-1",
+				ProcessClass:   fdbv1beta2.ProcessClassStorage,
+				Addresses:      []string{"192.168.0.1:4501"},
+				Roles: []string{
+					string(fdbv1beta2.ProcessRoleCoordinator),
+					string(fdbv1beta2.ProcessRoleStorage),
+				},
+				Pod:        "test-storage-1",
+				Node:       "node-1",
+				Versions:   []string{"7.1.26"},
+				Excluded:   true,
+				Conditions: []fdbv1beta2.ProcessGroupConditionType{fdbv1beta2.PodFailing},
+			}))
+			Expect(
+				overview.ProcessGroups[2].ProcessGroupID,
+			).To(Equal(fdbv1beta2.ProcessGroupID("test-storage-2")))
+			Expect(overview.ProcessGroups[2].Addresses).To(BeEmpty())
+		})
+
+		When("multiple clusters share the same connection string", func() {
+			JustBeforeEach(func() {
+				secondCluster = generateClusterStruct(secondClusterName, namespace)
+				overview = getClusterStatusOverview(
+					[]*fdbv1beta2.FoundationDBCluster{cluster, secondCluster},
+					pods,
+					status,
+				)
+			})
+
+			It("should contain the process groups of all clusters", func() {
+				Expect(overview.Clusters).To(HaveLen(2))
+				Expect(overview.ProcessGroups).To(HaveLen(6))
+			})
+		})
+	})
+
+	DescribeTable("printing the status overview",
+		func(output string, validate func(string)) {
+			outBuffer := bytes.Buffer{}
+			Expect(
+				printClusterStatusOverviews(&outBuffer, []*clusterStatusOverview{overview}, output),
+			).To(Succeed())
+			validate(outBuffer.String())
+		},
+		Entry("as text", outputFormatText, func(out string) {
+			Expect(out).To(ContainSubstring("version: 7.1.26 (pending: 7.1.57)"))
+			Expect(out).To(MatchRegexp(`In flight:\s+1.00Ki`))
+			Expect(
+				out,
+			).To(MatchRegexp(`192\.168\.0\.1:4501\s+true\s+test-storage-1\s+test-storage-1`))
+			Expect(
+				out,
+			).To(MatchRegexp(`test\s+test-storage-2\s+storage\s+-\s+-\s+-\s+-\s+-\s+false\s+PodFailing,MissingProcesses`))
+		}),
+		Entry("as JSON", outputFormatJSON, func(out string) {
+			var result []clusterStatusOverview
+			Expect(json.Unmarshal([]byte(out), &result)).To(Succeed())
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].ProcessGroups).To(HaveLen(3))
+		}),
+		Entry("as YAML", outputFormatYAML, func(out string) {
+			var result []clusterStatusOverview
+			Expect(yaml.Unmarshal([]byte(out), &result)).To(Succeed())
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Coordinators).To(HaveLen(1))
+		}),
+	)
+})
@@ -0,0 +1,711 @@
+/*
+ * support_bundle.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	kubeHelper "github.com/FoundationDB/fdb-kubernetes-operator/v2/internal/kubernetes"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// traceLogDirectory is the directory in the main container that contains the trace logs.
+	traceLogDirectory = "/var/log/fdb-trace-logs"
+	// redactedValue is the value that replaces sensitive information in the support bundle.
+	redactedValue = "<redacted>"
+)
+
+var (
+	// sensitiveNamePattern matches the names of environment variables that could contain secrets.
+	sensitiveNamePattern = regexp.MustCompile(
+		`(?i)(password|passwd|secret|token|credential|private|api_?key|access_?key)`,
+	)
+	// pemBlockPattern matches PEM encoded blocks like certificates and private keys.
+	pemBlockPattern = regexp.MustCompile(
+		`(?s)-----BEGIN [A-Z0-9 ]+-----.*?-----END [A-Z0-9 ]+-----`,
+	)
+)
+
+// supportBundleOptions defines the information that should be collected in the support bundle.
+type supportBundleOptions struct {
+	processGroupIDs   []fdbv1beta2.ProcessGroupID
+	since             time.Duration
+	operatorName      string
+	operatorNamespace string
+	traceLogs         bool
+}
+
+// supportBundle writes the collected information into a gzip compressed tarball. Errors during the collection are
+// recorded and written into the bundle, so that a single failure doesn't prevent the collection of the other
+// information.
+type supportBundle struct {
+	gzipWriter *gzip.Writer
+	tarWriter  *tar.Writer
+	prefix     string
+	timestamp  time.Time
+	errors     []string
+}
+
+func newSupportBundleCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collects the information required to debug a cluster into a single tarball",
+		Long: "Collects the FoundationDBCluster resource, the cluster ConfigMap, the Pod specs, the events, the machine-readable status, " +
+			"the container logs, the recent trace logs and the operator logs into a single tarball. Environment variables " +
+			"that look like secrets and PEM encoded data like certificates and keys are redacted. If process groups are provided " +
+			"only the Pods of those process groups will be collected.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+
+			since, err := cmd.Flags().GetDuration("since")
+			if err != nil {
+				return err
+			}
+
+			traceLogs, err := cmd.Flags().GetBool("trace-logs")
+			if err != nil {
+				return err
+			}
+
+			outputFile, err := cmd.Flags().GetString("output-file")
+			if err != nil {
+				return err
+			}
+
+			operatorName, err := cmd.Root().Flags().GetString("operator-name")
+			if err != nil {
+				return err
+			}
+
+			operatorNamespace, err := cmd.Flags().GetString("operator-namespace")
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, clusterName)
+			if err != nil {
+				return err
+			}
+
+			if operatorNamespace == "" {
+				operatorNamespace = namespace
+			}
+
+			processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(args))
+			for _, arg := range args {
+				processGroupIDs = append(processGroupIDs, fdbv1beta2.ProcessGroupID(arg))
+			}
+
+			timestamp := time.Now()
+			if outputFile == "" {
+				outputFile = fmt.Sprintf(
+					"%s-%s-support-bundle-%s.tar.gz",
+					namespace,
+					cluster.Name,
+					timestamp.UTC().Format("20060102T150405Z"),
+				)
+			}
+
+			file, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = file.Close()
+			}()
+
+			bundle := newSupportBundle(
+				file,
+				strings.TrimSuffix(path.Base(outputFile), ".tar.gz"),
+				timestamp,
+			)
+			err = collectSupportBundle(cmd, kubeClient, config, cluster, supportBundleOptions{
+				processGroupIDs:   processGroupIDs,
+				since:             since,
+				operatorName:      operatorName,
+				operatorNamespace: operatorNamespace,
+				traceLogs:         traceLogs,
+			}, bundle)
+			if err != nil {
+				return err
+			}
+
+			err = bundle.close()
+			if err != nil {
+				return err
+			}
+
+			for _, collectionErr := range bundle.errors {
+				printStatement(cmd, collectionErr, warnMessage)
+			}
+
+			cmd.Printf(
+				"wrote support bundle for cluster %s/%s to %s\n",
+				namespace,
+				cluster.Name,
+				outputFile,
+			)
+
+			return nil
+		},
+		Example: `
+# Collect a support bundle for the cluster in the current namespace with the information of the last hour
+kubectl fdb support-bundle -c cluster
+
+# Collect a support bundle with the information of the last 30 minutes for two process groups
+kubectl fdb support-bundle -c cluster --since 30m cluster-storage-1 cluster-storage-2
+
+# Collect a support bundle without the trace logs and write it to a specific file
+kubectl fdb support-bundle -c cluster --trace-logs=false --output-file /tmp/bundle.tar.gz
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "the cluster to collect the support bundle for.")
+	cmd.Flags().
+		Duration("since", time.Hour, "only collect logs and trace logs that were written in the provided duration.")
+	cmd.Flags().
+		Bool("trace-logs", true, "collect the recent trace logs of the selected process groups.")
+	cmd.Flags().
+		String("output-file", "", "the file the support bundle will be written to, defaults to a file in the current directory.")
+	cmd.Flags().
+		String("operator-namespace", "", "the namespace of the operator Deployment, defaults to the namespace of the cluster.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// newSupportBundle returns a new supportBundle that writes into the provided writer. All files are added below the
+// provided prefix.
+func newSupportBundle(out io.Writer, prefix string, timestamp time.Time) *supportBundle {
+	gzipWriter := gzip.NewWriter(out)
+
+	return &supportBundle{
+		gzipWriter: gzipWriter,
+		tarWriter:  tar.NewWriter(gzipWriter),
+		prefix:     prefix,
+		timestamp:  timestamp,
+	}
+}
+
+// addFile adds the provided data as file to the bundle, sensitive data will be redacted.
+func (bundle *supportBundle) addFile(name string, data []byte) error {
+	data = redactContent(data)
+	err := bundle.tarWriter.WriteHeader(&tar.Header{
+		Name:    path.Join(bundle.prefix, name),
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: bundle.timestamp,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = bundle.tarWriter.Write(data)
+	return err
+}
+
+// addObject adds the provided object as YAML file to the bundle, sensitive fields will be redacted.
+func (bundle *supportBundle) addObject(name string, object interface{}) error {
+	content, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	var generic interface{}
+	err = json.Unmarshal(content, &generic)
+	if err != nil {
+		return err
+	}
+
+	redactObject(generic)
+	data, err := yaml.Marshal(generic)
+	if err != nil {
+		return err
+	}
+
+	return bundle.addFile(name, data)
+}
+
+// recordError records an error that happened during the collection.
+func (bundle *supportBundle) recordError(format string, args ...interface{}) {
+	bundle.errors = append(bundle.errors, fmt.Sprintf(format, args...))
+}
+
+// close writes the recorded errors into the bundle and closes the underlying writers.
+func (bundle *supportBundle) close() error {
+	if len(bundle.errors) > 0 {
+		err := bundle.addFile("errors.txt", []byte(strings.Join(bundle.errors, "\n")+"\n"))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := bundle.tarWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	return bundle.gzipWriter.Close()
+}
+
+// collectSupportBundle collects all the information for the provided cluster into the bundle. Only errors that prevent
+// writing the bundle are returned, all other errors are recorded in the bundle.
+func collectSupportBundle(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	config *rest.Config,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	opts supportBundleOptions,
+	bundle *supportBundle,
+) error {
+	ctx := cmd.Context()
+	pods, err := collectSupportBundleResources(ctx, kubeClient, cluster, opts, bundle)
+	if err != nil {
+		return err
+	}
+
+	runningPods, err := getRunningPodsForCluster(ctx, kubeClient, cluster)
+	if err == nil {
+		var clientPod *corev1.Pod
+		clientPod, err = chooseRandomPod(runningPods)
+		if err == nil {
+			var status *fdbv1beta2.FoundationDBStatus
+			status, err = getStatus(ctx, kubeClient, config, clientPod)
+			if err == nil {
+				var content []byte
+				content, err = json.MarshalIndent(status, "", "  ")
+				if err == nil {
+					err = bundle.addFile("status.json", content)
+				}
+			}
+		}
+	}
+	if err != nil {
+		bundle.recordError("could not collect the machine-readable status: %s", err)
+	}
+
+	sinceSeconds := int64(math.Ceil(opts.since.Seconds()))
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		for _, container := range pod.Spec.Containers {
+			logs, err := kubeHelper.GetLogsFromPod(
+				ctx,
+				kubeClient,
+				config,
+				&pod,
+				container.Name,
+				&sinceSeconds,
+			)
+			if err != nil {
+				bundle.recordError(
+					"could not collect the logs of container %s in Pod %s: %s",
+					container.Name,
+					pod.Name,
+					err,
+				)
+				continue
+			}
+
+			err = bundle.addFile(path.Join("pods", pod.Name, container.Name+".log"), []byte(logs))
+			if err != nil {
+				return err
+			}
+		}
+
+		if !opts.traceLogs {
+			continue
+		}
+
+		err = collectTraceLogs(ctx, kubeClient, config, &pod, opts.since, bundle)
+		if err != nil {
+			return err
+		}
+	}
+
+	return collectOperatorLogs(ctx, kubeClient, config, opts, sinceSeconds, bundle)
+}
+
+// collectSupportBundleResources adds the Kubernetes resources of the cluster to the bundle and returns the Pods that
+// were selected based on the provided options.
+func collectSupportBundleResources(
+	ctx context.Context,
+	kubeClient client.Client,
+	cluster *fdbv1beta2.FoundationDBCluster,
+	opts supportBundleOptions,
+	bundle *supportBundle,
+) ([]corev1.Pod, error) {
+	err := bundle.addObject("cluster.yaml", cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err = kubeClient.Get(
+		ctx,
+		client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name + "-config"},
+		configMap,
+	)
+	if err != nil {
+		bundle.recordError("could not collect the ConfigMap: %s", err)
+	} else {
+		err = bundle.addObject("configmap.yaml", configMap)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	podList, err := getPodsForCluster(ctx, kubeClient, cluster)
+	if err != nil {
+		bundle.recordError("could not collect the Pods: %s", err)
+		return nil, nil
+	}
+
+	pods, missing := filterPodsByProcessGroupIDs(cluster, podList.Items, opts.processGroupIDs)
+	for _, processGroupID := range missing {
+		bundle.recordError("could not find a Pod for process group %s", processGroupID)
+	}
+
+	podNames := map[string]fdbv1beta2.None{}
+	for _, pod := range pods {
+		podNames[pod.Name] = fdbv1beta2.None{}
+		err = bundle.addObject(path.Join("pods", pod.Name+".yaml"), pod)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	eventList := &corev1.EventList{}
+	err = kubeClient.List(ctx, eventList, client.InNamespace(cluster.Namespace))
+	if err != nil {
+		bundle.recordError("could not collect the events: %s", err)
+		return pods, nil
+	}
+
+	err = bundle.addObject(
+		"events.yaml",
+		filterSupportBundleEvents(cluster, eventList.Items, podNames),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pods, nil
+}
+
+// collectTraceLogs adds the trace logs of the provided Pod that were modified in the provided duration to the bundle.
+func collectTraceLogs(
+	ctx context.Context,
+	kubeClient client.Client,
+	config *rest.Config,
+	pod *corev1.Pod,
+	since time.Duration,
+	bundle *supportBundle,
+) error {
+	stdout, stderr, err := kubeHelper.ExecuteCommandOnPod(
+		ctx,
+		kubeClient,
+		config,
+		pod,
+		fdbv1beta2.MainContainerName,
+		getTraceLogListCommand(since),
+		false,
+	)
+	if err != nil {
+		bundle.recordError("could not list the trace logs of Pod %s: %s, %s", pod.Name, stderr, err)
+		return nil
+	}
+
+	for _, file := range parseFileList(stdout) {
+		var content bytes.Buffer
+		err = kubeHelper.DownloadFile(
+			ctx,
+			kubeClient,
+			config,
+			pod,
+			fdbv1beta2.MainContainerName,
+			file,
+			&content,
+		)
+		if err != nil {
+			bundle.recordError("could not download %s from Pod %s: %s", file, pod.Name, err)
+			continue
+		}
+
+		err = bundle.addFile(
+			path.Join("pods", pod.Name, "trace-logs", path.Base(file)),
+			content.Bytes(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectOperatorLogs adds the logs of all operator Pods to the bundle.
+func collectOperatorLogs(
+	ctx context.Context,
+	kubeClient client.Client,
+	config *rest.Config,
+	opts supportBundleOptions,
+	sinceSeconds int64,
+	bundle *supportBundle,
+) error {
+	operator, err := getOperator(kubeClient, opts.operatorName, opts.operatorNamespace)
+	if err != nil {
+		bundle.recordError(
+			"could not get the operator Deployment %s/%s: %s",
+			opts.operatorNamespace,
+			opts.operatorName,
+			err,
+		)
+		return nil
+	}
+
+	if operator.Spec.Selector == nil {
+		bundle.recordError(
+			"operator Deployment %s/%s has no selector",
+			operator.Namespace,
+			operator.Name,
+		)
+		return nil
+	}
+
+	podList := &corev1.PodList{}
+	err = kubeClient.List(
+		ctx,
+		podList,
+		client.InNamespace(operator.Namespace),
+		client.MatchingLabels(operator.Spec.Selector.MatchLabels),
+	)
+	if err != nil {
+		bundle.recordError("could not list the operator Pods: %s", err)
+		return nil
+	}
+
+	for _, pod := range podList.Items {
+		for _, container := range pod.Spec.Containers {
+			logs, err := kubeHelper.GetLogsFromPod(
+				ctx,
+				kubeClient,
+				config,
+				&pod,
+				container.Name,
+				&sinceSeconds,
+			)
+			if err != nil {
+				bundle.recordError(
+					"could not collect the logs of container %s in operator Pod %s: %s",
+					container.Name,
+					pod.Name,
+					err,
+				)
+				continue
+			}
+
+			err = bundle.addFile(
+				path.Join("operator", pod.Name, container.Name+".log"),
+				[]byte(logs),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// filterPodsByProcessGroupIDs returns the Pods of the provided process groups and the process groups without a Pod.
+// If no process groups are provided, all Pods are returned.
+func filterPodsByProcessGroupIDs(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	pods []corev1.Pod,
+	processGroupIDs []fdbv1beta2.ProcessGroupID,
+) ([]corev1.Pod, []fdbv1beta2.ProcessGroupID) {
+	if len(processGroupIDs) == 0 {
+		return pods, nil
+	}
+
+	selected := make(map[fdbv1beta2.ProcessGroupID]bool, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		selected[processGroupID] = false
+	}
+
+	result := make([]corev1.Pod, 0, len(processGroupIDs))
+	for _, pod := range pods {
+		processGroupID := fdbv1beta2.ProcessGroupID(pod.Labels[cluster.GetProcessGroupIDLabel()])
+		if _, ok := selected[processGroupID]; !ok {
+			continue
+		}
+
+		selected[processGroupID] = true
+		result = append(result, pod)
+	}
+
+	var missing []fdbv1beta2.ProcessGroupID
+	for processGroupID, found := range selected {
+		if !found {
+			missing = append(missing, processGroupID)
+		}
+	}
+
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i] < missing[j]
+	})
+
+	return result, missing
+}
+
+// filterSupportBundleEvents returns the events for the cluster itself and the provided Pods.
+func filterSupportBundleEvents(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	events []corev1.Event,
+	podNames map[string]fdbv1beta2.None,
+) []corev1.Event {
+	result := make([]corev1.Event, 0, len(events))
+	for _, event := range events {
+		if event.InvolvedObject.Kind == "FoundationDBCluster" &&
+			event.InvolvedObject.Name == cluster.Name {
+			result = append(result, event)
+			continue
+		}
+
+		if event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+
+		if _, ok := podNames[event.InvolvedObject.Name]; ok {
+			result = append(result, event)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LastTimestamp.Before(&result[j].LastTimestamp)
+	})
+
+	return result
+}
+
+// getTraceLogListCommand returns the command to list all trace log files that were modified in the provided duration.
+func getTraceLogListCommand(since time.Duration) string {
+	return fmt.Sprintf(
+		"find %s -maxdepth 1 -type f -mmin -%d",
+		traceLogDirectory,
+		int64(math.Ceil(since.Minutes())),
+	)
+}
+
+// parseFileList returns the non-empty lines of the provided output.
+func parseFileList(output string) []string {
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		files = append(files, line)
+	}
+
+	sort.Strings(files)
+
+	return files
+}
+
+// redactContent replaces all PEM encoded blocks, e.g. certificates and private keys, in the provided data.
+func redactContent(data []byte) []byte {
+	return pemBlockPattern.ReplaceAll(data, []byte(redactedValue))
+}
+
+// redactObject walks the provided generic object and redacts the values of all environment variables with a name
+// that looks like it contains sensitive information.
+func redactObject(object interface{}) {
+	switch value := object.(type) {
+	case map[string]interface{}:
+		// Environment variables and similar entries are represented as name/value pairs.
+		name, hasName := value["name"].(string)
+		if _, hasValue := value["value"]; hasName && hasValue &&
+			sensitiveNamePattern.MatchString(name) {
+			value["value"] = redactedValue
+		}
+
+		for _, entry := range value {
+			redactObject(entry)
+		}
+	case []interface{}:
+		for _, entry := range value {
+			redactObject(entry)
+		}
+	}
+}
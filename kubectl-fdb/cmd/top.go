@@ -0,0 +1,400 @@
+/*
+ * top.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbstatus"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const (
+	topSortByCPU          = "cpu"
+	topSortByMemory       = "memory"
+	topSortByDisk         = "disk"
+	topSortByDiskBusy     = "disk-busy"
+	topSortByNetwork      = "network"
+	topSortByLag          = "lag"
+	topSortByProcessGroup = "process-group"
+)
+
+// processTopEntry contains the resource usage and the roles of a single fdbserver process.
+type processTopEntry struct {
+	processGroupID       fdbv1beta2.ProcessGroupID
+	processID            string
+	processClass         fdbv1beta2.ProcessClass
+	roles                []string
+	pod                  string
+	node                 string
+	cpuCores             float64
+	memoryUsedBytes      int64
+	memoryLimitBytes     int64
+	diskUsedBytes        int64
+	diskTotalBytes       int64
+	diskBusy             float64
+	megabitsReceived     float64
+	megabitsSent         float64
+	dataLagSeconds       float64
+	durabilityLagSeconds float64
+}
+
+// diskUsage returns the fraction of the disk that is used.
+func (entry processTopEntry) diskUsage() float64 {
+	if entry.diskTotalBytes == 0 {
+		return 0
+	}
+
+	return float64(entry.diskUsedBytes) / float64(entry.diskTotalBytes)
+}
+
+func newTopCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Shows the resource usage and the roles of all processes of the provided cluster.",
+		Long:  "Shows the resource usage and the roles of all processes of the provided cluster.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sortBy, err := cmd.Flags().GetString("sort-by")
+			if err != nil {
+				return err
+			}
+
+			interval, err := cmd.Flags().GetDuration("interval")
+			if err != nil {
+				return err
+			}
+
+			iterations, err := cmd.Flags().GetInt("iterations")
+			if err != nil {
+				return err
+			}
+
+			processClass, err := cmd.Flags().GetString("process-class")
+			if err != nil {
+				return err
+			}
+
+			limit, err := cmd.Flags().GetInt("limit")
+			if err != nil {
+				return err
+			}
+
+			// Validate the sort option before connecting to the cluster.
+			err = sortProcessTopEntries(nil, sortBy)
+			if err != nil {
+				return err
+			}
+
+			config, err := o.configFlags.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			cluster, err := loadCluster(kubeClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+
+			var iteration int
+			for iterations <= 0 || iteration < iterations {
+				if iteration > 0 {
+					select {
+					case <-cmd.Context().Done():
+						return nil
+					case <-time.After(interval):
+					}
+				}
+				iteration++
+
+				pods, err := getPodsForCluster(cmd.Context(), kubeClient, cluster)
+				if err != nil {
+					cmd.PrintErrln(err)
+					continue
+				}
+
+				runningPods, err := getRunningPodsForCluster(cmd.Context(), kubeClient, cluster)
+				if err != nil {
+					cmd.PrintErrln(err)
+					continue
+				}
+
+				clientPod, err := chooseRandomPod(runningPods)
+				if err != nil {
+					cmd.PrintErrln(err)
+					continue
+				}
+
+				status, err := getStatus(cmd.Context(), kubeClient, config, clientPod)
+				if err != nil {
+					cmd.PrintErrln(err)
+					continue
+				}
+
+				entries := getProcessTopEntries(
+					cluster,
+					pods.Items,
+					status,
+					fdbv1beta2.ProcessClass(processClass),
+				)
+				err = sortProcessTopEntries(entries, sortBy)
+				if err != nil {
+					return err
+				}
+
+				if limit > 0 && len(entries) > limit {
+					entries = entries[:limit]
+				}
+
+				err = printProcessTopEntries(cmd.OutOrStdout(), entries, time.Now())
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		Example: `
+# Show the resource usage of all processes of cluster c1, the information will be refreshed every 10 seconds
+kubectl fdb top c1
+
+# Show the 10 storage processes of cluster c1 with the highest data lag
+kubectl fdb top c1 --process-class=storage --sort-by=lag --limit=10
+
+# Show the resource usage of all processes of cluster c1 once, sorted by the disk busyness
+kubectl fdb top c1 --sort-by=disk-busy --iterations=1
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().
+		String("sort-by", topSortByCPU, "defines how the processes should be sorted, supported values are: cpu, memory, disk, disk-busy, network, lag and process-group.")
+	cmd.Flags().
+		Duration("interval", 10*time.Second, "defines in which interval new information should be fetched from the cluster.")
+	cmd.Flags().
+		Int("iterations", 0, "defines how often the information should be fetched, if set to 0 the information will be fetched until the command is stopped.")
+	cmd.Flags().
+		String("process-class", "", "only shows the processes of the provided process class.")
+	cmd.Flags().
+		Int("limit", 0, "defines the maximum number of processes that should be shown, if set to 0 all processes will be shown.")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// getProcessTopEntries returns an entry for every process of the cluster in the machine-readable status. If a process
+// class is provided, only processes of this process class will be returned.
+func getProcessTopEntries(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	pods []corev1.Pod,
+	status *fdbv1beta2.FoundationDBStatus,
+	processClass fdbv1beta2.ProcessClass,
+) []processTopEntry {
+	podByProcessGroup := map[fdbv1beta2.ProcessGroupID]corev1.Pod{}
+	for _, pod := range pods {
+		podByProcessGroup[fdbv1beta2.ProcessGroupID(pod.Labels[cluster.GetProcessGroupIDLabel()])] = pod
+	}
+
+	entries := make([]processTopEntry, 0, len(status.Cluster.Processes))
+	for _, process := range status.Cluster.Processes {
+		if processClass != "" && process.ProcessClass != processClass {
+			continue
+		}
+
+		processGroupID := fdbv1beta2.ProcessGroupID(
+			process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey],
+		)
+
+		entry := processTopEntry{
+			processGroupID:   processGroupID,
+			processID:        process.Locality[fdbv1beta2.FDBLocalityProcessIDKey],
+			processClass:     process.ProcessClass,
+			cpuCores:         process.CPU.UsageCores,
+			memoryUsedBytes:  process.Memory.UsedBytes,
+			memoryLimitBytes: process.Memory.LimitBytes,
+			diskUsedBytes:    process.Disk.TotalBytes - process.Disk.FreeBytes,
+			diskTotalBytes:   process.Disk.TotalBytes,
+			diskBusy:         process.Disk.Busy,
+			megabitsReceived: process.Network.MegabitsReceived.Hz,
+			megabitsSent:     process.Network.MegabitsSent.Hz,
+		}
+
+		if entry.processID == "" {
+			entry.processID = process.Address.StringWithoutFlags()
+		}
+
+		pod, ok := podByProcessGroup[processGroupID]
+		if ok {
+			entry.pod = pod.Name
+			entry.node = pod.Spec.NodeName
+		}
+
+		for _, role := range process.Roles {
+			entry.roles = append(entry.roles, role.Role)
+			if role.Role != string(fdbv1beta2.ProcessRoleStorage) {
+				continue
+			}
+
+			if role.DataLag.Seconds > entry.dataLagSeconds {
+				entry.dataLagSeconds = role.DataLag.Seconds
+			}
+
+			if role.DurabilityLag.Seconds > entry.durabilityLagSeconds {
+				entry.durabilityLagSeconds = role.DurabilityLag.Seconds
+			}
+		}
+		sort.Strings(entry.roles)
+
+		entries = append(entries, entry)
+	}
+
+	// Sort the entries by the process group ID first to have a stable order for entries with the same values.
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].processGroupID == entries[j].processGroupID {
+			return entries[i].processID < entries[j].processID
+		}
+
+		return entries[i].processGroupID < entries[j].processGroupID
+	})
+
+	return entries
+}
+
+// sortProcessTopEntries sorts the entries based on the sortBy value. All values except the process group are sorted in
+// descending order.
+func sortProcessTopEntries(entries []processTopEntry, sortBy string) error {
+	var less func(i, j int) bool
+	switch sortBy {
+	case topSortByCPU:
+		less = func(i, j int) bool {
+			return entries[i].cpuCores > entries[j].cpuCores
+		}
+	case topSortByMemory:
+		less = func(i, j int) bool {
+			return entries[i].memoryUsedBytes > entries[j].memoryUsedBytes
+		}
+	case topSortByDisk:
+		less = func(i, j int) bool {
+			return entries[i].diskUsage() > entries[j].diskUsage()
+		}
+	case topSortByDiskBusy:
+		less = func(i, j int) bool {
+			return entries[i].diskBusy > entries[j].diskBusy
+		}
+	case topSortByNetwork:
+		less = func(i, j int) bool {
+			return entries[i].megabitsReceived+entries[i].megabitsSent > entries[j].megabitsReceived+entries[j].megabitsSent
+		}
+	case topSortByLag:
+		less = func(i, j int) bool {
+			return entries[i].dataLagSeconds > entries[j].dataLagSeconds
+		}
+	case topSortByProcessGroup:
+		less = func(i, j int) bool {
+			return entries[i].processGroupID < entries[j].processGroupID
+		}
+	default:
+		return fmt.Errorf(
+			"unsupported sort option %q, supported values are: %s",
+			sortBy,
+			strings.Join([]string{
+				topSortByCPU,
+				topSortByMemory,
+				topSortByDisk,
+				topSortByDiskBusy,
+				topSortByNetwork,
+				topSortByLag,
+				topSortByProcessGroup,
+			}, ", "),
+		)
+	}
+
+	sort.SliceStable(entries, less)
+
+	return nil
+}
+
+// printProcessTopEntries prints the entries as a table.
+func printProcessTopEntries(out io.Writer, entries []processTopEntry, timestamp time.Time) error {
+	_, _ = fmt.Fprintf(out, "%s - %d processes\n", timestamp.Format(time.RFC3339), len(entries))
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(
+		writer,
+		"PROCESS GROUP\tPROCESS\tCLASS\tROLES\tPOD\tNODE\tCPU\tMEMORY\tDISK\tDISK BUSY\tNET RX/TX (Mbps)\tDATA LAG\tDURABILITY LAG",
+	)
+	for _, entry := range entries {
+		memory := fdbstatus.PrettyPrintBytes(entry.memoryUsedBytes)
+		if entry.memoryLimitBytes > 0 {
+			memory += "/" + fdbstatus.PrettyPrintBytes(entry.memoryLimitBytes)
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%.2f\t%s\t%.1f%%\t%.1f%%\t%.2f/%.2f\t%.2fs\t%.2fs\n",
+			entry.processGroupID,
+			entry.processID,
+			entry.processClass,
+			valueOrDash(strings.Join(entry.roles, ",")),
+			valueOrDash(entry.pod),
+			valueOrDash(entry.node),
+			entry.cpuCores,
+			memory,
+			entry.diskUsage()*100,
+			entry.diskBusy*100,
+			entry.megabitsReceived,
+			entry.megabitsSent,
+			entry.dataLagSeconds,
+			entry.durabilityLagSeconds,
+		)
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(out)
+
+	return err
+}
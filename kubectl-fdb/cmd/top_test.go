@@ -0,0 +1,238 @@
+/*
+ * top_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"net"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("[plugin] top command", func() {
+	var status *fdbv1beta2.FoundationDBStatus
+	var pods []corev1.Pod
+
+	BeforeEach(func() {
+		status = &fdbv1beta2.FoundationDBStatus{
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+					"1": {
+						Address: fdbv1beta2.ProcessAddress{
+							IPAddress: net.ParseIP("192.168.0.1"),
+							Port:      4501,
+						},
+						ProcessClass: fdbv1beta2.ProcessClassStorage,
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityInstanceIDKey: "test-storage-1",
+							fdbv1beta2.FDBLocalityProcessIDKey:  "test-storage-1-1",
+						},
+						CPU: fdbv1beta2.FoundationDBStatusProcessCPU{UsageCores: 0.5},
+						Memory: fdbv1beta2.FoundationDBStatusProcessMemory{
+							UsedBytes:  1024,
+							LimitBytes: 4096,
+						},
+						Disk: fdbv1beta2.FoundationDBStatusProcessDisk{
+							Busy:       0.9,
+							FreeBytes:  75,
+							TotalBytes: 100,
+						},
+						Network: fdbv1beta2.FoundationDBStatusProcessNetwork{
+							MegabitsReceived: fdbv1beta2.FoundationDBStatusRate{Hz: 1},
+							MegabitsSent:     fdbv1beta2.FoundationDBStatusRate{Hz: 2},
+						},
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{
+								Role: string(fdbv1beta2.ProcessRoleStorage),
+								DataLag: fdbv1beta2.FoundationDBStatusLagInfo{
+									Seconds: 1.5,
+								},
+								DurabilityLag: fdbv1beta2.FoundationDBStatusLagInfo{
+									Seconds: 5,
+								},
+							},
+						},
+					},
+					"2": {
+						Address: fdbv1beta2.ProcessAddress{
+							IPAddress: net.ParseIP("192.168.0.3"),
+							Port:      4501,
+						},
+						ProcessClass: fdbv1beta2.ProcessClassStateless,
+						Locality: map[string]string{
+							fdbv1beta2.FDBLocalityInstanceIDKey: "test-stateless-3",
+						},
+						CPU: fdbv1beta2.FoundationDBStatusProcessCPU{UsageCores: 0.9},
+						Memory: fdbv1beta2.FoundationDBStatusProcessMemory{
+							UsedBytes: 512,
+						},
+						Disk: fdbv1beta2.FoundationDBStatusProcessDisk{
+							Busy:       0.1,
+							FreeBytes:  50,
+							TotalBytes: 100,
+						},
+						Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+							{Role: string(fdbv1beta2.ProcessRoleMaster)},
+							{Role: string(fdbv1beta2.ProcessRoleClusterController)},
+						},
+					},
+				},
+			},
+		}
+
+		pods = []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-storage-1",
+					Labels: map[string]string{
+						fdbv1beta2.FDBProcessGroupIDLabel: "test-storage-1",
+					},
+				},
+				Spec: corev1.PodSpec{NodeName: "node-1"},
+			},
+		}
+	})
+
+	When("getting the entries for all processes", func() {
+		It("should map the processes to the pods", func() {
+			entries := getProcessTopEntries(cluster, pods, status, "")
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0]).To(Equal(processTopEntry{
+				processGroupID:   "test-stateless-3",
+				processID:        "192.168.0.3:4501",
+				processClass:     fdbv1beta2.ProcessClassStateless,
+				roles:            []string{"cluster_controller", "master"},
+				cpuCores:         0.9,
+				memoryUsedBytes:  512,
+				diskUsedBytes:    50,
+				diskTotalBytes:   100,
+				diskBusy:         0.1,
+				megabitsReceived: 0,
+				megabitsSent:     0,
+			}))
+			Expect(entries[1]).To(Equal(processTopEntry{
+				processGroupID:       "test-storage-1",
+				processID:            "test-storage-1-1",
+				processClass:         fdbv1beta2.ProcessClassStorage,
+				roles:                []string{"storage"},
+				pod:                  "test-storage-1",
+				node:                 "node-1",
+				cpuCores:             0.5,
+				memoryUsedBytes:      1024,
+				memoryLimitBytes:     4096,
+				diskUsedBytes:        25,
+				diskTotalBytes:       100,
+				diskBusy:             0.9,
+				megabitsReceived:     1,
+				megabitsSent:         2,
+				dataLagSeconds:       1.5,
+				durabilityLagSeconds: 5,
+			}))
+		})
+	})
+
+	When("getting the entries for a process class", func() {
+		It("should only return the processes of this process class", func() {
+			entries := getProcessTopEntries(cluster, pods, status, fdbv1beta2.ProcessClassStorage)
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].processGroupID).To(Equal(fdbv1beta2.ProcessGroupID("test-storage-1")))
+		})
+	})
+
+	DescribeTable("sorting the entries",
+		func(sortBy string, expected []fdbv1beta2.ProcessGroupID) {
+			entries := getProcessTopEntries(cluster, pods, status, "")
+			Expect(sortProcessTopEntries(entries, sortBy)).To(Succeed())
+
+			processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(entries))
+			for _, entry := range entries {
+				processGroupIDs = append(processGroupIDs, entry.processGroupID)
+			}
+			Expect(processGroupIDs).To(Equal(expected))
+		},
+		Entry(
+			"by CPU",
+			topSortByCPU,
+			[]fdbv1beta2.ProcessGroupID{"test-stateless-3", "test-storage-1"},
+		),
+		Entry(
+			"by memory",
+			topSortByMemory,
+			[]fdbv1beta2.ProcessGroupID{"test-storage-1", "test-stateless-3"},
+		),
+		Entry(
+			"by disk",
+			topSortByDisk,
+			[]fdbv1beta2.ProcessGroupID{"test-stateless-3", "test-storage-1"},
+		),
+		Entry(
+			"by disk busyness",
+			topSortByDiskBusy,
+			[]fdbv1beta2.ProcessGroupID{"test-storage-1", "test-stateless-3"},
+		),
+		Entry(
+			"by network",
+			topSortByNetwork,
+			[]fdbv1beta2.ProcessGroupID{"test-storage-1", "test-stateless-3"},
+		),
+		Entry(
+			"by lag",
+			topSortByLag,
+			[]fdbv1beta2.ProcessGroupID{"test-storage-1", "test-stateless-3"},
+		),
+		Entry(
+			"by process group",
+			topSortByProcessGroup,
+			[]fdbv1beta2.ProcessGroupID{"test-stateless-3", "test-storage-1"},
+		),
+	)
+
+	When("an unknown sort option is provided", func() {
+		It("should return an error", func() {
+			Expect(sortProcessTopEntries(nil, "unknown")).To(HaveOccurred())
+		})
+	})
+
+	When("printing the entries", func() {
+		It("should print a table with all processes", func() {
+			outBuffer := bytes.Buffer{}
+			Expect(printProcessTopEntries(
+				&outBuffer,
+				getProcessTopEntries(cluster, pods, status, ""),
+				time.Unix(0, 0).UTC(),
+			)).To(Succeed())
+
+			out := outBuffer.String()
+			Expect(out).To(HavePrefix("1970-01-01T00:00:00Z - 2 processes\n"))
+			Expect(
+				out,
+			).To(MatchRegexp(`test-storage-1\s+test-storage-1-1\s+storage\s+storage\s+test-storage-1\s+node-1\s+0\.50\s+1\.00Ki/4\.00Ki\s+25\.0%\s+90\.0%\s+1\.00/2\.00\s+1\.50s\s+5\.00s`))
+			Expect(
+				out,
+			).To(MatchRegexp(`test-stateless-3\s+192\.168\.0\.3:4501\s+stateless\s+cluster_controller,master\s+-\s+-\s+0\.90\s+512\.00\s+50\.0%`))
+		})
+	})
+})
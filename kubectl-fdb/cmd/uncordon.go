@@ -0,0 +1,339 @@
+/*
+ * uncordon.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newUncordonCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "uncordon",
+		Short: "Cancels the pending removals of the process groups that run on a node",
+		Long: "Cancels the pending removals of the process groups that run on a node, e.g. after a node was cordoned. " +
+			"Only removals where the exclusion has not started yet will be cancelled.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+			nodeSelector, err := cmd.Flags().GetStringToString("node-selector")
+			if err != nil {
+				return err
+			}
+			clusterLabel, err := cmd.Flags().GetString("cluster-label")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(cmd.Context(), o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			if len(nodeSelector) != 0 && len(args) != 0 {
+				return fmt.Errorf("it's not allowed to use the node-selector and pass nodes")
+			}
+
+			nodes := args
+			if len(nodeSelector) != 0 {
+				nodes, err = getNodes(kubeClient, nodeSelector)
+				if err != nil {
+					return err
+				}
+			}
+
+			return uncordonNode(cmd, kubeClient, clusterName, nodes, namespace, wait, clusterLabel)
+		},
+		Example: `
+# Cancel the removal of all process groups for a cluster in the current namespace that are hosted on node-1
+kubectl fdb uncordon -c cluster node-1
+
+# Cancel the removal of all process groups for a cluster in the current namespace that are hosted on nodes with the labels machine=a,disk=fast
+kubectl fdb uncordon -c cluster --node-selector machine=a,disk=fast
+
+# Cancel the removal of all process groups in the current namespace that are hosted on node-1 with cluster-label
+kubectl fdb uncordon -l fdb-cluster-label node-1
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().
+		StringP("fdb-cluster", "c", "", "cancel the removal of process group(s) from the provided cluster.")
+	cmd.Flags().
+		StringToString("node-selector", nil, "node-selector to select all nodes that should be uncordoned. Can't be used with specific nodes.")
+	cmd.Flags().
+		StringP("cluster-label", "l", fdbv1beta2.FDBClusterLabel, "cluster label to fetch the appropriate Pods and identify the according cluster.")
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// uncordonNode cancels the pending removals of all process groups of this cluster that run on the given nodes.
+func uncordonNode(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	inputClusterName string,
+	nodes []string,
+	namespace string,
+	wait bool,
+	clusterLabel string,
+) error {
+	cmd.Printf("Starting to uncordon %d nodes\n", len(nodes))
+	if len(nodes) == 0 {
+		return errors.New("no nodes were provided for uncordoning")
+	}
+
+	statistics := map[string]int{}
+	var observedErrors []error
+	for _, node := range nodes {
+		pods, err := fetchPodsOnNode(kubeClient, inputClusterName, namespace, node, clusterLabel)
+		if err != nil {
+			observedErrors = append(
+				observedErrors,
+				fmt.Errorf("error fetching Pods from node: %s", node),
+			)
+			continue
+		}
+
+		if len(pods.Items) == 0 {
+			cmd.PrintErrln("Uncordoning node:", node, "has no running pods")
+			continue
+		}
+
+		podNames := make([]string, 0, len(pods.Items))
+		for _, pod := range pods.Items {
+			podNames = append(podNames, pod.Name)
+		}
+
+		cmd.Println("Uncordoning node:", node)
+		processGroupsByCluster, err := getProcessGroupsByCluster(cmd, kubeClient,
+			processGroupSelectionOptions{
+				ids:          podNames,
+				namespace:    namespace,
+				clusterName:  inputClusterName,
+				clusterLabel: clusterLabel,
+			})
+		if err != nil {
+			observedErrors = append(
+				observedErrors,
+				fmt.Errorf("unable to uncordon all Pods on node %s: %w", node, err),
+			)
+			continue
+		}
+
+		cancelled, err := cancelProcessGroupRemovals(
+			cmd,
+			kubeClient,
+			processGroupsByCluster,
+			namespace,
+			wait,
+		)
+		if err != nil {
+			observedErrors = append(
+				observedErrors,
+				fmt.Errorf("unable to uncordon all Pods on node %s: %w", node, err),
+			)
+			continue
+		}
+
+		statistics[node] = cancelled
+	}
+
+	cmd.Println("Completed uncordoning, printing summary:")
+	var total int
+	for _, node := range nodes {
+		cmd.Println("Cancelled removal of:", statistics[node], "pods from node:", node)
+		total += statistics[node]
+	}
+	cmd.Println("Cancelled removal of:", total, "pods from", len(nodes), "nodes")
+
+	return errors.Join(observedErrors...)
+}
+
+// cancelProcessGroupRemovals removes the provided process groups from the removal lists of their clusters and resets
+// the removal timestamp in the cluster status. Process groups where the exclusion has already started are skipped.
+func cancelProcessGroupRemovals(
+	cmd *cobra.Command,
+	kubeClient client.Client,
+	processGroupsByCluster map[*fdbv1beta2.FoundationDBCluster][]fdbv1beta2.ProcessGroupID,
+	namespace string,
+	wait bool,
+) (int, error) {
+	totalCancelled := 0
+	for cluster, processGroupIDs := range processGroupsByCluster {
+		cmd.Printf("Cluster %v/%v:\n", namespace, cluster.Name)
+		cancellable, skipped := getCancellableRemovals(cluster, processGroupIDs)
+
+		skippedIDs := make([]string, 0, len(skipped))
+		for processGroupID := range skipped {
+			skippedIDs = append(skippedIDs, string(processGroupID))
+		}
+		sort.Strings(skippedIDs)
+
+		for _, processGroupID := range skippedIDs {
+			printStatement(
+				cmd,
+				fmt.Sprintf(
+					"cannot cancel the removal of %s: %s",
+					processGroupID,
+					skipped[fdbv1beta2.ProcessGroupID(processGroupID)],
+				),
+				warnMessage,
+			)
+		}
+
+		if len(cancellable) == 0 {
+			cmd.Println("no pending removals to cancel")
+			continue
+		}
+
+		if wait {
+			if !confirmAction(
+				fmt.Sprintf(
+					"Cancel the removal of %v in cluster %s/%s",
+					cancellable,
+					namespace,
+					cluster.Name,
+				),
+			) {
+				return totalCancelled, fmt.Errorf("user aborted the uncordon")
+			}
+		}
+
+		toCancel := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(cancellable))
+		for _, processGroupID := range cancellable {
+			toCancel[processGroupID] = fdbv1beta2.None{}
+		}
+
+		patch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Spec.ProcessGroupsToRemove = filterProcessGroupIDs(
+			cluster.Spec.ProcessGroupsToRemove,
+			toCancel,
+		)
+		cluster.Spec.ProcessGroupsToRemoveWithoutExclusion = filterProcessGroupIDs(
+			cluster.Spec.ProcessGroupsToRemoveWithoutExclusion,
+			toCancel,
+		)
+		err := kubeClient.Patch(cmd.Context(), cluster, patch)
+		if err != nil {
+			return totalCancelled, err
+		}
+
+		// The operator keeps the removal timestamp once a process group is marked for removal, so the timestamp must be
+		// reset to prevent the operator from removing the process group.
+		statusPatch := client.MergeFrom(cluster.DeepCopy())
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if _, ok := toCancel[processGroup.ProcessGroupID]; ok {
+				processGroup.RemovalTimestamp = nil
+			}
+		}
+		err = kubeClient.Status().Patch(cmd.Context(), cluster, statusPatch)
+		if err != nil {
+			return totalCancelled, err
+		}
+
+		totalCancelled += len(cancellable)
+		cmd.Printf("cancelled the removal of %v\n", cancellable)
+	}
+
+	return totalCancelled, nil
+}
+
+// getCancellableRemovals returns the process groups whose removal can be cancelled and the reason why the removal of
+// the other process groups cannot be cancelled. Process groups that are not marked for removal are ignored.
+func getCancellableRemovals(
+	cluster *fdbv1beta2.FoundationDBCluster,
+	processGroupIDs []fdbv1beta2.ProcessGroupID,
+) ([]fdbv1beta2.ProcessGroupID, map[fdbv1beta2.ProcessGroupID]string) {
+	var cancellable []fdbv1beta2.ProcessGroupID
+	skipped := map[fdbv1beta2.ProcessGroupID]string{}
+
+	// Only removals that were requested in the spec can be cancelled.
+	requested := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
+	for _, processGroupID := range cluster.Spec.ProcessGroupsToRemove {
+		requested[processGroupID] = fdbv1beta2.None{}
+	}
+	for _, processGroupID := range cluster.Spec.ProcessGroupsToRemoveWithoutExclusion {
+		requested[processGroupID] = fdbv1beta2.None{}
+	}
+
+	for _, processGroupID := range processGroupIDs {
+		processGroup := fdbv1beta2.FindProcessGroupByID(
+			cluster.Status.ProcessGroups,
+			processGroupID,
+		)
+		if _, ok := requested[processGroupID]; !ok {
+			if processGroup != nil && processGroup.IsMarkedForRemoval() {
+				skipped[processGroupID] = "the process group was marked for removal by the operator"
+			}
+
+			continue
+		}
+
+		if processGroup != nil && (processGroup.IsExcluded() ||
+			processGroup.GetConditionTime(fdbv1beta2.ProcessIsMarkedAsExcluded) != nil) {
+			skipped[processGroupID] = "the exclusion has already started"
+			continue
+		}
+
+		cancellable = append(cancellable, processGroupID)
+	}
+
+	return cancellable, skipped
+}
+
+// filterProcessGroupIDs returns the process group IDs that are not part of the provided set.
+func filterProcessGroupIDs(
+	processGroupIDs []fdbv1beta2.ProcessGroupID,
+	toFilter map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None,
+) []fdbv1beta2.ProcessGroupID {
+	result := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		if _, ok := toFilter[processGroupID]; ok {
+			continue
+		}
+
+		result = append(result, processGroupID)
+	}
+
+	return result
+}
@@ -0,0 +1,144 @@
+/*
+ * uncordon_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2025 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] uncordon command", func() {
+	var storageOne, storageTwo fdbv1beta2.ProcessGroupID
+
+	BeforeEach(func() {
+		storageOne = fdbv1beta2.ProcessGroupID(
+			fmt.Sprintf("%s-%s-1", clusterName, fdbv1beta2.ProcessClassStorage),
+		)
+		storageTwo = fdbv1beta2.ProcessGroupID(
+			fmt.Sprintf("%s-%s-2", clusterName, fdbv1beta2.ProcessClassStorage),
+		)
+		Expect(createPods(clusterName, namespace)).NotTo(HaveOccurred())
+	})
+
+	When("getting the cancellable removals", func() {
+		It(
+			"should only return removals that were requested in the spec and are not excluded",
+			func() {
+				cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{storageOne}
+				cluster.Spec.ProcessGroupsToRemoveWithoutExclusion = []fdbv1beta2.ProcessGroupID{
+					storageTwo,
+				}
+				cluster.Status.ProcessGroups[1].SetExclude()
+				cluster.Status.ProcessGroups[2].MarkForRemoval()
+
+				cancellable, skipped := getCancellableRemovals(cluster, []fdbv1beta2.ProcessGroupID{
+					storageOne,
+					storageTwo,
+					cluster.Status.ProcessGroups[2].ProcessGroupID,
+				})
+				Expect(cancellable).To(ConsistOf(storageOne))
+				Expect(skipped).To(HaveLen(2))
+				Expect(
+					skipped,
+				).To(HaveKeyWithValue(storageTwo, "the exclusion has already started"))
+				Expect(skipped).To(HaveKeyWithValue(
+					cluster.Status.ProcessGroups[2].ProcessGroupID,
+					"the process group was marked for removal by the operator",
+				))
+			},
+		)
+	})
+
+	When("running the uncordon command", func() {
+		var outBuffer bytes.Buffer
+
+		BeforeEach(func() {
+			cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{storageOne}
+			cluster.Spec.ProcessGroupsToRemoveWithoutExclusion = []fdbv1beta2.ProcessGroupID{
+				storageTwo,
+			}
+			cluster.Status.ProcessGroups[0].MarkForRemoval()
+			cluster.Status.ProcessGroups[1].MarkForRemoval()
+			cluster.Status.ProcessGroups[1].ProcessGroupConditions = append(
+				cluster.Status.ProcessGroups[1].ProcessGroupConditions,
+				fdbv1beta2.NewProcessGroupCondition(fdbv1beta2.ProcessIsMarkedAsExcluded),
+			)
+			outBuffer.Reset()
+		})
+
+		JustBeforeEach(func() {
+			cmd := newUncordonCmd(genericclioptions.IOStreams{})
+			cmd.SetOut(&outBuffer)
+			cmd.SetErr(&outBuffer)
+			Expect(uncordonNode(
+				cmd,
+				k8sClient,
+				clusterName,
+				[]string{"node-1", "node-2"},
+				namespace,
+				false,
+				"",
+			)).To(Succeed())
+		})
+
+		It("should cancel the removal that has not started the exclusion", func() {
+			resCluster := &fdbv1beta2.FoundationDBCluster{}
+			Expect(k8sClient.Get(context.Background(), client.ObjectKey{
+				Namespace: namespace,
+				Name:      clusterName,
+			}, resCluster)).To(Succeed())
+
+			Expect(resCluster.Spec.ProcessGroupsToRemove).To(BeEmpty())
+			Expect(resCluster.Spec.ProcessGroupsToRemoveWithoutExclusion).To(ConsistOf(storageTwo))
+
+			processGroup := fdbv1beta2.FindProcessGroupByID(
+				resCluster.Status.ProcessGroups,
+				storageOne,
+			)
+			Expect(processGroup).NotTo(BeNil())
+			Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+
+			processGroup = fdbv1beta2.FindProcessGroupByID(
+				resCluster.Status.ProcessGroups,
+				storageTwo,
+			)
+			Expect(processGroup).NotTo(BeNil())
+			Expect(processGroup.IsMarkedForRemoval()).To(BeTrue())
+
+			Expect(outBuffer.String()).To(ContainSubstring(
+				fmt.Sprintf(
+					"cannot cancel the removal of %s: the exclusion has already started",
+					storageTwo,
+				),
+			))
+			Expect(
+				outBuffer.String(),
+			).To(ContainSubstring("Cancelled removal of: 1 pods from 2 nodes"))
+		})
+	})
+})
@@ -21,6 +21,8 @@
 package fdbadminclient
 
 import (
+	"time"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/v2/api/v1beta2"
 )
 
@@ -57,3 +59,21 @@ type LockClient interface {
 	// UpdateDenyList updates the deny list to match a list of entries.
 	UpdateDenyList(locks []fdbv1beta2.LockDenyListEntry) error
 }
+
+// LockHolderReader is an optional interface for a LockClient that can provide the current lock holder.
+type LockHolderReader interface {
+	// GetLockHolder returns the current holder of the lock. If no lock is present nil will be returned.
+	GetLockHolder() (*LockHolder, error)
+}
+
+// LockHolder provides information about the operator instance that currently holds the lock.
+type LockHolder struct {
+	// OwnerID is the lock ID of the operator instance holding the lock.
+	OwnerID string `json:"ownerID"`
+
+	// Start is the time when the lock was acquired.
+	Start time.Time `json:"start"`
+
+	// End is the time when the lock expires.
+	End time.Time `json:"end"`
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/podmanager"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -770,9 +771,21 @@ func (client *AdminClient) KillProcesses(addresses []fdbv1beta2.ProcessAddress)
 
 	if client.Cluster.Status.RunningVersion != client.Cluster.Spec.Version {
 		// We have to do this in the mock client, in the real world the tryConnectionOptions in update_status,
-		// will update the version.
+		// will update the version. The cluster is fetched again as the status could have been updated since the mock
+		// client was created.
 		client.Cluster.Status.RunningVersion = client.Cluster.Spec.Version
-		err := client.KubeClient.Status().Update(context.TODO(), client.Cluster)
+		currentCluster := &fdbv1beta2.FoundationDBCluster{}
+		err := client.KubeClient.Get(
+			context.TODO(),
+			types.NamespacedName{Namespace: client.Cluster.Namespace, Name: client.Cluster.Name},
+			currentCluster,
+		)
+		if err != nil {
+			return err
+		}
+
+		currentCluster.Status.RunningVersion = client.Cluster.Status.RunningVersion
+		err = client.KubeClient.Status().Update(context.TODO(), currentCluster)
 		if err != nil {
 			return err
 		}
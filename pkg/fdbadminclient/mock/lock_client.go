@@ -23,6 +23,7 @@ package mock
 import (
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/pkg/fdbadminclient"
 
@@ -40,12 +41,28 @@ type LockClient struct {
 	// pendingUpgrades stores data about process groups that have a pending
 	// upgrade.
 	pendingUpgrades map[fdbv1beta2.Version]map[fdbv1beta2.ProcessGroupID]bool
+
+	// lockHolder stores the information about the current lock holder.
+	lockHolder *fdbadminclient.LockHolder
 }
 
 var _ fdbadminclient.LockClient = (*LockClient)(nil)
 
+var _ fdbadminclient.LockHolderReader = (*LockClient)(nil)
+
 // TakeLock attempts to acquire a lock.
 func (client *LockClient) TakeLock() error {
+	if client.Disabled() {
+		return nil
+	}
+
+	now := time.Now()
+	client.lockHolder = &fdbadminclient.LockHolder{
+		OwnerID: client.cluster.GetLockID(),
+		Start:   now,
+		End:     now.Add(client.cluster.GetLockDuration()),
+	}
+
 	return nil
 }
 
@@ -117,9 +134,15 @@ func (client *LockClient) UpdateDenyList(locks []fdbv1beta2.LockDenyListEntry) e
 // ReleaseLock will release the current lock. The method will only release the lock if the current
 // operator is the lock holder.
 func (client *LockClient) ReleaseLock() error {
+	client.lockHolder = nil
 	return nil
 }
 
+// GetLockHolder returns the current holder of the lock. If no lock is present nil will be returned.
+func (client *LockClient) GetLockHolder() (*fdbadminclient.LockHolder, error) {
+	return client.lockHolder, nil
+}
+
 // lockClientCache provides a cache of mock lock clients.
 var lockClientCache = make(map[string]*LockClient)
 var lockClientMutex sync.Mutex
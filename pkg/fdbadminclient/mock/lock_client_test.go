@@ -22,6 +22,7 @@ package mock
 
 import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/v2/internal"
+	"k8s.io/utils/pointer"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -44,6 +45,44 @@ var _ = Describe("lock_client_test", func() {
 		})
 	})
 
+	Describe("GetLockHolder", func() {
+		AfterEach(func() {
+			ClearMockLockClients()
+		})
+
+		When("locks are disabled", func() {
+			It("returns no lock holder", func() {
+				Expect(lockClient.TakeLock()).To(Succeed())
+				holder, err := lockClient.GetLockHolder()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holder).To(BeNil())
+			})
+		})
+
+		When("locks are enabled", func() {
+			BeforeEach(func() {
+				lockClient.cluster.Spec.LockOptions.DisableLocks = pointer.Bool(false)
+			})
+
+			It("returns the lock holder after the lock was taken", func() {
+				Expect(lockClient.TakeLock()).To(Succeed())
+				holder, err := lockClient.GetLockHolder()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holder).NotTo(BeNil())
+				Expect(holder.OwnerID).To(Equal(lockClient.cluster.GetLockID()))
+				Expect(holder.End).To(BeTemporally(">", holder.Start))
+			})
+
+			It("returns no lock holder after the lock was released", func() {
+				Expect(lockClient.TakeLock()).To(Succeed())
+				Expect(lockClient.ReleaseLock()).To(Succeed())
+				holder, err := lockClient.GetLockHolder()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(holder).To(BeNil())
+			})
+		})
+	})
+
 	Describe("AddPendingUpgrades", func() {
 		It("adds the upgrades to the map", func() {
 			err = lockClient.AddPendingUpgrades(
@@ -313,6 +313,52 @@ func GetCoordinatorsFromStatus(status *fdbv1beta2.FoundationDBStatus) map[string
 	return coordinators
 }
 
+// GetStoredBytesPerProcessGroup returns the number of bytes stored by the storage roles of each process group, based on
+// the machine-readable status. This can be used to estimate how much data must be moved when a process group is
+// excluded. Process groups without storage roles will not be part of the returned map.
+func GetStoredBytesPerProcessGroup(
+	status *fdbv1beta2.FoundationDBStatus,
+) map[fdbv1beta2.ProcessGroupID]int64 {
+	storedBytes := make(map[fdbv1beta2.ProcessGroupID]int64)
+
+	for _, pInfo := range status.Cluster.Processes {
+		for _, roleInfo := range pInfo.Roles {
+			if roleInfo.Role != string(fdbv1beta2.ProcessRoleStorage) || roleInfo.StoredBytes <= 0 {
+				continue
+			}
+
+			processGroupID := fdbv1beta2.ProcessGroupID(
+				pInfo.Locality[fdbv1beta2.FDBLocalityInstanceIDKey],
+			)
+			storedBytes[processGroupID] += int64(roleInfo.StoredBytes)
+		}
+	}
+
+	return storedBytes
+}
+
+// GetStoredBytesOfExcludedProcesses returns the number of bytes that are still stored by the storage roles of excluded
+// processes. Those bytes must still be moved before the exclusions are done.
+func GetStoredBytesOfExcludedProcesses(status *fdbv1beta2.FoundationDBStatus) int64 {
+	var storedBytes int64
+
+	for _, pInfo := range status.Cluster.Processes {
+		if !pInfo.Excluded {
+			continue
+		}
+
+		for _, roleInfo := range pInfo.Roles {
+			if roleInfo.Role != string(fdbv1beta2.ProcessRoleStorage) || roleInfo.StoredBytes <= 0 {
+				continue
+			}
+
+			storedBytes += int64(roleInfo.StoredBytes)
+		}
+	}
+
+	return storedBytes
+}
+
 // GetMinimumUptimeAndAddressMap returns address map of the processes included the the foundationdb status. The minimum
 // uptime will be either secondsSinceLastRecovered if the recovery state is supported and enabled otherwise we will
 // take the minimum uptime of all processes.
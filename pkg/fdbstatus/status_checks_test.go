@@ -840,6 +840,77 @@ var _ = Describe("status_checks", func() {
 		)
 	})
 
+	When("getting the stored bytes from the status", func() {
+		var status *fdbv1beta2.FoundationDBStatus
+
+		BeforeEach(func() {
+			status = &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"storage-1-1": {
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+							},
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role:        string(fdbv1beta2.ProcessRoleStorage),
+									StoredBytes: 100,
+								},
+							},
+						},
+						"storage-1-2": {
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-1",
+							},
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role:        string(fdbv1beta2.ProcessRoleStorage),
+									StoredBytes: 200,
+								},
+							},
+						},
+						"storage-2": {
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "storage-2",
+							},
+							Excluded: true,
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role:        string(fdbv1beta2.ProcessRoleStorage),
+									StoredBytes: 50,
+								},
+							},
+						},
+						"log-1": {
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "log-1",
+							},
+							Excluded: true,
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role: string(fdbv1beta2.ProcessRoleLog),
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should return the stored bytes per process group", func() {
+			Expect(
+				GetStoredBytesPerProcessGroup(status),
+			).To(Equal(map[fdbv1beta2.ProcessGroupID]int64{
+				"storage-1": 300,
+				"storage-2": 50,
+			}))
+		})
+
+		It("should return the stored bytes of the excluded processes", func() {
+			Expect(GetStoredBytesOfExcludedProcesses(status)).To(BeNumerically("==", 50))
+		})
+	})
+
 	DescribeTable(
 		"when getting the minimum uptime and the address map",
 		func(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, useRecoveryState bool, expectedMinimumUptime float64, expectedAddressMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress) {
@@ -67,7 +67,10 @@ type Options struct {
 	CacheDatabaseStatus                bool
 	EnableNodeIndex                    bool
 	ReplaceOnSecurityContextChange     bool
+	InsightAPIAuthentication           bool
 	MetricsAddr                        string
+	InsightAPIAddr                     string
+	InsightAPICertDir                  string
 	LeaderElectionID                   string
 	LogFile                            string
 	LogFilePermission                  string
@@ -115,6 +118,24 @@ func (o *Options) BindFlags(fs *flag.FlagSet) {
 		":8080",
 		"The address the metric endpoint binds to.",
 	)
+	fs.StringVar(
+		&o.InsightAPIAddr,
+		"insight-api-addr",
+		"0",
+		"The address the read-only insight API binds to. Set to \"0\" to disable the insight API.",
+	)
+	fs.BoolVar(
+		&o.InsightAPIAuthentication,
+		"insight-api-authentication",
+		true,
+		"Defines if requests to the insight API must be authenticated with a token and authorized for the requested path.",
+	)
+	fs.StringVar(
+		&o.InsightAPICertDir,
+		"insight-api-cert-dir",
+		"",
+		"The directory that contains the tls.crt and tls.key files to serve the insight API over TLS. This is required if the insight API authentication is enabled.",
+	)
 	fs.BoolVar(
 		&o.EnableLeaderElection,
 		"enable-leader-election",
@@ -430,6 +451,7 @@ func StartManager(
 
 	if clusterReconciler != nil {
 		clusterReconciler.Client = mgr.GetClient()
+		clusterReconciler.APIReader = mgr.GetAPIReader()
 		clusterReconciler.Recorder = mgr.GetEventRecorderFor("foundationdbcluster-controller")
 		clusterReconciler.DeprecationOptions = operatorOpts.DeprecationOptions
 		clusterReconciler.DatabaseClientProvider = fdbclient.NewDatabaseClientProvider(logger)
@@ -474,6 +496,42 @@ func StartManager(
 		if operatorOpts.MetricsAddr != "0" {
 			controllers.InitCustomMetrics(clusterReconciler)
 		}
+
+		if operatorOpts.InsightAPIAddr != "0" {
+			var filter metricsserver.Filter
+			if operatorOpts.InsightAPIAuthentication {
+				// The clients send their bearer tokens with every request, so those must not be sent in cleartext.
+				if operatorOpts.InsightAPICertDir == "" {
+					setupLog.Error(
+						nil,
+						"the insight API authentication requires TLS, set --insight-api-cert-dir or disable the insight API",
+					)
+					os.Exit(1)
+				}
+
+				filter = controllers.NewInsightAuthenticationFilter(mgr.GetClient())
+			} else {
+				// This is logged as an error to make sure the missing authentication is not overlooked.
+				setupLog.Error(
+					nil,
+					"insight API authentication is disabled, every client that can reach the insight API can read the cluster information including the machine-readable status",
+					"insightAPIAddr",
+					operatorOpts.InsightAPIAddr,
+				)
+			}
+
+			insightServer := controllers.NewInsightServer(
+				clusterReconciler,
+				operatorOpts.InsightAPIAddr,
+				operatorOpts.InsightAPICertDir,
+				filter,
+				logr.WithName("insight-api"),
+			)
+			if err := mgr.Add(insightServer); err != nil {
+				setupLog.Error(err, "unable to add insight API server")
+				os.Exit(1)
+			}
+		}
 	}
 
 	if backupReconciler != nil {